@@ -0,0 +1,428 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/sse"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// AgentCardPath is the well-known path where the agent card is served.
+const AgentCardPath = "/.well-known/agent.json"
+
+// ContextResolver derives the agent context value from the incoming HTTP
+// request (e.g. from auth headers). The zero value of C is used when no
+// resolver is configured.
+type ContextResolver[C any] func(ctx context.Context, r *http.Request) (C, error)
+
+// ServerOption customises the A2A server.
+type ServerOption[C any] func(*Server[C])
+
+// WithContextResolver resolves the agent context value per request.
+func WithContextResolver[C any](resolver ContextResolver[C]) ServerOption[C] {
+	return func(s *Server[C]) {
+		s.resolveContext = resolver
+	}
+}
+
+// Server exposes an Agent over the A2A protocol. It serves the agent card at
+// AgentCardPath and handles JSON-RPC calls (message/send, message/stream,
+// tasks/get, tasks/cancel) on every other path.
+type Server[C any] struct {
+	agent          *llmagent.Agent[C]
+	card           AgentCard
+	resolveContext ContextResolver[C]
+
+	mu    sync.Mutex
+	tasks map[string]*taskRecord
+	// histories accumulates run items per contextId so follow-up messages in
+	// the same context continue the conversation.
+	histories map[string][]llmagent.AgentItem
+}
+
+// taskRecord pairs the task snapshot with the cancel function of its run.
+type taskRecord struct {
+	task   Task
+	cancel context.CancelFunc
+}
+
+// NewServer wraps the agent in an A2A protocol server. The card's name and
+// streaming capability are filled in from the agent when left unset.
+func NewServer[C any](agent *llmagent.Agent[C], card AgentCard, options ...ServerOption[C]) *Server[C] {
+	if card.Name == "" {
+		card.Name = agent.Name
+	}
+	card.Capabilities.Streaming = true
+	s := &Server[C]{
+		agent:     agent,
+		card:      card,
+		tasks:     map[string]*taskRecord{},
+		histories: map[string][]llmagent.AgentItem{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server[C]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == AgentCardPath {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.card); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req jsonrpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeRPCError(w, nil, codeParseError, "parse error: "+err.Error())
+		return
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		writeRPCError(w, req.ID, codeInvalidRequest, "invalid request")
+		return
+	}
+
+	switch req.Method {
+	case "message/send":
+		s.handleMessageSend(w, r, req)
+	case "message/stream":
+		s.handleMessageStream(w, r, req)
+	case "tasks/get":
+		s.handleTaskGet(w, req)
+	case "tasks/cancel":
+		s.handleTaskCancel(w, req)
+	default:
+		writeRPCError(w, req.ID, codeMethodNotFound, fmt.Sprintf("method %q not found", req.Method))
+	}
+}
+
+func (s *Server[C]) handleMessageSend(w http.ResponseWriter, r *http.Request, req jsonrpcRequest) {
+	task, runCtx, agentReq, rpcErr := s.prepareRun(r, req)
+	if rpcErr != nil {
+		writeRPCError(w, req.ID, rpcErr.Code, rpcErr.Message)
+		return
+	}
+	defer runCtx.cancel()
+
+	response, err := s.agent.Run(runCtx.ctx, agentReq)
+	if err != nil {
+		final := s.finishTaskError(task, err)
+		writeRPCResult(w, req.ID, final)
+		return
+	}
+
+	final := s.finishTaskSuccess(task, response)
+	writeRPCResult(w, req.ID, final)
+}
+
+func (s *Server[C]) handleMessageStream(w http.ResponseWriter, r *http.Request, req jsonrpcRequest) {
+	task, runCtx, agentReq, rpcErr := s.prepareRun(r, req)
+	if rpcErr != nil {
+		writeRPCError(w, req.ID, rpcErr.Code, rpcErr.Message)
+		return
+	}
+	defer runCtx.cancel()
+
+	sse.SetHeaders(w)
+	writer, err := sse.NewWriter(w)
+	if err != nil {
+		writeRPCError(w, req.ID, codeInternalError, "streaming unsupported")
+		return
+	}
+
+	emit := func(result any) {
+		_ = writer.Send(jsonrpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+	}
+
+	emit(s.statusEvent(task, TaskStateSubmitted, nil, false))
+
+	stream, err := s.agent.RunStream(runCtx.ctx, agentReq)
+	if err != nil {
+		final := s.finishTaskError(task, err)
+		emit(statusUpdateFromTask(final, true))
+		return
+	}
+
+	s.setTaskState(task.ID, TaskStateWorking, nil)
+	emit(s.statusEvent(task, TaskStateWorking, nil, false))
+
+	for stream.Next() {
+		event := stream.Current()
+		if event.Response != nil {
+			final := s.finishTaskSuccess(task, event.Response)
+			for _, artifact := range final.Artifacts {
+				emit(TaskArtifactUpdateEvent{
+					Kind:      "artifact-update",
+					TaskID:    final.ID,
+					ContextID: final.ContextID,
+					Artifact:  artifact,
+				})
+			}
+			emit(statusUpdateFromTask(final, true))
+			return
+		}
+	}
+
+	err = stream.Err()
+	if err == nil {
+		err = fmt.Errorf("agent stream ended without a response")
+	}
+	final := s.finishTaskError(task, err)
+	emit(statusUpdateFromTask(final, true))
+}
+
+func (s *Server[C]) handleTaskGet(w http.ResponseWriter, req jsonrpcRequest) {
+	var params TaskIDParams
+	if err := json.Unmarshal(req.Params, &params); err != nil || params.ID == "" {
+		writeRPCError(w, req.ID, codeInvalidParams, "invalid task params")
+		return
+	}
+
+	s.mu.Lock()
+	record, ok := s.tasks[params.ID]
+	s.mu.Unlock()
+	if !ok {
+		writeRPCError(w, req.ID, codeTaskNotFound, fmt.Sprintf("task %q not found", params.ID))
+		return
+	}
+	writeRPCResult(w, req.ID, record.task)
+}
+
+func (s *Server[C]) handleTaskCancel(w http.ResponseWriter, req jsonrpcRequest) {
+	var params TaskIDParams
+	if err := json.Unmarshal(req.Params, &params); err != nil || params.ID == "" {
+		writeRPCError(w, req.ID, codeInvalidParams, "invalid task params")
+		return
+	}
+
+	s.mu.Lock()
+	record, ok := s.tasks[params.ID]
+	if ok && !isTerminal(record.task.Status.State) {
+		record.cancel()
+		record.task.Status = TaskStatus{State: TaskStateCanceled, Timestamp: timestamp()}
+	}
+	var task Task
+	if ok {
+		task = record.task
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeRPCError(w, req.ID, codeTaskNotFound, fmt.Sprintf("task %q not found", params.ID))
+		return
+	}
+	writeRPCResult(w, req.ID, task)
+}
+
+// runContext bundles the cancellable context created for a task run.
+type runContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// prepareRun validates the incoming message, registers a new task, and builds
+// the agent request from the stored context history plus the new message.
+func (s *Server[C]) prepareRun(r *http.Request, req jsonrpcRequest) (Task, runContext, llmagent.AgentRequest[C], *jsonrpcError) {
+	var params MessageSendParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return Task{}, runContext{}, llmagent.AgentRequest[C]{}, &jsonrpcError{Code: codeInvalidParams, Message: "invalid message params: " + err.Error()}
+	}
+	if params.Message.Role != MessageRoleUser || len(params.Message.Parts) == 0 {
+		return Task{}, runContext{}, llmagent.AgentRequest[C]{}, &jsonrpcError{Code: codeInvalidParams, Message: "message must have role user and at least one part"}
+	}
+
+	parts, err := partsToLLM(params.Message.Parts)
+	if err != nil {
+		return Task{}, runContext{}, llmagent.AgentRequest[C]{}, &jsonrpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	var contextVal C
+	if s.resolveContext != nil {
+		contextVal, err = s.resolveContext(r.Context(), r)
+		if err != nil {
+			return Task{}, runContext{}, llmagent.AgentRequest[C]{}, &jsonrpcError{Code: codeInternalError, Message: "resolve context: " + err.Error()}
+		}
+	}
+
+	contextID := params.Message.ContextID
+	if contextID == "" {
+		contextID = uuid.NewString()
+	}
+	message := params.Message
+	message.Kind = "message"
+	if message.MessageID == "" {
+		message.MessageID = uuid.NewString()
+	}
+	message.ContextID = contextID
+
+	task := Task{
+		Kind:      "task",
+		ID:        uuid.NewString(),
+		ContextID: contextID,
+		Status:    TaskStatus{State: TaskStateSubmitted, Timestamp: timestamp()},
+		History:   []Message{message},
+	}
+	message.TaskID = task.ID
+	task.History[0] = message
+
+	// The run must outlive the HTTP request for cancellation to be
+	// observable through tasks/cancel, but it is driven synchronously here,
+	// so the request context is the natural parent.
+	ctx, cancel := context.WithCancel(r.Context())
+
+	userItem := llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(parts...))
+	s.mu.Lock()
+	input := append(append([]llmagent.AgentItem{}, s.histories[contextID]...), userItem)
+	s.histories[contextID] = input
+	s.tasks[task.ID] = &taskRecord{task: task, cancel: cancel}
+	s.mu.Unlock()
+
+	return task, runContext{ctx: ctx, cancel: cancel}, llmagent.AgentRequest[C]{Input: input, Context: contextVal}, nil
+}
+
+// finishTaskSuccess records the completed run: agent message in the history,
+// final content as an artifact, and the run output appended to the context
+// history for follow-up turns.
+func (s *Server[C]) finishTaskSuccess(task Task, response *llmagent.AgentResponse) Task {
+	agentMessage := Message{
+		Kind:      "message",
+		MessageID: uuid.NewString(),
+		Role:      MessageRoleAgent,
+		Parts:     partsFromLLM(response.Content),
+		TaskID:    task.ID,
+		ContextID: task.ContextID,
+	}
+	artifact := Artifact{
+		ArtifactID: uuid.NewString(),
+		Name:       "response",
+		Parts:      agentMessage.Parts,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.histories[task.ContextID] = append(s.histories[task.ContextID], response.Output...)
+	record := s.tasks[task.ID]
+	if isTerminal(record.task.Status.State) {
+		return record.task
+	}
+	record.task.Status = TaskStatus{State: TaskStateCompleted, Message: &agentMessage, Timestamp: timestamp()}
+	record.task.History = append(record.task.History, agentMessage)
+	record.task.Artifacts = append(record.task.Artifacts, artifact)
+	return record.task
+}
+
+// finishTaskError records a failed run unless the task was already canceled.
+func (s *Server[C]) finishTaskError(task Task, err error) Task {
+	errorMessage := Message{
+		Kind:      "message",
+		MessageID: uuid.NewString(),
+		Role:      MessageRoleAgent,
+		Parts:     []Part{NewTextPart(err.Error())},
+		TaskID:    task.ID,
+		ContextID: task.ContextID,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := s.tasks[task.ID]
+	if isTerminal(record.task.Status.State) {
+		return record.task
+	}
+	record.task.Status = TaskStatus{State: TaskStateFailed, Message: &errorMessage, Timestamp: timestamp()}
+	return record.task
+}
+
+func (s *Server[C]) setTaskState(taskID string, state TaskState, message *Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.tasks[taskID]
+	if !ok || isTerminal(record.task.Status.State) {
+		return
+	}
+	record.task.Status = TaskStatus{State: state, Message: message, Timestamp: timestamp()}
+}
+
+func (s *Server[C]) statusEvent(task Task, state TaskState, message *Message, final bool) TaskStatusUpdateEvent {
+	return TaskStatusUpdateEvent{
+		Kind:      "status-update",
+		TaskID:    task.ID,
+		ContextID: task.ContextID,
+		Status:    TaskStatus{State: state, Message: message, Timestamp: timestamp()},
+		Final:     final,
+	}
+}
+
+func statusUpdateFromTask(task Task, final bool) TaskStatusUpdateEvent {
+	return TaskStatusUpdateEvent{
+		Kind:      "status-update",
+		TaskID:    task.ID,
+		ContextID: task.ContextID,
+		Status:    task.Status,
+		Final:     final,
+	}
+}
+
+func isTerminal(state TaskState) bool {
+	return state == TaskStateCompleted || state == TaskStateFailed || state == TaskStateCanceled
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// partsToLLM converts A2A message parts into llmsdk parts for the agent input.
+func partsToLLM(parts []Part) ([]llmsdk.Part, error) {
+	out := make([]llmsdk.Part, 0, len(parts))
+	for _, part := range parts {
+		switch part.Kind {
+		case "text":
+			out = append(out, llmsdk.NewTextPart(part.Text))
+		default:
+			return nil, fmt.Errorf("unsupported a2a part kind %q", part.Kind)
+		}
+	}
+	return out, nil
+}
+
+// partsFromLLM converts agent output parts into A2A parts. Text parts map
+// directly; other modalities are carried as data parts holding the llmsdk
+// part JSON.
+func partsFromLLM(parts []llmsdk.Part) []Part {
+	out := make([]Part, 0, len(parts))
+	for _, part := range parts {
+		if part.TextPart != nil {
+			out = append(out, NewTextPart(part.TextPart.Text))
+			continue
+		}
+		data, err := json.Marshal(part)
+		if err != nil {
+			continue
+		}
+		out = append(out, Part{Kind: "data", Data: data})
+	}
+	return out
+}
+
+func writeRPCResult(w http.ResponseWriter, id json.RawMessage, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(jsonrpcResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func writeRPCError(w http.ResponseWriter, id json.RawMessage, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(jsonrpcResponse{JSONRPC: "2.0", ID: id, Error: &jsonrpcError{Code: code, Message: message}})
+}
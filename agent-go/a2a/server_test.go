@@ -0,0 +1,206 @@
+package a2a_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/a2a"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func newTestServer(t *testing.T, model *llmsdktest.MockLanguageModel) *httptest.Server {
+	t.Helper()
+	agent := llmagent.NewAgent[struct{}]("assistant", model)
+	server := a2a.NewServer(agent, a2a.AgentCard{
+		Description: "Test agent",
+		Skills:      []a2a.AgentSkill{{ID: "chat", Name: "Chat"}},
+	})
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func postRPC(t *testing.T, url, method string, params any) *http.Response {
+	t.Helper()
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("marshal params: %v", err)
+	}
+	body, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  json.RawMessage(rawParams),
+	})
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("post %s: %v", method, err)
+	}
+	return resp
+}
+
+func TestA2AServerServesAgentCard(t *testing.T) {
+	ts := newTestServer(t, llmsdktest.NewMockLanguageModel())
+
+	resp, err := http.Get(ts.URL + a2a.AgentCardPath)
+	if err != nil {
+		t.Fatalf("get agent card: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var card a2a.AgentCard
+	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+		t.Fatalf("decode agent card: %v", err)
+	}
+	if card.Name != "assistant" || !card.Capabilities.Streaming {
+		t.Fatalf("expected agent name and streaming capability, got %+v", card)
+	}
+}
+
+func TestA2AServerMessageSend(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Hello from the agent")},
+	}))
+	ts := newTestServer(t, model)
+
+	resp := postRPC(t, ts.URL, "message/send", a2a.MessageSendParams{
+		Message: a2a.Message{
+			Role:  a2a.MessageRoleUser,
+			Parts: []a2a.Part{a2a.NewTextPart("Hi")},
+		},
+	})
+	defer resp.Body.Close()
+
+	var rpcResp struct {
+		Result a2a.Task `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	task := rpcResp.Result
+
+	if task.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("expected completed task, got %+v", task.Status)
+	}
+	if len(task.Artifacts) != 1 || len(task.Artifacts[0].Parts) != 1 ||
+		task.Artifacts[0].Parts[0].Text != "Hello from the agent" {
+		t.Fatalf("expected the agent text artifact, got %+v", task.Artifacts)
+	}
+	if len(task.History) != 2 || task.History[1].Role != a2a.MessageRoleAgent {
+		t.Fatalf("expected user and agent messages in history, got %+v", task.History)
+	}
+
+	// tasks/get returns the stored snapshot.
+	getResp := postRPC(t, ts.URL, "tasks/get", a2a.TaskIDParams{ID: task.ID})
+	defer getResp.Body.Close()
+	var getRPC struct {
+		Result a2a.Task `json:"result"`
+	}
+	if err := json.NewDecoder(getResp.Body).Decode(&getRPC); err != nil {
+		t.Fatalf("decode tasks/get response: %v", err)
+	}
+	if getRPC.Result.ID != task.ID || getRPC.Result.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("expected the completed task from tasks/get, got %+v", getRPC.Result)
+	}
+}
+
+func TestA2AServerMessageStream(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("Streamed reply")}},
+	}))
+	ts := newTestServer(t, model)
+
+	resp := postRPC(t, ts.URL, "message/stream", a2a.MessageSendParams{
+		Message: a2a.Message{
+			Role:  a2a.MessageRoleUser,
+			Parts: []a2a.Part{a2a.NewTextPart("Hi")},
+		},
+	})
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected SSE content type, got %q", ct)
+	}
+
+	var (
+		states      []a2a.TaskState
+		artifactTxt string
+		sawFinal    bool
+	)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var event struct {
+			Result struct {
+				Kind     string         `json:"kind"`
+				Status   a2a.TaskStatus `json:"status"`
+				Final    bool           `json:"final"`
+				Artifact a2a.Artifact   `json:"artifact"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			t.Fatalf("decode stream event: %v", err)
+		}
+		switch event.Result.Kind {
+		case "status-update":
+			states = append(states, event.Result.Status.State)
+			if event.Result.Final {
+				sawFinal = true
+			}
+		case "artifact-update":
+			if len(event.Result.Artifact.Parts) == 1 {
+				artifactTxt = event.Result.Artifact.Parts[0].Text
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+
+	wantStates := []a2a.TaskState{a2a.TaskStateSubmitted, a2a.TaskStateWorking, a2a.TaskStateCompleted}
+	if len(states) != len(wantStates) {
+		t.Fatalf("expected states %v, got %v", wantStates, states)
+	}
+	for i, want := range wantStates {
+		if states[i] != want {
+			t.Fatalf("expected states %v, got %v", wantStates, states)
+		}
+	}
+	if !sawFinal {
+		t.Fatal("expected a final status update")
+	}
+	if artifactTxt != "Streamed reply" {
+		t.Fatalf("expected the streamed artifact text, got %q", artifactTxt)
+	}
+}
+
+func TestA2AServerUnknownMethod(t *testing.T) {
+	ts := newTestServer(t, llmsdktest.NewMockLanguageModel())
+
+	resp := postRPC(t, ts.URL, "tasks/unknown", struct{}{})
+	defer resp.Body.Close()
+
+	var rpcResp struct {
+		Error *struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if rpcResp.Error == nil || rpcResp.Error.Code != -32601 {
+		t.Fatalf("expected method not found error, got %+v", rpcResp.Error)
+	}
+}
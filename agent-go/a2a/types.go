@@ -0,0 +1,162 @@
+// Package a2a exposes an Agent as an Agent-to-Agent (A2A) protocol endpoint
+// so llm-sdk agents can interoperate with other A2A-compatible frameworks.
+// The server publishes an agent card, accepts JSON-RPC message/send and
+// message/stream calls, and tracks tasks for tasks/get and tasks/cancel.
+package a2a
+
+import "encoding/json"
+
+// AgentCard is the self-describing document served from
+// /.well-known/agent.json so other frameworks can discover the agent.
+type AgentCard struct {
+	Name               string            `json:"name"`
+	Description        string            `json:"description,omitempty"`
+	URL                string            `json:"url,omitempty"`
+	Version            string            `json:"version,omitempty"`
+	Capabilities       AgentCapabilities `json:"capabilities"`
+	DefaultInputModes  []string          `json:"defaultInputModes,omitempty"`
+	DefaultOutputModes []string          `json:"defaultOutputModes,omitempty"`
+	Skills             []AgentSkill      `json:"skills"`
+}
+
+// AgentCapabilities advertises optional protocol features.
+type AgentCapabilities struct {
+	Streaming bool `json:"streaming"`
+}
+
+// AgentSkill describes a capability of the agent for discovery purposes.
+type AgentSkill struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// MessageRole identifies the author of an A2A message.
+type MessageRole string
+
+const (
+	MessageRoleUser  MessageRole = "user"
+	MessageRoleAgent MessageRole = "agent"
+)
+
+// Message is a single protocol message exchanged between client and agent.
+type Message struct {
+	Kind      string      `json:"kind"`
+	MessageID string      `json:"messageId"`
+	Role      MessageRole `json:"role"`
+	Parts     []Part      `json:"parts"`
+	TaskID    string      `json:"taskId,omitempty"`
+	ContextID string      `json:"contextId,omitempty"`
+}
+
+// Part is a unit of message or artifact content. Only the fields matching
+// Kind are set: "text" carries Text, "data" carries Data.
+type Part struct {
+	Kind string          `json:"kind"`
+	Text string          `json:"text,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// NewTextPart constructs a text content part.
+func NewTextPart(text string) Part {
+	return Part{Kind: "text", Text: text}
+}
+
+// TaskState is the lifecycle state of an A2A task.
+type TaskState string
+
+const (
+	TaskStateSubmitted TaskState = "submitted"
+	TaskStateWorking   TaskState = "working"
+	TaskStateCompleted TaskState = "completed"
+	TaskStateFailed    TaskState = "failed"
+	TaskStateCanceled  TaskState = "canceled"
+)
+
+// TaskStatus is the current state of a task plus an optional status message.
+type TaskStatus struct {
+	State TaskState `json:"state"`
+	// Message carries agent output or error details for terminal states.
+	Message   *Message `json:"message,omitempty"`
+	Timestamp string   `json:"timestamp,omitempty"`
+}
+
+// Task is the unit of work tracked by the server for each message/send or
+// message/stream call.
+type Task struct {
+	Kind      string     `json:"kind"`
+	ID        string     `json:"id"`
+	ContextID string     `json:"contextId"`
+	Status    TaskStatus `json:"status"`
+	History   []Message  `json:"history,omitempty"`
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+}
+
+// Artifact is a generated output attached to a task.
+type Artifact struct {
+	ArtifactID string `json:"artifactId"`
+	Name       string `json:"name,omitempty"`
+	Parts      []Part `json:"parts"`
+}
+
+// TaskStatusUpdateEvent is streamed over message/stream when the task state
+// changes. Final marks the last event of the stream.
+type TaskStatusUpdateEvent struct {
+	Kind      string     `json:"kind"`
+	TaskID    string     `json:"taskId"`
+	ContextID string     `json:"contextId"`
+	Status    TaskStatus `json:"status"`
+	Final     bool       `json:"final"`
+}
+
+// TaskArtifactUpdateEvent is streamed over message/stream when the task
+// produces an artifact.
+type TaskArtifactUpdateEvent struct {
+	Kind      string   `json:"kind"`
+	TaskID    string   `json:"taskId"`
+	ContextID string   `json:"contextId"`
+	Artifact  Artifact `json:"artifact"`
+}
+
+// MessageSendParams are the params of message/send and message/stream.
+type MessageSendParams struct {
+	Message Message `json:"message"`
+}
+
+// TaskIDParams are the params of tasks/get and tasks/cancel.
+type TaskIDParams struct {
+	ID string `json:"id"`
+}
+
+// JSON-RPC 2.0 envelope types used by the A2A transport.
+
+type jsonrpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type jsonrpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
+type jsonrpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON-RPC error codes used by the server, including the A2A-specific
+// TaskNotFound code.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+	codeTaskNotFound   = -32001
+)
@@ -19,6 +19,7 @@ type Agent[C any] struct {
 // - `tools`: empty
 // - `responseFormat`: `llmsdk.NewResponseFormatText()`
 // - `maxTurns`: 10
+// - `toolConcurrency`: 0 (unlimited)
 // - `temperature`: nil
 // - `topP`: nil
 // - `topK`: nil
@@ -42,6 +43,26 @@ func NewAgent[C any](name string, model llmsdk.LanguageModel, options ...AgentPa
 	return &Agent[C]{Name: name, params: params}
 }
 
+// Clone returns a copy of the agent with the given options applied on top of
+// its current configuration, so per-tenant or per-experiment variants can
+// override the model, instructions, or tools without rebuilding the whole
+// option list. The original agent is not modified.
+func (a *Agent[C]) Clone(options ...AgentParamsOption[C]) *Agent[C] {
+	params := a.params.clone()
+	for _, option := range options {
+		option(params)
+	}
+	return &Agent[C]{Name: params.Name, params: params}
+}
+
+// Tools returns the tools registered on the agent. The slice is a copy;
+// mutating it does not affect the agent.
+func (a *Agent[C]) Tools() []AgentTool[C] {
+	tools := make([]AgentTool[C], len(a.params.Tools))
+	copy(tools, a.params.Tools)
+	return tools
+}
+
 // Run creates a one-time run of the agent and generates a response.
 // A session is created for the run and cleaned up afterwards.
 func (a *Agent[C]) Run(ctx context.Context, request AgentRequest[C]) (*AgentResponse, error) {
@@ -49,7 +70,12 @@ func (a *Agent[C]) Run(ctx context.Context, request AgentRequest[C]) (*AgentResp
 	if err != nil {
 		return nil, err
 	}
-	result, runErr := session.Run(ctx, RunSessionRequest{Input: request.Input})
+	result, runErr := session.Run(ctx, RunSessionRequest{
+		Input:         request.Input,
+		Sampling:      request.Sampling,
+		EnabledTools:  request.EnabledTools,
+		DisabledTools: request.DisabledTools,
+	})
 	closeErr := session.Close(context.WithoutCancel(ctx))
 	if runErr != nil {
 		return nil, runErr
@@ -67,7 +93,12 @@ func (a *Agent[C]) RunStream(ctx context.Context, request AgentRequest[C]) (*Age
 	if err != nil {
 		return nil, err
 	}
-	agentStream, err := session.RunStream(ctx, RunSessionRequest{Input: request.Input})
+	agentStream, err := session.RunStream(ctx, RunSessionRequest{
+		Input:         request.Input,
+		Sampling:      request.Sampling,
+		EnabledTools:  request.EnabledTools,
+		DisabledTools: request.DisabledTools,
+	})
 	if err != nil {
 		_ = session.Close(context.WithoutCancel(ctx))
 		return nil, err
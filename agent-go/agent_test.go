@@ -164,7 +164,7 @@ func TestAgent_Run(t *testing.T) {
 		}
 	})
 
-	t.Run("does not start later tools after a non-cooperative tool finishes", func(t *testing.T) {
+	t.Run("does not start later tools after a non-cooperative tool finishes sequentially", func(t *testing.T) {
 		model := llmsdktest.NewMockLanguageModel()
 		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
 			Content: []llmsdk.Part{
@@ -191,6 +191,7 @@ func TestAgent_Run(t *testing.T) {
 				llmagent.NewAgentFunctionTool[struct{}](first),
 				llmagent.NewAgentFunctionTool[struct{}](second),
 			),
+			llmagent.WithToolConcurrency[struct{}](1),
 		)
 		ctx, cancel := context.WithCancel(t.Context())
 		type runResult struct {
@@ -269,7 +270,7 @@ func TestAgent_Run(t *testing.T) {
 				llmsdk.NewTextPart("Mock response"),
 			},
 			Output: []llmagent.AgentItem{
-				llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+				modelItemFrom(model, llmsdk.ModelResponse{
 					Content: []llmsdk.Part{
 						llmsdk.NewTextPart("Mock response"),
 					},
@@ -408,7 +409,7 @@ func TestAgent_RunStream(t *testing.T) {
 			},
 			llmagent.NewAgentStreamItemEvent(
 				0,
-				llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+				modelItemFrom(model, llmsdk.ModelResponse{
 					Content: []llmsdk.Part{
 						llmsdk.NewTextPart("Mock"),
 					},
@@ -421,7 +422,7 @@ func TestAgent_RunStream(t *testing.T) {
 						llmsdk.NewTextPart("Mock"),
 					},
 					Output: []llmagent.AgentItem{
-						llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+						modelItemFrom(model, llmsdk.ModelResponse{
 							Content: []llmsdk.Part{
 								llmsdk.NewTextPart("Mock"),
 							},
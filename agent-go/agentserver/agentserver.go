@@ -0,0 +1,436 @@
+// Package agentserver serves an Agent over HTTP. It generalises the
+// examples/server scaffolding into a reusable handler: a run endpoint, a
+// streaming endpoint emitting agent events as Server-Sent Events, a tool
+// listing endpoint, per-request agent resolution (e.g. picking a model from
+// the request), CORS headers, pluggable authentication and authorization
+// hooks, and graceful shutdown.
+package agentserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/sse"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// Default route paths. Override them with Params.Routes.
+const (
+	DefaultRunPath    = "/run"
+	DefaultStreamPath = "/run-stream"
+	DefaultToolsPath  = "/tools"
+)
+
+// RunRequest is the JSON body accepted by the run and stream endpoints.
+type RunRequest struct {
+	// Input items for the run.
+	Input []llmagent.AgentItem `json:"input"`
+	// Context is an opaque JSON value decoded into the agent context.
+	Context json.RawMessage `json:"context,omitempty"`
+	// Sampling overrides agent-level sampling defaults for this run.
+	Sampling *llmagent.SamplingOverrides `json:"sampling,omitempty"`
+	// EnabledTools restricts the run to the named tools when non-nil.
+	EnabledTools []string `json:"enabled_tools,omitempty"`
+	// Provider and ModelID identify the model to run against, for servers
+	// that resolve agents per request. The default fixed agent ignores them.
+	Provider string `json:"provider,omitempty"`
+	ModelID  string `json:"model_id,omitempty"`
+	// Metadata is passed through to the resolver alongside the model choice.
+	Metadata llmsdk.LanguageModelMetadata `json:"metadata,omitempty"`
+}
+
+// ToolInfo describes a function tool in the tools listing.
+type ToolInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ContextDecoder decodes the request context JSON into the agent context
+// value. The default decoder unmarshals the JSON directly into C.
+type ContextDecoder[C any] func(ctx context.Context, data json.RawMessage) (C, error)
+
+// Routes overrides the endpoint paths. Empty fields keep the defaults; set a
+// field to "-" to disable that endpoint.
+type Routes struct {
+	Run    string
+	Stream string
+	Tools  string
+}
+
+// Params configures the agent server.
+type Params[C any] struct {
+	// Agent serves every request. Exactly one of Agent or ResolveAgent is
+	// required.
+	Agent *llmagent.Agent[C]
+	// ResolveAgent builds the agent for a request, e.g. resolving the model
+	// from the request body and credentials from the headers. Errors are
+	// reported to the client as 400 responses.
+	ResolveAgent func(r *http.Request, req *RunRequest) (*llmagent.Agent[C], error)
+	// Authenticate verifies the request credentials and yields the caller's
+	// principal; a returned error is reported as a 401 response. The principal
+	// is stored on the request context (PrincipalFromContext) before
+	// ResolveAgent, Authorize, and DecodeContext run, and is injected into
+	// decoded context values implementing PrincipalCarrier. See APIKeyVerifier
+	// and JWTVerifier for ready-made verifiers.
+	Authenticate Verifier
+	// Authorize rejects a request before it is processed. A returned error is
+	// reported as a 401 response with the error message. It runs after
+	// Authenticate.
+	Authorize func(r *http.Request) error
+	// RateLimit bounds requests and token usage per key (API key, tenant, or
+	// address) on the run and stream endpoints, answering 429 with rate-limit
+	// headers once a key exhausts its window. Nil disables rate limiting.
+	RateLimit *RateLimitParams
+	// DecodeContext overrides how the request context JSON is decoded.
+	DecodeContext ContextDecoder[C]
+	// AllowedOrigins lists origins allowed by CORS. "*" allows any origin.
+	// Empty leaves CORS headers off entirely.
+	AllowedOrigins []string
+	// Coalesce merges small consecutive text deltas on the stream endpoint
+	// per the configured thresholds. Clients can tune it per connection with
+	// the coalesce_bytes and coalesce_ms query parameters, or opt out with
+	// coalesce=0. Nil streams every event as its own frame.
+	Coalesce *CoalesceParams
+	// Resume enables resumable streams: frames carry per-run event IDs and
+	// clients that reconnect with Last-Event-ID get the missed events
+	// replayed. Takes precedence over Coalesce, since replayed frames must
+	// be byte-identical to the originals. Nil disables resumption.
+	Resume *ResumeParams
+	// Routes overrides the endpoint paths.
+	Routes Routes
+}
+
+// Server exposes an agent over HTTP.
+type Server[C any] struct {
+	params    Params[C]
+	resume    *resumeRegistry
+	rateLimit *rateLimiter
+}
+
+// New validates the params and creates the server.
+func New[C any](params Params[C]) (*Server[C], error) {
+	if (params.Agent == nil) == (params.ResolveAgent == nil) {
+		return nil, errors.New("agentserver requires exactly one of Agent or ResolveAgent")
+	}
+	server := &Server[C]{params: params}
+	if params.Resume != nil {
+		server.resume = newResumeRegistry(*params.Resume)
+	}
+	if params.RateLimit != nil {
+		if params.RateLimit.Window <= 0 {
+			return nil, errors.New("rate limiting requires a positive Window")
+		}
+		server.rateLimit = newRateLimiter(*params.RateLimit)
+	}
+	return server, nil
+}
+
+// Handler returns the HTTP handler with the configured routes mounted.
+func (s *Server[C]) Handler() http.Handler {
+	mux := http.NewServeMux()
+	if path := routePath(s.params.Routes.Run, DefaultRunPath); path != "" {
+		mux.HandleFunc("POST "+path, s.handleRun)
+	}
+	if path := routePath(s.params.Routes.Stream, DefaultStreamPath); path != "" {
+		mux.HandleFunc("POST "+path, s.handleStream)
+	}
+	if path := routePath(s.params.Routes.Tools, DefaultToolsPath); path != "" {
+		mux.HandleFunc("GET "+path, s.handleTools)
+	}
+	if len(s.params.AllowedOrigins) > 0 {
+		mux.HandleFunc("OPTIONS /", func(w http.ResponseWriter, r *http.Request) {
+			s.setCORSHeaders(w, r)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		return corsMiddleware(s, mux)
+	}
+	return mux
+}
+
+// ListenAndServe serves the handler on the address until the context is
+// cancelled, then shuts the server down gracefully, waiting for in-flight
+// requests to finish.
+func (s *Server[C]) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{Addr: addr, Handler: s.Handler()}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		if err := server.Shutdown(context.WithoutCancel(ctx)); err != nil {
+			return err
+		}
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+func routePath(configured, fallback string) string {
+	switch configured {
+	case "":
+		return fallback
+	case "-":
+		return ""
+	default:
+		return configured
+	}
+}
+
+func corsMiddleware[C any](s *Server[C], next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.setCORSHeaders(w, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server[C]) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	allowed := ""
+	for _, candidate := range s.params.AllowedOrigins {
+		if candidate == "*" || candidate == origin {
+			allowed = candidate
+			break
+		}
+	}
+	if allowed == "" {
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", allowed)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	if allowed != "*" {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Add("Vary", "Origin")
+	}
+}
+
+func (s *Server[C]) handleRun(w http.ResponseWriter, r *http.Request) {
+	agent, agentReq, r, ok := s.prepareRun(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := agent.Run(r.Context(), agentReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.recordRunUsage(r, response)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server[C]) handleStream(w http.ResponseWriter, r *http.Request) {
+	if s.resume != nil {
+		if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+			r, ok := s.authenticate(w, r)
+			if !ok {
+				return
+			}
+			s.serveResume(w, r, lastEventID)
+			return
+		}
+	}
+
+	agent, agentReq, r, ok := s.prepareRun(w, r)
+	if !ok {
+		return
+	}
+
+	if s.resume != nil {
+		s.serveResumable(w, r, agent, agentReq)
+		return
+	}
+
+	stream, err := agent.RunStream(r.Context(), agentReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sse.SetHeaders(w)
+	writer, err := sse.NewWriter(w)
+	if err != nil {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	if coalesce := coalesceQueryParams(s.params.Coalesce, r); coalesce != nil {
+		emit := func(event *llmagent.AgentStreamEvent) error {
+			if event.Response != nil {
+				s.recordRunUsage(r, event.Response)
+			}
+			return writer.Send(event)
+		}
+		if err := CoalesceStream(stream, *coalesce, emit); err != nil {
+			sendStreamError(writer, err)
+		}
+		return
+	}
+
+	for stream.Next() {
+		event := stream.Current()
+		if event.Response != nil {
+			s.recordRunUsage(r, event.Response)
+		}
+		if err := writer.Send(event); err != nil {
+			return
+		}
+	}
+	if err := stream.Err(); err != nil {
+		sendStreamError(writer, err)
+	}
+}
+
+// recordRunUsage charges the finished run's token usage to the request's
+// rate-limit bucket.
+func (s *Server[C]) recordRunUsage(r *http.Request, response *llmagent.AgentResponse) {
+	if s.rateLimit == nil || response == nil || response.Usage == nil {
+		return
+	}
+	s.rateLimit.recordTokens(r, response.Usage.InputTokens+response.Usage.OutputTokens)
+}
+
+func (s *Server[C]) handleTools(w http.ResponseWriter, r *http.Request) {
+	r, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	agent := s.params.Agent
+	if agent == nil {
+		resolved, err := s.params.ResolveAgent(r, &RunRequest{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		agent = resolved
+	}
+
+	tools := []ToolInfo{}
+	for _, tool := range agent.Tools() {
+		if functionTool := tool.AsFunctionTool(); functionTool != nil {
+			tools = append(tools, ToolInfo{
+				Name:        functionTool.Name(),
+				Description: functionTool.Description(),
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tools); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// prepareRun authenticates and rate-limits the request, decodes the body,
+// and resolves the agent. It returns the request updated with the
+// authenticated principal, and reports false after writing an error
+// response.
+func (s *Server[C]) prepareRun(w http.ResponseWriter, r *http.Request) (*llmagent.Agent[C], llmagent.AgentRequest[C], *http.Request, bool) {
+	r, ok := s.authenticate(w, r)
+	if !ok {
+		return nil, llmagent.AgentRequest[C]{}, r, false
+	}
+	if s.rateLimit != nil && !s.rateLimit.allow(w, r) {
+		return nil, llmagent.AgentRequest[C]{}, r, false
+	}
+
+	var req RunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, llmagent.AgentRequest[C]{}, r, false
+	}
+	if len(req.Input) == 0 {
+		http.Error(w, "input must not be empty", http.StatusBadRequest)
+		return nil, llmagent.AgentRequest[C]{}, r, false
+	}
+
+	agent := s.params.Agent
+	if agent == nil {
+		resolved, err := s.params.ResolveAgent(r, &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return nil, llmagent.AgentRequest[C]{}, r, false
+		}
+		agent = resolved
+	}
+
+	var contextVal C
+	if len(req.Context) > 0 {
+		if s.params.DecodeContext != nil {
+			decoded, err := s.params.DecodeContext(r.Context(), req.Context)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("decode context: %v", err), http.StatusBadRequest)
+				return nil, llmagent.AgentRequest[C]{}, r, false
+			}
+			contextVal = decoded
+		} else if err := json.Unmarshal(req.Context, &contextVal); err != nil {
+			http.Error(w, fmt.Sprintf("decode context: %v", err), http.StatusBadRequest)
+			return nil, llmagent.AgentRequest[C]{}, r, false
+		}
+	}
+
+	if principal := PrincipalFromContext(r.Context()); principal != nil {
+		if carrier, ok := any(&contextVal).(PrincipalCarrier); ok {
+			carrier.SetPrincipal(*principal)
+		} else if carrier, ok := any(contextVal).(PrincipalCarrier); ok {
+			carrier.SetPrincipal(*principal)
+		}
+	}
+
+	return agent, llmagent.AgentRequest[C]{
+		Input:        req.Input,
+		Context:      contextVal,
+		Sampling:     req.Sampling,
+		EnabledTools: req.EnabledTools,
+	}, r, true
+}
+
+// authenticate runs the Authenticate and Authorize hooks. It returns the
+// request, updated with the authenticated principal on its context, and
+// reports false after writing a 401 response.
+func (s *Server[C]) authenticate(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	if s.params.Authenticate != nil {
+		principal, err := s.params.Authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return r, false
+		}
+		if principal != nil {
+			r = r.WithContext(ContextWithPrincipal(r.Context(), principal))
+		}
+	}
+	if s.params.Authorize != nil {
+		if err := s.params.Authorize(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return r, false
+		}
+	}
+	return r, true
+}
+
+// sendStreamError serializes agent errors with their stable code so clients
+// can branch on the failure kind; other errors fall back to a plain message.
+func sendStreamError(writer *sse.Writer, err error) {
+	var agentErr *llmagent.AgentError
+	if errors.As(err, &agentErr) {
+		_ = writer.Send(map[string]any{"event": "error", "error": agentErr})
+		return
+	}
+	_ = writer.SendError(err.Error())
+}
@@ -0,0 +1,241 @@
+package agentserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/agentserver"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+const runBody = `{"input":[{"type":"message","role":"user","content":[{"type":"text","text":"hi"}]}]}`
+
+func newTestAgent(model *llmsdktest.MockLanguageModel) *llmagent.Agent[any] {
+	return llmagent.NewAgent[any]("assistant", model)
+}
+
+// staticTool is a no-op function tool for the tools listing test.
+type staticTool struct {
+	name, description string
+}
+
+func (t *staticTool) Name() string                  { return t.name }
+func (t *staticTool) Description() string           { return t.description }
+func (t *staticTool) Parameters() llmsdk.JSONSchema { return llmsdk.JSONSchema{"type": "object"} }
+func (t *staticTool) Execute(context.Context, json.RawMessage, any, *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	return llmagent.AgentToolResult{}, nil
+}
+
+func TestServerRun(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hello there")},
+	}))
+
+	server, err := agentserver.New(agentserver.Params[any]{Agent: newTestAgent(model)})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/run", "application/json", strings.NewReader(runBody))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+
+	var response llmagent.AgentResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Content) != 1 || response.Content[0].TextPart == nil ||
+		response.Content[0].TextPart.Text != "hello there" {
+		t.Errorf("unexpected response content %+v", response.Content)
+	}
+}
+
+func TestServerRunStream(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("hello")}},
+	}))
+
+	server, err := agentserver.New(agentserver.Params[any]{Agent: newTestAgent(model)})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/run-stream", "application/json", strings.NewReader(runBody))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+	if got := res.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("unexpected content type %q", got)
+	}
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read stream body: %v", err)
+	}
+	body := string(raw)
+	if !strings.Contains(body, "data: ") || !strings.Contains(body, `"response"`) {
+		t.Errorf("expected SSE frames ending with a response event, got %q", body)
+	}
+}
+
+func TestServerTools(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	tool := &staticTool{name: "get_weather", description: "Get the weather for a location"}
+	agent := llmagent.NewAgent("assistant", model,
+		llmagent.WithTools(llmagent.FunctionTools[any](tool)...),
+	)
+
+	server, err := agentserver.New(agentserver.Params[any]{Agent: agent})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/tools")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	var tools []agentserver.ToolInfo
+	if err := json.NewDecoder(res.Body).Decode(&tools); err != nil {
+		t.Fatalf("failed to decode tools: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name != "get_weather" {
+		t.Errorf("unexpected tools %+v", tools)
+	}
+}
+
+func TestServerAuthorize(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	server, err := agentserver.New(agentserver.Params[any]{
+		Agent: newTestAgent(model),
+		Authorize: func(r *http.Request) error {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				return errors.New("missing credentials")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/run", "application/json", strings.NewReader(runBody))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 without credentials, got %d", res.StatusCode)
+	}
+}
+
+func TestServerResolveAgent(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("resolved")},
+	}))
+
+	var seen string
+	server, err := agentserver.New(agentserver.Params[any]{
+		ResolveAgent: func(r *http.Request, req *agentserver.RunRequest) (*llmagent.Agent[any], error) {
+			seen = req.Provider + "/" + req.ModelID
+			if req.Provider != "mock" {
+				return nil, errors.New("unknown provider")
+			}
+			return newTestAgent(model), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	body := `{"provider":"mock","model_id":"mock-1","input":[{"type":"message","role":"user","content":[{"type":"text","text":"hi"}]}]}`
+	res, err := http.Post(ts.URL+"/run", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+	if seen != "mock/mock-1" {
+		t.Errorf("expected the resolver to see the model choice, got %q", seen)
+	}
+
+	res, err = http.Post(ts.URL+"/run", "application/json",
+		strings.NewReader(`{"provider":"bad","input":[{"type":"message","role":"user","content":[{"type":"text","text":"hi"}]}]}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for a resolver error, got %d", res.StatusCode)
+	}
+}
+
+func TestServerCORS(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	server, err := agentserver.New(agentserver.Params[any]{
+		Agent:          newTestAgent(model),
+		AllowedOrigins: []string{"http://localhost:4321"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodOptions, ts.URL+"/run", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Origin", "http://localhost:4321")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		t.Errorf("expected 204 for preflight, got %d", res.StatusCode)
+	}
+	if got := res.Header.Get("Access-Control-Allow-Origin"); got != "http://localhost:4321" {
+		t.Errorf("unexpected allow origin %q", got)
+	}
+
+	req.Header.Set("Origin", "http://evil.example")
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if got := res.Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no allow origin for an unknown origin, got %q", got)
+	}
+}
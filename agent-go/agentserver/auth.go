@@ -0,0 +1,144 @@
+package agentserver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Principal identifies the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the caller, e.g. the API key owner or the JWT sub.
+	Subject string
+	// Claims carries verifier-specific attributes, e.g. the JWT claims.
+	Claims map[string]any
+}
+
+// Verifier authenticates a request and returns the caller's principal. Use
+// APIKeyVerifier or JWTVerifier, or supply a custom implementation (e.g.
+// session cookies or mTLS client certificates).
+type Verifier func(r *http.Request) (*Principal, error)
+
+// PrincipalCarrier is implemented by agent context values that want the
+// authenticated principal injected after the request context is decoded.
+type PrincipalCarrier interface {
+	SetPrincipal(principal Principal)
+}
+
+type principalContextKey struct{}
+
+// ContextWithPrincipal stores the principal on the context.
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored by the Authenticate hook,
+// or nil when the request was not authenticated. Available to ResolveAgent,
+// Authorize, and DecodeContext.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return principal
+}
+
+// APIKeyVerifier authenticates requests by the API key presented in the named
+// header, falling back to a bearer Authorization value when the header is
+// "Authorization". lookup maps the presented key to its principal and should
+// return an error for unknown keys.
+func APIKeyVerifier(header string, lookup func(ctx context.Context, key string) (*Principal, error)) Verifier {
+	return func(r *http.Request) (*Principal, error) {
+		key := r.Header.Get(header)
+		if strings.EqualFold(header, "Authorization") {
+			key = strings.TrimPrefix(key, "Bearer ")
+		}
+		if key == "" {
+			return nil, fmt.Errorf("missing API key in %s header", header)
+		}
+		return lookup(r.Context(), key)
+	}
+}
+
+// StaticAPIKeys builds an APIKeyVerifier lookup from a fixed map of API keys
+// to their subjects.
+func StaticAPIKeys(keys map[string]string) func(ctx context.Context, key string) (*Principal, error) {
+	return func(_ context.Context, key string) (*Principal, error) {
+		subject, ok := keys[key]
+		if !ok {
+			return nil, errors.New("unknown API key")
+		}
+		return &Principal{Subject: subject}, nil
+	}
+}
+
+// JWTVerifier authenticates requests by the HS256-signed bearer token in the
+// Authorization header, validating the signature against the secret and the
+// exp and nbf claims against the clock. The returned principal carries the
+// token claims with the sub claim as its subject. Tokens signed with other
+// algorithms need a custom Verifier built on a full JWT library.
+func JWTVerifier(secret []byte) Verifier {
+	return func(r *http.Request) (*Principal, error) {
+		authorization := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		if token == "" || token == authorization {
+			return nil, errors.New("missing bearer token")
+		}
+		return verifyJWT(token, secret, time.Now())
+	}
+}
+
+// verifyJWT validates the compact serialization of an HS256 JWT.
+func verifyJWT(token string, secret []byte, now time.Time) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.New("malformed token header")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, errors.New("malformed token header")
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported token algorithm %s", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed token signature")
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("invalid token signature")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed token claims")
+	}
+	claims := map[string]any{}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, errors.New("malformed token claims")
+	}
+
+	if exp, ok := claims["exp"].(float64); ok && now.Unix() >= int64(exp) {
+		return nil, errors.New("token has expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Unix() < int64(nbf) {
+		return nil, errors.New("token is not valid yet")
+	}
+
+	subject, _ := claims["sub"].(string)
+	return &Principal{Subject: subject, Claims: claims}, nil
+}
@@ -0,0 +1,184 @@
+package agentserver_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/agentserver"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// authedContext receives the authenticated principal after decoding.
+type authedContext struct {
+	Subject string `json:"subject"`
+}
+
+func (c *authedContext) SetPrincipal(principal agentserver.Principal) {
+	c.Subject = principal.Subject
+}
+
+func postRunWithHeader(t *testing.T, url, header, value string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url+"/run", strings.NewReader(runBody))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return res
+}
+
+func TestServerAPIKeyAuthentication(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+	}))
+
+	server, err := agentserver.New(agentserver.Params[any]{
+		Agent: newTestAgent(model),
+		Authenticate: agentserver.APIKeyVerifier("X-API-Key", agentserver.StaticAPIKeys(map[string]string{
+			"secret-key": "acme",
+		})),
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res := postRunWithHeader(t, ts.URL, "X-API-Key", "secret-key")
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 with a valid key, got %d", res.StatusCode)
+	}
+
+	missing := postRunWithHeader(t, ts.URL, "", "")
+	defer missing.Body.Close()
+	if missing.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a key, got %d", missing.StatusCode)
+	}
+
+	wrong := postRunWithHeader(t, ts.URL, "X-API-Key", "wrong-key")
+	defer wrong.Body.Close()
+	if wrong.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 with an unknown key, got %d", wrong.StatusCode)
+	}
+}
+
+// signJWT builds an HS256 token for the JWT verifier tests.
+func signJWT(t *testing.T, secret []byte, claims map[string]any) string {
+	t.Helper()
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("failed to marshal token segment: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encode(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestServerJWTAuthentication(t *testing.T) {
+	secret := []byte("jwt-secret")
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+	}))
+
+	server, err := agentserver.New(agentserver.Params[any]{
+		Agent:        newTestAgent(model),
+		Authenticate: agentserver.JWTVerifier(secret),
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	valid := signJWT(t, secret, map[string]any{
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	res := postRunWithHeader(t, ts.URL, "Authorization", "Bearer "+valid)
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 with a valid token, got %d", res.StatusCode)
+	}
+
+	expired := signJWT(t, secret, map[string]any{
+		"sub": "user-1",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	})
+	expiredRes := postRunWithHeader(t, ts.URL, "Authorization", "Bearer "+expired)
+	defer expiredRes.Body.Close()
+	if expiredRes.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 with an expired token, got %d", expiredRes.StatusCode)
+	}
+
+	forged := signJWT(t, []byte("other-secret"), map[string]any{"sub": "user-1"})
+	forgedRes := postRunWithHeader(t, ts.URL, "Authorization", "Bearer "+forged)
+	defer forgedRes.Body.Close()
+	if forgedRes.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 with a forged token, got %d", forgedRes.StatusCode)
+	}
+}
+
+func TestServerInjectsPrincipalIntoContextValue(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+	}))
+	agent := llmagent.NewAgent("assistant", model,
+		llmagent.WithInstructions(llmagent.InstructionParam[authedContext]{
+			Func: func(_ context.Context, contextVal authedContext) (string, error) {
+				return fmt.Sprintf("You are serving %s.", contextVal.Subject), nil
+			},
+		}),
+	)
+
+	server, err := agentserver.New(agentserver.Params[authedContext]{
+		Agent: agent,
+		Authenticate: agentserver.APIKeyVerifier("X-API-Key", agentserver.StaticAPIKeys(map[string]string{
+			"secret-key": "acme",
+		})),
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res := postRunWithHeader(t, ts.URL, "X-API-Key", "secret-key")
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected 1 model call, got %d", len(inputs))
+	}
+	if inputs[0].SystemPrompt == nil || *inputs[0].SystemPrompt != "You are serving acme." {
+		t.Errorf("expected the principal injected into the context value, got %v", inputs[0].SystemPrompt)
+	}
+}
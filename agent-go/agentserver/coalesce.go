@@ -0,0 +1,182 @@
+package agentserver
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// CoalesceParams configures text-delta coalescing on stream connections. Fast
+// models emit many tiny text deltas; coalescing merges consecutive deltas for
+// the same part into one event, flushed by byte or time threshold, cutting
+// SSE frame overhead without hurting perceived latency.
+type CoalesceParams struct {
+	// MaxBytes flushes the buffered text once it reaches this many bytes.
+	// Zero or less defaults to DefaultCoalesceBytes.
+	MaxBytes int
+	// MaxDelay flushes the buffered text once the oldest unsent delta is
+	// this old. Zero or less defaults to DefaultCoalesceDelay.
+	MaxDelay time.Duration
+}
+
+// Defaults for CoalesceParams fields that are left zero.
+const (
+	DefaultCoalesceBytes = 256
+	DefaultCoalesceDelay = 50 * time.Millisecond
+)
+
+// coalesceQueryParams applies per-connection overrides from the request query
+// parameters: coalesce_bytes and coalesce_ms tune the thresholds, and
+// coalesce=0 disables coalescing for the connection. A nil return means no
+// coalescing.
+func coalesceQueryParams(configured *CoalesceParams, r *http.Request) *CoalesceParams {
+	query := r.URL.Query()
+	if query.Get("coalesce") == "0" {
+		return nil
+	}
+	if configured == nil {
+		return nil
+	}
+	params := *configured
+	if raw := query.Get("coalesce_bytes"); raw != "" {
+		if bytes, err := strconv.Atoi(raw); err == nil && bytes > 0 {
+			params.MaxBytes = bytes
+		}
+	}
+	if raw := query.Get("coalesce_ms"); raw != "" {
+		if ms, err := strconv.Atoi(raw); err == nil && ms > 0 {
+			params.MaxDelay = time.Duration(ms) * time.Millisecond
+		}
+	}
+	return &params
+}
+
+// CoalesceStream reads the agent stream and invokes emit for every event,
+// merging consecutive text deltas for the same content index into single
+// events per the thresholds. Events other than plain text deltas flush the
+// buffer and pass through unchanged, so ordering is preserved. It returns the
+// stream error, or the first emit error.
+func CoalesceStream(stream *llmagent.AgentStream, params CoalesceParams, emit func(*llmagent.AgentStreamEvent) error) error {
+	if params.MaxBytes <= 0 {
+		params.MaxBytes = DefaultCoalesceBytes
+	}
+	if params.MaxDelay <= 0 {
+		params.MaxDelay = DefaultCoalesceDelay
+	}
+
+	events := make(chan *llmagent.AgentStreamEvent)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(events)
+		for stream.Next() {
+			events <- stream.Current()
+		}
+		errCh <- stream.Err()
+	}()
+
+	var (
+		bufferedIndex int
+		bufferedText  []byte
+		flushTimer    *time.Timer
+		timerC        <-chan time.Time
+	)
+	stopTimer := func() {
+		if flushTimer != nil {
+			flushTimer.Stop()
+			flushTimer = nil
+			timerC = nil
+		}
+	}
+	defer stopTimer()
+	// abandon drains the reader goroutine when emit fails, so it does not
+	// block on the events channel forever.
+	abandon := func() {
+		go func() {
+			for range events {
+			}
+		}()
+	}
+	flush := func() error {
+		stopTimer()
+		if bufferedText == nil {
+			return nil
+		}
+		event := llmagent.NewAgentStreamEventPartial(&llmsdk.PartialModelResponse{
+			Delta: &llmsdk.ContentDelta{
+				Index: bufferedIndex,
+				Part:  llmsdk.NewTextPartDelta(string(bufferedText)),
+			},
+		})
+		bufferedText = nil
+		return emit(event)
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if err := flush(); err != nil {
+					// Drain the reader goroutine before returning.
+					<-errCh
+					return err
+				}
+				return <-errCh
+			}
+			if delta, ok := plainTextDelta(event); ok {
+				if bufferedText != nil && delta.Index != bufferedIndex {
+					if err := flush(); err != nil {
+						abandon()
+						return err
+					}
+				}
+				bufferedIndex = delta.Index
+				bufferedText = append(bufferedText, delta.Part.TextPartDelta.Text...)
+				if len(bufferedText) >= params.MaxBytes {
+					if err := flush(); err != nil {
+						abandon()
+						return err
+					}
+				} else if flushTimer == nil {
+					flushTimer = time.NewTimer(params.MaxDelay)
+					timerC = flushTimer.C
+				}
+				continue
+			}
+			if err := flush(); err != nil {
+				abandon()
+				return err
+			}
+			if err := emit(event); err != nil {
+				abandon()
+				return err
+			}
+		case <-timerC:
+			flushTimer = nil
+			timerC = nil
+			if err := flush(); err != nil {
+				abandon()
+				return err
+			}
+		}
+	}
+}
+
+// plainTextDelta returns the content delta when the event is a text delta
+// carrying nothing else, i.e. safe to merge with its neighbours.
+func plainTextDelta(event *llmagent.AgentStreamEvent) (*llmsdk.ContentDelta, bool) {
+	if event == nil || event.Partial == nil {
+		return nil, false
+	}
+	partial := event.Partial
+	if partial.Usage != nil || partial.Cost != nil || partial.Delta == nil {
+		return nil, false
+	}
+	textDelta := partial.Delta.Part.TextPartDelta
+	if textDelta == nil || textDelta.Citation != nil || textDelta.Signature != nil {
+		return nil, false
+	}
+	return partial.Delta, true
+}
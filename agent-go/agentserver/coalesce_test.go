@@ -0,0 +1,202 @@
+package agentserver_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/agentserver"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/stream"
+)
+
+func textDeltaEvent(index int, text string) *llmagent.AgentStreamEvent {
+	return llmagent.NewAgentStreamEventPartial(&llmsdk.PartialModelResponse{
+		Delta: &llmsdk.ContentDelta{Index: index, Part: llmsdk.NewTextPartDelta(text)},
+	})
+}
+
+func eventStream(events ...*llmagent.AgentStreamEvent) *llmagent.AgentStream {
+	eventChan := make(chan *llmagent.AgentStreamEvent)
+	errChan := make(chan error, 1)
+	go func() {
+		defer close(eventChan)
+		defer close(errChan)
+		for _, event := range events {
+			eventChan <- event
+		}
+	}()
+	return stream.New(eventChan, errChan)
+}
+
+func collectCoalesced(t *testing.T, params agentserver.CoalesceParams, events ...*llmagent.AgentStreamEvent) []*llmagent.AgentStreamEvent {
+	t.Helper()
+	var emitted []*llmagent.AgentStreamEvent
+	err := agentserver.CoalesceStream(eventStream(events...), params, func(event *llmagent.AgentStreamEvent) error {
+		emitted = append(emitted, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return emitted
+}
+
+func TestCoalesceMergesSmallTextDeltas(t *testing.T) {
+	emitted := collectCoalesced(t, agentserver.CoalesceParams{MaxBytes: 1024, MaxDelay: time.Second},
+		textDeltaEvent(0, "Hel"),
+		textDeltaEvent(0, "lo "),
+		textDeltaEvent(0, "world"),
+	)
+
+	if len(emitted) != 1 {
+		t.Fatalf("expected 1 coalesced event, got %d", len(emitted))
+	}
+	if text := emitted[0].Partial.Delta.Part.TextPartDelta.Text; text != "Hello world" {
+		t.Errorf("unexpected coalesced text %q", text)
+	}
+}
+
+func TestCoalesceFlushesOnByteThreshold(t *testing.T) {
+	emitted := collectCoalesced(t, agentserver.CoalesceParams{MaxBytes: 6, MaxDelay: time.Second},
+		textDeltaEvent(0, "abc"),
+		textDeltaEvent(0, "def"),
+		textDeltaEvent(0, "gh"),
+	)
+
+	if len(emitted) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(emitted))
+	}
+	if text := emitted[0].Partial.Delta.Part.TextPartDelta.Text; text != "abcdef" {
+		t.Errorf("unexpected first flush %q", text)
+	}
+	if text := emitted[1].Partial.Delta.Part.TextPartDelta.Text; text != "gh" {
+		t.Errorf("unexpected final flush %q", text)
+	}
+}
+
+func TestCoalesceFlushesBeforeOtherEvents(t *testing.T) {
+	response := &llmagent.AgentResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("done")}}
+	emitted := collectCoalesced(t, agentserver.CoalesceParams{MaxBytes: 1024, MaxDelay: time.Second},
+		textDeltaEvent(0, "partial "),
+		textDeltaEvent(0, "text"),
+		llmagent.NewAgentStreamEventResponse(response),
+	)
+
+	if len(emitted) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(emitted))
+	}
+	if text := emitted[0].Partial.Delta.Part.TextPartDelta.Text; text != "partial text" {
+		t.Errorf("expected the buffer flushed before the response, got %q", text)
+	}
+	if emitted[1].Response == nil {
+		t.Errorf("expected the response event passed through, got %+v", emitted[1])
+	}
+}
+
+func TestCoalesceKeepsIndexesSeparate(t *testing.T) {
+	emitted := collectCoalesced(t, agentserver.CoalesceParams{MaxBytes: 1024, MaxDelay: time.Second},
+		textDeltaEvent(0, "first"),
+		textDeltaEvent(1, "second"),
+	)
+
+	if len(emitted) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(emitted))
+	}
+	if emitted[0].Partial.Delta.Index != 0 || emitted[1].Partial.Delta.Index != 1 {
+		t.Errorf("expected per-index events, got %+v", emitted)
+	}
+}
+
+func TestCoalesceFlushesOnDelay(t *testing.T) {
+	eventChan := make(chan *llmagent.AgentStreamEvent)
+	errChan := make(chan error, 1)
+	go func() {
+		defer close(eventChan)
+		defer close(errChan)
+		eventChan <- textDeltaEvent(0, "early")
+		// Hold the stream open past the flush delay.
+		time.Sleep(200 * time.Millisecond)
+		eventChan <- textDeltaEvent(0, "late")
+	}()
+
+	var emitted []*llmagent.AgentStreamEvent
+	flushed := make(chan time.Time, 2)
+	err := agentserver.CoalesceStream(stream.New(eventChan, errChan), agentserver.CoalesceParams{MaxBytes: 1024, MaxDelay: 20 * time.Millisecond}, func(event *llmagent.AgentStreamEvent) error {
+		emitted = append(emitted, event)
+		flushed <- time.Now()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(emitted) != 2 {
+		t.Fatalf("expected the delayed flush to split the deltas, got %d events", len(emitted))
+	}
+	if text := emitted[0].Partial.Delta.Part.TextPartDelta.Text; text != "early" {
+		t.Errorf("expected the first delta flushed by the timer, got %q", text)
+	}
+}
+
+func newStreamingAgent(chunks ...string) *llmagent.Agent[any] {
+	model := llmsdktest.NewMockLanguageModel()
+	partials := make([]llmsdk.PartialModelResponse, len(chunks))
+	for i, chunk := range chunks {
+		partials[i] = llmsdk.PartialModelResponse{
+			Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta(chunk)},
+		}
+	}
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials(partials))
+	return llmagent.NewAgent[any]("assistant", model)
+}
+
+func streamRequest(t *testing.T, params agentserver.Params[any], query string) string {
+	t.Helper()
+	server, err := agentserver.New(params)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/run-stream"+query, "application/json", strings.NewReader(runBody))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read stream body: %v", err)
+	}
+	return string(raw)
+}
+
+func TestCoalesceStreamEndpoint(t *testing.T) {
+	body := streamRequest(t, agentserver.Params[any]{
+		Agent:    newStreamingAgent("Hel", "lo ", "world"),
+		Coalesce: &agentserver.CoalesceParams{MaxBytes: 1024, MaxDelay: time.Second},
+	}, "")
+
+	if got := strings.Count(body, `"event":"partial"`); got != 1 {
+		t.Errorf("expected 1 coalesced partial frame, got %d in %q", got, body)
+	}
+	if !strings.Contains(body, "Hello world") {
+		t.Errorf("expected the merged text in the stream, got %q", body)
+	}
+}
+
+func TestCoalesceDisabledPerConnection(t *testing.T) {
+	body := streamRequest(t, agentserver.Params[any]{
+		Agent:    newStreamingAgent("Hel", "lo ", "world"),
+		Coalesce: &agentserver.CoalesceParams{MaxBytes: 1024, MaxDelay: time.Second},
+	}, "?coalesce=0")
+
+	if got := strings.Count(body, `"event":"partial"`); got != 3 {
+		t.Errorf("expected 3 uncoalesced partial frames, got %d in %q", got, body)
+	}
+}
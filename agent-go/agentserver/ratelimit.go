@@ -0,0 +1,114 @@
+package agentserver
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimitParams configures per-key rate limiting for the run and stream
+// endpoints.
+type RateLimitParams struct {
+	// Key derives the limit bucket from the request, e.g. the API key or the
+	// tenant ID. Nil uses the authenticated principal's subject, falling back
+	// to the remote address for unauthenticated requests.
+	Key func(r *http.Request) string
+	// Requests is the max number of requests per key per window. Zero
+	// disables the request limit.
+	Requests int
+	// Tokens is the max number of input+output tokens consumed per key per
+	// window. Usage is known only after a run finishes, so a key is rejected
+	// once its recorded usage has exhausted the budget rather than
+	// mid-overrun. Zero disables the token limit.
+	Tokens int
+	// Window is the fixed window the limits apply to. Required.
+	Window time.Duration
+}
+
+// rateLimiter tracks fixed-window request and token counts per key.
+type rateLimiter struct {
+	params RateLimitParams
+
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+}
+
+type rateBucket struct {
+	windowStart time.Time
+	requests    int
+	tokens      int
+}
+
+func newRateLimiter(params RateLimitParams) *rateLimiter {
+	return &rateLimiter{params: params, buckets: map[string]*rateBucket{}}
+}
+
+// key derives the limit bucket for the request.
+func (l *rateLimiter) key(r *http.Request) string {
+	if l.params.Key != nil {
+		return l.params.Key(r)
+	}
+	if principal := PrincipalFromContext(r.Context()); principal != nil && principal.Subject != "" {
+		return principal.Subject
+	}
+	return r.RemoteAddr
+}
+
+// allow reserves one request for the key, writing the rate-limit headers and
+// a 429 response when a limit is exhausted.
+func (l *rateLimiter) allow(w http.ResponseWriter, r *http.Request) bool {
+	key := l.key(r)
+	now := time.Now()
+
+	l.mu.Lock()
+	bucket, ok := l.buckets[key]
+	if !ok || now.Sub(bucket.windowStart) >= l.params.Window {
+		bucket = &rateBucket{windowStart: now}
+		l.buckets[key] = bucket
+	}
+	reset := bucket.windowStart.Add(l.params.Window)
+
+	exceeded := (l.params.Requests > 0 && bucket.requests >= l.params.Requests) ||
+		(l.params.Tokens > 0 && bucket.tokens >= l.params.Tokens)
+	if !exceeded {
+		bucket.requests++
+	}
+	remaining := 0
+	if l.params.Requests > 0 {
+		remaining = max(l.params.Requests-bucket.requests, 0)
+	}
+	l.mu.Unlock()
+
+	if l.params.Requests > 0 {
+		w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", l.params.Requests))
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
+	}
+	w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", reset.Unix()))
+
+	if exceeded {
+		retryAfter := max(int(time.Until(reset).Seconds()), 1)
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return false
+	}
+	return true
+}
+
+// recordTokens charges the run's token usage to the key's current window.
+func (l *rateLimiter) recordTokens(r *http.Request, tokens int) {
+	if l.params.Tokens == 0 || tokens <= 0 {
+		return
+	}
+	key := l.key(r)
+	now := time.Now()
+
+	l.mu.Lock()
+	bucket, ok := l.buckets[key]
+	if !ok || now.Sub(bucket.windowStart) >= l.params.Window {
+		bucket = &rateBucket{windowStart: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens += tokens
+	l.mu.Unlock()
+}
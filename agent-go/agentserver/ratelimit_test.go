@@ -0,0 +1,126 @@
+package agentserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/agentserver"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func newRateLimitedServer(t *testing.T, model *llmsdktest.MockLanguageModel, params agentserver.RateLimitParams) *httptest.Server {
+	t.Helper()
+	if params.Key == nil {
+		params.Key = func(r *http.Request) string { return r.Header.Get("X-API-Key") }
+	}
+	server, err := agentserver.New(agentserver.Params[any]{
+		Agent:     newTestAgent(model),
+		RateLimit: &params,
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestServerRateLimitsRequestsPerKey(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	for range 3 {
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+		}))
+	}
+	ts := newRateLimitedServer(t, model, agentserver.RateLimitParams{
+		Requests: 2,
+		Window:   time.Minute,
+	})
+
+	for i := range 2 {
+		res := postRunWithHeader(t, ts.URL, "X-API-Key", "noisy")
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected request %d allowed, got %d", i+1, res.StatusCode)
+		}
+		if got := res.Header.Get("X-RateLimit-Limit"); got != "2" {
+			t.Errorf("unexpected X-RateLimit-Limit %q", got)
+		}
+	}
+
+	limited := postRunWithHeader(t, ts.URL, "X-API-Key", "noisy")
+	defer limited.Body.Close()
+	if limited.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the window is exhausted, got %d", limited.StatusCode)
+	}
+	if limited.Header.Get("Retry-After") == "" || limited.Header.Get("X-RateLimit-Reset") == "" {
+		t.Error("expected Retry-After and X-RateLimit-Reset headers on the 429")
+	}
+
+	other := postRunWithHeader(t, ts.URL, "X-API-Key", "quiet")
+	defer other.Body.Close()
+	if other.StatusCode != http.StatusOK {
+		t.Errorf("expected another key unaffected, got %d", other.StatusCode)
+	}
+}
+
+func TestServerRateLimitsTokensPerKey(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+			Usage:   &llmsdk.ModelUsage{InputTokens: 80, OutputTokens: 40},
+		}),
+	)
+	ts := newRateLimitedServer(t, model, agentserver.RateLimitParams{
+		Tokens: 100,
+		Window: time.Minute,
+	})
+
+	res := postRunWithHeader(t, ts.URL, "X-API-Key", "hungry")
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected the first request allowed, got %d", res.StatusCode)
+	}
+
+	limited := postRunWithHeader(t, ts.URL, "X-API-Key", "hungry")
+	defer limited.Body.Close()
+	if limited.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected 429 once the token budget is spent, got %d", limited.StatusCode)
+	}
+}
+
+func TestServerRateLimitWindowResets(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	for range 2 {
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+		}))
+	}
+	ts := newRateLimitedServer(t, model, agentserver.RateLimitParams{
+		Requests: 1,
+		Window:   50 * time.Millisecond,
+	})
+
+	res := postRunWithHeader(t, ts.URL, "X-API-Key", "key")
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected the first request allowed, got %d", res.StatusCode)
+	}
+
+	limited := postRunWithHeader(t, ts.URL, "X-API-Key", "key")
+	limited.Body.Close()
+	if limited.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 within the window, got %d", limited.StatusCode)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	recovered := postRunWithHeader(t, ts.URL, "X-API-Key", "key")
+	defer recovered.Body.Close()
+	if recovered.StatusCode != http.StatusOK {
+		t.Errorf("expected the limit reset after the window, got %d", recovered.StatusCode)
+	}
+}
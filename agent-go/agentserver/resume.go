@@ -0,0 +1,206 @@
+package agentserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/sse"
+)
+
+// ResumeParams enables resumable streams. Every SSE frame carries an id of
+// the form "<run id>:<sequence>"; when a dropped client reconnects to the
+// stream endpoint with a Last-Event-ID header, the server replays the events
+// it missed from a short-lived per-run buffer and continues with the live
+// stream. The run keeps executing server-side while no client is attached.
+type ResumeParams struct {
+	// TTL is how long a finished run's event buffer is retained for late
+	// reconnects. Zero or less defaults to DefaultResumeTTL.
+	TTL time.Duration
+}
+
+// DefaultResumeTTL is the retention for finished run buffers when none is
+// configured.
+const DefaultResumeTTL = time.Minute
+
+// runBuffer accumulates the events of one run so reconnecting clients can
+// replay from any sequence number.
+type runBuffer struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	events []*llmagent.AgentStreamEvent
+	done   bool
+	err    error
+}
+
+func newRunBuffer() *runBuffer {
+	b := &runBuffer{}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+func (b *runBuffer) append(event *llmagent.AgentStreamEvent) {
+	b.mu.Lock()
+	b.events = append(b.events, event)
+	b.mu.Unlock()
+	b.cond.Broadcast()
+}
+
+func (b *runBuffer) finish(err error) {
+	b.mu.Lock()
+	b.done = true
+	b.err = err
+	b.mu.Unlock()
+	b.cond.Broadcast()
+}
+
+// next blocks until the event at index exists, the run finishes, or the
+// context is cancelled. It returns the event, or nil when the stream ended
+// (with the run error, if any).
+func (b *runBuffer) next(ctx context.Context, index int) (*llmagent.AgentStreamEvent, error) {
+	stop := context.AfterFunc(ctx, b.cond.Broadcast)
+	defer stop()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for {
+		if index < len(b.events) {
+			return b.events[index], nil
+		}
+		if b.done {
+			return nil, b.err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		b.cond.Wait()
+	}
+}
+
+// resumeRegistry tracks the buffers of live and recently finished runs.
+type resumeRegistry struct {
+	ttl time.Duration
+
+	mu   sync.Mutex
+	runs map[string]*runBuffer
+}
+
+func newResumeRegistry(params ResumeParams) *resumeRegistry {
+	ttl := params.TTL
+	if ttl <= 0 {
+		ttl = DefaultResumeTTL
+	}
+	return &resumeRegistry{ttl: ttl, runs: map[string]*runBuffer{}}
+}
+
+func (r *resumeRegistry) create(runID string) *runBuffer {
+	buffer := newRunBuffer()
+	r.mu.Lock()
+	r.runs[runID] = buffer
+	r.mu.Unlock()
+	return buffer
+}
+
+func (r *resumeRegistry) get(runID string) (*runBuffer, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	buffer, ok := r.runs[runID]
+	return buffer, ok
+}
+
+// evictAfterTTL drops the buffer once the retention window for the finished
+// run has passed.
+func (r *resumeRegistry) evictAfterTTL(runID string) {
+	time.AfterFunc(r.ttl, func() {
+		r.mu.Lock()
+		delete(r.runs, runID)
+		r.mu.Unlock()
+	})
+}
+
+// serveResumable starts the run detached from the request context, records
+// its events, and streams them to the client with resumable frame IDs.
+func (s *Server[C]) serveResumable(w http.ResponseWriter, r *http.Request, agent *llmagent.Agent[C], agentReq llmagent.AgentRequest[C]) {
+	// The run must survive client disconnects so a reconnect can pick it
+	// up; it ends on its own or when the buffer expires.
+	stream, err := agent.RunStream(context.WithoutCancel(r.Context()), agentReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	runID := uuid.NewString()
+	buffer := s.resume.create(runID)
+	go func() {
+		for stream.Next() {
+			event := stream.Current()
+			if event.Response != nil {
+				s.recordRunUsage(r, event.Response)
+			}
+			buffer.append(event)
+		}
+		buffer.finish(stream.Err())
+		s.resume.evictAfterTTL(runID)
+	}()
+
+	s.streamBuffer(w, r, runID, buffer, 0)
+}
+
+// serveResume replays a run's events from the sequence in the Last-Event-ID
+// header and continues with the live stream.
+func (s *Server[C]) serveResume(w http.ResponseWriter, r *http.Request, lastEventID string) {
+	runID, sequence, ok := parseEventID(lastEventID)
+	if !ok {
+		http.Error(w, "invalid Last-Event-ID", http.StatusBadRequest)
+		return
+	}
+	buffer, ok := s.resume.get(runID)
+	if !ok {
+		http.Error(w, "unknown or expired run", http.StatusNotFound)
+		return
+	}
+	s.streamBuffer(w, r, runID, buffer, sequence)
+}
+
+func (s *Server[C]) streamBuffer(w http.ResponseWriter, r *http.Request, runID string, buffer *runBuffer, start int) {
+	sse.SetHeaders(w)
+	writer, err := sse.NewWriter(w)
+	if err != nil {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	index := start
+	for {
+		event, err := buffer.next(r.Context(), index)
+		if event == nil {
+			if err != nil && r.Context().Err() == nil {
+				sendStreamError(writer, err)
+			}
+			return
+		}
+		if err := writer.SendWithID(fmt.Sprintf("%s:%d", runID, index+1), event); err != nil {
+			return
+		}
+		index++
+	}
+}
+
+// parseEventID splits a frame ID of the form "<run id>:<sequence>".
+func parseEventID(id string) (runID string, sequence int, ok bool) {
+	runID, raw, found := strings.Cut(id, ":")
+	if !found || runID == "" {
+		return "", 0, false
+	}
+	sequence, err := strconv.Atoi(raw)
+	if err != nil || sequence < 0 {
+		return "", 0, false
+	}
+	return runID, sequence, true
+}
@@ -0,0 +1,116 @@
+package agentserver_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/agentserver"
+)
+
+// parseFrameIDs returns the id fields of the SSE frames in the body.
+func parseFrameIDs(body string) []string {
+	var ids []string
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		if id, ok := strings.CutPrefix(scanner.Text(), "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestStreamResume(t *testing.T) {
+	server, err := agentserver.New(agentserver.Params[any]{
+		Agent:  newStreamingAgent("Hel", "lo ", "world"),
+		Resume: &agentserver.ResumeParams{},
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/run-stream", "application/json", strings.NewReader(runBody))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	raw, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatalf("failed to read stream body: %v", err)
+	}
+	body := string(raw)
+
+	ids := parseFrameIDs(body)
+	if len(ids) < 3 {
+		t.Fatalf("expected resumable frame IDs, got %v in %q", ids, body)
+	}
+	runID, _, _ := strings.Cut(ids[0], ":")
+	for i, id := range ids {
+		if want := fmt.Sprintf("%s:%d", runID, i+1); id != want {
+			t.Errorf("expected monotonically increasing IDs, frame %d has %q, want %q", i, id, want)
+		}
+	}
+
+	// Reconnect as if the connection dropped after the second event.
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/run-stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", ids[1])
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("resume request failed: %v", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected resume status %d", res.StatusCode)
+	}
+
+	resumed, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read resumed body: %v", err)
+	}
+	resumedBody := string(resumed)
+	resumedIDs := parseFrameIDs(resumedBody)
+	if len(resumedIDs) != len(ids)-2 {
+		t.Fatalf("expected the %d missed events replayed, got %v", len(ids)-2, resumedIDs)
+	}
+	if resumedIDs[0] != ids[2] {
+		t.Errorf("expected the replay to start after the acknowledged event, got %q", resumedIDs[0])
+	}
+	if strings.Contains(resumedBody, `"text":"Hel"`) {
+		t.Errorf("expected already-delivered deltas not to be replayed, got %q", resumedBody)
+	}
+}
+
+func TestStreamResumeUnknownRun(t *testing.T) {
+	server, err := agentserver.New(agentserver.Params[any]{
+		Agent:  newStreamingAgent("hi"),
+		Resume: &agentserver.ResumeParams{},
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/run-stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", "no-such-run:2")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown run, got %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,169 @@
+package agentserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+// TenantResolver extracts the tenant ID from a request, e.g. from a header
+// set by an upstream gateway or from the authenticated principal.
+type TenantResolver func(r *http.Request) (string, error)
+
+// HeaderTenantResolver resolves the tenant ID from the named request header.
+func HeaderTenantResolver(header string) TenantResolver {
+	return func(r *http.Request) (string, error) {
+		id := r.Header.Get(header)
+		if id == "" {
+			return "", fmt.Errorf("missing tenant header %s", header)
+		}
+		return id, nil
+	}
+}
+
+// Tenant holds the configuration of one customer in a shared deployment.
+type Tenant[C any] struct {
+	// Agent serves this tenant's requests, typically a clone of a base agent
+	// with the tenant's model and credentials. Required.
+	Agent *llmagent.Agent[C]
+	// AllowedTools restricts the tenant to the named tools. Nil allows every
+	// tool; an empty slice allows none.
+	AllowedTools []string
+}
+
+// MultiTenantParams configures tenant isolation for the server.
+type MultiTenantParams[C any] struct {
+	// Resolve extracts the tenant ID from the request. Required.
+	Resolve TenantResolver
+	// Lookup returns the configuration for a tenant ID. Required. Return an
+	// error for unknown tenants.
+	Lookup func(ctx context.Context, tenantID string) (*Tenant[C], error)
+	// Sessions enables tenant-scoped session stores, created lazily per
+	// tenant with these params. Nil disables SessionManager.
+	Sessions *llmagent.SessionManagerParams
+}
+
+// MultiTenant isolates the customers sharing one deployment: each request
+// resolves to the tenant's own agent (and thus model and credentials), tool
+// access is limited to the tenant's allowlist, and session stores are kept
+// per tenant so one customer's state can never be fetched by another. Its
+// ResolveAgent method plugs into Params.ResolveAgent.
+type MultiTenant[C any] struct {
+	params MultiTenantParams[C]
+
+	mu       sync.Mutex
+	managers map[string]*llmagent.SessionManager[C]
+	closed   bool
+}
+
+// NewMultiTenant validates the params and creates the tenant resolver.
+func NewMultiTenant[C any](params MultiTenantParams[C]) (*MultiTenant[C], error) {
+	if params.Resolve == nil {
+		return nil, errors.New("multi-tenant serving requires a Resolve func")
+	}
+	if params.Lookup == nil {
+		return nil, errors.New("multi-tenant serving requires a Lookup func")
+	}
+	return &MultiTenant[C]{
+		params:   params,
+		managers: map[string]*llmagent.SessionManager[C]{},
+	}, nil
+}
+
+// ResolveAgent resolves the request to the tenant's agent and narrows the
+// requested tools to the tenant's allowlist. Use it as Params.ResolveAgent.
+func (m *MultiTenant[C]) ResolveAgent(r *http.Request, req *RunRequest) (*llmagent.Agent[C], error) {
+	tenant, _, err := m.tenant(r)
+	if err != nil {
+		return nil, err
+	}
+	req.EnabledTools = restrictTools(req.EnabledTools, tenant.AllowedTools)
+	return tenant.Agent, nil
+}
+
+// SessionManager returns the session store scoped to the request's tenant,
+// creating it on first use. Keys from different tenants land in different
+// stores, so a guessed session key never exposes another customer's session.
+func (m *MultiTenant[C]) SessionManager(r *http.Request) (*llmagent.SessionManager[C], error) {
+	if m.params.Sessions == nil {
+		return nil, errors.New("multi-tenant sessions are not configured")
+	}
+
+	tenant, tenantID, err := m.tenant(r)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil, errors.New("multi-tenant resolver is closed")
+	}
+	if manager, ok := m.managers[tenantID]; ok {
+		return manager, nil
+	}
+	manager, err := llmagent.NewSessionManager(tenant.Agent, *m.params.Sessions)
+	if err != nil {
+		return nil, err
+	}
+	m.managers[tenantID] = manager
+	return manager, nil
+}
+
+// Close closes every tenant session store. The resolver cannot create new
+// stores afterwards.
+func (m *MultiTenant[C]) Close(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	managers := m.managers
+	m.managers = map[string]*llmagent.SessionManager[C]{}
+	m.mu.Unlock()
+
+	var errs []error
+	for _, manager := range managers {
+		if err := manager.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// tenant resolves and looks up the tenant for the request.
+func (m *MultiTenant[C]) tenant(r *http.Request) (*Tenant[C], string, error) {
+	tenantID, err := m.params.Resolve(r)
+	if err != nil {
+		return nil, "", err
+	}
+	tenant, err := m.params.Lookup(r.Context(), tenantID)
+	if err != nil {
+		return nil, "", err
+	}
+	if tenant == nil || tenant.Agent == nil {
+		return nil, "", fmt.Errorf("tenant %s has no agent configured", tenantID)
+	}
+	return tenant, tenantID, nil
+}
+
+// restrictTools narrows the requested tool selection to the allowlist. A nil
+// allowlist keeps the request as is; a nil request selects the whole
+// allowlist.
+func restrictTools(requested, allowed []string) []string {
+	if allowed == nil {
+		return requested
+	}
+	if requested == nil {
+		return slices.Clone(allowed)
+	}
+	kept := make([]string, 0, len(requested))
+	for _, name := range requested {
+		if slices.Contains(allowed, name) {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
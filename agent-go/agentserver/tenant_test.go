@@ -0,0 +1,181 @@
+package agentserver_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/agentserver"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func newMultiTenant(t *testing.T, tenants map[string]*agentserver.Tenant[any], sessions *llmagent.SessionManagerParams) *agentserver.MultiTenant[any] {
+	t.Helper()
+	multiTenant, err := agentserver.NewMultiTenant(agentserver.MultiTenantParams[any]{
+		Resolve: agentserver.HeaderTenantResolver("X-Tenant-ID"),
+		Lookup: func(_ context.Context, tenantID string) (*agentserver.Tenant[any], error) {
+			tenant, ok := tenants[tenantID]
+			if !ok {
+				return nil, fmt.Errorf("unknown tenant %s", tenantID)
+			}
+			return tenant, nil
+		},
+		Sessions: sessions,
+	})
+	if err != nil {
+		t.Fatalf("failed to create multi-tenant resolver: %v", err)
+	}
+	return multiTenant
+}
+
+func postRunAs(t *testing.T, url, tenantID string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url+"/run", strings.NewReader(runBody))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tenantID != "" {
+		req.Header.Set("X-Tenant-ID", tenantID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return res
+}
+
+func TestMultiTenantResolvesPerTenantAgents(t *testing.T) {
+	modelA := llmsdktest.NewMockLanguageModel()
+	modelA.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("served by a")},
+	}))
+	modelB := llmsdktest.NewMockLanguageModel()
+	modelB.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("served by b")},
+	}))
+
+	multiTenant := newMultiTenant(t, map[string]*agentserver.Tenant[any]{
+		"tenant-a": {Agent: newTestAgent(modelA)},
+		"tenant-b": {Agent: newTestAgent(modelB)},
+	}, nil)
+
+	server, err := agentserver.New(agentserver.Params[any]{ResolveAgent: multiTenant.ResolveAgent})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res := postRunAs(t, ts.URL, "tenant-b")
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+	if len(modelB.TrackedGenerateInputs()) != 1 || len(modelA.TrackedGenerateInputs()) != 0 {
+		t.Errorf("expected the request routed to tenant-b's model")
+	}
+
+	missing := postRunAs(t, ts.URL, "")
+	defer missing.Body.Close()
+	if missing.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 without a tenant header, got %d", missing.StatusCode)
+	}
+
+	unknown := postRunAs(t, ts.URL, "tenant-c")
+	defer unknown.Body.Close()
+	if unknown.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown tenant, got %d", unknown.StatusCode)
+	}
+}
+
+func TestMultiTenantAppliesToolAllowlist(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+	agent := llmagent.NewAgent("assistant", model,
+		llmagent.WithTools(llmagent.FunctionTools[any](
+			&staticTool{name: "get_weather"},
+			&staticTool{name: "delete_account"},
+		)...),
+	)
+
+	multiTenant := newMultiTenant(t, map[string]*agentserver.Tenant[any]{
+		"tenant-a": {Agent: agent, AllowedTools: []string{"get_weather"}},
+	}, nil)
+
+	server, err := agentserver.New(agentserver.Params[any]{ResolveAgent: multiTenant.ResolveAgent})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	res := postRunAs(t, ts.URL, "tenant-a")
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected 1 model call, got %d", len(inputs))
+	}
+	if len(inputs[0].Tools) != 1 || inputs[0].Tools[0].FunctionTool == nil ||
+		inputs[0].Tools[0].FunctionTool.Name != "get_weather" {
+		t.Errorf("expected only the allowlisted tool sent to the model, got %+v", inputs[0].Tools)
+	}
+}
+
+func TestMultiTenantSessionStoresAreScoped(t *testing.T) {
+	multiTenant := newMultiTenant(t, map[string]*agentserver.Tenant[any]{
+		"tenant-a": {Agent: newTestAgent(llmsdktest.NewMockLanguageModel())},
+		"tenant-b": {Agent: newTestAgent(llmsdktest.NewMockLanguageModel())},
+	}, &llmagent.SessionManagerParams{TTL: time.Minute})
+	defer multiTenant.Close(context.Background())
+
+	requestAs := func(tenantID string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/run", nil)
+		r.Header.Set("X-Tenant-ID", tenantID)
+		return r
+	}
+
+	managerA, err := multiTenant.SessionManager(requestAs("tenant-a"))
+	if err != nil {
+		t.Fatalf("failed to get tenant-a store: %v", err)
+	}
+	managerB, err := multiTenant.SessionManager(requestAs("tenant-b"))
+	if err != nil {
+		t.Fatalf("failed to get tenant-b store: %v", err)
+	}
+	if managerA == managerB {
+		t.Fatal("expected distinct session stores per tenant")
+	}
+
+	sessionA, err := managerA.GetOrCreate(t.Context(), "chat-1", nil)
+	if err != nil {
+		t.Fatalf("failed to create tenant-a session: %v", err)
+	}
+	sessionB, err := managerB.GetOrCreate(t.Context(), "chat-1", nil)
+	if err != nil {
+		t.Fatalf("failed to create tenant-b session: %v", err)
+	}
+	if sessionA == sessionB {
+		t.Fatal("expected the same key to map to different sessions per tenant")
+	}
+
+	again, err := multiTenant.SessionManager(requestAs("tenant-a"))
+	if err != nil {
+		t.Fatalf("failed to refetch tenant-a store: %v", err)
+	}
+	if again != managerA {
+		t.Error("expected the tenant store reused across requests")
+	}
+}
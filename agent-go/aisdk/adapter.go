@@ -0,0 +1,611 @@
+package aisdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/sse"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// SetStreamHeaders writes the SSE and UI message stream headers expected by
+// the Vercel AI SDK client before the response body is started.
+func SetStreamHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache, no-transform")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("x-vercel-ai-ui-message-stream", "v1")
+}
+
+type textStreamState struct {
+	id string
+}
+
+type reasoningStreamState struct {
+	id string
+}
+
+type audioStreamState struct {
+	id string
+}
+
+type toolCallStreamState struct {
+	toolCallID   string
+	toolName     string
+	argsBuilder  strings.Builder
+	didEmitStart bool
+}
+
+type startChunk struct {
+	MessageID string `json:"messageId,omitempty"`
+}
+
+func (c startChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type      string `json:"type"`
+		MessageID string `json:"messageId,omitempty"`
+	}
+	return json.Marshal(alias{Type: "start", MessageID: c.MessageID})
+}
+
+type startStepChunk struct{}
+
+func (startStepChunk) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{Type: "start-step"})
+}
+
+type finishStepChunk struct{}
+
+func (finishStepChunk) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{Type: "finish-step"})
+}
+
+type textStartChunk struct {
+	ID string `json:"id"`
+}
+
+func (c textStartChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+	return json.Marshal(alias{Type: "text-start", ID: c.ID})
+}
+
+type textDeltaChunk struct {
+	ID    string `json:"id"`
+	Delta string `json:"delta"`
+}
+
+func (c textDeltaChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type  string `json:"type"`
+		ID    string `json:"id"`
+		Delta string `json:"delta"`
+	}
+	return json.Marshal(alias{Type: "text-delta", ID: c.ID, Delta: c.Delta})
+}
+
+type textEndChunk struct {
+	ID string `json:"id"`
+}
+
+func (c textEndChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+	return json.Marshal(alias{Type: "text-end", ID: c.ID})
+}
+
+type reasoningStartChunk struct {
+	ID string `json:"id"`
+}
+
+func (c reasoningStartChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+	return json.Marshal(alias{Type: "reasoning-start", ID: c.ID})
+}
+
+type reasoningDeltaChunk struct {
+	ID    string `json:"id"`
+	Delta string `json:"delta"`
+}
+
+func (c reasoningDeltaChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type  string `json:"type"`
+		ID    string `json:"id"`
+		Delta string `json:"delta"`
+	}
+	return json.Marshal(alias{Type: "reasoning-delta", ID: c.ID, Delta: c.Delta})
+}
+
+type reasoningEndChunk struct {
+	ID string `json:"id"`
+}
+
+func (c reasoningEndChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+	return json.Marshal(alias{Type: "reasoning-end", ID: c.ID})
+}
+
+type toolInputStartChunk struct {
+	ToolCallID string `json:"toolCallId"`
+	ToolName   string `json:"toolName"`
+}
+
+func (c toolInputStartChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type       string `json:"type"`
+		ToolCallID string `json:"toolCallId"`
+		ToolName   string `json:"toolName"`
+	}
+	return json.Marshal(alias{
+		Type:       "tool-input-start",
+		ToolCallID: c.ToolCallID,
+		ToolName:   c.ToolName,
+	})
+}
+
+type toolInputDeltaChunk struct {
+	ToolCallID     string `json:"toolCallId"`
+	InputTextDelta string `json:"inputTextDelta"`
+}
+
+func (c toolInputDeltaChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type           string `json:"type"`
+		ToolCallID     string `json:"toolCallId"`
+		InputTextDelta string `json:"inputTextDelta"`
+	}
+	return json.Marshal(alias{
+		Type:           "tool-input-delta",
+		ToolCallID:     c.ToolCallID,
+		InputTextDelta: c.InputTextDelta,
+	})
+}
+
+type toolInputAvailableChunk struct {
+	ToolCallID string `json:"toolCallId"`
+	ToolName   string `json:"toolName"`
+	Input      any    `json:"input"`
+}
+
+func (c toolInputAvailableChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type       string `json:"type"`
+		ToolCallID string `json:"toolCallId"`
+		ToolName   string `json:"toolName"`
+		Input      any    `json:"input"`
+	}
+	return json.Marshal(alias{
+		Type:       "tool-input-available",
+		ToolCallID: c.ToolCallID,
+		ToolName:   c.ToolName,
+		Input:      c.Input,
+	})
+}
+
+type toolOutputAvailableChunk struct {
+	ToolCallID string `json:"toolCallId"`
+	Output     any    `json:"output"`
+}
+
+func (c toolOutputAvailableChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type       string `json:"type"`
+		ToolCallID string `json:"toolCallId"`
+		Output     any    `json:"output"`
+	}
+	return json.Marshal(alias{
+		Type:       "tool-output-available",
+		ToolCallID: c.ToolCallID,
+		Output:     c.Output,
+	})
+}
+
+type toolOutputErrorChunk struct {
+	ToolCallID string `json:"toolCallId"`
+	ErrorText  string `json:"errorText"`
+}
+
+func (c toolOutputErrorChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type       string `json:"type"`
+		ToolCallID string `json:"toolCallId"`
+		ErrorText  string `json:"errorText"`
+	}
+	return json.Marshal(alias{
+		Type:       "tool-output-error",
+		ToolCallID: c.ToolCallID,
+		ErrorText:  c.ErrorText,
+	})
+}
+
+// audioDeltaChunk carries one audio part delta as a typed data part. The data
+// stream protocol has no native audio chunk, so voice UIs subscribe to
+// data-audio parts and feed the base64 chunks to their playback pipeline.
+type audioDeltaChunk struct {
+	ID    string `json:"id"`
+	Delta *llmsdk.AudioPartDelta
+}
+
+func (c audioDeltaChunk) MarshalJSON() ([]byte, error) {
+	type payload struct {
+		ID         string              `json:"id"`
+		Data       *string             `json:"data,omitempty"`
+		Format     *llmsdk.AudioFormat `json:"format,omitempty"`
+		SampleRate *int                `json:"sampleRate,omitempty"`
+		Channels   *int                `json:"channels,omitempty"`
+		Transcript *string             `json:"transcript,omitempty"`
+	}
+	type alias struct {
+		Type string  `json:"type"`
+		Data payload `json:"data"`
+	}
+	return json.Marshal(alias{Type: "data-audio", Data: payload{
+		ID:         c.ID,
+		Data:       c.Delta.Data,
+		Format:     c.Delta.Format,
+		SampleRate: c.Delta.SampleRate,
+		Channels:   c.Delta.Channels,
+		Transcript: c.Delta.Transcript,
+	}})
+}
+
+type dataChunk struct {
+	Name string          `json:"-"`
+	Data json.RawMessage `json:"data"`
+}
+
+func (c dataChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	return json.Marshal(alias{Type: "data-" + c.Name, Data: c.Data})
+}
+
+type errorChunk struct {
+	ErrorText string `json:"errorText"`
+}
+
+func (c errorChunk) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type      string `json:"type"`
+		ErrorText string `json:"errorText"`
+	}
+	return json.Marshal(alias{Type: "error", ErrorText: c.ErrorText})
+}
+
+type finishChunk struct{}
+
+func (finishChunk) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{Type: "finish"})
+}
+
+// DataStreamProtocolAdapter bridges AgentStreamEvent values to the Vercel AI
+// SDK data stream protocol. Feed every event emitted by Agent.RunStream into
+// Write so the frontend receives the expected stream chunks, then call Close.
+type DataStreamProtocolAdapter struct {
+	writer            *sse.Writer
+	textStateMap      map[int]textStreamState
+	reasoningStateMap map[int]reasoningStreamState
+	audioStateMap     map[int]audioStreamState
+	toolCallStateMap  map[int]*toolCallStreamState
+	stepStarted       bool
+	closed            bool
+}
+
+// NewDataStreamProtocolAdapter starts a data stream protocol response on the
+// given ResponseWriter, emitting the opening start chunk.
+func NewDataStreamProtocolAdapter(w http.ResponseWriter) (*DataStreamProtocolAdapter, error) {
+	writer, err := sse.NewWriter(w)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter := &DataStreamProtocolAdapter{
+		writer:            writer,
+		textStateMap:      make(map[int]textStreamState),
+		reasoningStateMap: make(map[int]reasoningStreamState),
+		audioStateMap:     make(map[int]audioStreamState),
+		toolCallStateMap:  make(map[int]*toolCallStreamState),
+	}
+
+	messageID := "msg_" + uuid.NewString()
+	if err := adapter.writer.Send(startChunk{MessageID: messageID}); err != nil {
+		return nil, err
+	}
+
+	return adapter, nil
+}
+
+// Write translates a single agent stream event into protocol chunks.
+func (a *DataStreamProtocolAdapter) Write(event *llmagent.AgentStreamEvent) error {
+	if a.closed {
+		return nil
+	}
+
+	switch {
+	case event.Partial != nil:
+		if event.Partial.Delta == nil {
+			return nil
+		}
+		if err := a.ensureStepStarted(); err != nil {
+			return err
+		}
+		return a.writeDelta(event.Partial.Delta)
+	case event.Item != nil:
+		if err := a.finishStep(); err != nil {
+			return err
+		}
+		if event.Item.Item.Tool != nil {
+			if err := a.ensureStepStarted(); err != nil {
+				return err
+			}
+			if err := a.writeForToolItem(event.Item.Item.Tool); err != nil {
+				return err
+			}
+			return a.finishStep()
+		}
+	case event.Data != nil:
+		// Tool-emitted data events map to the protocol's typed data parts.
+		return a.writer.Send(dataChunk{Name: event.Data.Name, Data: event.Data.Data})
+	case event.Response != nil:
+		// Final agent response does not emit an extra stream part.
+		return nil
+	}
+
+	return nil
+}
+
+// EmitError surfaces a run failure to the frontend as an error chunk.
+func (a *DataStreamProtocolAdapter) EmitError(errorText string) error {
+	if a.closed {
+		return nil
+	}
+	return a.writer.Send(errorChunk{ErrorText: errorText})
+}
+
+// Close flushes pending part state and terminates the stream with the finish
+// chunk and [DONE] sentinel.
+func (a *DataStreamProtocolAdapter) Close() error {
+	if a.closed {
+		return nil
+	}
+	if err := a.finishStep(); err != nil {
+		return err
+	}
+	if err := a.writer.Send(finishChunk{}); err != nil {
+		return err
+	}
+	if err := a.writer.Done(); err != nil {
+		return err
+	}
+	a.closed = true
+	return nil
+}
+
+func (a *DataStreamProtocolAdapter) ensureStepStarted() error {
+	if a.stepStarted {
+		return nil
+	}
+	if err := a.writer.Send(startStepChunk{}); err != nil {
+		return err
+	}
+	a.stepStarted = true
+	return nil
+}
+
+func (a *DataStreamProtocolAdapter) finishStep() error {
+	if !a.stepStarted {
+		return nil
+	}
+	if err := a.flushStates(); err != nil {
+		return err
+	}
+	if err := a.writer.Send(finishStepChunk{}); err != nil {
+		return err
+	}
+	a.stepStarted = false
+	return nil
+}
+
+func (a *DataStreamProtocolAdapter) flushStates() error {
+	for index, state := range a.textStateMap {
+		if err := a.writer.Send(textEndChunk{ID: state.id}); err != nil {
+			return err
+		}
+		delete(a.textStateMap, index)
+	}
+
+	for index, state := range a.reasoningStateMap {
+		if err := a.writer.Send(reasoningEndChunk{ID: state.id}); err != nil {
+			return err
+		}
+		delete(a.reasoningStateMap, index)
+	}
+
+	// Audio data parts need no end chunk; dropping the state just assigns a
+	// fresh id to the next audio part.
+	for index := range a.audioStateMap {
+		delete(a.audioStateMap, index)
+	}
+
+	for index, state := range a.toolCallStateMap {
+		if state.toolCallID != "" && state.toolName != "" && state.argsBuilder.Len() > 0 {
+			input := safeJSONParse(state.argsBuilder.String())
+			if err := a.writer.Send(toolInputAvailableChunk{
+				ToolCallID: state.toolCallID,
+				ToolName:   state.toolName,
+				Input:      input,
+			}); err != nil {
+				return err
+			}
+		}
+		delete(a.toolCallStateMap, index)
+	}
+
+	return nil
+}
+
+func (a *DataStreamProtocolAdapter) writeDelta(delta *llmsdk.ContentDelta) error {
+	switch {
+	case delta.Part.TextPartDelta != nil:
+		return a.writeForTextPartDelta(delta.Index, delta.Part.TextPartDelta)
+	case delta.Part.ReasoningPartDelta != nil:
+		return a.writeForReasoningPartDelta(delta.Index, delta.Part.ReasoningPartDelta)
+	case delta.Part.ToolCallPartDelta != nil:
+		return a.writeForToolCallPartDelta(delta.Index, delta.Part.ToolCallPartDelta)
+	case delta.Part.AudioPartDelta != nil:
+		return a.writeForAudioPartDelta(delta.Index, delta.Part.AudioPartDelta)
+	case delta.Part.ImagePartDelta != nil:
+		return a.flushStates()
+	default:
+		return nil
+	}
+}
+
+func (a *DataStreamProtocolAdapter) writeForTextPartDelta(index int, part *llmsdk.TextPartDelta) error {
+	state, ok := a.textStateMap[index]
+	if !ok {
+		if err := a.flushStates(); err != nil {
+			return err
+		}
+		state = textStreamState{id: "text_" + uuid.NewString()}
+		a.textStateMap[index] = state
+		if err := a.writer.Send(textStartChunk{ID: state.id}); err != nil {
+			return err
+		}
+	}
+
+	return a.writer.Send(textDeltaChunk{ID: state.id, Delta: part.Text})
+}
+
+func (a *DataStreamProtocolAdapter) writeForReasoningPartDelta(index int, part *llmsdk.ReasoningPartDelta) error {
+	state, ok := a.reasoningStateMap[index]
+	if !ok {
+		if err := a.flushStates(); err != nil {
+			return err
+		}
+		id := "reasoning_" + uuid.NewString()
+		if part.ID != nil && *part.ID != "" {
+			id = "reasoning_" + *part.ID
+		}
+		state = reasoningStreamState{id: id}
+		a.reasoningStateMap[index] = state
+		if err := a.writer.Send(reasoningStartChunk{ID: state.id}); err != nil {
+			return err
+		}
+	}
+
+	return a.writer.Send(reasoningDeltaChunk{ID: state.id, Delta: part.Text})
+}
+
+func (a *DataStreamProtocolAdapter) writeForAudioPartDelta(index int, part *llmsdk.AudioPartDelta) error {
+	state, ok := a.audioStateMap[index]
+	if !ok {
+		if err := a.flushStates(); err != nil {
+			return err
+		}
+		id := "audio_" + uuid.NewString()
+		if part.ID != nil && *part.ID != "" {
+			id = "audio_" + *part.ID
+		}
+		state = audioStreamState{id: id}
+		a.audioStateMap[index] = state
+	}
+
+	return a.writer.Send(audioDeltaChunk{ID: state.id, Delta: part})
+}
+
+func (a *DataStreamProtocolAdapter) writeForToolCallPartDelta(index int, part *llmsdk.ToolCallPartDelta) error {
+	call := part.Call.Function
+	if call == nil {
+		return nil
+	}
+	state, ok := a.toolCallStateMap[index]
+	if !ok {
+		if err := a.flushStates(); err != nil {
+			return err
+		}
+		state = &toolCallStreamState{}
+		a.toolCallStateMap[index] = state
+	}
+
+	if part.ToolCallID != nil && *part.ToolCallID != "" {
+		state.toolCallID = *part.ToolCallID
+	}
+	if call.Name != nil && *call.Name != "" {
+		state.toolName = *call.Name
+	}
+
+	if !state.didEmitStart && state.toolCallID != "" && state.toolName != "" {
+		state.didEmitStart = true
+		if err := a.writer.Send(toolInputStartChunk{
+			ToolCallID: state.toolCallID,
+			ToolName:   state.toolName,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if call.Args != nil && *call.Args != "" {
+		state.argsBuilder.WriteString(*call.Args)
+		return a.writer.Send(toolInputDeltaChunk{
+			ToolCallID:     state.toolCallID,
+			InputTextDelta: *call.Args,
+		})
+	}
+
+	return nil
+}
+
+func (a *DataStreamProtocolAdapter) writeForToolItem(item *llmagent.AgentItemTool) error {
+	if err := a.flushStates(); err != nil {
+		return err
+	}
+
+	var textBuffer strings.Builder
+	for _, part := range item.Output {
+		if part.TextPart != nil {
+			textBuffer.WriteString(part.TextPart.Text)
+		}
+	}
+
+	if item.Status == llmsdk.ToolResultStatusFailed {
+		return a.writer.Send(toolOutputErrorChunk{
+			ToolCallID: item.ToolCallID,
+			ErrorText:  textBuffer.String(),
+		})
+	}
+
+	var output any
+	if textBuffer.Len() > 0 {
+		output = safeJSONParse(textBuffer.String())
+	} else {
+		output = item.Output
+	}
+
+	return a.writer.Send(toolOutputAvailableChunk{
+		ToolCallID: item.ToolCallID,
+		Output:     output,
+	})
+}
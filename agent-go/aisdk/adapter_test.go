@@ -0,0 +1,221 @@
+package aisdk_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/aisdk"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+func decodeChunks(t *testing.T, body string) []map[string]any {
+	t.Helper()
+	var chunks []map[string]any
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.HasPrefix(line, "data: ") || line == "data: [DONE]" {
+			continue
+		}
+		var chunk map[string]any
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &chunk); err != nil {
+			t.Fatalf("decode chunk %q: %v", line, err)
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func chunkTypes(chunks []map[string]any) []string {
+	types := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		types = append(types, chunk["type"].(string))
+	}
+	return types
+}
+
+func TestDataStreamProtocolAdapter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	adapter, err := aisdk.NewDataStreamProtocolAdapter(recorder)
+	if err != nil {
+		t.Fatalf("new adapter: %v", err)
+	}
+
+	events := []*llmagent.AgentStreamEvent{
+		{Partial: &llmsdk.PartialModelResponse{Delta: &llmsdk.ContentDelta{
+			Index: 0, Part: llmsdk.NewReasoningPartDelta("thinking"),
+		}}},
+		{Partial: &llmsdk.PartialModelResponse{Delta: &llmsdk.ContentDelta{
+			Index: 1, Part: llmsdk.NewTextPartDelta("Hel"),
+		}}},
+		{Partial: &llmsdk.PartialModelResponse{Delta: &llmsdk.ContentDelta{
+			Index: 1, Part: llmsdk.NewTextPartDelta("lo"),
+		}}},
+		{Item: &llmagent.AgentStreamItemEvent{Item: llmagent.NewAgentItemTool(
+			"call_1", "get_local_weather", json.RawMessage(`{"location":"Hanoi"}`),
+			[]llmsdk.Part{llmsdk.NewTextPart(`{"condition":"sunny"}`)},
+			llmsdk.ToolResultStatusCompleted,
+		)}},
+		{Item: &llmagent.AgentStreamItemEvent{Item: llmagent.NewAgentItemTool(
+			"call_2", "get_current_time", nil,
+			[]llmsdk.Part{llmsdk.NewTextPart("clock unavailable")},
+			llmsdk.ToolResultStatusFailed,
+		)}},
+	}
+	for _, event := range events {
+		if err := adapter.Write(event); err != nil {
+			t.Fatalf("write event: %v", err)
+		}
+	}
+	if err := adapter.Close(); err != nil {
+		t.Fatalf("close adapter: %v", err)
+	}
+
+	body := recorder.Body.String()
+	if !strings.HasSuffix(body, "data: [DONE]\n\n") {
+		t.Fatalf("expected the [DONE] sentinel, got %q", body)
+	}
+
+	chunks := decodeChunks(t, body)
+	types := chunkTypes(chunks)
+	want := []string{
+		"start",
+		"start-step",
+		"reasoning-start", "reasoning-delta",
+		"reasoning-end",
+		"text-start", "text-delta", "text-delta",
+		"text-end",
+		"finish-step",
+		"start-step",
+		"tool-output-available",
+		"finish-step",
+		"start-step",
+		"tool-output-error",
+		"finish-step",
+		"finish",
+	}
+	if strings.Join(types, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected chunk sequence %v, got %v", want, types)
+	}
+
+	for _, chunk := range chunks {
+		switch chunk["type"] {
+		case "tool-output-available":
+			output, ok := chunk["output"].(map[string]any)
+			if chunk["toolCallId"] != "call_1" || !ok || output["condition"] != "sunny" {
+				t.Fatalf("expected parsed tool output, got %+v", chunk)
+			}
+		case "tool-output-error":
+			if chunk["toolCallId"] != "call_2" || chunk["errorText"] != "clock unavailable" {
+				t.Fatalf("expected the tool error chunk, got %+v", chunk)
+			}
+		}
+	}
+}
+
+func TestDataStreamProtocolAdapterAudioDeltas(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	adapter, err := aisdk.NewDataStreamProtocolAdapter(recorder)
+	if err != nil {
+		t.Fatalf("new adapter: %v", err)
+	}
+
+	events := []*llmagent.AgentStreamEvent{
+		{Partial: &llmsdk.PartialModelResponse{Delta: &llmsdk.ContentDelta{
+			Index: 0, Part: llmsdk.NewAudioPartDelta(
+				llmsdk.WithAudioPartDeltaData("YXVkaW8x"),
+				llmsdk.WithAudioPartDeltaFormat(llmsdk.AudioFormatLinear16),
+				llmsdk.WithAudioPartDeltaSampleRate(24000),
+			),
+		}}},
+		{Partial: &llmsdk.PartialModelResponse{Delta: &llmsdk.ContentDelta{
+			Index: 0, Part: llmsdk.NewAudioPartDelta(
+				llmsdk.WithAudioPartDeltaData("YXVkaW8y"),
+				llmsdk.WithAudioPartDeltaTranscript("Hello"),
+			),
+		}}},
+	}
+	for _, event := range events {
+		if err := adapter.Write(event); err != nil {
+			t.Fatalf("write event: %v", err)
+		}
+	}
+	if err := adapter.Close(); err != nil {
+		t.Fatalf("close adapter: %v", err)
+	}
+
+	var audioChunks []map[string]any
+	for _, chunk := range decodeChunks(t, recorder.Body.String()) {
+		if chunk["type"] == "data-audio" {
+			audioChunks = append(audioChunks, chunk["data"].(map[string]any))
+		}
+	}
+	if len(audioChunks) != 2 {
+		t.Fatalf("expected both audio deltas encoded, got %+v", audioChunks)
+	}
+	if audioChunks[0]["data"] != "YXVkaW8x" || audioChunks[0]["format"] != "linear16" || audioChunks[0]["sampleRate"] != float64(24000) {
+		t.Fatalf("unexpected first audio chunk: %+v", audioChunks[0])
+	}
+	if audioChunks[1]["data"] != "YXVkaW8y" || audioChunks[1]["transcript"] != "Hello" {
+		t.Fatalf("unexpected second audio chunk: %+v", audioChunks[1])
+	}
+	if audioChunks[0]["id"] == "" || audioChunks[0]["id"] != audioChunks[1]["id"] {
+		t.Fatalf("expected both deltas to share the part id, got %+v", audioChunks)
+	}
+}
+
+func TestDataStreamProtocolAdapterEmitError(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	adapter, err := aisdk.NewDataStreamProtocolAdapter(recorder)
+	if err != nil {
+		t.Fatalf("new adapter: %v", err)
+	}
+	if err := adapter.EmitError("model exploded"); err != nil {
+		t.Fatalf("emit error: %v", err)
+	}
+	if err := adapter.Close(); err != nil {
+		t.Fatalf("close adapter: %v", err)
+	}
+
+	chunks := decodeChunks(t, recorder.Body.String())
+	found := false
+	for _, chunk := range chunks {
+		if chunk["type"] == "error" && chunk["errorText"] == "model exploded" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected an error chunk, got %+v", chunks)
+	}
+}
+
+func TestDataStreamProtocolAdapterDataEvents(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	adapter, err := aisdk.NewDataStreamProtocolAdapter(recorder)
+	if err != nil {
+		t.Fatalf("new adapter: %v", err)
+	}
+	event := llmagent.NewAgentStreamEventData("search_progress", json.RawMessage(`{"scanned":10}`))
+	if err := adapter.Write(event); err != nil {
+		t.Fatalf("write event: %v", err)
+	}
+	if err := adapter.Close(); err != nil {
+		t.Fatalf("close adapter: %v", err)
+	}
+
+	chunks := decodeChunks(t, recorder.Body.String())
+	found := false
+	for _, chunk := range chunks {
+		if chunk["type"] == "data-search_progress" {
+			data, ok := chunk["data"].(map[string]any)
+			if !ok || data["scanned"] != float64(10) {
+				t.Fatalf("unexpected data payload %+v", chunk["data"])
+			}
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a data part chunk, got %+v", chunks)
+	}
+}
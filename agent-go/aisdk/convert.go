@@ -0,0 +1,324 @@
+package aisdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/partutil"
+)
+
+// UIPartToParts converts a UI message part produced by the Vercel AI SDK into
+// llm-sdk Part values so the agent can reconstruct history, tool calls, and
+// intermediate reasoning steps.
+func UIPartToParts(part UIPart) []llmsdk.Part {
+	switch {
+	case part.Text != nil:
+		return []llmsdk.Part{llmsdk.NewTextPart(part.Text.Text)}
+	case part.Custom != nil:
+		// Provider-specific custom content has no portable llm-sdk equivalent.
+		return nil
+	case part.Reasoning != nil:
+		return []llmsdk.Part{llmsdk.NewReasoningPart(part.Reasoning.Text)}
+	case part.DynamicTool != nil:
+		if part.DynamicTool.ToolCallID == "" || part.DynamicTool.ToolName == "" {
+			return nil
+		}
+		return []llmsdk.Part{llmsdk.NewToolCallPart(part.DynamicTool.ToolCallID, part.DynamicTool.ToolName, part.DynamicTool.Input)}
+	case part.File != nil:
+		return convertFileData(part.File.URL, part.File.MediaType)
+	case part.ReasoningFile != nil:
+		return convertFileData(part.ReasoningFile.URL, part.ReasoningFile.MediaType)
+	case part.Tool != nil:
+		return convertToolPart(part.Tool)
+	default:
+		return nil
+	}
+}
+
+func convertFileData(url, declaredMediaType string) []llmsdk.Part {
+	data := extractDataPayload(url)
+	mediaType := resolveMediaType(url, declaredMediaType)
+	switch {
+	case isMediaType(mediaType, "image"):
+		imagePart := llmsdk.NewImagePart(data, mediaType)
+		return []llmsdk.Part{imagePart}
+	case isMediaType(mediaType, "audio"):
+		format, err := partutil.MapMimeTypeToAudioFormat(mediaType)
+		if err != nil {
+			return nil
+		}
+		return []llmsdk.Part{llmsdk.NewAudioPart(data, format)}
+	case isMediaType(mediaType, "text"):
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil
+		}
+		return []llmsdk.Part{llmsdk.NewTextPart(string(decoded))}
+	default:
+		return nil
+	}
+}
+
+// resolveMediaType handles the top-level media types accepted by AI SDK 7 by
+// recovering the full MIME type from an uploaded data URL when available.
+func resolveMediaType(url, declaredMediaType string) string {
+	normalized := strings.TrimSpace(strings.SplitN(declaredMediaType, ";", 2)[0])
+	if strings.Contains(normalized, "/") && !strings.HasSuffix(normalized, "/*") {
+		return declaredMediaType
+	}
+
+	if comma := strings.IndexByte(url, ','); comma > len("data:") && strings.EqualFold(url[:len("data:")], "data:") {
+		header := url[len("data:"):comma]
+		if mediaType := strings.SplitN(header, ";", 2)[0]; mediaType != "" {
+			return mediaType
+		}
+	}
+	return declaredMediaType
+}
+
+func isMediaType(mediaType, topLevelType string) bool {
+	normalized := strings.ToLower(mediaType)
+	return normalized == topLevelType || strings.HasPrefix(normalized, topLevelType+"/")
+}
+
+func convertToolPart(part *ToolUIPart) []llmsdk.Part {
+	name := part.resolvedToolName()
+	if part.ToolCallID == "" || name == "" {
+		return nil
+	}
+	switch part.State {
+	case ToolStateInputAvailable:
+		return []llmsdk.Part{llmsdk.NewToolCallPart(part.ToolCallID, name, part.Input)}
+	case ToolStateOutputAvailable:
+		call := llmsdk.NewToolCallPart(part.ToolCallID, name, part.Input)
+		result := llmsdk.NewToolResultPart(part.ToolCallID, name, []llmsdk.Part{
+			llmsdk.NewTextPart(safeJSONMarshal(part.Output)),
+		})
+		return []llmsdk.Part{call, result}
+	case ToolStateOutputError:
+		call := llmsdk.NewToolCallPart(part.ToolCallID, name, part.Input)
+		result := llmsdk.NewToolResultPart(part.ToolCallID, name, []llmsdk.Part{
+			llmsdk.NewTextPart(part.ErrorText),
+		}, llmsdk.WithToolResultStatus(llmsdk.ToolResultStatusFailed))
+		return []llmsdk.Part{call, result}
+	default:
+		return nil
+	}
+}
+
+// UIMessagesToMessages converts a UI message transcript into llm-sdk messages.
+// Assistant parts are split into assistant and tool messages as required by
+// the llm-sdk message ordering.
+func UIMessagesToMessages(messages []UIMessage) ([]llmsdk.Message, error) {
+	history := make([]llmsdk.Message, 0, len(messages))
+
+	for _, message := range messages {
+		switch message.Role {
+		case UIRoleUser:
+			var parts []llmsdk.Part
+			for _, part := range message.Parts {
+				parts = append(parts, UIPartToParts(part)...)
+			}
+			if len(parts) == 0 {
+				continue
+			}
+			history = append(history, llmsdk.NewUserMessage(parts...))
+		case UIRoleAssistant:
+			for _, part := range message.Parts {
+				for _, converted := range UIPartToParts(part) {
+					switch converted.Type() {
+					case llmsdk.PartTypeText, llmsdk.PartTypeReasoning, llmsdk.PartTypeAudio, llmsdk.PartTypeImage, llmsdk.PartTypeToolCall:
+						appendAssistantMessage(&history, converted)
+					case llmsdk.PartTypeToolResult:
+						appendToolMessage(&history, converted)
+					}
+				}
+			}
+		default:
+			// ignore unsupported roles
+		}
+	}
+
+	return history, nil
+}
+
+func appendAssistantMessage(history *[]llmsdk.Message, part llmsdk.Part) {
+	n := len(*history)
+	if n > 0 {
+		last := &(*history)[n-1]
+		if msg := last.AssistantMessage; msg != nil {
+			msg.Content = append(msg.Content, part)
+			return
+		}
+		if last.ToolMessage != nil && n >= 2 {
+			prev := &(*history)[n-2]
+			if msg := prev.AssistantMessage; msg != nil {
+				msg.Content = append(msg.Content, part)
+				return
+			}
+		}
+	}
+
+	*history = append(*history, llmsdk.NewAssistantMessage(part))
+}
+
+func appendToolMessage(history *[]llmsdk.Message, part llmsdk.Part) {
+	n := len(*history)
+	if n > 0 {
+		last := &(*history)[n-1]
+		if msg := last.ToolMessage; msg != nil {
+			msg.Content = append(msg.Content, part)
+			return
+		}
+	}
+
+	*history = append(*history, llmsdk.NewToolMessage(part))
+}
+
+// MessagesToUIMessages converts llm-sdk messages back into the UI message
+// format. Tool messages do not produce standalone UI messages; their results
+// upgrade the matching tool part on the preceding assistant message to
+// output-available, or output-error for failed calls.
+func MessagesToUIMessages(messages []llmsdk.Message) []UIMessage {
+	var out []UIMessage
+	toolParts := map[string]*ToolUIPart{}
+
+	appendParts := func(role UIMessageRole, parts []UIPart) {
+		if len(parts) == 0 {
+			return
+		}
+		if n := len(out); n > 0 && out[n-1].Role == role && role == UIRoleAssistant {
+			out[n-1].Parts = append(out[n-1].Parts, parts...)
+			return
+		}
+		out = append(out, UIMessage{ID: "msg_" + uuid.NewString(), Role: role, Parts: parts})
+	}
+
+	for _, message := range messages {
+		switch {
+		case message.UserMessage != nil:
+			appendParts(UIRoleUser, partsToUIParts(message.UserMessage.Content, nil))
+		case message.AssistantMessage != nil:
+			appendParts(UIRoleAssistant, partsToUIParts(message.AssistantMessage.Content, toolParts))
+		case message.ToolMessage != nil:
+			for _, part := range message.ToolMessage.Content {
+				resultPart := part.ToolResultPart
+				if resultPart == nil {
+					continue
+				}
+				toolPart, ok := toolParts[resultPart.ToolCallID]
+				if !ok {
+					continue
+				}
+				text := toolResultText(resultPart)
+				if resultPart.Status == llmsdk.ToolResultStatusFailed {
+					toolPart.State = ToolStateOutputError
+					toolPart.ErrorText = text
+				} else {
+					toolPart.State = ToolStateOutputAvailable
+					toolPart.Output = safeJSONParse(text)
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// partsToUIParts converts llm-sdk parts into UI parts. Tool call parts are
+// additionally registered in toolParts (keyed by tool call ID) so later tool
+// results can upgrade their state.
+func partsToUIParts(parts []llmsdk.Part, toolParts map[string]*ToolUIPart) []UIPart {
+	out := make([]UIPart, 0, len(parts))
+	for _, part := range parts {
+		switch {
+		case part.TextPart != nil:
+			out = append(out, UIPart{Text: &TextUIPart{Text: part.TextPart.Text}})
+		case part.ReasoningPart != nil:
+			out = append(out, UIPart{Reasoning: &ReasoningUIPart{Text: part.ReasoningPart.Text}})
+		case part.ImagePart != nil:
+			out = append(out, UIPart{File: &FileUIPart{
+				URL:       dataURL(part.ImagePart.MimeType, part.ImagePart.Data),
+				MediaType: part.ImagePart.MimeType,
+			}})
+		case part.AudioPart != nil:
+			mimeType := partutil.MapAudioFormatToMimeType(part.AudioPart.Format)
+			out = append(out, UIPart{File: &FileUIPart{
+				URL:       dataURL(mimeType, part.AudioPart.Data),
+				MediaType: mimeType,
+			}})
+		case part.ToolCallPart != nil && part.ToolCallPart.Call.Function != nil:
+			toolPart := &ToolUIPart{
+				State:      ToolStateInputAvailable,
+				ToolCallID: part.ToolCallPart.ToolCallID,
+				ToolName:   part.ToolCallPart.Call.Function.Name,
+				Input:      safeJSONParse(string(part.ToolCallPart.Call.Function.Args)),
+			}
+			if toolParts != nil {
+				toolParts[toolPart.ToolCallID] = toolPart
+			}
+			out = append(out, UIPart{Tool: toolPart})
+		}
+	}
+	return out
+}
+
+func toolResultText(part *llmsdk.ToolResultPart) string {
+	if part.Result.Function == nil {
+		return ""
+	}
+	var builder strings.Builder
+	for _, content := range part.Result.Function.Content {
+		if content.TextPart != nil {
+			builder.WriteString(content.TextPart.Text)
+		}
+	}
+	return builder.String()
+}
+
+func dataURL(mediaType, base64Data string) string {
+	return "data:" + mediaType + ";base64," + base64Data
+}
+
+// safeJSONParse attempts to decode tool arguments or results as JSON. When a
+// payload is not valid JSON we fall back to the original string so the UI can
+// still surface something meaningful to the user.
+func safeJSONParse(raw string) any {
+	if strings.TrimSpace(raw) == "" {
+		return raw
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return raw
+	}
+	if decoder.More() {
+		return raw
+	}
+	return value
+}
+
+func safeJSONMarshal(value any) string {
+	if value == nil {
+		return "null"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func extractDataPayload(dataURL string) string {
+	if idx := strings.Index(dataURL, ","); idx != -1 {
+		return dataURL[idx+1:]
+	}
+	return dataURL
+}
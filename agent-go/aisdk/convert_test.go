@@ -0,0 +1,156 @@
+package aisdk_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/aisdk"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestUIMessagesToMessages(t *testing.T) {
+	imageData := base64.StdEncoding.EncodeToString([]byte("png-bytes"))
+	messages := []aisdk.UIMessage{
+		{
+			ID:   "m1",
+			Role: aisdk.UIRoleUser,
+			Parts: []aisdk.UIPart{
+				{Text: &aisdk.TextUIPart{Text: "What's the weather?"}},
+				{File: &aisdk.FileUIPart{URL: "data:image/png;base64," + imageData, MediaType: "image/png"}},
+			},
+		},
+		{
+			ID:   "m2",
+			Role: aisdk.UIRoleAssistant,
+			Parts: []aisdk.UIPart{
+				{Reasoning: &aisdk.ReasoningUIPart{Text: "The user wants a forecast."}},
+				{Text: &aisdk.TextUIPart{Text: "Let me check."}},
+				{Tool: &aisdk.ToolUIPart{
+					State:      aisdk.ToolStateOutputAvailable,
+					ToolCallID: "call_1",
+					ToolName:   "get_local_weather",
+					Input:      map[string]any{"location": "Hanoi"},
+					Output:     map[string]any{"condition": "sunny"},
+				}},
+				{Tool: &aisdk.ToolUIPart{
+					State:      aisdk.ToolStateOutputError,
+					ToolCallID: "call_2",
+					ToolName:   "get_current_time",
+					ErrorText:  "clock unavailable",
+				}},
+			},
+		},
+	}
+
+	history, err := aisdk.UIMessagesToMessages(messages)
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected user, assistant, and tool messages, got %+v", history)
+	}
+
+	user := history[0].UserMessage
+	if user == nil || len(user.Content) != 2 || user.Content[1].ImagePart == nil ||
+		user.Content[1].ImagePart.Data != imageData {
+		t.Fatalf("expected text and image user parts, got %+v", history[0])
+	}
+
+	assistant := history[1].AssistantMessage
+	if assistant == nil || len(assistant.Content) != 4 {
+		t.Fatalf("expected reasoning, text, and two tool calls, got %+v", history[1])
+	}
+	if assistant.Content[0].ReasoningPart == nil || assistant.Content[0].ReasoningPart.Text != "The user wants a forecast." {
+		t.Fatalf("expected the reasoning part, got %+v", assistant.Content[0])
+	}
+	if assistant.Content[2].ToolCallPart == nil || assistant.Content[2].ToolCallPart.ToolCallID != "call_1" {
+		t.Fatalf("expected the first tool call, got %+v", assistant.Content[2])
+	}
+
+	tool := history[2].ToolMessage
+	if tool == nil || len(tool.Content) != 2 {
+		t.Fatalf("expected both tool results, got %+v", history[2])
+	}
+	failed := tool.Content[1].ToolResultPart
+	if failed == nil || failed.Status != llmsdk.ToolResultStatusFailed ||
+		failed.Result.Function.Content[0].TextPart.Text != "clock unavailable" {
+		t.Fatalf("expected a failed tool result carrying the error text, got %+v", tool.Content[1])
+	}
+}
+
+func TestMessagesToUIMessages(t *testing.T) {
+	messages := []llmsdk.Message{
+		llmsdk.NewUserMessage(llmsdk.NewTextPart("Run the tools")),
+		llmsdk.NewAssistantMessage(
+			llmsdk.NewReasoningPart("Planning tool calls."),
+			llmsdk.NewToolCallPart("call_1", "get_local_weather", map[string]any{"location": "Hanoi"}),
+			llmsdk.NewToolCallPart("call_2", "get_current_time", nil),
+		),
+		llmsdk.NewToolMessage(
+			llmsdk.NewToolResultPart("call_1", "get_local_weather", []llmsdk.Part{
+				llmsdk.NewTextPart(`{"condition":"sunny"}`),
+			}),
+			llmsdk.NewToolResultPart("call_2", "get_current_time", []llmsdk.Part{
+				llmsdk.NewTextPart("clock unavailable"),
+			}, llmsdk.WithToolResultStatus(llmsdk.ToolResultStatusFailed)),
+		),
+		llmsdk.NewAssistantMessage(llmsdk.NewTextPart("It is sunny.")),
+	}
+
+	uiMessages := aisdk.MessagesToUIMessages(messages)
+	if len(uiMessages) != 2 {
+		t.Fatalf("expected one user and one assistant UI message, got %+v", uiMessages)
+	}
+	if uiMessages[0].Role != aisdk.UIRoleUser || uiMessages[0].Parts[0].Text.Text != "Run the tools" {
+		t.Fatalf("expected the user message, got %+v", uiMessages[0])
+	}
+
+	assistant := uiMessages[1]
+	if assistant.Role != aisdk.UIRoleAssistant || len(assistant.Parts) != 4 {
+		t.Fatalf("expected reasoning, two tools, and text, got %+v", assistant)
+	}
+	if assistant.Parts[0].Reasoning == nil || assistant.Parts[0].Reasoning.Text != "Planning tool calls." {
+		t.Fatalf("expected the reasoning part, got %+v", assistant.Parts[0])
+	}
+
+	weather := assistant.Parts[1].Tool
+	if weather == nil || weather.State != aisdk.ToolStateOutputAvailable {
+		t.Fatalf("expected an output-available tool part, got %+v", assistant.Parts[1])
+	}
+	output, ok := weather.Output.(map[string]any)
+	if !ok || output["condition"] != "sunny" {
+		t.Fatalf("expected parsed JSON output, got %+v", weather.Output)
+	}
+
+	clock := assistant.Parts[2].Tool
+	if clock == nil || clock.State != aisdk.ToolStateOutputError || clock.ErrorText != "clock unavailable" {
+		t.Fatalf("expected an output-error tool part, got %+v", assistant.Parts[2])
+	}
+
+	if assistant.Parts[3].Text == nil || assistant.Parts[3].Text.Text != "It is sunny." {
+		t.Fatalf("expected the final text part, got %+v", assistant.Parts[3])
+	}
+}
+
+func TestUIPartToPartsFiles(t *testing.T) {
+	textData := base64.StdEncoding.EncodeToString([]byte("hello from a file"))
+
+	parts := aisdk.UIPartToParts(aisdk.UIPart{File: &aisdk.FileUIPart{
+		URL:       "data:text/plain;base64," + textData,
+		MediaType: "text",
+		Filename:  strPtr("note.txt"),
+	}})
+	if len(parts) != 1 || parts[0].TextPart == nil || parts[0].TextPart.Text != "hello from a file" {
+		t.Fatalf("expected decoded text content, got %+v", parts)
+	}
+
+	parts = aisdk.UIPartToParts(aisdk.UIPart{ReasoningFile: &aisdk.ReasoningFileUIPart{
+		URL:       "data:image/jpeg;base64,QUJD",
+		MediaType: "image",
+	}})
+	if len(parts) != 1 || parts[0].ImagePart == nil || parts[0].ImagePart.MimeType != "image/jpeg" {
+		t.Fatalf("expected an image part with the recovered MIME type, got %+v", parts)
+	}
+}
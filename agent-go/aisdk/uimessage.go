@@ -0,0 +1,249 @@
+// Package aisdk adapts agent runs to the Vercel AI SDK UI message format and
+// data stream protocol so an Agent can back a useChat frontend. It converts
+// UI messages to llm-sdk messages (and back) and streams AgentStreamEvent
+// values as data stream protocol chunks over SSE.
+package aisdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// UIMessageRole identifies the author of a UI message.
+type UIMessageRole string
+
+const (
+	UIRoleSystem    UIMessageRole = "system"
+	UIRoleUser      UIMessageRole = "user"
+	UIRoleAssistant UIMessageRole = "assistant"
+)
+
+type providerMetadata = any
+
+// UIMessage is a message in the Vercel AI SDK UI message format.
+type UIMessage struct {
+	ID       string        `json:"id"`
+	Role     UIMessageRole `json:"role"`
+	Parts    []UIPart      `json:"parts"`
+	Metadata any           `json:"metadata,omitempty"`
+}
+
+// UIPart is a discriminated union of UI message parts. Only one of the fields
+// is non-nil.
+type UIPart struct {
+	Text          *TextUIPart
+	Custom        *CustomUIPart
+	Reasoning     *ReasoningUIPart
+	DynamicTool   *DynamicToolUIPart
+	Tool          *ToolUIPart
+	File          *FileUIPart
+	ReasoningFile *ReasoningFileUIPart
+}
+
+func (p *UIPart) UnmarshalJSON(data []byte) error {
+	var base baseUIPart
+	if err := json.Unmarshal(data, &base); err != nil {
+		return err
+	}
+	switch {
+	case base.Type == "text":
+		var part TextUIPart
+		if err := json.Unmarshal(data, &part); err != nil {
+			return err
+		}
+		p.Text = &part
+	case base.Type == "custom":
+		var part CustomUIPart
+		if err := json.Unmarshal(data, &part); err != nil {
+			return err
+		}
+		p.Custom = &part
+	case base.Type == "reasoning":
+		var part ReasoningUIPart
+		if err := json.Unmarshal(data, &part); err != nil {
+			return err
+		}
+		p.Reasoning = &part
+	case base.Type == "dynamic-tool":
+		var part DynamicToolUIPart
+		if err := json.Unmarshal(data, &part); err != nil {
+			return err
+		}
+		p.DynamicTool = &part
+	case base.Type == "file":
+		var part FileUIPart
+		if err := json.Unmarshal(data, &part); err != nil {
+			return err
+		}
+		p.File = &part
+	case base.Type == "reasoning-file":
+		var part ReasoningFileUIPart
+		if err := json.Unmarshal(data, &part); err != nil {
+			return err
+		}
+		p.ReasoningFile = &part
+	default:
+		if strings.HasPrefix(base.Type, "tool-") {
+			var part ToolUIPart
+			if err := json.Unmarshal(data, &part); err != nil {
+				return err
+			}
+			part.rawType = base.Type
+			if part.ToolName == "" {
+				part.ToolName = strings.TrimPrefix(base.Type, "tool-")
+			}
+			p.Tool = &part
+		} else {
+			p.Tool = nil
+		}
+	}
+	return nil
+}
+
+func (p UIPart) MarshalJSON() ([]byte, error) {
+	switch {
+	case p.Text != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*TextUIPart
+		}{
+			Type:       "text",
+			TextUIPart: p.Text,
+		})
+	case p.Custom != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*CustomUIPart
+		}{
+			Type:         "custom",
+			CustomUIPart: p.Custom,
+		})
+	case p.Reasoning != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*ReasoningUIPart
+		}{
+			Type:            "reasoning",
+			ReasoningUIPart: p.Reasoning,
+		})
+	case p.DynamicTool != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*DynamicToolUIPart
+		}{
+			Type:              "dynamic-tool",
+			DynamicToolUIPart: p.DynamicTool,
+		})
+	case p.File != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*FileUIPart
+		}{
+			Type:       "file",
+			FileUIPart: p.File,
+		})
+	case p.ReasoningFile != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*ReasoningFileUIPart
+		}{
+			Type:                "reasoning-file",
+			ReasoningFileUIPart: p.ReasoningFile,
+		})
+	case p.Tool != nil:
+		typeValue := p.Tool.rawType
+		if typeValue == "" {
+			if p.Tool.ToolName != "" {
+				typeValue = "tool-" + p.Tool.ToolName
+			} else {
+				typeValue = "tool"
+			}
+		}
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*ToolUIPart
+		}{
+			Type:       typeValue,
+			ToolUIPart: p.Tool,
+		})
+	default:
+		return nil, fmt.Errorf("UIPart marshal: no variant populated")
+	}
+}
+
+type baseUIPart struct {
+	Type string `json:"type"`
+}
+
+// TextUIPart is plain message text.
+type TextUIPart struct {
+	Text             string           `json:"text"`
+	State            *string          `json:"state,omitempty"`
+	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
+}
+
+// CustomUIPart carries provider-specific content with no portable equivalent.
+type CustomUIPart struct {
+	Kind             string           `json:"kind"`
+	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
+}
+
+// ReasoningUIPart is intermediate model reasoning surfaced to the UI.
+type ReasoningUIPart struct {
+	Text             string           `json:"text"`
+	State            *string          `json:"state,omitempty"`
+	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
+}
+
+// FileUIPart references uploaded or generated file content, typically as a
+// data URL.
+type FileUIPart struct {
+	URL               string            `json:"url"`
+	MediaType         string            `json:"mediaType"`
+	Filename          *string           `json:"filename,omitempty"`
+	ProviderReference map[string]string `json:"providerReference,omitempty"`
+	ProviderMetadata  providerMetadata  `json:"providerMetadata,omitempty"`
+}
+
+// ReasoningFileUIPart is file content attached to a reasoning step.
+type ReasoningFileUIPart struct {
+	URL              string           `json:"url"`
+	MediaType        string           `json:"mediaType"`
+	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
+}
+
+// DynamicToolUIPart is a tool invocation whose tool was not statically
+// declared to the frontend.
+type DynamicToolUIPart struct {
+	ToolName   string `json:"toolName"`
+	ToolCallID string `json:"toolCallId"`
+	Input      any    `json:"input,omitempty"`
+}
+
+// ToolUIPart tracks a tool invocation through its lifecycle states
+// (input-available, output-available, output-error).
+type ToolUIPart struct {
+	State            string           `json:"state"`
+	ToolCallID       string           `json:"toolCallId"`
+	ToolName         string           `json:"toolName,omitempty"`
+	Input            any              `json:"input,omitempty"`
+	Output           any              `json:"output,omitempty"`
+	ErrorText        string           `json:"errorText,omitempty"`
+	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
+	rawType          string           `json:"-"`
+}
+
+// ToolUIPart lifecycle states.
+const (
+	ToolStateInputAvailable  = "input-available"
+	ToolStateOutputAvailable = "output-available"
+	ToolStateOutputError     = "output-error"
+)
+
+func (p *ToolUIPart) resolvedToolName() string {
+	if p.ToolName != "" {
+		return p.ToolName
+	}
+	return strings.TrimPrefix(p.rawType, "tool-")
+}
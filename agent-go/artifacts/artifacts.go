@@ -0,0 +1,192 @@
+// Package artifacts maintains named, versioned documents separate from the
+// chat transcript — the artifacts/canvas pattern from the examples as a
+// library. A Store keeps every version of each document, the toolkit exposes
+// CRUD tools for the agent, Diff renders a line diff between versions, and
+// registered change observers receive create/update/delete events so UIs can
+// render a canvas pane.
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	dmp "github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Kind classifies the document content.
+type Kind string
+
+const (
+	KindMarkdown Kind = "markdown"
+	KindText     Kind = "text"
+	KindCode     Kind = "code"
+)
+
+// Artifact is one version of a document.
+type Artifact struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Kind      Kind      `json:"kind"`
+	Content   string    `json:"content"`
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store persists versioned documents. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Create stores a new document at version 1.
+	Create(ctx context.Context, title string, kind Kind, content string) (Artifact, error)
+	// Update replaces the content of the document, bumping its version.
+	Update(ctx context.Context, id, content string) (Artifact, error)
+	// Get returns the latest version of the document.
+	Get(ctx context.Context, id string) (Artifact, error)
+	// GetVersion returns a specific version of the document.
+	GetVersion(ctx context.Context, id string, version int) (Artifact, error)
+	// List returns the latest version of every document.
+	List(ctx context.Context) ([]Artifact, error)
+	// Delete removes the document and its history.
+	Delete(ctx context.Context, id string) error
+}
+
+// ErrArtifactNotFound is returned by stores for unknown document IDs.
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+// ErrVersionNotFound is returned by stores for unknown document versions.
+var ErrVersionNotFound = errors.New("artifact version not found")
+
+// InMemoryStore is a Store backed by in-process maps, keeping the full
+// version history of each document. It is safe for concurrent use.
+type InMemoryStore struct {
+	mu       sync.RWMutex
+	versions map[string][]Artifact
+}
+
+// NewInMemoryStore creates an empty in-memory artifact store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{versions: map[string][]Artifact{}}
+}
+
+func (s *InMemoryStore) Create(ctx context.Context, title string, kind Kind, content string) (Artifact, error) {
+	artifact := Artifact{
+		ID:        uuid.NewString(),
+		Title:     title,
+		Kind:      kind,
+		Content:   content,
+		Version:   1,
+		UpdatedAt: time.Now().UTC(),
+	}
+	s.mu.Lock()
+	s.versions[artifact.ID] = []Artifact{artifact}
+	s.mu.Unlock()
+	return artifact, nil
+}
+
+func (s *InMemoryStore) Update(ctx context.Context, id, content string) (Artifact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	history, ok := s.versions[id]
+	if !ok {
+		return Artifact{}, fmt.Errorf("%w: %s", ErrArtifactNotFound, id)
+	}
+	artifact := history[len(history)-1]
+	artifact.Content = content
+	artifact.Version++
+	artifact.UpdatedAt = time.Now().UTC()
+	s.versions[id] = append(history, artifact)
+	return artifact, nil
+}
+
+func (s *InMemoryStore) Get(ctx context.Context, id string) (Artifact, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	history, ok := s.versions[id]
+	if !ok {
+		return Artifact{}, fmt.Errorf("%w: %s", ErrArtifactNotFound, id)
+	}
+	return history[len(history)-1], nil
+}
+
+func (s *InMemoryStore) GetVersion(ctx context.Context, id string, version int) (Artifact, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	history, ok := s.versions[id]
+	if !ok {
+		return Artifact{}, fmt.Errorf("%w: %s", ErrArtifactNotFound, id)
+	}
+	if version < 1 || version > len(history) {
+		return Artifact{}, fmt.Errorf("%w: %s version %d", ErrVersionNotFound, id, version)
+	}
+	return history[version-1], nil
+}
+
+func (s *InMemoryStore) List(ctx context.Context) ([]Artifact, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	artifacts := make([]Artifact, 0, len(s.versions))
+	for _, history := range s.versions {
+		artifacts = append(artifacts, history[len(history)-1])
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].ID < artifacts[j].ID })
+	return artifacts, nil
+}
+
+func (s *InMemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.versions[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrArtifactNotFound, id)
+	}
+	delete(s.versions, id)
+	return nil
+}
+
+// Diff renders a plain line diff between two document contents, with "+ ",
+// "- ", and "  " prefixes, for change events and UI panes.
+func Diff(oldText, newText string) string {
+	differ := dmp.New()
+	oldRunes, newRunes, lines := differ.DiffLinesToRunes(oldText, newText)
+	diffs := differ.DiffMainRunes(oldRunes, newRunes, false)
+	diffs = differ.DiffCharsToLines(diffs, lines)
+
+	var b strings.Builder
+	for _, diff := range diffs {
+		prefix := "  "
+		switch diff.Type {
+		case dmp.DiffInsert:
+			prefix = "+ "
+		case dmp.DiffDelete:
+			prefix = "- "
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(diff.Text, "\n"), "\n") {
+			b.WriteString(prefix + line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// ChangeType identifies what happened to a document.
+type ChangeType string
+
+const (
+	ChangeTypeCreated ChangeType = "created"
+	ChangeTypeUpdated ChangeType = "updated"
+	ChangeTypeDeleted ChangeType = "deleted"
+)
+
+// ChangeEvent announces a document change to observers.
+type ChangeEvent struct {
+	// Type is what happened.
+	Type ChangeType `json:"type"`
+	// Artifact is the document after the change; for deletions, the last
+	// version before removal.
+	Artifact Artifact `json:"artifact"`
+	// Diff is the line diff from the previous version, set for updates.
+	Diff string `json:"diff,omitempty"`
+}
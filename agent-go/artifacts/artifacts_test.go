@@ -0,0 +1,156 @@
+package artifacts_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/artifacts"
+)
+
+func newSession(t *testing.T, toolkit *artifacts.Toolkit[any]) llmagent.ToolkitSession[any] {
+	t.Helper()
+	session, err := toolkit.CreateSession(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	t.Cleanup(func() { session.Close(context.Background()) })
+	return session
+}
+
+func findTool(t *testing.T, session llmagent.ToolkitSession[any], name string) llmagent.AgentFunctionTool[any] {
+	t.Helper()
+	for _, tool := range session.Tools() {
+		if functionTool := tool.AsFunctionTool(); functionTool != nil && functionTool.Name() == name {
+			return functionTool
+		}
+	}
+	t.Fatalf("tool %q not found", name)
+	return nil
+}
+
+func execute(t *testing.T, tool llmagent.AgentFunctionTool[any], args string) llmagent.AgentToolResult {
+	t.Helper()
+	result, err := tool.Execute(t.Context(), json.RawMessage(args), nil, nil)
+	if err != nil {
+		t.Fatalf("tool %q failed: %v", tool.Name(), err)
+	}
+	return result
+}
+
+func resultText(t *testing.T, result llmagent.AgentToolResult) string {
+	t.Helper()
+	if len(result.Content) != 1 || result.Content[0].TextPart == nil {
+		t.Fatalf("unexpected result content %+v", result.Content)
+	}
+	return result.Content[0].TextPart.Text
+}
+
+func createdID(t *testing.T, result llmagent.AgentToolResult) string {
+	t.Helper()
+	var payload struct {
+		Artifact artifacts.Artifact `json:"artifact"`
+	}
+	if err := json.Unmarshal([]byte(resultText(t, result)), &payload); err != nil {
+		t.Fatalf("invalid tool payload: %v", err)
+	}
+	return payload.Artifact.ID
+}
+
+func TestToolkitCRUDAndEvents(t *testing.T) {
+	store := artifacts.NewInMemoryStore()
+	var events []artifacts.ChangeEvent
+	toolkit := artifacts.NewToolkit(store, artifacts.WithOnChange[any](func(event artifacts.ChangeEvent) {
+		events = append(events, event)
+	}))
+	session := newSession(t, toolkit)
+
+	if session.SystemPrompt() == nil || !strings.Contains(*session.SystemPrompt(), "documents by id") {
+		t.Error("expected the artifacts system prompt")
+	}
+
+	create := findTool(t, session, "artifact_create")
+	result := execute(t, create, `{"title":"PRD","kind":"markdown","content":"# Todo App\n"}`)
+	id := createdID(t, result)
+	if id == "" {
+		t.Fatal("expected an artifact ID")
+	}
+
+	update := findTool(t, session, "artifact_update")
+	execute(t, update, `{"id":"`+id+`","content":"# Todo App\n## Goals\n"}`)
+
+	artifact, err := store.Get(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to get artifact: %v", err)
+	}
+	if artifact.Version != 2 || !strings.Contains(artifact.Content, "Goals") {
+		t.Errorf("unexpected artifact after update %+v", artifact)
+	}
+
+	list := findTool(t, session, "artifact_list")
+	if text := resultText(t, execute(t, list, `{}`)); !strings.Contains(text, id) {
+		t.Errorf("expected the artifact in the listing, got %q", text)
+	}
+
+	deleteTool := findTool(t, session, "artifact_delete")
+	execute(t, deleteTool, `{"id":"`+id+`"}`)
+	if _, err := store.Get(t.Context(), id); !errors.Is(err, artifacts.ErrArtifactNotFound) {
+		t.Errorf("expected the artifact deleted, got %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 change events, got %d", len(events))
+	}
+	if events[0].Type != artifacts.ChangeTypeCreated ||
+		events[1].Type != artifacts.ChangeTypeUpdated ||
+		events[2].Type != artifacts.ChangeTypeDeleted {
+		t.Errorf("unexpected event order %+v", events)
+	}
+	if !strings.Contains(events[1].Diff, "+ ## Goals") {
+		t.Errorf("expected the update diff, got %q", events[1].Diff)
+	}
+}
+
+func TestToolkitUnknownIDIsErrorResult(t *testing.T) {
+	toolkit := artifacts.NewToolkit[any](artifacts.NewInMemoryStore())
+	session := newSession(t, toolkit)
+
+	get := findTool(t, session, "artifact_get")
+	result := execute(t, get, `{"id":"missing"}`)
+	if !result.IsError {
+		t.Error("expected a failed tool result for an unknown id")
+	}
+}
+
+func TestStoreVersionHistory(t *testing.T) {
+	store := artifacts.NewInMemoryStore()
+	artifact, err := store.Create(t.Context(), "Notes", artifacts.KindText, "v1")
+	if err != nil {
+		t.Fatalf("failed to create: %v", err)
+	}
+	if _, err := store.Update(t.Context(), artifact.ID, "v2"); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	first, err := store.GetVersion(t.Context(), artifact.ID, 1)
+	if err != nil {
+		t.Fatalf("failed to get version 1: %v", err)
+	}
+	if first.Content != "v1" || first.Version != 1 {
+		t.Errorf("unexpected version 1 %+v", first)
+	}
+
+	if _, err := store.GetVersion(t.Context(), artifact.ID, 3); !errors.Is(err, artifacts.ErrVersionNotFound) {
+		t.Errorf("expected ErrVersionNotFound, got %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	diff := artifacts.Diff("alpha\nbeta\n", "alpha\ngamma\n")
+	if !strings.Contains(diff, "- beta") || !strings.Contains(diff, "+ gamma") {
+		t.Errorf("unexpected diff %q", diff)
+	}
+}
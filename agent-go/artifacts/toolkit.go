@@ -0,0 +1,270 @@
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// ArtifactsPrompt steers the model towards putting substantive deliverables
+// into documents instead of the chat reply.
+const ArtifactsPrompt = `Use documents (artifacts/canvases) for substantive deliverables like documents, plans, specs, or code. Keep chat replies brief and status-oriented; put the full content into a document via the tools. Always reference documents by id.
+- Prefer creating/updating documents instead of pasting large content into chat
+- When asked to revise or extend prior work, read/update the relevant document
+- Keep the chat response short: what changed, where it lives (document id), and next steps`
+
+// Toolkit exposes the artifact CRUD tools over a store. It implements
+// llmagent.Toolkit.
+type Toolkit[C any] struct {
+	store    Store
+	onChange func(ChangeEvent)
+}
+
+// Option customises the toolkit.
+type Option[C any] func(*Toolkit[C])
+
+// WithOnChange registers an observer called after every document change, so
+// UIs can refresh their canvas pane. The callback runs on the tool execution
+// goroutine and should return quickly.
+func WithOnChange[C any](onChange func(ChangeEvent)) Option[C] {
+	return func(t *Toolkit[C]) {
+		t.onChange = onChange
+	}
+}
+
+// NewToolkit creates an artifacts toolkit over the store.
+func NewToolkit[C any](store Store, options ...Option[C]) *Toolkit[C] {
+	t := &Toolkit[C]{store: store}
+	for _, option := range options {
+		option(t)
+	}
+	return t
+}
+
+// CreateSession implements llmagent.Toolkit.
+func (t *Toolkit[C]) CreateSession(_ context.Context, _ C) (llmagent.ToolkitSession[C], error) {
+	return &session[C]{toolkit: t}, nil
+}
+
+type session[C any] struct {
+	toolkit *Toolkit[C]
+}
+
+func (s *session[C]) SystemPrompt() *string {
+	prompt := ArtifactsPrompt
+	return &prompt
+}
+
+func (s *session[C]) Tools() []llmagent.AgentTool[C] {
+	return llmagent.FunctionTools[C](
+		&createTool[C]{toolkit: s.toolkit},
+		&updateTool[C]{toolkit: s.toolkit},
+		&getTool[C]{toolkit: s.toolkit},
+		&listTool[C]{toolkit: s.toolkit},
+		&deleteTool[C]{toolkit: s.toolkit},
+	)
+}
+
+func (s *session[C]) Close(context.Context) error { return nil }
+
+// notify reports a change to the registered observer, if any.
+func (t *Toolkit[C]) notify(event ChangeEvent) {
+	if t.onChange != nil {
+		t.onChange(event)
+	}
+}
+
+// errorResult returns the failed tool result for a store error so the model
+// can correct the document ID instead of crashing the run.
+func errorResult(err error) llmagent.AgentToolResult {
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(err.Error())},
+		IsError: true,
+	}
+}
+
+// jsonResult marshals the payload as the tool result text.
+func jsonResult(payload any) (llmagent.AgentToolResult, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(string(body))},
+	}, nil
+}
+
+type createTool[C any] struct {
+	toolkit *Toolkit[C]
+}
+
+func (t *createTool[C]) Name() string        { return "artifact_create" }
+func (t *createTool[C]) Description() string { return "Create a new document and return it" }
+
+func (t *createTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"title":   map[string]any{"type": "string"},
+			"kind":    map[string]any{"type": "string", "enum": []string{"markdown", "text", "code"}},
+			"content": map[string]any{"type": "string"},
+		},
+		"required":             []string{"title", "kind", "content"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *createTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args struct {
+		Title   string `json:"title"`
+		Kind    string `json:"kind"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	artifact, err := t.toolkit.store.Create(ctx, args.Title, Kind(args.Kind), args.Content)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	t.toolkit.notify(ChangeEvent{Type: ChangeTypeCreated, Artifact: artifact})
+	return jsonResult(map[string]any{"artifact": artifact})
+}
+
+type updateTool[C any] struct {
+	toolkit *Toolkit[C]
+}
+
+func (t *updateTool[C]) Name() string { return "artifact_update" }
+func (t *updateTool[C]) Description() string {
+	return "Replace the content of a document and return it"
+}
+
+func (t *updateTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"id":      map[string]any{"type": "string"},
+			"content": map[string]any{"type": "string"},
+		},
+		"required":             []string{"id", "content"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *updateTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args struct {
+		ID      string `json:"id"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	before, err := t.toolkit.store.Get(ctx, args.ID)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	artifact, err := t.toolkit.store.Update(ctx, args.ID, args.Content)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	t.toolkit.notify(ChangeEvent{
+		Type:     ChangeTypeUpdated,
+		Artifact: artifact,
+		Diff:     Diff(before.Content, artifact.Content),
+	})
+	return jsonResult(map[string]any{"artifact": artifact})
+}
+
+type getTool[C any] struct {
+	toolkit *Toolkit[C]
+}
+
+func (t *getTool[C]) Name() string        { return "artifact_get" }
+func (t *getTool[C]) Description() string { return "Fetch a document by id" }
+
+func (t *getTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"id": map[string]any{"type": "string"},
+		},
+		"required":             []string{"id"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *getTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	artifact, err := t.toolkit.store.Get(ctx, args.ID)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	return jsonResult(map[string]any{"artifact": artifact})
+}
+
+type listTool[C any] struct {
+	toolkit *Toolkit[C]
+}
+
+func (t *listTool[C]) Name() string        { return "artifact_list" }
+func (t *listTool[C]) Description() string { return "List all documents" }
+
+func (t *listTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type":                 "object",
+		"properties":           map[string]any{},
+		"additionalProperties": false,
+	}
+}
+
+func (t *listTool[C]) Execute(ctx context.Context, _ json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	artifacts, err := t.toolkit.store.List(ctx)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	return jsonResult(map[string]any{"artifacts": artifacts})
+}
+
+type deleteTool[C any] struct {
+	toolkit *Toolkit[C]
+}
+
+func (t *deleteTool[C]) Name() string        { return "artifact_delete" }
+func (t *deleteTool[C]) Description() string { return "Delete a document by id" }
+
+func (t *deleteTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"id": map[string]any{"type": "string"},
+		},
+		"required":             []string{"id"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *deleteTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	artifact, err := t.toolkit.store.Get(ctx, args.ID)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	if err := t.toolkit.store.Delete(ctx, args.ID); err != nil {
+		return errorResult(err), nil
+	}
+	t.toolkit.notify(ChangeEvent{Type: ChangeTypeDeleted, Artifact: artifact})
+	return jsonResult(map[string]any{"success": true})
+}
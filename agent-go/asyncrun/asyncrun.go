@@ -0,0 +1,248 @@
+// Package asyncrun executes agent runs in the background so long tool chains
+// survive HTTP timeouts. Submit returns a run ID immediately; the run record
+// moves through pending, running, and a terminal status in a pluggable store
+// that callers poll, and completion can additionally be observed through a
+// wait channel or delivered to a webhook URL.
+package asyncrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+// RunStatus is the lifecycle state of a background run.
+type RunStatus string
+
+const (
+	RunStatusPending   RunStatus = "pending"
+	RunStatusRunning   RunStatus = "running"
+	RunStatusCompleted RunStatus = "completed"
+	RunStatusFailed    RunStatus = "failed"
+)
+
+// Terminal reports whether the status is final.
+func (s RunStatus) Terminal() bool {
+	return s == RunStatusCompleted || s == RunStatusFailed
+}
+
+// RunRecord is the stored state of a background run.
+type RunRecord struct {
+	// ID identifies the run.
+	ID string `json:"id"`
+	// Status is the current lifecycle state.
+	Status RunStatus `json:"status"`
+	// CreatedAt is when the run was submitted.
+	CreatedAt time.Time `json:"created_at"`
+	// CompletedAt is when the run reached a terminal status.
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Response is the agent response, set when the run completed.
+	Response *llmagent.AgentResponse `json:"response,omitempty"`
+	// Error is the failure message, set when the run failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Store persists run records. Implementations must be safe for concurrent
+// use.
+type Store interface {
+	// Save inserts or replaces the record under its ID.
+	Save(ctx context.Context, record RunRecord) error
+	// Load returns the record stored under the ID.
+	Load(ctx context.Context, id string) (RunRecord, error)
+}
+
+// ErrRunNotFound is returned by stores for unknown run IDs.
+var ErrRunNotFound = errors.New("run not found")
+
+// InMemoryStore is a Store backed by an in-process map. It is safe for
+// concurrent use.
+type InMemoryStore struct {
+	mu      sync.RWMutex
+	records map[string]RunRecord
+}
+
+// NewInMemoryStore creates an empty in-memory run store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{records: map[string]RunRecord{}}
+}
+
+func (s *InMemoryStore) Save(ctx context.Context, record RunRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.ID] = record
+	return nil
+}
+
+func (s *InMemoryStore) Load(ctx context.Context, id string) (RunRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.records[id]
+	if !ok {
+		return RunRecord{}, fmt.Errorf("%w: %s", ErrRunNotFound, id)
+	}
+	return record, nil
+}
+
+// Params configures the background runner.
+type Params struct {
+	// Store persists run records. Defaults to a new in-memory store.
+	Store Store
+	// WebhookClient sends webhook deliveries. Defaults to an HTTP client
+	// with a 30 second timeout.
+	WebhookClient *http.Client
+}
+
+// SubmitOptions configures one background run.
+type SubmitOptions struct {
+	// WebhookURL receives the terminal run record as a JSON POST when the
+	// run finishes. Empty disables webhook delivery.
+	WebhookURL string
+}
+
+// Runner executes agent runs in the background.
+type Runner[C any] struct {
+	agent  *llmagent.Agent[C]
+	store  Store
+	client *http.Client
+
+	mu   sync.Mutex
+	done map[string]chan struct{}
+}
+
+// NewRunner creates a background runner for the agent.
+func NewRunner[C any](agent *llmagent.Agent[C], params Params) (*Runner[C], error) {
+	if agent == nil {
+		return nil, errors.New("async runner requires an agent")
+	}
+	if params.Store == nil {
+		params.Store = NewInMemoryStore()
+	}
+	if params.WebhookClient == nil {
+		params.WebhookClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Runner[C]{
+		agent:  agent,
+		store:  params.Store,
+		client: params.WebhookClient,
+		done:   map[string]chan struct{}{},
+	}, nil
+}
+
+// Submit stores a pending record, starts the run in the background, and
+// returns the run ID immediately. The run uses the given context for
+// cancellation; cancelling it fails the run.
+func (r *Runner[C]) Submit(ctx context.Context, request llmagent.AgentRequest[C], options SubmitOptions) (string, error) {
+	record := RunRecord{
+		ID:        uuid.NewString(),
+		Status:    RunStatusPending,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := r.store.Save(ctx, record); err != nil {
+		return "", fmt.Errorf("failed to store run record: %w", err)
+	}
+
+	r.mu.Lock()
+	r.done[record.ID] = make(chan struct{})
+	r.mu.Unlock()
+
+	go r.execute(ctx, record, request, options)
+	return record.ID, nil
+}
+
+// Status returns the stored record for the run ID.
+func (r *Runner[C]) Status(ctx context.Context, id string) (RunRecord, error) {
+	return r.store.Load(ctx, id)
+}
+
+// Wait blocks until the run reaches a terminal status or the context is
+// cancelled. It returns the terminal record from the store.
+func (r *Runner[C]) Wait(ctx context.Context, id string) (RunRecord, error) {
+	r.mu.Lock()
+	done, ok := r.done[id]
+	r.mu.Unlock()
+
+	if ok {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return RunRecord{}, ctx.Err()
+		}
+	}
+
+	record, err := r.store.Load(ctx, id)
+	if err != nil {
+		return RunRecord{}, err
+	}
+	if !record.Status.Terminal() {
+		return RunRecord{}, fmt.Errorf("run %s is not terminal", id)
+	}
+	return record, nil
+}
+
+// execute runs the agent and records the terminal state.
+func (r *Runner[C]) execute(ctx context.Context, record RunRecord, request llmagent.AgentRequest[C], options SubmitOptions) {
+	record.Status = RunStatusRunning
+	_ = r.store.Save(ctx, record)
+
+	response, err := r.agent.Run(ctx, request)
+
+	now := time.Now().UTC()
+	record.CompletedAt = &now
+	if err != nil {
+		record.Status = RunStatusFailed
+		record.Error = err.Error()
+	} else {
+		record.Status = RunStatusCompleted
+		record.Response = response
+	}
+
+	// The submit context may already be cancelled; still persist the
+	// terminal record.
+	saveCtx := context.WithoutCancel(ctx)
+	_ = r.store.Save(saveCtx, record)
+
+	r.mu.Lock()
+	if done, ok := r.done[record.ID]; ok {
+		close(done)
+		delete(r.done, record.ID)
+	}
+	r.mu.Unlock()
+
+	if options.WebhookURL != "" {
+		r.deliverWebhook(saveCtx, options.WebhookURL, record)
+	}
+}
+
+// deliverWebhook posts the terminal record as JSON to the webhook URL.
+// Delivery failures are recorded on the run record so they are observable.
+func (r *Runner[C]) deliverWebhook(ctx context.Context, url string, record RunRecord) {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := r.client.Do(req)
+	if err == nil {
+		res.Body.Close()
+		if res.StatusCode < 400 {
+			return
+		}
+		err = fmt.Errorf("webhook returned status %d", res.StatusCode)
+	}
+
+	record.Error = fmt.Sprintf("webhook delivery failed: %v", err)
+	_ = r.store.Save(ctx, record)
+}
@@ -0,0 +1,147 @@
+package asyncrun_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/asyncrun"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func request(text string) llmagent.AgentRequest[any] {
+	return llmagent.AgentRequest[any]{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text))),
+		},
+	}
+}
+
+func TestSubmitAndWait(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+	agent := llmagent.NewAgent[any]("assistant", model)
+
+	runner, err := asyncrun.NewRunner(agent, asyncrun.Params{})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	id, err := runner.Submit(t.Context(), request("hi"), asyncrun.SubmitOptions{})
+	if err != nil {
+		t.Fatalf("failed to submit run: %v", err)
+	}
+	if id == "" {
+		t.Fatal("expected a run ID")
+	}
+
+	record, err := runner.Wait(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to wait for run: %v", err)
+	}
+	if record.Status != asyncrun.RunStatusCompleted {
+		t.Errorf("unexpected status %q", record.Status)
+	}
+	if record.Response == nil || record.Response.Content[0].TextPart.Text != "done" {
+		t.Errorf("unexpected response %+v", record.Response)
+	}
+	if record.CompletedAt == nil {
+		t.Error("expected a completion time")
+	}
+
+	// Status and a second Wait observe the same terminal record.
+	status, err := runner.Status(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to load status: %v", err)
+	}
+	if status.Status != asyncrun.RunStatusCompleted {
+		t.Errorf("unexpected stored status %q", status.Status)
+	}
+	if _, err := runner.Wait(t.Context(), id); err != nil {
+		t.Errorf("expected waiting on a terminal run to succeed, got %v", err)
+	}
+}
+
+func TestSubmitRecordsFailure(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultError(
+		llmsdk.NewInvariantError("mock", "boom"),
+	))
+	agent := llmagent.NewAgent[any]("assistant", model)
+
+	runner, err := asyncrun.NewRunner(agent, asyncrun.Params{})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	id, err := runner.Submit(t.Context(), request("hi"), asyncrun.SubmitOptions{})
+	if err != nil {
+		t.Fatalf("failed to submit run: %v", err)
+	}
+
+	record, err := runner.Wait(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to wait for run: %v", err)
+	}
+	if record.Status != asyncrun.RunStatusFailed || record.Error == "" {
+		t.Errorf("expected a failed record, got %+v", record)
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+	agent := llmagent.NewAgent[any]("assistant", model)
+
+	received := make(chan asyncrun.RunRecord, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var record asyncrun.RunRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			t.Errorf("invalid webhook payload: %v", err)
+		}
+		received <- record
+	}))
+	defer server.Close()
+
+	runner, err := asyncrun.NewRunner(agent, asyncrun.Params{})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	id, err := runner.Submit(t.Context(), request("hi"), asyncrun.SubmitOptions{WebhookURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to submit run: %v", err)
+	}
+
+	select {
+	case record := <-received:
+		if record.ID != id || record.Status != asyncrun.RunStatusCompleted {
+			t.Errorf("unexpected webhook record %+v", record)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the webhook delivery")
+	}
+}
+
+func TestStatusUnknownRun(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	agent := llmagent.NewAgent[any]("assistant", model)
+
+	runner, err := asyncrun.NewRunner(agent, asyncrun.Params{})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	if _, err := runner.Status(t.Context(), "missing"); !errors.Is(err, asyncrun.ErrRunNotFound) {
+		t.Errorf("expected ErrRunNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package llmagent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// AuditSink receives an append-only record of every tool execution, for
+// compliance in regulated deployments. Implementations must be safe for
+// concurrent use. Approval flows that live outside the run loop, such as the
+// chatcli prompts, can append their decisions to the same sink by calling
+// Record with the Approval field set.
+type AuditSink interface {
+	// Record appends one audit record.
+	Record(record AuditRecord)
+}
+
+// AuditRecord describes one tool execution, or an approval decision recorded
+// by a human-in-the-loop flow.
+type AuditRecord struct {
+	// Time is when the record was appended.
+	Time time.Time `json:"time"`
+	// SessionID and AgentName identify where the execution happened.
+	SessionID string `json:"session_id,omitempty"`
+	AgentName string `json:"agent_name,omitempty"`
+	// ToolCallID and ToolName identify the execution.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	ToolName   string `json:"tool_name"`
+	// Input is the tool call arguments as provided by the model.
+	Input json.RawMessage `json:"input,omitempty"`
+	// ResultHash is the SHA-256 hex digest of the JSON-encoded result
+	// content, so records stay small and free of result payloads while
+	// remaining verifiable against stored transcripts.
+	ResultHash string `json:"result_hash,omitempty"`
+	// Status is the terminal status of the execution.
+	Status llmsdk.ToolResultStatus `json:"status,omitempty"`
+	// Duration is how long the execution took.
+	Duration time.Duration `json:"duration,omitempty"`
+	// Context is the session context value the tool executed against.
+	Context any `json:"context,omitempty"`
+	// Approval is the human decision for flows that gate tools on approval;
+	// nil for plain executions.
+	Approval *bool `json:"approval,omitempty"`
+}
+
+// JSONLAuditSink appends audit records as JSON lines to a writer. It is safe
+// for concurrent use.
+type JSONLAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLAuditSink creates a sink that appends one JSON line per record to
+// the writer, typically a file opened with os.O_APPEND.
+func NewJSONLAuditSink(w io.Writer) *JSONLAuditSink {
+	return &JSONLAuditSink{w: w}
+}
+
+func (s *JSONLAuditSink) Record(record AuditRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		// The context value may not be serializable; keep the record without
+		// it rather than dropping the entry.
+		record.Context = nil
+		data, err = json.Marshal(record)
+		if err != nil {
+			return
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.w.Write(append(data, '\n'))
+}
+
+// auditToolExecution appends the execution record to the configured sink.
+func (s *RunSession[C]) auditToolExecution(toolCallID, toolName string, input json.RawMessage, output []llmsdk.Part, status llmsdk.ToolResultStatus, duration time.Duration) {
+	sink := s.params.Audit
+	if sink == nil {
+		return
+	}
+	sink.Record(AuditRecord{
+		Time:       time.Now().UTC(),
+		SessionID:  s.id,
+		AgentName:  s.params.Name,
+		ToolCallID: toolCallID,
+		ToolName:   toolName,
+		Input:      input,
+		ResultHash: hashParts(output),
+		Status:     status,
+		Duration:   duration,
+		Context:    s.contextVal,
+	})
+}
+
+// hashParts returns the SHA-256 hex digest of the JSON-encoded parts.
+func hashParts(parts []llmsdk.Part) string {
+	data, err := json.Marshal(parts)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
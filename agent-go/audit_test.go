@@ -0,0 +1,167 @@
+package llmagent_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+type recordingAuditSink struct {
+	mu      sync.Mutex
+	records []llmagent.AuditRecord
+}
+
+func (s *recordingAuditSink) Record(record llmagent.AuditRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+}
+
+func (s *recordingAuditSink) all() []llmagent.AuditRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]llmagent.AuditRecord(nil), s.records...)
+}
+
+func TestAuditRecordsToolExecutions(t *testing.T) {
+	t.Parallel()
+
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{
+				llmsdk.NewToolCallPart("call_1", "get_weather", map[string]any{"city": "Hanoi"}),
+			},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("It is sunny.")},
+		}),
+	)
+
+	sink := &recordingAuditSink{}
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+		Tools: []llmagent.AgentTool[any]{
+			llmagent.NewAgentFunctionTool[any](NewMockTool[any]("get_weather", llmagent.AgentToolResult{
+				Content: []llmsdk.Part{llmsdk.NewTextPart("sunny")},
+			}, nil)),
+		},
+		Audit: sink,
+	}, nil)
+
+	if _, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Weather in Hanoi?"))),
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := sink.all()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 audit record, got %d", len(records))
+	}
+	record := records[0]
+	if record.ToolName != "get_weather" || record.ToolCallID != "call_1" {
+		t.Errorf("unexpected tool identity %+v", record)
+	}
+	if record.Status != llmsdk.ToolResultStatusCompleted {
+		t.Errorf("expected completed status, got %q", record.Status)
+	}
+	if record.AgentName != "assistant" || record.SessionID == "" {
+		t.Errorf("unexpected session identity %+v", record)
+	}
+	if record.ResultHash == "" {
+		t.Error("expected a result hash")
+	}
+	if record.Time.IsZero() {
+		t.Error("expected a record time")
+	}
+	if !strings.Contains(string(record.Input), "Hanoi") {
+		t.Errorf("expected the tool input recorded, got %s", record.Input)
+	}
+}
+
+func TestAuditRecordsFailedExecutions(t *testing.T) {
+	t.Parallel()
+
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{
+				llmsdk.NewToolCallPart("call_1", "get_weather", map[string]any{"city": "Hanoi"}),
+			},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Could not check.")},
+		}),
+	)
+
+	sink := &recordingAuditSink{}
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+		Tools: []llmagent.AgentTool[any]{
+			llmagent.NewAgentFunctionTool[any](NewMockTool[any]("get_weather", llmagent.AgentToolResult{
+				Content: []llmsdk.Part{llmsdk.NewTextPart("upstream unavailable")},
+				IsError: true,
+			}, nil)),
+		},
+		Audit: sink,
+	}, nil)
+
+	if _, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Weather in Hanoi?"))),
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := sink.all()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 audit record, got %d", len(records))
+	}
+	if records[0].Status != llmsdk.ToolResultStatusFailed {
+		t.Errorf("expected failed status, got %q", records[0].Status)
+	}
+}
+
+func TestJSONLAuditSink(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	sink := llmagent.NewJSONLAuditSink(&buf)
+	sink.Record(llmagent.AuditRecord{ToolName: "get_weather", SessionID: "s1"})
+	sink.Record(llmagent.AuditRecord{ToolName: "get_time", Context: func() {}})
+
+	scanner := bufio.NewScanner(&buf)
+	var lines []llmagent.AuditRecord
+	for scanner.Scan() {
+		var record llmagent.AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid JSON line: %v", err)
+		}
+		lines = append(lines, record)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if lines[0].ToolName != "get_weather" || lines[0].SessionID != "s1" {
+		t.Errorf("unexpected first record %+v", lines[0])
+	}
+	// The unserializable context is dropped rather than losing the record.
+	if lines[1].ToolName != "get_time" || lines[1].Context != nil {
+		t.Errorf("unexpected second record %+v", lines[1])
+	}
+}
@@ -0,0 +1,207 @@
+// Package batch runs an agent over a slice of independent inputs for offline
+// processing jobs. The runner bounds concurrency, spaces run starts with a
+// shared rate limit, retries failed runs with exponential backoff, and
+// aggregates per-input results and token usage into a summary. A failed input
+// is recorded in its result instead of aborting the batch; only context
+// cancellation stops the run early.
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// RetryPolicy controls how failed runs are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retries.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry; it doubles on every
+	// subsequent retry. Zero defaults to one second.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay. Zero leaves it uncapped.
+	MaxDelay time.Duration
+}
+
+// Params configures the batch runner.
+type Params struct {
+	// Concurrency bounds how many runs execute at once. Zero or negative
+	// means unlimited, matching ToolConcurrency on the agent params.
+	Concurrency int
+	// MinInterval is the minimum time between run starts, shared across all
+	// workers, to respect provider rate limits. Zero disables rate limiting.
+	MinInterval time.Duration
+	// Retry controls retries of failed runs.
+	Retry RetryPolicy
+}
+
+// Result records the outcome of one input.
+type Result struct {
+	// Index is the position of the input in the batch.
+	Index int `json:"index"`
+	// Response is the agent response, nil when every attempt failed.
+	Response *llmagent.AgentResponse `json:"response,omitempty"`
+	// Error is the error from the last attempt, empty on success.
+	Error string `json:"error,omitempty"`
+	// Attempts is how many times the input was run.
+	Attempts int `json:"attempts"`
+	// Duration is the total time spent on the input across attempts.
+	Duration time.Duration `json:"duration"`
+}
+
+// Summary aggregates the outcome of a batch.
+type Summary struct {
+	// Results holds one entry per input, in input order.
+	Results []Result `json:"results"`
+	// Succeeded and Failed count the inputs by outcome.
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	// Usage is the aggregated token usage across all successful runs.
+	Usage llmsdk.ModelUsage `json:"usage"`
+}
+
+// Runner executes an agent over batches of independent inputs.
+type Runner[C any] struct {
+	agent  *llmagent.Agent[C]
+	params Params
+}
+
+// NewRunner creates a batch runner for the agent.
+func NewRunner[C any](agent *llmagent.Agent[C], params Params) (*Runner[C], error) {
+	if agent == nil {
+		return nil, errors.New("batch runner requires an agent")
+	}
+	if params.Retry.BaseDelay <= 0 {
+		params.Retry.BaseDelay = time.Second
+	}
+	return &Runner[C]{agent: agent, params: params}, nil
+}
+
+// Run executes every request and returns the collected summary. Results are
+// in input order regardless of completion order. Run returns an error only
+// when the context is cancelled before the batch finishes.
+func (r *Runner[C]) Run(ctx context.Context, requests []llmagent.AgentRequest[C]) (*Summary, error) {
+	summary := &Summary{Results: make([]Result, len(requests))}
+
+	limiter := newRateLimiter(r.params.MinInterval)
+	sem := make(chan struct{}, concurrencyLimit(r.params.Concurrency, len(requests)))
+
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(index int, request llmagent.AgentRequest[C]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			summary.Results[index] = r.runOne(ctx, index, request, limiter)
+		}(i, request)
+	}
+	wg.Wait()
+
+	for _, result := range summary.Results {
+		if result.Response != nil {
+			summary.Succeeded++
+			summary.Usage.Add(result.Response.Usage)
+		} else {
+			summary.Failed++
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+// runOne executes a single request with the retry policy applied.
+func (r *Runner[C]) runOne(ctx context.Context, index int, request llmagent.AgentRequest[C], limiter *rateLimiter) Result {
+	result := Result{Index: index}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	delay := r.params.Retry.BaseDelay
+	for attempt := 0; ; attempt++ {
+		if err := limiter.wait(ctx); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+
+		result.Attempts++
+		response, err := r.agent.Run(ctx, request)
+		if err == nil {
+			result.Response = response
+			result.Error = ""
+			return result
+		}
+		result.Error = err.Error()
+
+		if attempt >= r.params.Retry.MaxRetries || ctx.Err() != nil {
+			return result
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return result
+		}
+		delay *= 2
+		if max := r.params.Retry.MaxDelay; max > 0 && delay > max {
+			delay = max
+		}
+	}
+}
+
+func concurrencyLimit(concurrency, size int) int {
+	if concurrency <= 0 || concurrency > size {
+		if size == 0 {
+			return 1
+		}
+		return size
+	}
+	return concurrency
+}
+
+// rateLimiter spaces run starts by a minimum interval, shared across
+// workers.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimiter(interval time.Duration) *rateLimiter {
+	return &rateLimiter{interval: interval}
+}
+
+// wait blocks until the caller may start a run or the context is cancelled.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.interval <= 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait := time.Until(at); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return ctx.Err()
+}
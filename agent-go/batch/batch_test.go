@@ -0,0 +1,141 @@
+package batch_test
+
+import (
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/batch"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func request(text string) llmagent.AgentRequest[any] {
+	return llmagent.AgentRequest[any]{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text))),
+		},
+	}
+}
+
+func textResult(text string) llmsdktest.MockGenerateResult {
+	return llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(text)},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 3, OutputTokens: 5},
+	})
+}
+
+func TestBatchRunAggregatesResults(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(textResult("one"), textResult("two"), textResult("three"))
+	agent := llmagent.NewAgent[any]("assistant", model)
+
+	runner, err := batch.NewRunner(agent, batch.Params{Concurrency: 1})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	summary, err := runner.Run(t.Context(), []llmagent.AgentRequest[any]{
+		request("a"), request("b"), request("c"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.Succeeded != 3 || summary.Failed != 0 {
+		t.Errorf("unexpected counts %+v", summary)
+	}
+	if len(summary.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(summary.Results))
+	}
+	for i, result := range summary.Results {
+		if result.Index != i || result.Response == nil || result.Attempts != 1 {
+			t.Errorf("unexpected result %d: %+v", i, result)
+		}
+	}
+	if summary.Usage.InputTokens != 9 || summary.Usage.OutputTokens != 15 {
+		t.Errorf("unexpected aggregated usage %+v", summary.Usage)
+	}
+}
+
+func TestBatchRunRetriesFailures(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultError(llmsdk.NewInvariantError("mock", "transient failure")),
+		textResult("recovered"),
+	)
+	agent := llmagent.NewAgent[any]("assistant", model)
+
+	runner, err := batch.NewRunner(agent, batch.Params{
+		Retry: batch.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	summary, err := runner.Run(t.Context(), []llmagent.AgentRequest[any]{request("a")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := summary.Results[0]
+	if result.Response == nil || result.Attempts != 2 || result.Error != "" {
+		t.Errorf("expected success on the second attempt, got %+v", result)
+	}
+}
+
+func TestBatchRunRecordsExhaustedRetries(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultError(llmsdk.NewInvariantError("mock", "boom")),
+		llmsdktest.NewMockGenerateResultError(llmsdk.NewInvariantError("mock", "boom")),
+	)
+	agent := llmagent.NewAgent[any]("assistant", model)
+
+	runner, err := batch.NewRunner(agent, batch.Params{
+		Retry: batch.RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	summary, err := runner.Run(t.Context(), []llmagent.AgentRequest[any]{request("a")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := summary.Results[0]
+	if result.Response != nil || result.Attempts != 2 || result.Error == "" {
+		t.Errorf("expected a recorded failure after retries, got %+v", result)
+	}
+	if summary.Failed != 1 || summary.Succeeded != 0 {
+		t.Errorf("unexpected counts %+v", summary)
+	}
+}
+
+func TestBatchRunRateLimitsStarts(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(textResult("one"), textResult("two"), textResult("three"))
+	agent := llmagent.NewAgent[any]("assistant", model)
+
+	// The mock model is not safe for concurrent calls, so keep one worker;
+	// the limiter spacing is shared regardless of the worker count.
+	interval := 20 * time.Millisecond
+	runner, err := batch.NewRunner(agent, batch.Params{Concurrency: 1, MinInterval: interval})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	start := time.Now()
+	summary, err := runner.Run(t.Context(), []llmagent.AgentRequest[any]{
+		request("a"), request("b"), request("c"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Succeeded != 3 {
+		t.Fatalf("unexpected counts %+v", summary)
+	}
+	// Three starts spaced by the interval take at least two intervals.
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("expected the batch to take at least %v, took %v", 2*interval, elapsed)
+	}
+}
@@ -0,0 +1,255 @@
+// Package blob offloads large binary part payloads from agent items into a
+// pluggable store, replacing the inline base64 data with a reference so
+// transcripts persisted by session stores stay small. The Store interface
+// admits filesystem, S3, or GCS backends; FSStore is the bundled
+// content-addressed filesystem implementation. RestoreItems rehydrates
+// referenced parts before the items are sent back to a model.
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// refPrefix marks part data that has been offloaded to a store.
+const refPrefix = "blob:"
+
+// Store persists binary payloads by reference. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Put stores the data and returns its reference.
+	Put(ctx context.Context, data []byte, mimeType string) (string, error)
+	// Get returns the data stored under the reference.
+	Get(ctx context.Context, ref string) ([]byte, error)
+}
+
+// ErrBlobNotFound is returned by stores for unknown references.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// FSStore is a content-addressed Store on the local filesystem: blobs are
+// stored under the SHA-256 hex of their content, so identical payloads
+// deduplicate naturally.
+type FSStore struct {
+	dir string
+}
+
+// NewFSStore creates a filesystem store rooted at the directory, creating it
+// if needed.
+func NewFSStore(dir string) (*FSStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &FSStore{dir: dir}, nil
+}
+
+func (s *FSStore) Put(ctx context.Context, data []byte, mimeType string) (string, error) {
+	sum := sha256.Sum256(data)
+	ref := hex.EncodeToString(sum[:])
+	path := filepath.Join(s.dir, ref)
+	if _, err := os.Stat(path); err == nil {
+		return ref, nil
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	return ref, nil
+}
+
+func (s *FSStore) Get(ctx context.Context, ref string) ([]byte, error) {
+	// References are hex digests; reject anything path-like.
+	if ref != filepath.Base(ref) || strings.ContainsAny(ref, "./\\") {
+		return nil, fmt.Errorf("%w: invalid reference %q", ErrBlobNotFound, ref)
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, ref))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, ref)
+		}
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, nil
+}
+
+// Offloader moves large image and audio payloads between agent items and a
+// store.
+type Offloader struct {
+	store Store
+	// threshold is the minimum decoded payload size, in bytes, that gets
+	// offloaded.
+	threshold int
+}
+
+// DefaultThreshold is the offload threshold used when none is configured.
+const DefaultThreshold = 256 * 1024
+
+// NewOffloader creates an offloader over the store. A threshold of zero or
+// less defaults to DefaultThreshold.
+func NewOffloader(store Store, threshold int) (*Offloader, error) {
+	if store == nil {
+		return nil, errors.New("offloader requires a store")
+	}
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	return &Offloader{store: store, threshold: threshold}, nil
+}
+
+// OffloadItems replaces large inline image and audio payloads with store
+// references. The input items are not modified; the returned slice shares
+// unchanged items.
+func (o *Offloader) OffloadItems(ctx context.Context, items []llmagent.AgentItem) ([]llmagent.AgentItem, error) {
+	return o.mapItems(items, func(parts []llmsdk.Part) ([]llmsdk.Part, error) {
+		return o.offloadParts(ctx, parts)
+	})
+}
+
+// RestoreItems rehydrates parts whose payloads were offloaded, fetching the
+// referenced blobs and restoring the inline base64 data.
+func (o *Offloader) RestoreItems(ctx context.Context, items []llmagent.AgentItem) ([]llmagent.AgentItem, error) {
+	return o.mapItems(items, func(parts []llmsdk.Part) ([]llmsdk.Part, error) {
+		return o.restoreParts(ctx, parts)
+	})
+}
+
+// mapItems applies the part transform to every part slice reachable from the
+// items: message content, model response content, and tool output.
+func (o *Offloader) mapItems(items []llmagent.AgentItem, transform func([]llmsdk.Part) ([]llmsdk.Part, error)) ([]llmagent.AgentItem, error) {
+	result := make([]llmagent.AgentItem, len(items))
+	for i, item := range items {
+		switch {
+		case item.Message != nil:
+			message := *item.Message
+			if err := transformMessageContent(&message, transform); err != nil {
+				return nil, err
+			}
+			item.Message = &message
+		case item.Model != nil && item.Model.ModelResponse != nil:
+			response := *item.Model.ModelResponse
+			parts, err := transform(response.Content)
+			if err != nil {
+				return nil, err
+			}
+			response.Content = parts
+			// Copy the wrapper so provenance stamps like Provider, ModelID,
+			// and Duration survive the rewrite.
+			wrapper := *item.Model
+			wrapper.ModelResponse = &response
+			item.Model = &wrapper
+		case item.Tool != nil:
+			tool := *item.Tool
+			parts, err := transform(tool.Output)
+			if err != nil {
+				return nil, err
+			}
+			tool.Output = parts
+			item.Tool = &tool
+		}
+		result[i] = item
+	}
+	return result, nil
+}
+
+func transformMessageContent(message *llmsdk.Message, transform func([]llmsdk.Part) ([]llmsdk.Part, error)) error {
+	switch {
+	case message.UserMessage != nil:
+		userMessage := *message.UserMessage
+		parts, err := transform(userMessage.Content)
+		if err != nil {
+			return err
+		}
+		userMessage.Content = parts
+		message.UserMessage = &userMessage
+	case message.AssistantMessage != nil:
+		assistantMessage := *message.AssistantMessage
+		parts, err := transform(assistantMessage.Content)
+		if err != nil {
+			return err
+		}
+		assistantMessage.Content = parts
+		message.AssistantMessage = &assistantMessage
+	case message.ToolMessage != nil:
+		toolMessage := *message.ToolMessage
+		parts, err := transform(toolMessage.Content)
+		if err != nil {
+			return err
+		}
+		toolMessage.Content = parts
+		message.ToolMessage = &toolMessage
+	}
+	return nil
+}
+
+func (o *Offloader) offloadParts(ctx context.Context, parts []llmsdk.Part) ([]llmsdk.Part, error) {
+	result := make([]llmsdk.Part, len(parts))
+	for i, part := range parts {
+		switch {
+		case part.ImagePart != nil && !isReference(part.ImagePart.Data):
+			data, err := base64.StdEncoding.DecodeString(part.ImagePart.Data)
+			if err != nil || len(data) < o.threshold {
+				// Undecodable or small payloads stay inline.
+				break
+			}
+			ref, err := o.store.Put(ctx, data, part.ImagePart.MimeType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to offload image part: %w", err)
+			}
+			imagePart := *part.ImagePart
+			imagePart.Data = refPrefix + ref
+			part.ImagePart = &imagePart
+		case part.AudioPart != nil && !isReference(part.AudioPart.Data):
+			data, err := base64.StdEncoding.DecodeString(part.AudioPart.Data)
+			if err != nil || len(data) < o.threshold {
+				break
+			}
+			ref, err := o.store.Put(ctx, data, "audio/"+string(part.AudioPart.Format))
+			if err != nil {
+				return nil, fmt.Errorf("failed to offload audio part: %w", err)
+			}
+			audioPart := *part.AudioPart
+			audioPart.Data = refPrefix + ref
+			part.AudioPart = &audioPart
+		}
+		result[i] = part
+	}
+	return result, nil
+}
+
+func (o *Offloader) restoreParts(ctx context.Context, parts []llmsdk.Part) ([]llmsdk.Part, error) {
+	result := make([]llmsdk.Part, len(parts))
+	for i, part := range parts {
+		switch {
+		case part.ImagePart != nil && isReference(part.ImagePart.Data):
+			data, err := o.store.Get(ctx, strings.TrimPrefix(part.ImagePart.Data, refPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore image part: %w", err)
+			}
+			imagePart := *part.ImagePart
+			imagePart.Data = base64.StdEncoding.EncodeToString(data)
+			part.ImagePart = &imagePart
+		case part.AudioPart != nil && isReference(part.AudioPart.Data):
+			data, err := o.store.Get(ctx, strings.TrimPrefix(part.AudioPart.Data, refPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore audio part: %w", err)
+			}
+			audioPart := *part.AudioPart
+			audioPart.Data = base64.StdEncoding.EncodeToString(data)
+			part.AudioPart = &audioPart
+		}
+		result[i] = part
+	}
+	return result, nil
+}
+
+func isReference(data string) bool {
+	return strings.HasPrefix(data, refPrefix)
+}
@@ -0,0 +1,147 @@
+package blob_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/blob"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+func newOffloader(t *testing.T, threshold int) (*blob.Offloader, *blob.FSStore) {
+	t.Helper()
+	store, err := blob.NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	offloader, err := blob.NewOffloader(store, threshold)
+	if err != nil {
+		t.Fatalf("failed to create offloader: %v", err)
+	}
+	return offloader, store
+}
+
+func imageItem(data []byte) llmagent.AgentItem {
+	return llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(
+		llmsdk.NewTextPart("look at this"),
+		llmsdk.NewImagePart(base64.StdEncoding.EncodeToString(data), "image/png"),
+	))
+}
+
+func TestOffloadAndRestoreRoundTrip(t *testing.T) {
+	offloader, _ := newOffloader(t, 16)
+	payload := bytes.Repeat([]byte{0xAB}, 64)
+
+	items, err := offloader.OffloadItems(t.Context(), []llmagent.AgentItem{imageItem(payload)})
+	if err != nil {
+		t.Fatalf("failed to offload: %v", err)
+	}
+
+	content := items[0].Message.UserMessage.Content
+	if content[0].TextPart == nil || content[0].TextPart.Text != "look at this" {
+		t.Errorf("expected the text part untouched, got %+v", content[0])
+	}
+	if !strings.HasPrefix(content[1].ImagePart.Data, "blob:") {
+		t.Fatalf("expected an offloaded reference, got %q", content[1].ImagePart.Data)
+	}
+	if content[1].ImagePart.MimeType != "image/png" {
+		t.Errorf("expected the mime type preserved, got %q", content[1].ImagePart.MimeType)
+	}
+
+	restored, err := offloader.RestoreItems(t.Context(), items)
+	if err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+	data := restored[0].Message.UserMessage.Content[1].ImagePart.Data
+	if data != base64.StdEncoding.EncodeToString(payload) {
+		t.Errorf("restored payload does not match the original")
+	}
+}
+
+func TestOffloadPreservesModelItemProvenance(t *testing.T) {
+	offloader, _ := newOffloader(t, 16)
+	payload := bytes.Repeat([]byte{0xEF}, 64)
+
+	item := llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{
+		llmsdk.NewImagePart(base64.StdEncoding.EncodeToString(payload), "image/png"),
+	}})
+	item.Model.Provider = "mock"
+	item.Model.ModelID = "mock-model"
+	item.Model.Duration = 5 * time.Millisecond
+
+	items, err := offloader.OffloadItems(t.Context(), []llmagent.AgentItem{item})
+	if err != nil {
+		t.Fatalf("failed to offload: %v", err)
+	}
+	restored, err := offloader.RestoreItems(t.Context(), items)
+	if err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+
+	for _, got := range []*llmagent.AgentItemModelResponse{items[0].Model, restored[0].Model} {
+		if got.Provider != "mock" || got.ModelID != "mock-model" || got.Duration != 5*time.Millisecond {
+			t.Errorf("expected provenance stamps preserved, got provider=%q model_id=%q duration=%v",
+				got.Provider, got.ModelID, got.Duration)
+		}
+	}
+}
+
+func TestOffloadKeepsSmallPartsInline(t *testing.T) {
+	offloader, _ := newOffloader(t, 1024)
+	payload := []byte("tiny")
+	inline := base64.StdEncoding.EncodeToString(payload)
+
+	items, err := offloader.OffloadItems(t.Context(), []llmagent.AgentItem{imageItem(payload)})
+	if err != nil {
+		t.Fatalf("failed to offload: %v", err)
+	}
+	if got := items[0].Message.UserMessage.Content[1].ImagePart.Data; got != inline {
+		t.Errorf("expected the small payload inline, got %q", got)
+	}
+}
+
+func TestOffloadDoesNotMutateInput(t *testing.T) {
+	offloader, _ := newOffloader(t, 16)
+	payload := bytes.Repeat([]byte{0xCD}, 64)
+	original := []llmagent.AgentItem{imageItem(payload)}
+	inline := original[0].Message.UserMessage.Content[1].ImagePart.Data
+
+	if _, err := offloader.OffloadItems(t.Context(), original); err != nil {
+		t.Fatalf("failed to offload: %v", err)
+	}
+	if got := original[0].Message.UserMessage.Content[1].ImagePart.Data; got != inline {
+		t.Error("expected the input items to stay unchanged")
+	}
+}
+
+func TestFSStoreDeduplicatesAndRejectsBadRefs(t *testing.T) {
+	store, err := blob.NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	first, err := store.Put(context.Background(), []byte("same bytes"), "image/png")
+	if err != nil {
+		t.Fatalf("failed to put: %v", err)
+	}
+	second, err := store.Put(context.Background(), []byte("same bytes"), "image/png")
+	if err != nil {
+		t.Fatalf("failed to put: %v", err)
+	}
+	if first != second {
+		t.Errorf("expected identical payloads to share a reference, got %q and %q", first, second)
+	}
+
+	if _, err := store.Get(context.Background(), "../escape"); !errors.Is(err, blob.ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound for a path-like reference, got %v", err)
+	}
+	if _, err := store.Get(context.Background(), strings.Repeat("0", 64)); !errors.Is(err, blob.ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound for a missing blob, got %v", err)
+	}
+}
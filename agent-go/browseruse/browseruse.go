@@ -0,0 +1,62 @@
+// Package browseruse provides a browser automation toolkit for agents.
+//
+// The toolkit exposes navigate, click, extract, and screenshot tools so a
+// web-research agent can drive a real browser page. Tools execute through a
+// pluggable BrowserDriver; the package ships a CDPDriver that speaks the
+// Chrome DevTools Protocol to a browser started with a remote debugging port,
+// which is the same transport chromedp uses. Screenshots are returned to the
+// model as image parts so it can see the rendered page.
+package browseruse
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+)
+
+// Screenshot is a captured image of the current page.
+type Screenshot struct {
+	// Data is the raw image bytes.
+	Data []byte
+	// MimeType is the image MIME type, e.g. "image/png".
+	MimeType string
+}
+
+// base64Data returns the screenshot data in the base64 encoding expected by
+// image parts.
+func (s Screenshot) base64Data() string {
+	return base64.StdEncoding.EncodeToString(s.Data)
+}
+
+// BrowserDriver executes browser actions against a page. Implementations must
+// be safe for concurrent use if the agent executes tool calls concurrently;
+// with the default sequential tool execution they are called one action at a
+// time.
+type BrowserDriver interface {
+	// Navigate loads the URL and waits for the page to finish loading.
+	Navigate(ctx context.Context, url string) error
+	// Click clicks the first element matching the CSS selector.
+	Click(ctx context.Context, selector string) error
+	// Extract returns the visible text of the first element matching the
+	// CSS selector, or of the whole page when the selector is empty.
+	Extract(ctx context.Context, selector string) (string, error)
+	// Screenshot captures the current state of the page.
+	Screenshot(ctx context.Context) (Screenshot, error)
+	// Close releases the page and its connection.
+	Close(ctx context.Context) error
+}
+
+// ActionError reports a driver failure for a specific action. It is returned
+// to the model as a failed tool result so it can retry or adjust.
+type ActionError struct {
+	Action string
+	Err    error
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("browser action %s failed: %v", e.Action, e.Err)
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
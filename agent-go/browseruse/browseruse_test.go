@@ -0,0 +1,133 @@
+package browseruse_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/browseruse"
+)
+
+// fakeDriver records actions and returns canned results.
+type fakeDriver struct {
+	actions  []string
+	pageText string
+	closed   bool
+}
+
+func (d *fakeDriver) Navigate(_ context.Context, url string) error {
+	d.actions = append(d.actions, "navigate "+url)
+	return nil
+}
+
+func (d *fakeDriver) Click(_ context.Context, selector string) error {
+	d.actions = append(d.actions, "click "+selector)
+	if selector == "#missing" {
+		return fmt.Errorf("no element matches selector")
+	}
+	return nil
+}
+
+func (d *fakeDriver) Extract(_ context.Context, selector string) (string, error) {
+	d.actions = append(d.actions, "extract "+selector)
+	return d.pageText, nil
+}
+
+func (d *fakeDriver) Screenshot(context.Context) (browseruse.Screenshot, error) {
+	d.actions = append(d.actions, "screenshot")
+	return browseruse.Screenshot{Data: []byte{0x89, 0x50}, MimeType: "image/png"}, nil
+}
+
+func (d *fakeDriver) Close(context.Context) error {
+	d.closed = true
+	return nil
+}
+
+func newSession(t *testing.T, driver browseruse.BrowserDriver) llmagent.ToolkitSession[struct{}] {
+	t.Helper()
+	toolkit := browseruse.New[struct{}](func(context.Context) (browseruse.BrowserDriver, error) {
+		return driver, nil
+	})
+	session, err := toolkit.CreateSession(t.Context(), struct{}{})
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	return session
+}
+
+func executeTool(t *testing.T, session llmagent.ToolkitSession[struct{}], name string, args any) llmagent.AgentToolResult {
+	t.Helper()
+	params, err := json.Marshal(args)
+	if err != nil {
+		t.Fatalf("failed to marshal args: %v", err)
+	}
+	for _, tool := range session.Tools() {
+		if tool.Name() != name {
+			continue
+		}
+		result, err := tool.AsFunctionTool().Execute(t.Context(), params, struct{}{}, nil)
+		if err != nil {
+			t.Fatalf("unexpected execute error: %v", err)
+		}
+		return result
+	}
+	t.Fatalf("tool %q not found", name)
+	return llmagent.AgentToolResult{}
+}
+
+func TestToolkitDrivesBrowser(t *testing.T) {
+	driver := &fakeDriver{pageText: "Results: 42"}
+	session := newSession(t, driver)
+
+	if session.SystemPrompt() == nil {
+		t.Error("expected a system prompt")
+	}
+
+	navigate := executeTool(t, session, "browser_navigate", map[string]string{"url": "https://example.com"})
+	if navigate.IsError {
+		t.Fatalf("unexpected navigate error: %+v", navigate)
+	}
+	click := executeTool(t, session, "browser_click", map[string]string{"selector": "#search"})
+	if click.IsError {
+		t.Fatalf("unexpected click error: %+v", click)
+	}
+	extract := executeTool(t, session, "browser_extract", map[string]string{})
+	if extract.Content[0].TextPart == nil || extract.Content[0].TextPart.Text != "Results: 42" {
+		t.Errorf("unexpected extract result: %+v", extract)
+	}
+	screenshot := executeTool(t, session, "browser_screenshot", map[string]string{})
+	if screenshot.Content[0].ImagePart == nil || screenshot.Content[0].ImagePart.MimeType != "image/png" {
+		t.Errorf("expected image part, got %+v", screenshot)
+	}
+
+	want := []string{"navigate https://example.com", "click #search", "extract ", "screenshot"}
+	if len(driver.actions) != len(want) {
+		t.Fatalf("unexpected actions %v", driver.actions)
+	}
+	for i, action := range want {
+		if driver.actions[i] != action {
+			t.Errorf("expected action %q, got %q", action, driver.actions[i])
+		}
+	}
+
+	if err := session.Close(t.Context()); err != nil {
+		t.Fatalf("failed to close session: %v", err)
+	}
+	if !driver.closed {
+		t.Error("expected driver to be closed with the session")
+	}
+}
+
+func TestToolkitReturnsDriverFailuresAsErrorResults(t *testing.T) {
+	session := newSession(t, &fakeDriver{})
+
+	result := executeTool(t, session, "browser_click", map[string]string{"selector": "#missing"})
+	if !result.IsError {
+		t.Fatalf("expected error result, got %+v", result)
+	}
+	if text := result.Content[0].TextPart.Text; text != "browser action click failed: no element matches selector" {
+		t.Errorf("unexpected error message %q", text)
+	}
+}
@@ -0,0 +1,300 @@
+package browseruse
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// CDPDriver drives a browser page over the Chrome DevTools Protocol. It
+// connects to a browser started with --remote-debugging-port, opens a fresh
+// tab, and implements BrowserDriver against it.
+type CDPDriver struct {
+	ws *websocket.Conn
+
+	mu      sync.Mutex
+	nextID  int
+	pending map[int]chan cdpResponse
+	loaded  chan struct{}
+
+	readErr  error
+	readDone chan struct{}
+}
+
+type cdpMessage struct {
+	ID     int             `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params any             `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *cdpError       `json:"error,omitempty"`
+}
+
+type cdpResponse struct {
+	result json.RawMessage
+	err    error
+}
+
+type cdpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *cdpError) Error() string {
+	return fmt.Sprintf("cdp error %d: %s", e.Code, e.Message)
+}
+
+// NewCDPDriver opens a new page in the browser exposing its DevTools endpoint
+// at devtoolsURL, e.g. "http://127.0.0.1:9222".
+func NewCDPDriver(ctx context.Context, devtoolsURL string) (*CDPDriver, error) {
+	wsURL, err := newTarget(ctx, devtoolsURL)
+	if err != nil {
+		return nil, err
+	}
+	return newCDPDriver(ctx, wsURL, devtoolsURL)
+}
+
+// newTarget asks the browser to open a blank page and returns its DevTools
+// websocket URL.
+func newTarget(ctx context.Context, devtoolsURL string) (string, error) {
+	endpoint := strings.TrimSuffix(devtoolsURL, "/") + "/json/new?about:blank"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("devtools endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var target struct {
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}
+	if err := json.Unmarshal(body, &target); err != nil {
+		return "", err
+	}
+	if target.WebSocketDebuggerURL == "" {
+		return "", fmt.Errorf("devtools endpoint returned no websocket URL")
+	}
+	return target.WebSocketDebuggerURL, nil
+}
+
+func newCDPDriver(ctx context.Context, wsURL, origin string) (*CDPDriver, error) {
+	ws, err := websocket.Dial(wsURL, "", origin)
+	if err != nil {
+		return nil, err
+	}
+	d := &CDPDriver{
+		ws:       ws,
+		pending:  map[int]chan cdpResponse{},
+		readDone: make(chan struct{}),
+	}
+	go d.readLoop()
+	if _, err := d.call(ctx, "Page.enable", nil); err != nil {
+		ws.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// readLoop dispatches responses to pending calls and load events to the
+// waiting navigation, if any.
+func (d *CDPDriver) readLoop() {
+	defer close(d.readDone)
+	for {
+		var msg cdpMessage
+		if err := websocket.JSON.Receive(d.ws, &msg); err != nil {
+			d.mu.Lock()
+			d.readErr = err
+			for id, ch := range d.pending {
+				ch <- cdpResponse{err: err}
+				delete(d.pending, id)
+			}
+			d.mu.Unlock()
+			return
+		}
+		d.mu.Lock()
+		switch {
+		case msg.ID != 0:
+			if ch, ok := d.pending[msg.ID]; ok {
+				delete(d.pending, msg.ID)
+				if msg.Error != nil {
+					ch <- cdpResponse{err: msg.Error}
+				} else {
+					ch <- cdpResponse{result: msg.Result}
+				}
+			}
+		case msg.Method == "Page.loadEventFired":
+			if d.loaded != nil {
+				close(d.loaded)
+				d.loaded = nil
+			}
+		}
+		d.mu.Unlock()
+	}
+}
+
+// call sends a CDP command and waits for its response.
+func (d *CDPDriver) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	d.mu.Lock()
+	if d.readErr != nil {
+		d.mu.Unlock()
+		return nil, d.readErr
+	}
+	d.nextID++
+	id := d.nextID
+	ch := make(chan cdpResponse, 1)
+	d.pending[id] = ch
+	d.mu.Unlock()
+
+	if err := websocket.JSON.Send(d.ws, cdpMessage{ID: id, Method: method, Params: params}); err != nil {
+		d.mu.Lock()
+		delete(d.pending, id)
+		d.mu.Unlock()
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		return resp.result, resp.err
+	case <-ctx.Done():
+		d.mu.Lock()
+		delete(d.pending, id)
+		d.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// Navigate implements BrowserDriver.
+func (d *CDPDriver) Navigate(ctx context.Context, url string) error {
+	loaded := make(chan struct{})
+	d.mu.Lock()
+	d.loaded = loaded
+	d.mu.Unlock()
+
+	result, err := d.call(ctx, "Page.navigate", map[string]any{"url": url})
+	if err != nil {
+		return err
+	}
+	var nav struct {
+		ErrorText string `json:"errorText"`
+	}
+	if err := json.Unmarshal(result, &nav); err == nil && nav.ErrorText != "" {
+		return fmt.Errorf("navigation failed: %s", nav.ErrorText)
+	}
+
+	select {
+	case <-loaded:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-d.readDone:
+		return d.readErr
+	}
+}
+
+// Click implements BrowserDriver.
+func (d *CDPDriver) Click(ctx context.Context, selector string) error {
+	_, err := d.evaluate(ctx, fmt.Sprintf(`(() => {
+		const el = document.querySelector(%s);
+		if (!el) throw new Error("no element matches selector");
+		el.click();
+	})()`, jsString(selector)))
+	return err
+}
+
+// Extract implements BrowserDriver.
+func (d *CDPDriver) Extract(ctx context.Context, selector string) (string, error) {
+	target := "document.body"
+	if selector != "" {
+		target = fmt.Sprintf("document.querySelector(%s)", jsString(selector))
+	}
+	value, err := d.evaluate(ctx, fmt.Sprintf(`(() => {
+		const el = %s;
+		if (!el) throw new Error("no element matches selector");
+		return el.innerText;
+	})()`, target))
+	if err != nil {
+		return "", err
+	}
+	var text string
+	if err := json.Unmarshal(value, &text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// Screenshot implements BrowserDriver.
+func (d *CDPDriver) Screenshot(ctx context.Context) (Screenshot, error) {
+	result, err := d.call(ctx, "Page.captureScreenshot", map[string]any{"format": "png"})
+	if err != nil {
+		return Screenshot{}, err
+	}
+	var capture struct {
+		Data string `json:"data"`
+	}
+	if err := json.Unmarshal(result, &capture); err != nil {
+		return Screenshot{}, err
+	}
+	data, err := base64.StdEncoding.DecodeString(capture.Data)
+	if err != nil {
+		return Screenshot{}, err
+	}
+	return Screenshot{Data: data, MimeType: "image/png"}, nil
+}
+
+// Close implements BrowserDriver.
+func (d *CDPDriver) Close(context.Context) error {
+	return d.ws.Close()
+}
+
+// evaluate runs an expression on the page and returns its JSON value.
+func (d *CDPDriver) evaluate(ctx context.Context, expression string) (json.RawMessage, error) {
+	result, err := d.call(ctx, "Runtime.evaluate", map[string]any{
+		"expression":    expression,
+		"returnByValue": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var eval struct {
+		Result struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"result"`
+		ExceptionDetails *struct {
+			Text      string `json:"text"`
+			Exception *struct {
+				Description string `json:"description"`
+			} `json:"exception"`
+		} `json:"exceptionDetails"`
+	}
+	if err := json.Unmarshal(result, &eval); err != nil {
+		return nil, err
+	}
+	if details := eval.ExceptionDetails; details != nil {
+		if details.Exception != nil && details.Exception.Description != "" {
+			return nil, fmt.Errorf("evaluation failed: %s", details.Exception.Description)
+		}
+		return nil, fmt.Errorf("evaluation failed: %s", details.Text)
+	}
+	return eval.Result.Value, nil
+}
+
+// jsString encodes a Go string as a JavaScript string literal.
+func jsString(s string) string {
+	encoded, _ := json.Marshal(s)
+	return string(encoded)
+}
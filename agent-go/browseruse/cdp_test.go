@@ -0,0 +1,107 @@
+package browseruse
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+// fakeDevTools implements just enough of the DevTools protocol to exercise
+// the driver: a /json/new endpoint and a websocket that answers the commands
+// the driver sends.
+type fakeDevTools struct {
+	server *httptest.Server
+}
+
+func newFakeDevTools(t *testing.T) *fakeDevTools {
+	t.Helper()
+	f := &fakeDevTools{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/json/new", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"webSocketDebuggerUrl": "ws://" + f.server.Listener.Addr().String() + "/devtools/page/1",
+		})
+	})
+	mux.Handle("/devtools/page/1", websocket.Handler(f.serve))
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeDevTools) serve(ws *websocket.Conn) {
+	for {
+		var msg cdpMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			return
+		}
+		switch msg.Method {
+		case "Page.enable":
+			f.reply(ws, msg.ID, map[string]any{})
+		case "Page.navigate":
+			f.reply(ws, msg.ID, map[string]any{"frameId": "1"})
+			websocket.JSON.Send(ws, map[string]any{"method": "Page.loadEventFired", "params": map[string]any{}})
+		case "Runtime.evaluate":
+			params, _ := json.Marshal(msg.Params)
+			if strings.Contains(string(params), "#missing") {
+				f.reply(ws, msg.ID, map[string]any{
+					"result":           map[string]any{},
+					"exceptionDetails": map[string]any{"text": "Uncaught", "exception": map[string]any{"description": "Error: no element matches selector"}},
+				})
+			} else {
+				f.reply(ws, msg.ID, map[string]any{
+					"result": map[string]any{"type": "string", "value": "page text"},
+				})
+			}
+		case "Page.captureScreenshot":
+			f.reply(ws, msg.ID, map[string]any{
+				"data": base64.StdEncoding.EncodeToString([]byte{0x89, 0x50}),
+			})
+		default:
+			websocket.JSON.Send(ws, map[string]any{"id": msg.ID, "error": map[string]any{"code": -32601, "message": "unknown method"}})
+		}
+	}
+}
+
+func (f *fakeDevTools) reply(ws *websocket.Conn, id int, result map[string]any) {
+	websocket.JSON.Send(ws, map[string]any{"id": id, "result": result})
+}
+
+func TestCDPDriver(t *testing.T) {
+	devtools := newFakeDevTools(t)
+
+	driver, err := NewCDPDriver(t.Context(), devtools.server.URL)
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	defer driver.Close(t.Context())
+
+	if err := driver.Navigate(t.Context(), "https://example.com"); err != nil {
+		t.Fatalf("navigate failed: %v", err)
+	}
+	text, err := driver.Extract(t.Context(), "body")
+	if err != nil {
+		t.Fatalf("extract failed: %v", err)
+	}
+	if text != "page text" {
+		t.Errorf("unexpected extract result %q", text)
+	}
+	if err := driver.Click(t.Context(), "#missing"); err == nil || !strings.Contains(err.Error(), "no element matches selector") {
+		t.Errorf("expected evaluation error, got %v", err)
+	}
+	screenshot, err := driver.Screenshot(t.Context())
+	if err != nil {
+		t.Fatalf("screenshot failed: %v", err)
+	}
+	if screenshot.MimeType != "image/png" || len(screenshot.Data) != 2 {
+		t.Errorf("unexpected screenshot %+v", screenshot)
+	}
+}
@@ -0,0 +1,210 @@
+package browseruse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// BrowserUsePrompt instructs the agent to research through the browser tools.
+const BrowserUsePrompt = `You can browse the web through the browser tools.
+Navigate to a page first, then use browser_extract to read its content or browser_screenshot to see it rendered.
+Use CSS selectors to click elements and to extract specific parts of a page.`
+
+// Toolkit exposes the browser tools. It implements llmagent.Toolkit. Each
+// run session gets its own driver so concurrent runs drive separate pages.
+type Toolkit[C any] struct {
+	newDriver func(ctx context.Context) (BrowserDriver, error)
+}
+
+// New creates a browser toolkit. newDriver is called once per run session to
+// open a page; the driver is closed when the session ends.
+func New[C any](newDriver func(ctx context.Context) (BrowserDriver, error)) *Toolkit[C] {
+	return &Toolkit[C]{newDriver: newDriver}
+}
+
+// CreateSession implements llmagent.Toolkit.
+func (t *Toolkit[C]) CreateSession(ctx context.Context, _ C) (llmagent.ToolkitSession[C], error) {
+	driver, err := t.newDriver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &session[C]{driver: driver}, nil
+}
+
+type session[C any] struct {
+	driver BrowserDriver
+}
+
+func (s *session[C]) SystemPrompt() *string {
+	return ptr.To(BrowserUsePrompt)
+}
+
+func (s *session[C]) Tools() []llmagent.AgentTool[C] {
+	return llmagent.FunctionTools[C](
+		&navigateTool[C]{driver: s.driver},
+		&clickTool[C]{driver: s.driver},
+		&extractTool[C]{driver: s.driver},
+		&screenshotTool[C]{driver: s.driver},
+	)
+}
+
+func (s *session[C]) Close(ctx context.Context) error {
+	return s.driver.Close(ctx)
+}
+
+func errorResult(err error) llmagent.AgentToolResult {
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(err.Error())},
+		IsError: true,
+	}
+}
+
+func selectorParameters(required bool, description string) llmsdk.JSONSchema {
+	schema := llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"selector": map[string]any{
+				"type":        "string",
+				"description": description,
+			},
+		},
+		"additionalProperties": false,
+	}
+	if required {
+		schema["required"] = []string{"selector"}
+	}
+	return schema
+}
+
+type navigateTool[C any] struct {
+	driver BrowserDriver
+}
+
+func (t *navigateTool[C]) Name() string { return "browser_navigate" }
+
+func (t *navigateTool[C]) Description() string {
+	return "Navigate the browser to a URL and wait for the page to load."
+}
+
+func (t *navigateTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"url": map[string]any{
+				"type":        "string",
+				"description": "The URL to navigate to.",
+			},
+		},
+		"required":             []string{"url"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *navigateTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	if err := t.driver.Navigate(ctx, args.URL); err != nil {
+		return errorResult(&ActionError{Action: "navigate", Err: err}), nil
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(fmt.Sprintf("navigated to %s", args.URL))},
+	}, nil
+}
+
+type clickTool[C any] struct {
+	driver BrowserDriver
+}
+
+func (t *clickTool[C]) Name() string { return "browser_click" }
+
+func (t *clickTool[C]) Description() string {
+	return "Click the first element matching a CSS selector on the current page."
+}
+
+func (t *clickTool[C]) Parameters() llmsdk.JSONSchema {
+	return selectorParameters(true, "The CSS selector of the element to click.")
+}
+
+func (t *clickTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args struct {
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	if err := t.driver.Click(ctx, args.Selector); err != nil {
+		return errorResult(&ActionError{Action: "click", Err: err}), nil
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(fmt.Sprintf("clicked %q", args.Selector))},
+	}, nil
+}
+
+type extractTool[C any] struct {
+	driver BrowserDriver
+}
+
+func (t *extractTool[C]) Name() string { return "browser_extract" }
+
+func (t *extractTool[C]) Description() string {
+	return "Extract the visible text of the current page, or of the first element matching a CSS selector."
+}
+
+func (t *extractTool[C]) Parameters() llmsdk.JSONSchema {
+	return selectorParameters(false, "An optional CSS selector to extract from. Omit to extract the whole page.")
+}
+
+func (t *extractTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args struct {
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	text, err := t.driver.Extract(ctx, args.Selector)
+	if err != nil {
+		return errorResult(&ActionError{Action: "extract", Err: err}), nil
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(text)},
+	}, nil
+}
+
+type screenshotTool[C any] struct {
+	driver BrowserDriver
+}
+
+func (t *screenshotTool[C]) Name() string { return "browser_screenshot" }
+
+func (t *screenshotTool[C]) Description() string {
+	return "Take a screenshot of the current page."
+}
+
+func (t *screenshotTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type":                 "object",
+		"properties":           map[string]any{},
+		"additionalProperties": false,
+	}
+}
+
+func (t *screenshotTool[C]) Execute(ctx context.Context, _ json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	screenshot, err := t.driver.Screenshot(ctx)
+	if err != nil {
+		return errorResult(&ActionError{Action: "screenshot", Err: err}), nil
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{
+			llmsdk.NewImagePart(screenshot.base64Data(), screenshot.MimeType),
+		},
+	}, nil
+}
@@ -0,0 +1,75 @@
+package llmagent_test
+
+import (
+	"errors"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRun_StopsWhenTokenBudgetExceeded(t *testing.T) {
+	tool := NewMockTool[struct{}]("lookup", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("result")},
+	}, nil)
+
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "lookup", map[string]any{})},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 80, OutputTokens: 30},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 80, OutputTokens: 30},
+	}))
+
+	agent := llmagent.NewAgent(
+		"test_agent",
+		model,
+		llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](tool)),
+		llmagent.WithMaxTokensBudget[struct{}](100),
+	)
+
+	_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hello"))),
+		},
+	})
+
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.AgentErrorKindBudgetExceeded {
+		t.Fatalf("expected budget exceeded error, got %v", err)
+	}
+	if agentErr.Snapshot == nil || len(agentErr.Snapshot.Output) != 1 {
+		t.Fatalf("expected partial results in snapshot, got %#v", agentErr.Snapshot)
+	}
+}
+
+func TestRun_CompletesWithinTokenBudget(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 50, OutputTokens: 20},
+	}))
+
+	agent := llmagent.NewAgent[struct{}](
+		"test_agent",
+		model,
+		llmagent.WithMaxTokensBudget[struct{}](100),
+	)
+
+	response, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hello"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected run within budget to succeed, got %v", err)
+	}
+	if response.Status != llmagent.AgentResponseStatusCompleted {
+		t.Fatalf("expected completed status, got %q", response.Status)
+	}
+}
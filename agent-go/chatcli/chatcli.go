@@ -0,0 +1,250 @@
+// Package chatcli wires a run session to a line-based terminal chat. It
+// reads user messages from an input stream, runs them through RunStream, and
+// renders streaming text, reasoning, and tool events as they arrive — the
+// scaffolding every CLI example otherwise reimplements. Runs interrupted by
+// an approval error pause for a y/N prompt and resume with the recorded
+// decision.
+package chatcli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// ApprovalRequest is implemented by tool errors that pause the run for a
+// human decision, following the require-approval example pattern: the tool
+// returns such an error when it needs sign-off, the REPL prompts the user,
+// and the recorded decision lets the retried turn proceed.
+type ApprovalRequest interface {
+	error
+	// ApprovalPrompt is shown to the user before the y/N prompt.
+	ApprovalPrompt() string
+}
+
+// Params configures the REPL.
+type Params[C any] struct {
+	// Session runs the conversation and accumulates the transcript. Required.
+	Session *llmagent.RunSession[C]
+	// In is the user input stream. Defaults to os.Stdin.
+	In io.Reader
+	// Out is the rendering destination. Defaults to os.Stdout.
+	Out io.Writer
+	// Prompt is printed before each user input line. Defaults to "> ".
+	Prompt string
+	// ShowReasoning renders streaming reasoning deltas when set.
+	ShowReasoning bool
+	// HandleApproval records the decision for an ApprovalRequest so the
+	// retried turn can proceed. When nil, approval errors end the turn like
+	// any other error.
+	HandleApproval func(request ApprovalRequest, approved bool) error
+}
+
+// REPL is an interactive chat loop over a run session.
+type REPL[C any] struct {
+	params Params[C]
+	out    io.Writer
+	reader *bufio.Reader
+}
+
+// New validates the params and creates the REPL.
+func New[C any](params Params[C]) (*REPL[C], error) {
+	if params.Session == nil {
+		return nil, errors.New("chatcli requires a session")
+	}
+	if params.In == nil {
+		params.In = os.Stdin
+	}
+	if params.Out == nil {
+		params.Out = os.Stdout
+	}
+	if params.Prompt == "" {
+		params.Prompt = "> "
+	}
+	return &REPL[C]{
+		params: params,
+		out:    params.Out,
+		reader: bufio.NewReader(params.In),
+	}, nil
+}
+
+// Run reads user messages until the input stream ends, the user types
+// "exit" or "quit", or the context is cancelled. Turn failures are rendered
+// and the loop continues, so a transient model error does not end the chat.
+func (r *REPL[C]) Run(ctx context.Context) error {
+	for {
+		fmt.Fprint(r.out, r.params.Prompt)
+		line, err := r.reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				if trimmed := strings.TrimSpace(line); trimmed != "" && !isExit(trimmed) {
+					if err := r.step(ctx, trimmed); err != nil {
+						return err
+					}
+				}
+				fmt.Fprintln(r.out)
+				return nil
+			}
+			return err
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if isExit(trimmed) {
+			return nil
+		}
+		if err := r.step(ctx, trimmed); err != nil {
+			return err
+		}
+	}
+}
+
+func isExit(line string) bool {
+	return line == "exit" || line == "quit"
+}
+
+// step runs one user message, retrying after approval decisions. Errors
+// other than context cancellation are rendered and swallowed.
+func (r *REPL[C]) step(ctx context.Context, text string) error {
+	userItem := llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text)))
+
+	for {
+		err := r.runTurn(ctx, userItem)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+
+		var approval ApprovalRequest
+		if errors.As(err, &approval) && r.params.HandleApproval != nil {
+			approved, promptErr := r.promptApproval(approval)
+			if promptErr != nil {
+				return promptErr
+			}
+			if err := r.params.HandleApproval(approval, approved); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fmt.Fprintf(r.out, "[error] %v\n", err)
+		return nil
+	}
+}
+
+// runTurn streams one pass of the agent and appends the turn to the session
+// transcript on success.
+func (r *REPL[C]) runTurn(ctx context.Context, userItem llmagent.AgentItem) error {
+	input := append(r.params.Session.History(), userItem)
+
+	stream, err := r.params.Session.RunStream(ctx, llmagent.RunSessionRequest{Input: input})
+	if err != nil {
+		return err
+	}
+
+	var response *llmagent.AgentResponse
+	renderer := &eventRenderer{out: r.out, showReasoning: r.params.ShowReasoning}
+	for stream.Next() {
+		event := stream.Current()
+		renderer.render(event)
+		if event.Response != nil {
+			response = event.Response
+		}
+	}
+	renderer.finish()
+	if err := stream.Err(); err != nil {
+		return err
+	}
+	if response == nil {
+		return errors.New("agent stream completed without emitting a response")
+	}
+
+	r.params.Session.AppendHistory(slices.Concat([]llmagent.AgentItem{userItem}, response.Output)...)
+	return nil
+}
+
+func (r *REPL[C]) promptApproval(request ApprovalRequest) (bool, error) {
+	fmt.Fprintf(r.out, "\n%s (y/N) ", request.ApprovalPrompt())
+	line, err := r.reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	switch strings.TrimSpace(strings.ToLower(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// eventRenderer turns stream events into terminal output. It tracks whether
+// a streaming line is open so tool events start on their own line.
+type eventRenderer struct {
+	out           io.Writer
+	showReasoning bool
+	lineOpen      bool
+}
+
+func (r *eventRenderer) render(event *llmagent.AgentStreamEvent) {
+	switch {
+	case event.Partial != nil:
+		if event.Partial.Delta == nil {
+			return
+		}
+		part := event.Partial.Delta.Part
+		switch {
+		case part.TextPartDelta != nil:
+			fmt.Fprint(r.out, part.TextPartDelta.Text)
+			r.lineOpen = true
+		case part.ReasoningPartDelta != nil && r.showReasoning:
+			fmt.Fprint(r.out, part.ReasoningPartDelta.Text)
+			r.lineOpen = true
+		}
+	case event.ToolCallStart != nil:
+		r.breakLine()
+		fmt.Fprintf(r.out, "[tool:%s] %s\n", event.ToolCallStart.ToolName, string(event.ToolCallStart.Input))
+	case event.Item != nil && event.Item.Item.Tool != nil:
+		tool := event.Item.Item.Tool
+		r.breakLine()
+		if output := renderParts(tool.Output); output != "" {
+			fmt.Fprintf(r.out, "[tool:%s] -> %s\n", tool.ToolName, output)
+		}
+	}
+}
+
+// finish closes any open streaming line.
+func (r *eventRenderer) finish() {
+	r.breakLine()
+}
+
+func (r *eventRenderer) breakLine() {
+	if r.lineOpen {
+		fmt.Fprintln(r.out)
+		r.lineOpen = false
+	}
+}
+
+func renderParts(parts []llmsdk.Part) string {
+	var lines []string
+	for _, part := range parts {
+		if part.TextPart == nil {
+			continue
+		}
+		if trimmed := strings.TrimSpace(part.TextPart.Text); trimmed != "" {
+			lines = append(lines, trimmed)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,174 @@
+package chatcli_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/chatcli"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func textStream(text string) llmsdktest.MockStreamResult {
+	return llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta(text)}},
+	})
+}
+
+func toolCallStream(toolName string) llmsdktest.MockStreamResult {
+	return llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewToolCallPartDelta(
+			llmsdk.WithToolCallPartDeltaToolCallID("call_1"),
+			llmsdk.WithToolCallPartDeltaToolName(toolName),
+			llmsdk.WithToolCallPartDeltaArgs(`{}`),
+		)}},
+	})
+}
+
+func newREPL(t *testing.T, params *llmagent.AgentParams[*chatContext], in string, out *strings.Builder, handle func(chatcli.ApprovalRequest, bool) error) (*chatcli.REPL[*chatContext], *llmagent.RunSession[*chatContext]) {
+	t.Helper()
+	session, err := llmagent.NewRunSession(t.Context(), params, &chatContext{})
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	t.Cleanup(func() { session.Close(context.Background()) })
+
+	repl, err := chatcli.New(chatcli.Params[*chatContext]{
+		Session:        session,
+		In:             strings.NewReader(in),
+		Out:            out,
+		HandleApproval: handle,
+	})
+	if err != nil {
+		t.Fatalf("failed to create REPL: %v", err)
+	}
+	return repl, session
+}
+
+type chatContext struct {
+	approved bool
+}
+
+// approvalError pauses the run until a human decision is recorded.
+type approvalError struct{ prompt string }
+
+func (e *approvalError) Error() string          { return e.prompt }
+func (e *approvalError) ApprovalPrompt() string { return e.prompt }
+
+// gatedTool fails with an approval error until the context records approval.
+type gatedTool struct{}
+
+func (t *gatedTool) Name() string                  { return "unlock" }
+func (t *gatedTool) Description() string           { return "Unlock the vault" }
+func (t *gatedTool) Parameters() llmsdk.JSONSchema { return llmsdk.JSONSchema{"type": "object"} }
+func (t *gatedTool) Execute(_ context.Context, _ json.RawMessage, contextVal *chatContext, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	if !contextVal.approved {
+		return llmagent.AgentToolResult{}, &approvalError{prompt: "Unlock the vault?"}
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("vault unlocked")},
+	}, nil
+}
+
+func TestREPLStreamsResponses(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(textStream("Hello there!"))
+
+	out := &strings.Builder{}
+	repl, session := newREPL(t, &llmagent.AgentParams[*chatContext]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+	}, "hi\nexit\n", out, nil)
+
+	if err := repl.Run(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Hello there!") {
+		t.Errorf("expected the streamed text in the output, got %q", out.String())
+	}
+	if history := session.History(); len(history) != 2 {
+		t.Errorf("expected the turn recorded in the history, got %d items", len(history))
+	}
+}
+
+func TestREPLRendersErrorsAndContinues(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultError(context.DeadlineExceeded))
+	model.EnqueueStreamResult(textStream("Recovered."))
+
+	out := &strings.Builder{}
+	repl, _ := newREPL(t, &llmagent.AgentParams[*chatContext]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+	}, "hi\nagain\nexit\n", out, nil)
+
+	if err := repl.Run(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "[error]") {
+		t.Errorf("expected the turn failure rendered, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "Recovered.") {
+		t.Errorf("expected the next turn to run, got %q", out.String())
+	}
+}
+
+func TestREPLHandlesApproval(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	// First pass hits the gated tool, the retried pass unlocks it and a
+	// second turn produces the final answer.
+	model.EnqueueStreamResult(
+		toolCallStream("unlock"),
+		toolCallStream("unlock"),
+		textStream("The vault is open."),
+	)
+
+	out := &strings.Builder{}
+	var repl *chatcli.REPL[*chatContext]
+	var session *llmagent.RunSession[*chatContext]
+	chatCtx := &chatContext{}
+
+	params := &llmagent.AgentParams[*chatContext]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 3,
+		Tools:    llmagent.FunctionTools[*chatContext](&gatedTool{}),
+	}
+	var err error
+	session, err = llmagent.NewRunSession(t.Context(), params, chatCtx)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	t.Cleanup(func() { session.Close(context.Background()) })
+
+	repl, err = chatcli.New(chatcli.Params[*chatContext]{
+		Session: session,
+		In:      strings.NewReader("open the vault\ny\nexit\n"),
+		Out:     out,
+		HandleApproval: func(request chatcli.ApprovalRequest, approved bool) error {
+			chatCtx.approved = approved
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create REPL: %v", err)
+	}
+
+	if err := repl.Run(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Unlock the vault? (y/N)") {
+		t.Errorf("expected the approval prompt, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "The vault is open.") {
+		t.Errorf("expected the final answer after approval, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "[tool:unlock]") {
+		t.Errorf("expected the tool event rendered, got %q", out.String())
+	}
+}
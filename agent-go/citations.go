@@ -0,0 +1,95 @@
+package llmagent
+
+import (
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// AgentCitation is a citation aggregated from the run for
+// AgentResponse.Citations, so RAG UIs can render footnotes without re-parsing
+// the content parts.
+type AgentCitation struct {
+	// Source is the URL or identifier of the cited document.
+	Source string `json:"source"`
+	// Title of the cited document, when known.
+	Title *string `json:"title,omitempty"`
+	// CitedText is the text snippet from the document being cited.
+	CitedText *string `json:"cited_text,omitempty"`
+	// StartIndex and EndIndex delimit the cited span of the document content,
+	// when the provider reports one.
+	StartIndex *int `json:"start_index,omitempty"`
+	EndIndex   *int `json:"end_index,omitempty"`
+	// ContentIndex is the index of the part in AgentResponse.Content the
+	// citation supports.
+	ContentIndex int `json:"content_index"`
+}
+
+// collectCitations aggregates the citation annotations on the final content
+// parts, filling in missing titles from the source parts that flowed through
+// the run — for example RAG documents attached to the input or returned by
+// retrieval tools.
+func collectCitations(items []AgentItem, content []llmsdk.Part) []AgentCitation {
+	titles := map[string]string{}
+	for _, item := range items {
+		for _, part := range itemParts(item) {
+			recordSourceTitle(titles, part)
+		}
+	}
+	for _, part := range content {
+		recordSourceTitle(titles, part)
+	}
+
+	var citations []AgentCitation
+	for index, part := range content {
+		if part.TextPart == nil {
+			continue
+		}
+		for _, annotation := range part.TextPart.Citations {
+			citation := AgentCitation{
+				Source:       annotation.Source,
+				Title:        annotation.Title,
+				CitedText:    annotation.CitedText,
+				StartIndex:   annotation.StartIndex,
+				EndIndex:     annotation.EndIndex,
+				ContentIndex: index,
+			}
+			if citation.Title == nil {
+				if title, ok := titles[annotation.Source]; ok && title != "" {
+					citation.Title = &title
+				}
+			}
+			citations = append(citations, citation)
+		}
+	}
+	return citations
+}
+
+// recordSourceTitle remembers the title of a source part under its source,
+// keeping the first title seen for a document.
+func recordSourceTitle(titles map[string]string, part llmsdk.Part) {
+	if part.SourcePart == nil {
+		return
+	}
+	if _, ok := titles[part.SourcePart.Source]; !ok {
+		titles[part.SourcePart.Source] = part.SourcePart.Title
+	}
+}
+
+// itemParts returns the message parts carried by a run item.
+func itemParts(item AgentItem) []llmsdk.Part {
+	switch {
+	case item.Message != nil:
+		switch {
+		case item.Message.UserMessage != nil:
+			return item.Message.UserMessage.Content
+		case item.Message.AssistantMessage != nil:
+			return item.Message.AssistantMessage.Content
+		case item.Message.ToolMessage != nil:
+			return item.Message.ToolMessage.Content
+		}
+	case item.Model != nil:
+		return item.Model.Content
+	case item.Tool != nil:
+		return item.Tool.Output
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package llmagent_test
+
+import (
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+func TestCitationsAggregatedOnResponse(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{
+			{TextPart: &llmsdk.TextPart{
+				Text: "Mars is the fourth planet from the Sun.",
+				Citations: []llmsdk.Citation{{
+					Source:     "https://example.com/mars",
+					CitedText:  ptr.To("the fourth planet"),
+					StartIndex: ptr.To(12),
+					EndIndex:   ptr.To(29),
+				}},
+			}},
+		},
+	}))
+	agent := llmagent.NewAgent[struct{}]("test_agent", model)
+
+	response, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(
+				llmsdk.NewTextPart("Which planet is Mars?"),
+				llmsdk.Part{SourcePart: &llmsdk.SourcePart{
+					Source:  "https://example.com/mars",
+					Title:   "Mars Fact Sheet",
+					Content: []llmsdk.Part{llmsdk.NewTextPart("Mars is the fourth planet from the Sun.")},
+				}},
+			)),
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if len(response.Citations) != 1 {
+		t.Fatalf("expected one citation, got %v", response.Citations)
+	}
+	citation := response.Citations[0]
+	if citation.Source != "https://example.com/mars" {
+		t.Errorf("unexpected source: %q", citation.Source)
+	}
+	if citation.Title == nil || *citation.Title != "Mars Fact Sheet" {
+		t.Errorf("expected the title resolved from the input source part, got %v", citation.Title)
+	}
+	if citation.CitedText == nil || *citation.CitedText != "the fourth planet" {
+		t.Errorf("unexpected cited text: %v", citation.CitedText)
+	}
+	if citation.StartIndex == nil || *citation.StartIndex != 12 || citation.EndIndex == nil || *citation.EndIndex != 29 {
+		t.Errorf("unexpected cited span: %v-%v", citation.StartIndex, citation.EndIndex)
+	}
+	if citation.ContentIndex != 0 {
+		t.Errorf("expected the citation to point at content part 0, got %d", citation.ContentIndex)
+	}
+}
+
+func TestCitationsOmittedWithoutAnnotations(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Mars is the fourth planet.")},
+	}))
+	agent := llmagent.NewAgent[struct{}]("test_agent", model)
+
+	response, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Which planet is Mars?"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if response.Citations != nil {
+		t.Errorf("expected no citations, got %v", response.Citations)
+	}
+}
@@ -0,0 +1,101 @@
+package llmagent_test
+
+import (
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+func TestAgentClone_AppliesOverridesWithoutMutatingOriginal(t *testing.T) {
+	baseModel := llmsdktest.NewMockLanguageModel()
+	baseModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("base reply")},
+	}))
+	variantModel := llmsdktest.NewMockLanguageModel()
+	variantModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("variant reply")},
+	}))
+
+	base := llmagent.NewAgent("assistant", baseModel,
+		llmagent.WithInstructions(llmagent.InstructionParam[any]{String: ptr.To("Be formal.")}),
+	)
+	variant := base.Clone(
+		llmagent.WithName[any]("assistant-casual"),
+		llmagent.WithModel[any](variantModel),
+		llmagent.WithInstructions(llmagent.InstructionParam[any]{String: ptr.To("Be casual.")}),
+	)
+
+	if variant.Name != "assistant-casual" {
+		t.Errorf("unexpected clone name %q", variant.Name)
+	}
+	if base.Name != "assistant" {
+		t.Errorf("expected original name to be unchanged, got %q", base.Name)
+	}
+
+	request := llmagent.AgentRequest[any]{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("hello"))),
+		},
+	}
+
+	variantResponse, err := variant.Run(t.Context(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variantResponse.Text() != "variant reply" {
+		t.Errorf("unexpected clone response %q", variantResponse.Text())
+	}
+	baseResponse, err := base.Run(t.Context(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if baseResponse.Text() != "base reply" {
+		t.Errorf("unexpected base response %q", baseResponse.Text())
+	}
+
+	baseInputs := baseModel.TrackedGenerateInputs()
+	if len(baseInputs) != 1 || baseInputs[0].SystemPrompt == nil || *baseInputs[0].SystemPrompt != "Be formal." {
+		t.Errorf("expected original instructions on the base agent, got %+v", baseInputs)
+	}
+	variantInputs := variantModel.TrackedGenerateInputs()
+	if len(variantInputs) != 1 || variantInputs[0].SystemPrompt == nil || *variantInputs[0].SystemPrompt != "Be casual." {
+		t.Errorf("expected overridden instructions on the clone, got %+v", variantInputs)
+	}
+}
+
+func TestAgentClone_CopiesToolSlice(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+
+	baseTool := NewMockTool[any]("base_tool", llmagent.AgentToolResult{}, nil)
+	base := llmagent.NewAgent("assistant", model,
+		llmagent.WithTools(llmagent.FunctionTools[any](baseTool)...),
+	)
+	extraTool := NewMockTool[any]("extra_tool", llmagent.AgentToolResult{}, nil)
+	clone := base.Clone(llmagent.WithTools(
+		append(llmagent.FunctionTools[any](baseTool), llmagent.FunctionTools[any](extraTool)...)...,
+	))
+
+	_, err := base.Run(t.Context(), llmagent.AgentRequest[any]{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("hi"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = clone
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 || len(inputs[0].Tools) != 1 {
+		t.Fatalf("expected the base agent to keep a single tool, got %+v", inputs)
+	}
+	if inputs[0].Tools[0].FunctionTool == nil || inputs[0].Tools[0].FunctionTool.Name != "base_tool" {
+		t.Errorf("unexpected base agent tool %+v", inputs[0].Tools[0])
+	}
+}
@@ -0,0 +1,85 @@
+// Package codeexec provides a sandboxed code execution tool for agents.
+//
+// The "execute_code" tool runs model-generated Python or JavaScript inside a
+// sandbox and returns stdout, stderr, and any files the code wrote to its
+// workspace as tool result parts. The sandbox is defined as an interface so
+// applications can plug in their own isolation backend; DockerSandbox runs
+// the code in a disposable container with CPU, memory, and time limits and
+// with networking disabled.
+package codeexec
+
+import (
+	"context"
+	"time"
+)
+
+// Language identifies the interpreter used to run the code.
+type Language string
+
+const (
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "javascript"
+)
+
+// Limits bounds the resources available to one execution. Zero fields fall
+// back to the defaults.
+type Limits struct {
+	// CPUs is the number of CPU cores available. Defaults to 1.
+	CPUs float64
+	// MemoryMB is the memory limit in megabytes. Defaults to 256.
+	MemoryMB int
+	// Timeout aborts the execution when exceeded. Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+const (
+	defaultCPUs     = 1
+	defaultMemoryMB = 256
+	defaultTimeout  = 30 * time.Second
+)
+
+// withDefaults fills the zero fields with the default limits.
+func (l Limits) withDefaults() Limits {
+	if l.CPUs <= 0 {
+		l.CPUs = defaultCPUs
+	}
+	if l.MemoryMB <= 0 {
+		l.MemoryMB = defaultMemoryMB
+	}
+	if l.Timeout <= 0 {
+		l.Timeout = defaultTimeout
+	}
+	return l
+}
+
+// RunRequest describes one code execution.
+type RunRequest struct {
+	Language Language
+	Code     string
+	Limits   Limits
+}
+
+// File is a file the executed code wrote to its workspace.
+type File struct {
+	// Name is the path relative to the workspace root.
+	Name string
+	// Data is the file content.
+	Data []byte
+}
+
+// RunResult is the outcome of one code execution.
+type RunResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	// Files are the files the code wrote to its workspace, excluding the
+	// source file itself.
+	Files []File
+}
+
+// Sandbox executes untrusted code in isolation. Implementations must enforce
+// the limits in the request and must not give the code access to the host
+// environment.
+type Sandbox interface {
+	Run(ctx context.Context, req RunRequest) (RunResult, error)
+}
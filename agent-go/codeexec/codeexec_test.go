@@ -0,0 +1,79 @@
+package codeexec_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/codeexec"
+)
+
+// fakeSandbox records the request and returns a canned result.
+type fakeSandbox struct {
+	lastRequest codeexec.RunRequest
+	result      codeexec.RunResult
+}
+
+func (s *fakeSandbox) Run(_ context.Context, req codeexec.RunRequest) (codeexec.RunResult, error) {
+	s.lastRequest = req
+	return s.result, nil
+}
+
+func TestCodeExecToolReturnsOutputsAsParts(t *testing.T) {
+	sandbox := &fakeSandbox{result: codeexec.RunResult{
+		Stdout: "42\n",
+		Files: []codeexec.File{
+			{Name: "result.txt", Data: []byte("the answer")},
+			{Name: "chart.png", Data: []byte{0x89, 0x50}},
+		},
+	}}
+	tool := codeexec.NewCodeExecTool[struct{}](sandbox, codeexec.Limits{MemoryMB: 512})
+
+	result, err := tool.Execute(t.Context(), []byte(`{"language":"python","code":"print(6*7)"}`), struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %+v", result)
+	}
+
+	if sandbox.lastRequest.Language != codeexec.LanguagePython {
+		t.Errorf("unexpected language %q", sandbox.lastRequest.Language)
+	}
+	if sandbox.lastRequest.Limits.MemoryMB != 512 {
+		t.Errorf("expected limits to be forwarded, got %+v", sandbox.lastRequest.Limits)
+	}
+
+	if len(result.Content) != 3 {
+		t.Fatalf("expected stdout and two file parts, got %+v", result.Content)
+	}
+	if result.Content[0].TextPart == nil || result.Content[0].TextPart.Text != "42\n" {
+		t.Errorf("expected stdout part, got %+v", result.Content[0])
+	}
+	if result.Content[1].TextPart == nil || !strings.Contains(result.Content[1].TextPart.Text, "result.txt") {
+		t.Errorf("expected text file part, got %+v", result.Content[1])
+	}
+	if result.Content[2].ImagePart == nil || result.Content[2].ImagePart.MimeType != "image/png" {
+		t.Errorf("expected image file part, got %+v", result.Content[2])
+	}
+}
+
+func TestCodeExecToolReportsFailures(t *testing.T) {
+	sandbox := &fakeSandbox{result: codeexec.RunResult{
+		Stderr:   "NameError: name 'x' is not defined\n",
+		ExitCode: 1,
+	}}
+	tool := codeexec.NewCodeExecTool[struct{}](sandbox, codeexec.Limits{})
+
+	result, err := tool.Execute(t.Context(), []byte(`{"language":"python","code":"x"}`), struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result for non-zero exit code")
+	}
+	text := result.Content[0].TextPart.Text
+	if !strings.Contains(text, "NameError") {
+		t.Errorf("expected stderr in result, got %q", text)
+	}
+}
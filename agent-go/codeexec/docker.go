@@ -0,0 +1,188 @@
+package codeexec
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// DockerSandbox runs code in a disposable Docker container with networking
+// disabled. The workspace is a host temp directory mounted at /workspace;
+// files the code writes there are collected into the result.
+type DockerSandbox struct {
+	// PythonImage is the image used for Python code. Defaults to "python:3-slim".
+	PythonImage string
+	// NodeImage is the image used for JavaScript code. Defaults to "node:lts-slim".
+	NodeImage string
+}
+
+const (
+	defaultPythonImage = "python:3-slim"
+	defaultNodeImage   = "node:lts-slim"
+)
+
+// containerName generates a unique name for the container so it can be
+// killed explicitly when the run times out.
+func containerName() (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return "codeexec-" + hex.EncodeToString(suffix), nil
+}
+
+// sourceFileName returns the name of the source file for the language.
+func sourceFileName(language Language) (string, error) {
+	switch language {
+	case LanguagePython:
+		return "main.py", nil
+	case LanguageJavaScript:
+		return "main.js", nil
+	default:
+		return "", fmt.Errorf("unsupported language %q", language)
+	}
+}
+
+func (s *DockerSandbox) command(language Language) (image string, argv []string, err error) {
+	switch language {
+	case LanguagePython:
+		image = s.PythonImage
+		if image == "" {
+			image = defaultPythonImage
+		}
+		return image, []string{"python", "main.py"}, nil
+	case LanguageJavaScript:
+		image = s.NodeImage
+		if image == "" {
+			image = defaultNodeImage
+		}
+		return image, []string{"node", "main.js"}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported language %q", language)
+	}
+}
+
+// Run implements Sandbox.
+func (s *DockerSandbox) Run(ctx context.Context, req RunRequest) (RunResult, error) {
+	limits := req.Limits.withDefaults()
+
+	image, argv, err := s.command(req.Language)
+	if err != nil {
+		return RunResult{}, err
+	}
+	sourceName, err := sourceFileName(req.Language)
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	workspace, err := os.MkdirTemp("", "codeexec-")
+	if err != nil {
+		return RunResult{}, err
+	}
+	defer os.RemoveAll(workspace)
+
+	if err := os.WriteFile(filepath.Join(workspace, sourceName), []byte(req.Code), 0o644); err != nil {
+		return RunResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+
+	name, err := containerName()
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	args := []string{
+		"run", "--rm",
+		"--name", name,
+		"--network", "none",
+		"--cpus", fmt.Sprintf("%g", limits.CPUs),
+		"--memory", fmt.Sprintf("%dm", limits.MemoryMB),
+		"--volume", workspace + ":/workspace",
+		"--workdir", "/workspace",
+		image,
+	}
+	args = append(args, argv...)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return RunResult{}, err
+	}
+	waitCh := make(chan error, 1)
+	go func() { waitCh <- cmd.Wait() }()
+
+	var runErr error
+	select {
+	case runErr = <-waitCh:
+	case <-ctx.Done():
+		// Killing the docker CLI would leave the container running against
+		// the bind-mounted workspace; kill the container itself and wait for
+		// the --rm teardown before the deferred workspace removal.
+		_ = exec.Command("docker", "kill", name).Run()
+		runErr = <-waitCh
+	}
+
+	result := RunResult{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+	if runErr != nil {
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() != nil:
+			return RunResult{}, fmt.Errorf("execution timed out after %s", limits.Timeout)
+		case errors.As(runErr, &exitErr):
+			result.ExitCode = exitErr.ExitCode()
+		default:
+			return RunResult{}, runErr
+		}
+	}
+
+	files, err := collectFiles(workspace, sourceName)
+	if err != nil {
+		return RunResult{}, err
+	}
+	result.Files = files
+
+	return result, nil
+}
+
+// collectFiles gathers the files written to the workspace, excluding the
+// source file.
+func collectFiles(workspace, sourceName string) ([]File, error) {
+	var files []File
+	err := filepath.WalkDir(workspace, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(workspace, path)
+		if err != nil {
+			return err
+		}
+		if name == sourceName {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Name: name, Data: data})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
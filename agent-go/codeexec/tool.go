@@ -0,0 +1,113 @@
+package codeexec
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// CodeExecTool exposes sandboxed code execution as the "execute_code"
+// function tool.
+type CodeExecTool[C any] struct {
+	sandbox Sandbox
+	limits  Limits
+}
+
+// NewCodeExecTool creates the execute_code tool backed by the sandbox. The
+// limits apply to every execution; zero fields use the package defaults.
+func NewCodeExecTool[C any](sandbox Sandbox, limits Limits) *CodeExecTool[C] {
+	return &CodeExecTool[C]{sandbox: sandbox, limits: limits}
+}
+
+func (t *CodeExecTool[C]) Name() string { return "execute_code" }
+
+func (t *CodeExecTool[C]) Description() string {
+	return "Execute Python or JavaScript code in a sandbox and return its stdout, stderr, and any files it writes to the working directory. The sandbox has no network access."
+}
+
+func (t *CodeExecTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"language": map[string]any{
+				"type": "string",
+				"enum": []string{string(LanguagePython), string(LanguageJavaScript)},
+			},
+			"code": map[string]any{
+				"type":        "string",
+				"description": "The source code to execute.",
+			},
+		},
+		"required":             []string{"language", "code"},
+		"additionalProperties": false,
+	}
+}
+
+type codeExecArgs struct {
+	Language Language `json:"language"`
+	Code     string   `json:"code"`
+}
+
+func (t *CodeExecTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args codeExecArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+
+	result, err := t.sandbox.Run(ctx, RunRequest{
+		Language: args.Language,
+		Code:     args.Code,
+		Limits:   t.limits,
+	})
+	if err != nil {
+		return llmagent.AgentToolResult{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(err.Error())},
+			IsError: true,
+		}, nil
+	}
+
+	var parts []llmsdk.Part
+	if result.Stdout != "" {
+		parts = append(parts, llmsdk.NewTextPart(result.Stdout))
+	}
+	if result.Stderr != "" {
+		parts = append(parts, llmsdk.NewTextPart("stderr:\n"+result.Stderr))
+	}
+	if result.ExitCode != 0 {
+		parts = append(parts, llmsdk.NewTextPart(fmt.Sprintf("exit code: %d", result.ExitCode)))
+	}
+	for _, file := range result.Files {
+		parts = append(parts, filePart(file))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, llmsdk.NewTextPart("(no output)"))
+	}
+
+	return llmagent.AgentToolResult{
+		Content: parts,
+		IsError: result.ExitCode != 0,
+	}, nil
+}
+
+// filePart converts a workspace file into a tool result part: images become
+// image parts and everything else is returned as text labeled with the file
+// name.
+func filePart(file File) llmsdk.Part {
+	mimeType := mime.TypeByExtension(filepath.Ext(file.Name))
+	if strings.HasPrefix(mimeType, "image/") {
+		return llmsdk.NewImagePart(base64.StdEncoding.EncodeToString(file.Data), mimeType)
+	}
+	return llmsdk.NewTextPart(fmt.Sprintf("file %s:\n%s", file.Name, file.Data))
+}
+
+// Tools returns the agent tools for sandboxed code execution.
+func Tools[C any](sandbox Sandbox, limits Limits) []llmagent.AgentTool[C] {
+	return llmagent.FunctionTools[C](NewCodeExecTool[C](sandbox, limits))
+}
@@ -0,0 +1,67 @@
+// Package computeruse provides a computer-use primitive for agents.
+//
+// The agent drives a screen through the single "computer" tool: it takes a
+// screenshot to observe the current state, then issues click and type actions
+// until the task is done. Each action executes through a pluggable
+// ComputerDriver, so the same loop works against a local display, a VM, or a
+// remote browser. Actions run as ordinary tool calls, so every one of them is
+// recorded as an agent item in the run output, and every action returns a
+// fresh screenshot so the model always acts on the latest state.
+package computeruse
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+)
+
+// Screenshot is a captured image of the current screen.
+type Screenshot struct {
+	// Data is the raw image bytes.
+	Data []byte
+	// MimeType is the image MIME type, e.g. "image/png".
+	MimeType string
+}
+
+// base64Data returns the screenshot data in the base64 encoding expected by
+// image parts.
+func (s Screenshot) base64Data() string {
+	return base64.StdEncoding.EncodeToString(s.Data)
+}
+
+// MouseButton identifies which mouse button to press for a click action.
+type MouseButton string
+
+const (
+	MouseButtonLeft   MouseButton = "left"
+	MouseButtonRight  MouseButton = "right"
+	MouseButtonMiddle MouseButton = "middle"
+)
+
+// ComputerDriver executes computer actions against a real or virtual screen.
+// Implementations must be safe for concurrent use if the agent executes tool
+// calls concurrently; with the default sequential tool execution they are
+// called one action at a time.
+type ComputerDriver interface {
+	// Screenshot captures the current state of the screen.
+	Screenshot(ctx context.Context) (Screenshot, error)
+	// Click presses the given mouse button at the given pixel coordinates.
+	Click(ctx context.Context, x, y int, button MouseButton) error
+	// Type enters the given text at the current focus.
+	Type(ctx context.Context, text string) error
+}
+
+// ActionError reports a driver failure for a specific action. It is returned
+// to the model as a failed tool result so it can retry or adjust.
+type ActionError struct {
+	Action string
+	Err    error
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("computer action %s failed: %v", e.Action, e.Err)
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,130 @@
+package computeruse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/computeruse"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// fakeDriver records actions and serves numbered screenshots.
+type fakeDriver struct {
+	actions []string
+	shots   int
+}
+
+func (d *fakeDriver) Screenshot(context.Context) (computeruse.Screenshot, error) {
+	d.shots++
+	return computeruse.Screenshot{
+		Data:     []byte(fmt.Sprintf("shot-%d", d.shots)),
+		MimeType: "image/png",
+	}, nil
+}
+
+func (d *fakeDriver) Click(_ context.Context, x, y int, button computeruse.MouseButton) error {
+	d.actions = append(d.actions, fmt.Sprintf("click(%d,%d,%s)", x, y, button))
+	return nil
+}
+
+func (d *fakeDriver) Type(_ context.Context, text string) error {
+	d.actions = append(d.actions, fmt.Sprintf("type(%s)", text))
+	return nil
+}
+
+func TestComputerUseLoopEmitsActionItems(t *testing.T) {
+	driver := &fakeDriver{}
+	model := llmsdktest.NewMockLanguageModel()
+
+	// screenshot -> click -> type -> done
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "computer", map[string]any{
+			"action": "screenshot",
+		})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_2", "computer", map[string]any{
+			"action":     "click",
+			"coordinate": []int{10, 20},
+		})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_3", "computer", map[string]any{
+			"action": "type",
+			"text":   "hello",
+		})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Typed the greeting.")},
+	}))
+
+	agent := llmagent.NewAgent(
+		"computer_agent",
+		model,
+		llmagent.WithInstructions(llmagent.InstructionParam[struct{}]{String: ptr.To(computeruse.ComputerUsePrompt)}),
+		llmagent.WithTools(computeruse.Tools[struct{}](driver)...),
+		llmagent.WithMaxTurns[struct{}](10),
+	)
+
+	response, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Type hello into the form"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(driver.actions) != 2 {
+		t.Fatalf("expected 2 driver actions, got %v", driver.actions)
+	}
+	if driver.actions[0] != "click(10,20,left)" || driver.actions[1] != "type(hello)" {
+		t.Errorf("unexpected driver actions: %v", driver.actions)
+	}
+	if driver.shots != 3 {
+		t.Errorf("expected a screenshot after every action, got %d", driver.shots)
+	}
+
+	// Every action is recorded as a tool item carrying the screenshot taken
+	// after it.
+	var toolItems []*llmagent.AgentItemTool
+	for _, item := range response.Output {
+		if item.Tool != nil {
+			toolItems = append(toolItems, item.Tool)
+		}
+	}
+	if len(toolItems) != 3 {
+		t.Fatalf("expected 3 action items, got %d", len(toolItems))
+	}
+	for _, toolItem := range toolItems {
+		if toolItem.ToolName != "computer" || toolItem.Status != llmsdk.ToolResultStatusCompleted {
+			t.Errorf("unexpected action item: %+v", toolItem)
+		}
+		if len(toolItem.Output) != 1 || toolItem.Output[0].ImagePart == nil {
+			t.Errorf("expected screenshot output, got %+v", toolItem.Output)
+		}
+	}
+}
+
+func TestComputerToolRejectsInvalidActions(t *testing.T) {
+	tool := computeruse.NewComputerTool[struct{}](&fakeDriver{})
+
+	for _, args := range []string{
+		`{"action":"click"}`,
+		`{"action":"type"}`,
+		`{"action":"dance"}`,
+	} {
+		result, err := tool.Execute(t.Context(), []byte(args), struct{}{}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", args, err)
+		}
+		if !result.IsError {
+			t.Errorf("expected error result for %s", args)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package computeruse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// ComputerUsePrompt instructs the agent to drive the screen through the
+// computer tool, one observed action at a time.
+const ComputerUsePrompt = `You can control a computer through the "computer" tool.
+Take a screenshot first to see the current state of the screen.
+Issue one action at a time and inspect the screenshot returned after each action before deciding the next one.
+Coordinates are pixel positions with the origin at the top-left corner of the screen.`
+
+// action names accepted by the computer tool.
+const (
+	actionScreenshot = "screenshot"
+	actionClick      = "click"
+	actionType       = "type"
+)
+
+type computerToolArgs struct {
+	Action     string `json:"action"`
+	Coordinate []int  `json:"coordinate,omitempty"`
+	Button     string `json:"button,omitempty"`
+	Text       string `json:"text,omitempty"`
+}
+
+// ComputerTool exposes the screenshot, click, and type actions of a
+// ComputerDriver as a single "computer" function tool. Every action returns a
+// fresh screenshot so the model observes the effect before acting again.
+type ComputerTool[C any] struct {
+	driver ComputerDriver
+}
+
+// NewComputerTool creates the computer tool backed by the driver.
+func NewComputerTool[C any](driver ComputerDriver) *ComputerTool[C] {
+	return &ComputerTool[C]{driver: driver}
+}
+
+func (t *ComputerTool[C]) Name() string { return "computer" }
+
+func (t *ComputerTool[C]) Description() string {
+	return "Control the computer screen: take a screenshot, click at pixel coordinates, or type text. Returns a screenshot of the screen after the action."
+}
+
+func (t *ComputerTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"action": map[string]any{
+				"type": "string",
+				"enum": []string{actionScreenshot, actionClick, actionType},
+			},
+			"coordinate": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "integer"},
+				"minItems":    2,
+				"maxItems":    2,
+				"description": "The [x, y] pixel coordinates for click actions.",
+			},
+			"button": map[string]any{
+				"type": "string",
+				"enum": []string{string(MouseButtonLeft), string(MouseButtonRight), string(MouseButtonMiddle)},
+			},
+			"text": map[string]any{
+				"type":        "string",
+				"description": "The text to enter for type actions.",
+			},
+		},
+		"required":             []string{"action"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *ComputerTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args computerToolArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+
+	switch args.Action {
+	case actionScreenshot:
+		// The screenshot after the "action" is the observation itself.
+	case actionClick:
+		if len(args.Coordinate) != 2 {
+			return errorResult(fmt.Sprintf("click requires a [x, y] coordinate, got %v", args.Coordinate)), nil
+		}
+		button := MouseButton(args.Button)
+		if button == "" {
+			button = MouseButtonLeft
+		}
+		if err := t.driver.Click(ctx, args.Coordinate[0], args.Coordinate[1], button); err != nil {
+			return errorResult((&ActionError{Action: actionClick, Err: err}).Error()), nil
+		}
+	case actionType:
+		if args.Text == "" {
+			return errorResult("type requires a non-empty text"), nil
+		}
+		if err := t.driver.Type(ctx, args.Text); err != nil {
+			return errorResult((&ActionError{Action: actionType, Err: err}).Error()), nil
+		}
+	default:
+		return errorResult(fmt.Sprintf("unknown action %q", args.Action)), nil
+	}
+
+	screenshot, err := t.driver.Screenshot(ctx)
+	if err != nil {
+		return errorResult((&ActionError{Action: actionScreenshot, Err: err}).Error()), nil
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{
+			llmsdk.NewImagePart(screenshot.base64Data(), screenshot.MimeType),
+		},
+	}, nil
+}
+
+func errorResult(message string) llmagent.AgentToolResult {
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(message)},
+		IsError: true,
+	}
+}
+
+// Tools returns the agent tools for driving the computer.
+func Tools[C any](driver ComputerDriver) []llmagent.AgentTool[C] {
+	return llmagent.FunctionTools[C](NewComputerTool[C](driver))
+}
@@ -0,0 +1,101 @@
+package llmagent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// Send runs the agent with a new user message appended to the transcript
+// owned by the session. The session records the message and the run output
+// internally, so callers converse turn by turn without threading the
+// accumulated items through every request. Sends are serialized; concurrent
+// calls wait for the previous turn to finish.
+//
+// On error, the transcript is left unchanged so the same message can be
+// retried.
+func (s *RunSession[C]) Send(ctx context.Context, parts ...llmsdk.Part) (*AgentResponse, error) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	userItem := NewAgentItemMessage(llmsdk.NewUserMessage(parts...))
+	input := append(slices.Clone(s.transcript), userItem)
+
+	response, err := s.Run(ctx, RunSessionRequest{Input: input})
+	if err != nil {
+		return nil, err
+	}
+
+	s.transcript = append(input, response.Output...)
+	return response, nil
+}
+
+// History returns a copy of the transcript accumulated by Send.
+func (s *RunSession[C]) History() []AgentItem {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return slices.Clone(s.transcript)
+}
+
+// Regenerate discards the model response item at the given transcript index
+// together with everything after it, and reruns the turn from the preceding
+// items. Pass a non-nil sampling to rerun with different sampling parameters,
+// for example a higher temperature for a more varied answer. The replacement
+// items are recorded on the transcript and returned in the response output.
+//
+// Items are addressed by transcript index since run items carry no stable
+// identifiers; use History to locate the model item to regenerate.
+func (s *RunSession[C]) Regenerate(ctx context.Context, index int, sampling *SamplingOverrides) (*AgentResponse, error) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	if index < 0 || index >= len(s.transcript) {
+		return nil, fmt.Errorf("regenerate index %d out of range for %d transcript items", index, len(s.transcript))
+	}
+	if s.transcript[index].Model == nil {
+		return nil, fmt.Errorf("transcript item %d is a %s item, not a model response", index, s.transcript[index].Type())
+	}
+
+	input := slices.Clone(s.transcript[:index])
+	response, err := s.Run(ctx, RunSessionRequest{Input: input, Sampling: sampling})
+	if err != nil {
+		return nil, err
+	}
+
+	s.transcript = append(input, response.Output...)
+	return response, nil
+}
+
+// Fork creates a new session from the first index items of the transcript,
+// so alternative continuations can be explored without touching the original
+// conversation. The fork copies the history (copy-on-write: later sends on
+// either session never affect the other), binds the same context value, and
+// initializes fresh toolkit sessions. Index len(History()) forks the whole
+// conversation.
+func (s *RunSession[C]) Fork(ctx context.Context, index int) (*RunSession[C], error) {
+	s.sendMu.Lock()
+	if index < 0 || index > len(s.transcript) {
+		length := len(s.transcript)
+		s.sendMu.Unlock()
+		return nil, fmt.Errorf("fork index %d out of range for %d transcript items", index, length)
+	}
+	history := slices.Clone(s.transcript[:index])
+	s.sendMu.Unlock()
+
+	fork, err := NewRunSession(ctx, s.params, s.contextVal)
+	if err != nil {
+		return nil, err
+	}
+	fork.transcript = history
+	return fork, nil
+}
+
+// AppendHistory seeds or restores the session transcript, for example when
+// resuming a stored conversation.
+func (s *RunSession[C]) AppendHistory(items ...AgentItem) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	s.transcript = append(s.transcript, items...)
+}
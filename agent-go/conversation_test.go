@@ -0,0 +1,95 @@
+package llmagent_test
+
+import (
+	"errors"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRunSession_SendOwnsTranscript(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Hi there")},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Doing well")},
+		}),
+	)
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+	}, struct{}{})
+
+	first, err := session.Send(t.Context(), llmsdk.NewTextPart("Hello"))
+	if err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	if first.Text() != "Hi there" {
+		t.Fatalf("unexpected first response: %q", first.Text())
+	}
+
+	second, err := session.Send(t.Context(), llmsdk.NewTextPart("How are you?"))
+	if err != nil {
+		t.Fatalf("second send: %v", err)
+	}
+	if second.Text() != "Doing well" {
+		t.Fatalf("unexpected second response: %q", second.Text())
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 2 {
+		t.Fatalf("expected two model calls, got %d", len(inputs))
+	}
+	messages := inputs[1].Messages
+	if len(messages) != 3 ||
+		messages[0].UserMessage == nil ||
+		messages[1].AssistantMessage == nil ||
+		messages[2].UserMessage == nil {
+		t.Fatalf("expected the second call to carry the transcript, got %#v", messages)
+	}
+
+	history := session.History()
+	if len(history) != 4 {
+		t.Fatalf("expected four transcript items, got %#v", history)
+	}
+}
+
+func TestRunSession_SendLeavesTranscriptUnchangedOnError(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultError(errors.New("model is down")),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Recovered")},
+		}),
+	)
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+	}, struct{}{})
+
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("Hello")); err == nil {
+		t.Fatal("expected the first send to fail")
+	}
+	if len(session.History()) != 0 {
+		t.Fatalf("expected an unchanged transcript, got %#v", session.History())
+	}
+
+	response, err := session.Send(t.Context(), llmsdk.NewTextPart("Hello"))
+	if err != nil {
+		t.Fatalf("retried send: %v", err)
+	}
+	if response.Text() != "Recovered" {
+		t.Fatalf("unexpected response: %q", response.Text())
+	}
+	if len(session.History()) != 2 {
+		t.Fatalf("expected the retried turn in the transcript, got %#v", session.History())
+	}
+}
@@ -0,0 +1,95 @@
+package llmagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// titleGenerationPrompt instructs the title model to answer with nothing but
+// the title itself.
+const titleGenerationPrompt = "You title chat conversations. Given the opening exchange, reply with a short " +
+	"title of at most %d words that captures what the conversation is about. Reply with only the " +
+	"title: no quotes, no trailing punctuation."
+
+// defaultTitleMaxWords is the title length requested when no explicit value is
+// configured.
+const defaultTitleMaxWords = 6
+
+// TitleGenerationParams configure conversation title generation. Chat UIs
+// typically point Model at a cheap model since titles do not need the agent's
+// main model.
+type TitleGenerationParams struct {
+	// Model used to produce the title. Nil uses the session model.
+	Model llmsdk.LanguageModel
+	// MaxWords bounds the requested title length. Zero uses a default of 6.
+	MaxWords int
+}
+
+// GenerateTitle produces a short title for the conversation from the first
+// exchange on the session transcript — the opening user message through the
+// first assistant response. Call it after the first Send so chat UIs can label
+// the conversation without implementing their own titling.
+func (s *RunSession[C]) GenerateTitle(ctx context.Context) (string, error) {
+	s.sendMu.Lock()
+	transcript := slices.Clone(s.transcript)
+	s.sendMu.Unlock()
+
+	exchange := firstExchange(transcript)
+	if len(exchange) == 0 {
+		return "", errors.New("generate title requires at least one exchange on the transcript")
+	}
+
+	params := s.params.TitleGeneration
+	if params == nil {
+		params = &TitleGenerationParams{}
+	}
+	model := params.Model
+	if model == nil {
+		model = s.model
+	}
+	maxWords := params.MaxWords
+	if maxWords <= 0 {
+		maxWords = defaultTitleMaxWords
+	}
+
+	response, err := model.Generate(ctx, &llmsdk.LanguageModelInput{
+		SystemPrompt: ptr.To(fmt.Sprintf(titleGenerationPrompt, maxWords)),
+		Messages: []llmsdk.Message{
+			llmsdk.NewUserMessage(llmsdk.NewTextPart(renderItemsForSummary(exchange))),
+		},
+	})
+	if err != nil {
+		return "", NewLanguageModelError(err)
+	}
+
+	title := cleanTitle(partsToText(response.Content))
+	if title == "" {
+		return "", errors.New("title model returned no text")
+	}
+	return title, nil
+}
+
+// firstExchange returns the transcript items up to and including the first
+// assistant response, so later turns do not dilute the title.
+func firstExchange(items []AgentItem) []AgentItem {
+	for i, item := range items {
+		if item.Model != nil || (item.Message != nil && item.Message.AssistantMessage != nil) {
+			return items[:i+1]
+		}
+	}
+	return items
+}
+
+// cleanTitle normalizes the model output into a single-line title, stripping
+// the quoting and trailing punctuation models add despite instructions.
+func cleanTitle(text string) string {
+	title, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	title = strings.Trim(title, "\"'“”")
+	return strings.TrimRight(strings.TrimSpace(title), ".!")
+}
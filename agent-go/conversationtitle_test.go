@@ -0,0 +1,97 @@
+package llmagent_test
+
+import (
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestGenerateTitleUsesConfiguredModel(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Sure, here is a gratin recipe.")},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Yes, swap in sweet potatoes.")},
+		}),
+	)
+	titleModel := llmsdktest.NewMockLanguageModel()
+	titleModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("\"Potato Gratin Recipe.\"\n")},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:            "test_agent",
+		Model:           model,
+		MaxTurns:        10,
+		TitleGeneration: &llmagent.TitleGenerationParams{Model: titleModel},
+	}, struct{}{})
+
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("How do I make a potato gratin?")); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("Can I use sweet potatoes?")); err != nil {
+		t.Fatalf("second send: %v", err)
+	}
+
+	title, err := session.GenerateTitle(t.Context())
+	if err != nil {
+		t.Fatalf("generate title: %v", err)
+	}
+	if title != "Potato Gratin Recipe" {
+		t.Errorf("expected the cleaned title, got %q", title)
+	}
+
+	inputs := titleModel.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected one title model call, got %d", len(inputs))
+	}
+	prompt := inputs[0].Messages[0].UserMessage.Content[0].TextPart.Text
+	if !strings.Contains(prompt, "potato gratin") {
+		t.Errorf("expected the first exchange in the title prompt, got %q", prompt)
+	}
+	if strings.Contains(prompt, "sweet potatoes") {
+		t.Errorf("expected later turns excluded from the title prompt, got %q", prompt)
+	}
+}
+
+func TestGenerateTitleFallsBackToSessionModel(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Trip Planning")},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+	}, struct{}{})
+	session.AppendHistory(
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Help me plan a trip to Japan"))),
+		llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart("Happy to help. When are you going?"))),
+	)
+
+	title, err := session.GenerateTitle(t.Context())
+	if err != nil {
+		t.Fatalf("generate title: %v", err)
+	}
+	if title != "Trip Planning" {
+		t.Errorf("unexpected title: %q", title)
+	}
+}
+
+func TestGenerateTitleRequiresTranscript(t *testing.T) {
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    llmsdktest.NewMockLanguageModel(),
+		MaxTurns: 10,
+	}, struct{}{})
+
+	if _, err := session.GenerateTitle(t.Context()); err == nil {
+		t.Fatal("expected an error for an empty transcript")
+	}
+}
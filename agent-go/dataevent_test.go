@@ -0,0 +1,133 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func newDataEmittingSession(t *testing.T, model *llmsdktest.MockLanguageModel) *llmagent.RunSession[struct{}] {
+	t.Helper()
+	tool := NewMockTool[struct{}]("search", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("found")},
+	}, func(_ context.Context, _ json.RawMessage, _ struct{}, state *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		if err := state.EmitData("search_progress", map[string]any{"scanned": 10}); err != nil {
+			return llmagent.AgentToolResult{}, err
+		}
+		if err := state.EmitData("search_progress", map[string]any{"scanned": 20}); err != nil {
+			return llmagent.AgentToolResult{}, err
+		}
+		return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("found")}}, nil
+	})
+	return mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name: "test_agent", Model: model, Tools: llmagent.FunctionTools[struct{}](tool), MaxTurns: 10,
+		},
+		struct{}{},
+	)
+}
+
+func dataEmittingResults() []llmsdktest.MockGenerateResult {
+	return []llmsdktest.MockGenerateResult{
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{
+			llmsdk.NewToolCallPart("call_1", "search", map[string]any{}),
+		}}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{
+			llmsdk.NewTextPart("done"),
+		}}),
+	}
+}
+
+func TestRunStreamPassesThroughToolDataEvents(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewToolCallPartDelta(
+			llmsdk.WithToolCallPartDeltaToolCallID("call_1"),
+			llmsdk.WithToolCallPartDeltaToolName("search"),
+			llmsdk.WithToolCallPartDeltaArgs("{}"),
+		)}},
+	}))
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("done")}},
+	}))
+	session := newDataEmittingSession(t, model)
+
+	stream, err := session.RunStream(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Search"))),
+	}})
+	if err != nil {
+		t.Fatalf("create stream: %v", err)
+	}
+
+	var dataEvents []*llmagent.AgentStreamDataEvent
+	sawResponse := false
+	for stream.Next() {
+		event := stream.Current()
+		if event.Data != nil {
+			if sawResponse {
+				t.Error("expected data events before the final response")
+			}
+			dataEvents = append(dataEvents, event.Data)
+		}
+		if event.Response != nil {
+			sawResponse = true
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	if len(dataEvents) != 2 {
+		t.Fatalf("expected 2 data events, got %d", len(dataEvents))
+	}
+	for i, event := range dataEvents {
+		if event.Name != "search_progress" {
+			t.Errorf("unexpected event name %q", event.Name)
+		}
+		var payload struct {
+			Scanned int `json:"scanned"`
+		}
+		if err := json.Unmarshal(event.Data, &payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if want := (i + 1) * 10; payload.Scanned != want {
+			t.Errorf("expected events in emission order, event %d has scanned=%d, want %d", i, payload.Scanned, want)
+		}
+	}
+}
+
+func TestRunDiscardsToolDataEvents(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(dataEmittingResults()...)
+	session := newDataEmittingSession(t, model)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Search"))),
+	}})
+	if err != nil {
+		t.Fatalf("run session: %v", err)
+	}
+	if response.Text() != "done" {
+		t.Errorf("unexpected response text %q", response.Text())
+	}
+}
+
+func TestAgentStreamDataEventJSONRoundTrip(t *testing.T) {
+	event := llmagent.NewAgentStreamEventData("chart", json.RawMessage(`{"points":[1,2]}`))
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded llmagent.AgentStreamEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.Data == nil || decoded.Data.Name != "chart" || string(decoded.Data.Data) != `{"points":[1,2]}` {
+		t.Errorf("unexpected round trip result %+v", decoded.Data)
+	}
+}
@@ -0,0 +1,161 @@
+// Package debate provides a multi-agent debate primitive.
+//
+// A Debate runs several agents on the same task in parallel, optionally lets
+// them critique each other's answers for a number of rounds, and synthesizes
+// a final answer with a judge model. Sampling multiple agents and forcing
+// them to defend their answers surfaces mistakes a single run would keep;
+// the judge sees every final position and writes the consensus answer.
+package debate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+	"golang.org/x/sync/errgroup"
+)
+
+// Proposal is one agent's final position after the critique rounds.
+type Proposal struct {
+	// Agent is the name of the agent that produced the answer.
+	Agent string `json:"agent"`
+	// Answer is the agent's final answer text.
+	Answer string `json:"answer"`
+}
+
+// Result is the outcome of a debate.
+type Result struct {
+	// Answer is the final answer synthesized by the judge.
+	Answer string `json:"answer"`
+	// Proposals are the positions of each agent after the last round, in
+	// registration order.
+	Proposals []Proposal `json:"proposals"`
+}
+
+// Debate orchestrates the participating agents and the judge.
+type Debate[C any] struct {
+	agents []*llmagent.Agent[C]
+	judge  llmsdk.LanguageModel
+	rounds int
+}
+
+// New creates a debate between the agents. rounds is the number of critique
+// rounds after the initial answers; zero means the judge synthesizes directly
+// from the initial answers. The judge model is typically stronger than the
+// participants since it writes the final answer.
+func New[C any](judge llmsdk.LanguageModel, rounds int, agents ...*llmagent.Agent[C]) (*Debate[C], error) {
+	if judge == nil {
+		return nil, errors.New("debate: judge model is required")
+	}
+	if len(agents) < 2 {
+		return nil, errors.New("debate: at least two agents are required")
+	}
+	if rounds < 0 {
+		return nil, errors.New("debate: rounds must not be negative")
+	}
+	return &Debate[C]{agents: agents, judge: judge, rounds: rounds}, nil
+}
+
+// Run executes the debate on the request and returns the synthesized answer.
+func (d *Debate[C]) Run(ctx context.Context, request llmagent.AgentRequest[C]) (*Result, error) {
+	proposals, err := d.collect(ctx, request, nil)
+	if err != nil {
+		return nil, err
+	}
+	for round := 0; round < d.rounds; round++ {
+		proposals, err = d.collect(ctx, request, proposals)
+		if err != nil {
+			return nil, fmt.Errorf("debate round %d failed: %w", round+1, err)
+		}
+	}
+
+	answer, err := d.judgeAnswer(ctx, request, proposals)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Answer: answer, Proposals: proposals}, nil
+}
+
+// collect runs every agent once, concurrently. When previous proposals are
+// given, each agent is asked to critique them and revise its own answer.
+func (d *Debate[C]) collect(ctx context.Context, request llmagent.AgentRequest[C], previous []Proposal) ([]Proposal, error) {
+	proposals := make([]Proposal, len(d.agents))
+	g, ctx := errgroup.WithContext(ctx)
+	for i, agent := range d.agents {
+		i, agent := i, agent
+		g.Go(func() error {
+			input := request.Input
+			if previous != nil {
+				input = append(append([]llmagent.AgentItem{}, input...), critiqueItem(agent.Name, previous))
+			}
+			response, err := agent.Run(ctx, llmagent.AgentRequest[C]{
+				Context:  request.Context,
+				Input:    input,
+				Sampling: request.Sampling,
+			})
+			if err != nil {
+				return fmt.Errorf("agent %q failed: %w", agent.Name, err)
+			}
+			proposals[i] = Proposal{Agent: agent.Name, Answer: response.Text()}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return proposals, nil
+}
+
+// critiqueItem renders the other participants' answers as a user message
+// asking the agent to defend or revise its position.
+func critiqueItem(agentName string, proposals []Proposal) llmagent.AgentItem {
+	var b strings.Builder
+	b.WriteString("Other participants answered the same task as follows:\n\n")
+	for _, proposal := range proposals {
+		if proposal.Agent == agentName {
+			continue
+		}
+		fmt.Fprintf(&b, "%s answered:\n%s\n\n", proposal.Agent, proposal.Answer)
+	}
+	b.WriteString("Critique these answers against your own reasoning and reply with your revised answer to the original task. Reply with the answer only.")
+	return llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(b.String())))
+}
+
+// judgeAnswer asks the judge model to synthesize the final answer from the
+// task and the final proposals.
+func (d *Debate[C]) judgeAnswer(ctx context.Context, request llmagent.AgentRequest[C], proposals []Proposal) (string, error) {
+	var b strings.Builder
+	b.WriteString("Here are the final answers from every participant:\n\n")
+	for _, proposal := range proposals {
+		fmt.Fprintf(&b, "%s answered:\n%s\n\n", proposal.Agent, proposal.Answer)
+	}
+	b.WriteString("Synthesize the single best final answer to the task above. Resolve disagreements with your own judgment and reply with the final answer only.")
+
+	messages := make([]llmsdk.Message, 0, len(request.Input)+1)
+	for _, item := range request.Input {
+		if item.Message != nil {
+			messages = append(messages, *item.Message)
+		}
+	}
+	messages = append(messages, llmsdk.NewUserMessage(llmsdk.NewTextPart(b.String())))
+
+	response, err := d.judge.Generate(ctx, &llmsdk.LanguageModelInput{
+		SystemPrompt: ptr.To("You are the judge of a debate between multiple assistants that answered the same task."),
+		Messages:     messages,
+	})
+	if err != nil {
+		return "", fmt.Errorf("debate: judge failed: %w", err)
+	}
+
+	var text strings.Builder
+	for _, part := range response.Content {
+		if part.TextPart != nil {
+			text.WriteString(part.TextPart.Text)
+		}
+	}
+	return text.String(), nil
+}
@@ -0,0 +1,142 @@
+package debate_test
+
+import (
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/debate"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func newDebater(name string, answers ...string) (*llmagent.Agent[struct{}], *llmsdktest.MockLanguageModel) {
+	model := llmsdktest.NewMockLanguageModel()
+	for _, answer := range answers {
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(answer)},
+		}))
+	}
+	return llmagent.NewAgent[struct{}](name, model), model
+}
+
+func taskRequest(text string) llmagent.AgentRequest[struct{}] {
+	return llmagent.AgentRequest[struct{}]{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text))),
+		},
+	}
+}
+
+func lastUserText(t *testing.T, input llmsdk.LanguageModelInput) string {
+	t.Helper()
+	for i := len(input.Messages) - 1; i >= 0; i-- {
+		if user := input.Messages[i].UserMessage; user != nil {
+			for _, part := range user.Content {
+				if part.TextPart != nil {
+					return part.TextPart.Text
+				}
+			}
+		}
+	}
+	t.Fatal("no user message found")
+	return ""
+}
+
+func TestDebateCritiquesAndSynthesizes(t *testing.T) {
+	alice, aliceModel := newDebater("alice", "answer is 41", "revised: 42")
+	bob, bobModel := newDebater("bob", "answer is 42", "still 42")
+
+	judge := llmsdktest.NewMockLanguageModel()
+	judge.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("final: 42")},
+	}))
+
+	d, err := debate.New(judge, 1, alice, bob)
+	if err != nil {
+		t.Fatalf("failed to create debate: %v", err)
+	}
+
+	result, err := d.Run(t.Context(), taskRequest("What is 6*7?"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Answer != "final: 42" {
+		t.Errorf("unexpected answer %q", result.Answer)
+	}
+	want := []debate.Proposal{
+		{Agent: "alice", Answer: "revised: 42"},
+		{Agent: "bob", Answer: "still 42"},
+	}
+	if len(result.Proposals) != len(want) {
+		t.Fatalf("unexpected proposals %+v", result.Proposals)
+	}
+	for i, proposal := range want {
+		if result.Proposals[i] != proposal {
+			t.Errorf("expected proposal %+v, got %+v", proposal, result.Proposals[i])
+		}
+	}
+
+	// Each agent ran twice: the initial answer and one critique round, where
+	// it saw the other agent's answer but not its own.
+	aliceInputs := aliceModel.TrackedGenerateInputs()
+	if len(aliceInputs) != 2 {
+		t.Fatalf("expected 2 alice calls, got %d", len(aliceInputs))
+	}
+	critique := lastUserText(t, aliceInputs[1])
+	if !strings.Contains(critique, "bob answered:\nanswer is 42") {
+		t.Errorf("expected bob's answer in alice's critique prompt, got %q", critique)
+	}
+	if strings.Contains(critique, "alice answered") {
+		t.Errorf("expected alice's own answer to be omitted, got %q", critique)
+	}
+	if len(bobModel.TrackedGenerateInputs()) != 2 {
+		t.Fatalf("expected 2 bob calls, got %d", len(bobModel.TrackedGenerateInputs()))
+	}
+
+	// The judge saw the task and the final proposals.
+	judgeInputs := judge.TrackedGenerateInputs()
+	if len(judgeInputs) != 1 {
+		t.Fatalf("expected 1 judge call, got %d", len(judgeInputs))
+	}
+	judgePrompt := lastUserText(t, judgeInputs[0])
+	for _, wantText := range []string{"alice answered:\nrevised: 42", "bob answered:\nstill 42"} {
+		if !strings.Contains(judgePrompt, wantText) {
+			t.Errorf("expected %q in judge prompt, got %q", wantText, judgePrompt)
+		}
+	}
+}
+
+func TestDebateWithoutCritiqueRounds(t *testing.T) {
+	alice, aliceModel := newDebater("alice", "A")
+	bob, _ := newDebater("bob", "B")
+
+	judge := llmsdktest.NewMockLanguageModel()
+	judge.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("A")},
+	}))
+
+	d, err := debate.New(judge, 0, alice, bob)
+	if err != nil {
+		t.Fatalf("failed to create debate: %v", err)
+	}
+	result, err := d.Run(t.Context(), taskRequest("pick a letter"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Answer != "A" {
+		t.Errorf("unexpected answer %q", result.Answer)
+	}
+	if len(aliceModel.TrackedGenerateInputs()) != 1 {
+		t.Errorf("expected a single call per agent with zero rounds")
+	}
+}
+
+func TestDebateRequiresTwoAgents(t *testing.T) {
+	alice, _ := newDebater("alice", "A")
+	judge := llmsdktest.NewMockLanguageModel()
+	if _, err := debate.New(judge, 0, alice); err == nil {
+		t.Error("expected error for a single-agent debate")
+	}
+}
@@ -0,0 +1,100 @@
+// Package docload turns common document formats — PDF, HTML, and DOCX —
+// into plain text with page metadata, so knowledge ingestion for the rag
+// vector stores does not require third-party glue. Loaders are deliberately
+// small and dependency-free; they handle the common shapes of each format
+// and report honest errors on the rest.
+package docload
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/rag"
+	"github.com/hoangvvo/llm-sdk/agent-go/textsplit"
+)
+
+// Page is one page of a loaded document. Formats without pagination (HTML)
+// produce a single page.
+type Page struct {
+	// Number is the 1-based page number.
+	Number int `json:"number"`
+	// Text is the extracted plain text of the page.
+	Text string `json:"text"`
+}
+
+// Document is the result of loading a file: its pages in order plus
+// format-level metadata such as the title when the format records one.
+type Document struct {
+	// Source identifies where the document came from, typically a file
+	// path. LoadFile sets it; reader-based loaders leave it empty.
+	Source string `json:"source,omitempty"`
+	// Pages holds the extracted text in page order.
+	Pages []Page `json:"pages"`
+	// Metadata carries format-level properties (e.g. "title", "creator").
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// Text returns the document text with pages joined by blank lines.
+func (d *Document) Text() string {
+	texts := make([]string, 0, len(d.Pages))
+	for _, page := range d.Pages {
+		if page.Text != "" {
+			texts = append(texts, page.Text)
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// Chunks splits each page with the splitter and returns rag documents ready
+// to embed and upsert. Every chunk carries the document metadata plus its
+// source and page number, so retrieval results can cite their origin.
+func (d *Document) Chunks(splitter textsplit.Splitter) []rag.Document {
+	source := d.Source
+	if source == "" {
+		source = "document"
+	}
+	var docs []rag.Document
+	for _, page := range d.Pages {
+		for i, chunk := range splitter.Split(page.Text) {
+			metadata := map[string]any{"source": source, "page": page.Number}
+			for key, value := range d.Metadata {
+				metadata[key] = value
+			}
+			docs = append(docs, rag.Document{
+				ID:       fmt.Sprintf("%s:%d:%d", source, page.Number, i),
+				Content:  chunk,
+				Metadata: metadata,
+			})
+		}
+	}
+	return docs
+}
+
+// LoadFile loads a document, picking the loader from the file extension.
+// Supported extensions are .pdf, .html, .htm, and .docx.
+func LoadFile(path string) (*Document, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var doc *Document
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".pdf":
+		doc, err = LoadPDF(file)
+	case ".html", ".htm":
+		doc, err = LoadHTML(file)
+	case ".docx":
+		doc, err = LoadDOCX(file)
+	default:
+		return nil, fmt.Errorf("docload: unsupported file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("docload: load %s: %w", path, err)
+	}
+	doc.Source = path
+	return doc, nil
+}
@@ -0,0 +1,189 @@
+package docload_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/docload"
+	"github.com/hoangvvo/llm-sdk/agent-go/textsplit"
+)
+
+// testPDF assembles a minimal two-page uncompressed PDF.
+func testPDF() []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	b.WriteString("1 0 obj<</Type/Catalog/Pages 2 0 R>>endobj\n")
+	b.WriteString("2 0 obj<</Type/Pages/Kids[3 0 R 5 0 R]/Count 2>>endobj\n")
+	b.WriteString("3 0 obj<</Type/Page/Parent 2 0 R/Contents 4 0 R>>endobj\n")
+	content1 := "BT /F1 12 Tf 72 720 Td (Hello first page.) Tj T* [(With) -120 ( kerned text.)] TJ ET"
+	fmt.Fprintf(&b, "4 0 obj<</Length %d>>stream\n%s\nendstream\nendobj\n", len(content1), content1)
+	b.WriteString("5 0 obj<</Type/Page/Parent 2 0 R/Contents 6 0 R>>endobj\n")
+	content2 := "BT (Second page \\(escaped\\).) Tj ET"
+	fmt.Fprintf(&b, "6 0 obj<</Length %d>>stream\n%s\nendstream\nendobj\n", len(content2), content2)
+	b.WriteString("%%EOF\n")
+	return b.Bytes()
+}
+
+func TestLoadPDF(t *testing.T) {
+	doc, err := docload.LoadPDF(bytes.NewReader(testPDF()))
+	if err != nil {
+		t.Fatalf("failed to load PDF: %v", err)
+	}
+	if len(doc.Pages) != 2 {
+		t.Fatalf("expected 2 pages, got %+v", doc.Pages)
+	}
+	if !strings.Contains(doc.Pages[0].Text, "Hello first page.") {
+		t.Errorf("unexpected first page text %q", doc.Pages[0].Text)
+	}
+	if !strings.Contains(doc.Pages[0].Text, "With kerned text.") {
+		t.Errorf("expected TJ arrays extracted, got %q", doc.Pages[0].Text)
+	}
+	if doc.Pages[1].Text != "Second page (escaped)." {
+		t.Errorf("unexpected second page text %q", doc.Pages[1].Text)
+	}
+	if doc.Pages[1].Number != 2 {
+		t.Errorf("expected page numbers assigned in order, got %d", doc.Pages[1].Number)
+	}
+}
+
+func TestLoadPDFRejectsNonPDF(t *testing.T) {
+	if _, err := docload.LoadPDF(strings.NewReader("plain text")); err == nil {
+		t.Error("expected an error for non-PDF input")
+	}
+}
+
+const testHTML = `<html><head><title>Guide</title><script>evil()</script></head><body>
+<h1>Welcome</h1>
+<p>First paragraph.</p>
+<ul><li>one</li><li>two</li></ul>
+</body></html>`
+
+func TestLoadHTML(t *testing.T) {
+	doc, err := docload.LoadHTML(strings.NewReader(testHTML))
+	if err != nil {
+		t.Fatalf("failed to load HTML: %v", err)
+	}
+	if len(doc.Pages) != 1 {
+		t.Fatalf("expected a single page, got %+v", doc.Pages)
+	}
+	text := doc.Pages[0].Text
+	if !strings.Contains(text, "Welcome") || !strings.Contains(text, "First paragraph.") {
+		t.Errorf("unexpected text %q", text)
+	}
+	if strings.Contains(text, "evil") {
+		t.Errorf("expected scripts dropped, got %q", text)
+	}
+	if title := doc.Metadata["title"]; title != "Guide" {
+		t.Errorf("expected the title recorded, got %v", title)
+	}
+}
+
+// testDOCX assembles a minimal Word archive with one explicit page break.
+func testDOCX(t *testing.T) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	document := `<?xml version="1.0"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t>First paragraph.</w:t></w:r></w:p>
+<w:p><w:r><w:t>Second paragraph.</w:t></w:r><w:r><w:br w:type="page"/></w:r></w:p>
+<w:p><w:r><w:t>On the next page.</w:t></w:r></w:p>
+</w:body>
+</w:document>`
+	core := `<?xml version="1.0"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title>Quarterly Report</dc:title>
+<dc:creator>Ana</dc:creator>
+</cp:coreProperties>`
+	for name, content := range map[string]string{
+		"word/document.xml": document,
+		"docProps/core.xml": core,
+	} {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestLoadDOCX(t *testing.T) {
+	doc, err := docload.LoadDOCX(bytes.NewReader(testDOCX(t)))
+	if err != nil {
+		t.Fatalf("failed to load DOCX: %v", err)
+	}
+	if len(doc.Pages) != 2 {
+		t.Fatalf("expected 2 pages, got %+v", doc.Pages)
+	}
+	if !strings.Contains(doc.Pages[0].Text, "First paragraph.\nSecond paragraph.") {
+		t.Errorf("unexpected first page text %q", doc.Pages[0].Text)
+	}
+	if !strings.Contains(doc.Pages[1].Text, "On the next page.") {
+		t.Errorf("unexpected second page text %q", doc.Pages[1].Text)
+	}
+	if doc.Metadata["title"] != "Quarterly Report" || doc.Metadata["creator"] != "Ana" {
+		t.Errorf("expected core properties in metadata, got %v", doc.Metadata)
+	}
+}
+
+func TestLoadFileDispatchesByExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page.html")
+	if err := os.WriteFile(path, []byte(testHTML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := docload.LoadFile(path)
+	if err != nil {
+		t.Fatalf("failed to load file: %v", err)
+	}
+	if doc.Source != path {
+		t.Errorf("expected the source recorded, got %q", doc.Source)
+	}
+
+	if _, err := docload.LoadFile(filepath.Join(dir, "notes.xyz")); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+}
+
+func TestChunksCarryPageMetadata(t *testing.T) {
+	doc, err := docload.LoadPDF(bytes.NewReader(testPDF()))
+	if err != nil {
+		t.Fatalf("failed to load PDF: %v", err)
+	}
+	doc.Source = "report.pdf"
+
+	splitter, err := textsplit.NewRecursiveSplitter(textsplit.Params{ChunkSize: 30, ChunkOverlap: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := doc.Chunks(splitter)
+	if len(chunks) < 3 {
+		t.Fatalf("expected multiple chunks, got %+v", chunks)
+	}
+	seenPages := map[any]bool{}
+	for _, chunk := range chunks {
+		if chunk.ID == "" {
+			t.Error("expected chunk IDs assigned")
+		}
+		if chunk.Metadata["source"] != "report.pdf" {
+			t.Errorf("expected source metadata, got %v", chunk.Metadata)
+		}
+		seenPages[chunk.Metadata["page"]] = true
+	}
+	if !seenPages[1] || !seenPages[2] {
+		t.Errorf("expected chunks attributed to both pages, got %v", seenPages)
+	}
+}
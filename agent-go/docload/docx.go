@@ -0,0 +1,136 @@
+package docload
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LoadDOCX extracts the text of a Word document. Paragraphs become lines;
+// explicit page breaks and the page breaks Word recorded at last render
+// start a new page. Title and creator from the core properties, when
+// present, are recorded in the metadata.
+func LoadDOCX(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a docx archive: %w", err)
+	}
+
+	body, err := readZipFile(archive, "word/document.xml")
+	if err != nil {
+		return nil, fmt.Errorf("not a docx archive: %w", err)
+	}
+	pages, err := docxPages(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse document.xml: %w", err)
+	}
+
+	doc := &Document{Pages: pages}
+	if props, err := readZipFile(archive, "docProps/core.xml"); err == nil {
+		doc.Metadata = docxMetadata(props)
+	}
+	return doc, nil
+}
+
+func readZipFile(archive *zip.Reader, name string) ([]byte, error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// docxPages walks the document XML collecting run text, breaking lines at
+// paragraph ends and pages at page breaks.
+func docxPages(body []byte) ([]Page, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	var pages []Page
+	var b strings.Builder
+	flush := func() {
+		pages = append(pages, Page{Number: len(pages) + 1, Text: strings.TrimSpace(b.String())})
+		b.Reset()
+	}
+	var inText bool
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteString("\t")
+			case "br":
+				if docxAttr(t, "type") == "page" {
+					flush()
+				} else {
+					b.WriteString("\n")
+				}
+			case "lastRenderedPageBreak":
+				flush()
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				b.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+	flush()
+	// A break at the very end leaves an empty trailing page.
+	if len(pages) > 1 && pages[len(pages)-1].Text == "" {
+		pages = pages[:len(pages)-1]
+	}
+	return pages, nil
+}
+
+func docxAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// docxMetadata pulls title and creator from docProps/core.xml.
+func docxMetadata(props []byte) map[string]any {
+	var core struct {
+		Title   string `xml:"title"`
+		Creator string `xml:"creator"`
+	}
+	if err := xml.Unmarshal(props, &core); err != nil {
+		return nil
+	}
+	metadata := map[string]any{}
+	if core.Title != "" {
+		metadata["title"] = core.Title
+	}
+	if core.Creator != "" {
+		metadata["creator"] = core.Creator
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
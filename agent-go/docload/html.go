@@ -0,0 +1,72 @@
+package docload
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// LoadHTML extracts the readable text of an HTML page as a single-page
+// document. Scripts, styles, and markup are dropped; block elements become
+// paragraph breaks. The document title, when present, is recorded in the
+// metadata.
+func LoadHTML(r io.Reader) (*Document, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	var title string
+	var render func(node *html.Node)
+	render = func(node *html.Node) {
+		switch node.Type {
+		case html.TextNode:
+			b.WriteString(strings.ReplaceAll(node.Data, "\n", " "))
+			return
+		case html.ElementNode:
+			switch node.Data {
+			case "script", "style", "noscript", "template":
+				return
+			case "title":
+				if node.FirstChild != nil && title == "" {
+					title = strings.TrimSpace(node.FirstChild.Data)
+				}
+				return
+			case "h1", "h2", "h3", "h4", "h5", "h6", "p", "div", "section",
+				"article", "main", "header", "footer", "blockquote", "table",
+				"tr", "ul", "ol", "pre":
+				b.WriteString("\n\n")
+				defer b.WriteString("\n\n")
+			case "br", "li":
+				b.WriteString("\n")
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			render(child)
+		}
+	}
+	render(root)
+
+	doc := &Document{Pages: []Page{{Number: 1, Text: tidyText(b.String())}}}
+	if title != "" {
+		doc.Metadata = map[string]any{"title": title}
+	}
+	return doc, nil
+}
+
+var (
+	spaceRuns   = regexp.MustCompile(`[ \t]+`)
+	newlineRuns = regexp.MustCompile(`\n{3,}`)
+)
+
+// tidyText collapses the whitespace runs left behind by dropped markup.
+func tidyText(s string) string {
+	lines := strings.Split(spaceRuns.ReplaceAllString(s, " "), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(newlineRuns.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,264 @@
+package docload
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LoadPDF extracts the text of a PDF, one entry per page. It reads page
+// objects and their content streams directly, decompressing FlateDecode
+// streams and interpreting the text-showing operators. Encrypted files,
+// object streams, and exotic filters are not supported; text that a PDF
+// stores with a custom font encoding may come out garbled. For those, run a
+// full PDF tool before ingestion.
+func LoadPDF(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return nil, fmt.Errorf("not a PDF file")
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return nil, fmt.Errorf("encrypted PDFs are not supported")
+	}
+
+	objects := parsePDFObjects(data)
+	var pages []Page
+	for _, object := range objects {
+		if !object.isPage() {
+			continue
+		}
+		var b strings.Builder
+		for _, ref := range object.contentRefs() {
+			content, ok := objects[ref]
+			if !ok {
+				continue
+			}
+			b.WriteString(pdfStreamText(content.decodedStream()))
+		}
+		pages = append(pages, Page{Number: len(pages) + 1, Text: strings.TrimSpace(b.String())})
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("no pages found")
+	}
+	return &Document{Pages: pages}, nil
+}
+
+// pdfObject is one indirect object: its dictionary source and, when
+// present, the raw stream bytes.
+type pdfObject struct {
+	dict   string
+	stream []byte
+}
+
+var pdfObjectPattern = regexp.MustCompile(`(?s)(\d+)\s+\d+\s+obj\b(.*?)\bendobj`)
+
+// parsePDFObjects scans the file for indirect objects by number. Scanning
+// instead of following the xref table tolerates files with incremental
+// updates or broken offsets; a later definition of the same object number
+// wins, matching how updates supersede earlier objects.
+func parsePDFObjects(data []byte) map[int]pdfObject {
+	objects := map[int]pdfObject{}
+	for _, match := range pdfObjectPattern.FindAllSubmatch(data, -1) {
+		number, err := strconv.Atoi(string(match[1]))
+		if err != nil {
+			continue
+		}
+		body := match[2]
+		object := pdfObject{dict: string(body)}
+		if start := bytes.Index(body, []byte("stream")); start >= 0 {
+			object.dict = string(body[:start])
+			raw := body[start+len("stream"):]
+			raw = bytes.TrimPrefix(raw, []byte("\r\n"))
+			raw = bytes.TrimPrefix(raw, []byte("\n"))
+			if end := bytes.LastIndex(raw, []byte("endstream")); end >= 0 {
+				object.stream = bytes.TrimRight(raw[:end], "\r\n")
+			}
+		}
+		objects[number] = object
+	}
+	return objects
+}
+
+var pdfPagePattern = regexp.MustCompile(`/Type\s*/Page\b`)
+
+func (o pdfObject) isPage() bool {
+	return pdfPagePattern.MatchString(o.dict) && !strings.Contains(o.dict, "/Pages")
+}
+
+var pdfRefPattern = regexp.MustCompile(`(\d+)\s+\d+\s+R`)
+
+// contentRefs returns the object numbers of the page's content streams, in
+// order. /Contents is either a single reference or an array of them.
+func (o pdfObject) contentRefs() []int {
+	start := strings.Index(o.dict, "/Contents")
+	if start < 0 {
+		return nil
+	}
+	value := o.dict[start+len("/Contents"):]
+	if end := strings.IndexByte(value, '/'); end >= 0 {
+		value = value[:end]
+	}
+	var refs []int
+	for _, match := range pdfRefPattern.FindAllStringSubmatch(value, -1) {
+		if number, err := strconv.Atoi(match[1]); err == nil {
+			refs = append(refs, number)
+		}
+	}
+	return refs
+}
+
+// decodedStream returns the stream bytes with FlateDecode applied when the
+// dictionary asks for it. Failures fall back to the raw bytes.
+func (o pdfObject) decodedStream() []byte {
+	if !strings.Contains(o.dict, "/FlateDecode") {
+		return o.stream
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(o.stream))
+	if err != nil {
+		return o.stream
+	}
+	defer reader.Close()
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return o.stream
+	}
+	return decoded
+}
+
+// pdfStreamText interprets the text-showing operators of a content stream:
+// Tj, ', and " show a single string, TJ shows an array of strings with
+// kerning. Text-positioning operators and ET become line breaks so
+// paragraphs keep some shape.
+func pdfStreamText(content []byte) string {
+	var b strings.Builder
+	var pending []string
+	newline := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+	}
+	emit := func(prefix string) {
+		b.WriteString(prefix + strings.Join(pending, ""))
+		pending = nil
+	}
+	for i := 0; i < len(content); i++ {
+		switch c := content[i]; {
+		case c == '(':
+			text, next := pdfLiteralString(content, i)
+			pending = append(pending, text)
+			i = next - 1
+		case c == '<' && i+1 < len(content) && content[i+1] != '<':
+			text, next := pdfHexString(content, i)
+			pending = append(pending, text)
+			i = next - 1
+		case c == '\'' || c == '"':
+			newline()
+			emit("")
+		case isPDFOperatorByte(c):
+			start := i
+			for i < len(content) && isPDFOperatorByte(content[i]) {
+				i++
+			}
+			switch operator := string(content[start:i]); operator {
+			case "Tj", "TJ":
+				emit("")
+			case "Td", "TD", "T*", "ET":
+				pending = nil
+				newline()
+			default:
+				pending = nil
+			}
+			i--
+		}
+	}
+	return b.String()
+}
+
+func isPDFOperatorByte(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '*'
+}
+
+// pdfLiteralString decodes the parenthesized string starting at i, handling
+// nested parentheses and escape sequences. It returns the text and the
+// index after the closing parenthesis.
+func pdfLiteralString(content []byte, i int) (string, int) {
+	var b strings.Builder
+	depth := 0
+	for ; i < len(content); i++ {
+		switch content[i] {
+		case '(':
+			depth++
+			if depth > 1 {
+				b.WriteByte('(')
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return b.String(), i + 1
+			}
+			b.WriteByte(')')
+		case '\\':
+			if i+1 >= len(content) {
+				return b.String(), i + 1
+			}
+			i++
+			switch c := content[i]; c {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'b', 'f':
+				// Rarely meaningful in extracted text; drop.
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				value := 0
+				for digits := 0; digits < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7'; digits++ {
+					value = value*8 + int(content[i]-'0')
+					i++
+				}
+				i--
+				b.WriteByte(byte(value))
+			default:
+				b.WriteByte(c)
+			}
+		default:
+			b.WriteByte(content[i])
+		}
+	}
+	return b.String(), i
+}
+
+// pdfHexString decodes the angle-bracketed hex string starting at i,
+// assuming single-byte character codes.
+func pdfHexString(content []byte, i int) (string, int) {
+	end := bytes.IndexByte(content[i:], '>')
+	if end < 0 {
+		return "", len(content)
+	}
+	hex := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\n' || r == '\r' || r == '\t' {
+			return -1
+		}
+		return r
+	}, string(content[i+1:i+end]))
+	if len(hex)%2 == 1 {
+		hex += "0"
+	}
+	var b strings.Builder
+	for j := 0; j+2 <= len(hex); j += 2 {
+		value, err := strconv.ParseUint(hex[j:j+2], 16, 8)
+		if err != nil {
+			break
+		}
+		b.WriteByte(byte(value))
+	}
+	return b.String(), i + end + 1
+}
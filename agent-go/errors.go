@@ -1,15 +1,43 @@
 package llmagent
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
 
 type AgentError struct {
 	Kind    ErrorKind
 	Message string
 	Err     error
+	// ToolName and ToolCallID identify the failing call for tool execution errors.
+	ToolName   string
+	ToolCallID string
+	// Guardrail names the tripped guardrail for guardrail errors.
+	Guardrail string
 	// Snapshot contains best-effort work completed before the run failed.
 	Snapshot *AgentRunSnapshot
 }
 
+// MarshalJSON serializes the error with its stable code so stream error
+// events can be matched programmatically across SDK versions.
+func (e *AgentError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code       ErrorKind `json:"code"`
+		Message    string    `json:"message"`
+		ToolName   string    `json:"tool_name,omitempty"`
+		ToolCallID string    `json:"tool_call_id,omitempty"`
+		Guardrail  string    `json:"guardrail,omitempty"`
+	}{
+		Code:       e.Kind,
+		Message:    e.Error(),
+		ToolName:   e.ToolName,
+		ToolCallID: e.ToolCallID,
+		Guardrail:  e.Guardrail,
+	})
+}
+
 func (e *AgentError) Error() string {
 	if e.Err != nil {
 		return e.Message + ": " + e.Err.Error()
@@ -35,8 +63,15 @@ const (
 	InvariantErrorKind             ErrorKind = "invariant_error"
 	ToolExecutionErrorKind         ErrorKind = "tool_execution_error"
 	AgentErrorKindMaxTurnsExceeded ErrorKind = "max_turns_exceeded"
+	AgentErrorKindBudgetExceeded   ErrorKind = "budget_exceeded"
+	AgentErrorKindToolCallsLimit   ErrorKind = "max_tool_calls_exceeded"
+	AgentErrorKindQuotaExceeded    ErrorKind = "quota_exceeded"
+	AgentErrorKindSpendLimit       ErrorKind = "spend_limit_exceeded"
+	AgentErrorKindTurnTimeout      ErrorKind = "turn_timeout"
+	StructuredOutputErrorKind      ErrorKind = "structured_output_invalid"
 	InitErrorKind                  ErrorKind = "init_error"
 	CleanupErrorKind               ErrorKind = "cleanup_error"
+	GuardrailTrippedErrorKind      ErrorKind = "guardrail_tripped"
 )
 
 func NewLanguageModelError(err error) *AgentError {
@@ -47,6 +82,17 @@ func NewLanguageModelError(err error) *AgentError {
 	}
 }
 
+// wrapModelCallError classifies a failed model call. Agent errors raised by
+// model wrappers — such as a tripped spend guard — keep their kind instead of
+// being reported as a provider failure.
+func wrapModelCallError(err error) *AgentError {
+	var agentErr *AgentError
+	if errors.As(err, &agentErr) {
+		return agentErr
+	}
+	return NewLanguageModelError(err)
+}
+
 func NewInvariantError(msg string) *AgentError {
 	return &AgentError{
 		Kind:    InvariantErrorKind,
@@ -62,6 +108,18 @@ func NewToolExecutionError(err error) *AgentError {
 	}
 }
 
+// NewToolCallExecutionError records which tool call failed alongside the
+// underlying execution error.
+func NewToolCallExecutionError(toolName, toolCallID string, err error) *AgentError {
+	return &AgentError{
+		Kind:       ToolExecutionErrorKind,
+		Message:    fmt.Sprintf("tool execution error for %s", toolName),
+		Err:        err,
+		ToolName:   toolName,
+		ToolCallID: toolCallID,
+	}
+}
+
 func NewMaxTurnsExceededError(turns int) *AgentError {
 	return &AgentError{
 		Kind:    AgentErrorKindMaxTurnsExceeded,
@@ -69,6 +127,70 @@ func NewMaxTurnsExceededError(turns int) *AgentError {
 	}
 }
 
+// NewTurnTimeoutError reports that one turn — a model call plus its tool
+// executions — exceeded the configured turn timeout. The attached snapshot
+// preserves the partial turn.
+func NewTurnTimeoutError(turn uint, timeout time.Duration) *AgentError {
+	return &AgentError{
+		Kind:    AgentErrorKindTurnTimeout,
+		Message: fmt.Sprintf("turn %d exceeded the turn timeout of %s.", turn, timeout),
+	}
+}
+
+// NewStructuredOutputError reports that the final output still failed schema
+// validation after the configured correction attempts.
+func NewStructuredOutputError(err error) *AgentError {
+	return &AgentError{
+		Kind:    StructuredOutputErrorKind,
+		Message: "structured output validation error",
+		Err:     err,
+	}
+}
+
+func NewBudgetExceededError(budget uint, used int) *AgentError {
+	return &AgentError{
+		Kind:    AgentErrorKindBudgetExceeded,
+		Message: fmt.Sprintf("the token budget (%d) has been exceeded: %d tokens used.", budget, used),
+	}
+}
+
+// NewQuotaExceededError reports that the subject's cumulative usage has
+// exhausted its configured quota for the current period.
+func NewQuotaExceededError(subject, detail string) *AgentError {
+	return &AgentError{
+		Kind:    AgentErrorKindQuotaExceeded,
+		Message: fmt.Sprintf("the usage quota for %s has been exceeded: %s.", subject, detail),
+	}
+}
+
+// NewMaxToolCallsExceededError reports that executing the pending tool calls
+// would exceed the run-wide tool call limit.
+func NewMaxToolCallsExceededError(limit uint, attempted int) *AgentError {
+	return &AgentError{
+		Kind:    AgentErrorKindToolCallsLimit,
+		Message: fmt.Sprintf("the maximum number of tool calls (%d) has been exceeded: %d attempted.", limit, attempted),
+	}
+}
+
+// NewMaxToolCallsPerToolExceededError reports that executing the pending tool
+// calls would exceed the per-tool call limit for the named tool.
+func NewMaxToolCallsPerToolExceededError(toolName string, limit uint, attempted int) *AgentError {
+	return &AgentError{
+		Kind:     AgentErrorKindToolCallsLimit,
+		Message:  fmt.Sprintf("the maximum number of calls for tool %s (%d) has been exceeded: %d attempted.", toolName, limit, attempted),
+		ToolName: toolName,
+	}
+}
+
+// NewSpendLimitError reports that the process-wide spend guard has tripped
+// for the current window.
+func NewSpendLimitError(spend, limit float64) *AgentError {
+	return &AgentError{
+		Kind:    AgentErrorKindSpendLimit,
+		Message: fmt.Sprintf("the spend limit (%.6f) for the current window has been exceeded: %.6f spent.", limit, spend),
+	}
+}
+
 func NewInitError(err error) *AgentError {
 	return &AgentError{
 		Kind:    InitErrorKind,
@@ -84,3 +206,12 @@ func NewCleanupError(err error) *AgentError {
 		Err:     err,
 	}
 }
+
+// NewGuardrailTrippedError reports that the named guardrail blocked the run.
+func NewGuardrailTrippedError(guardrail, reason string) *AgentError {
+	return &AgentError{
+		Kind:      GuardrailTrippedErrorKind,
+		Message:   fmt.Sprintf("guardrail %s tripped: %s", guardrail, reason),
+		Guardrail: guardrail,
+	}
+}
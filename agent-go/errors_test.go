@@ -0,0 +1,52 @@
+package llmagent_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+func TestAgentErrorSerializesStableCodes(t *testing.T) {
+	cause := errors.New("boom")
+	err := llmagent.NewToolCallExecutionError("get_weather", "call_1", cause)
+
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected the underlying error to be preserved")
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("marshal error: %v", marshalErr)
+	}
+	var payload map[string]string
+	if unmarshalErr := json.Unmarshal(data, &payload); unmarshalErr != nil {
+		t.Fatalf("decode error payload: %v", unmarshalErr)
+	}
+	if payload["code"] != string(llmagent.ToolExecutionErrorKind) {
+		t.Fatalf("expected tool execution code, got %q", payload["code"])
+	}
+	if payload["tool_name"] != "get_weather" || payload["tool_call_id"] != "call_1" {
+		t.Fatalf("expected tool call details, got %v", payload)
+	}
+}
+
+func TestGuardrailTrippedError(t *testing.T) {
+	err := llmagent.NewGuardrailTrippedError("no_profanity", "output contained banned words")
+	if err.Kind != llmagent.GuardrailTrippedErrorKind {
+		t.Fatalf("expected guardrail kind, got %q", err.Kind)
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("marshal error: %v", marshalErr)
+	}
+	var payload map[string]string
+	if unmarshalErr := json.Unmarshal(data, &payload); unmarshalErr != nil {
+		t.Fatalf("decode error payload: %v", unmarshalErr)
+	}
+	if payload["code"] != string(llmagent.GuardrailTrippedErrorKind) || payload["guardrail"] != "no_profanity" {
+		t.Fatalf("expected guardrail details, got %v", payload)
+	}
+}
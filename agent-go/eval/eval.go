@@ -0,0 +1,122 @@
+// Package eval runs an agent over a dataset of scenarios and scores the
+// recorded trajectories with pluggable graders.
+//
+// A Scenario seeds one agent run with input items and the expectations the
+// graders check: expected tool calls for trajectory grading and a reference
+// answer for LLM-as-judge grading. The runner executes every scenario,
+// records the trajectory (output items, final response, duration), applies
+// each grader, and collects the results in a Report that can be written as
+// JSON or HTML.
+package eval
+
+import (
+	"context"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+// Scenario is a single evaluation case.
+type Scenario[C any] struct {
+	// Name identifies the scenario in the report.
+	Name string
+	// Input seeds the agent run, such as LLM messages.
+	Input []llmagent.AgentItem
+	// Context is the context value for the run.
+	Context C
+	// ExpectedToolCalls lists the tool calls the agent is expected to make,
+	// checked by ToolCallGrader.
+	ExpectedToolCalls []ExpectedToolCall
+	// Reference is the expected final answer, used by JudgeGrader.
+	Reference string
+}
+
+// ExpectedToolCall describes one tool call a scenario expects.
+type ExpectedToolCall struct {
+	// ToolName is the name of the expected tool.
+	ToolName string
+	// Args optionally constrains the call arguments: every listed key must be
+	// present in the recorded arguments with an equal value.
+	Args map[string]any
+}
+
+// Trajectory records what the agent did during one scenario run.
+type Trajectory struct {
+	// Output contains the items generated during the run.
+	Output []llmagent.AgentItem `json:"output"`
+	// FinalText is the text of the final response content.
+	FinalText string `json:"final_text"`
+	// Response is the final agent response, nil when the run failed.
+	Response *llmagent.AgentResponse `json:"response,omitempty"`
+	// Duration is how long the run took.
+	Duration time.Duration `json:"duration"`
+}
+
+// Score is the outcome of grading one trajectory, with Value in [0, 1].
+type Score struct {
+	Value  float64 `json:"value"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+// Grader scores a trajectory against the scenario expectations.
+type Grader[C any] interface {
+	// Name identifies the grader in the report.
+	Name() string
+	// Grade scores the trajectory recorded for the scenario.
+	Grade(ctx context.Context, scenario Scenario[C], trajectory Trajectory) (Score, error)
+}
+
+// Runner executes scenarios against an agent and grades the trajectories.
+type Runner[C any] struct {
+	agent   *llmagent.Agent[C]
+	graders []Grader[C]
+}
+
+// NewRunner creates a runner for the agent with the given graders.
+func NewRunner[C any](agent *llmagent.Agent[C], graders ...Grader[C]) *Runner[C] {
+	return &Runner[C]{agent: agent, graders: graders}
+}
+
+// Run executes every scenario in order and returns the collected report.
+// A failed run is recorded in the scenario result instead of aborting the
+// evaluation; grading errors are recorded per grader as zero scores.
+func (r *Runner[C]) Run(ctx context.Context, scenarios []Scenario[C]) (*Report, error) {
+	report := &Report{
+		Agent:     r.agent.Name,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	for _, scenario := range scenarios {
+		result := ScenarioResult{Scenario: scenario.Name}
+
+		start := time.Now()
+		response, err := r.agent.Run(ctx, llmagent.AgentRequest[C]{
+			Input:   scenario.Input,
+			Context: scenario.Context,
+		})
+		trajectory := Trajectory{Duration: time.Since(start)}
+
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			trajectory.Output = response.Output
+			trajectory.FinalText = response.Text()
+			trajectory.Response = response
+		}
+		result.Trajectory = trajectory
+
+		for _, grader := range r.graders {
+			score, gradeErr := grader.Grade(ctx, scenario, trajectory)
+			graderScore := GraderScore{Grader: grader.Name(), Score: score}
+			if gradeErr != nil {
+				graderScore.Score = Score{}
+				graderScore.Error = gradeErr.Error()
+			}
+			result.Scores = append(result.Scores, graderScore)
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,154 @@
+package eval_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/eval"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+type weatherTool struct{}
+
+func (t *weatherTool) Name() string        { return "get_weather" }
+func (t *weatherTool) Description() string { return "Get the weather for a city" }
+func (t *weatherTool) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"city": map[string]any{"type": "string"},
+		},
+		"required":             []string{"city"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *weatherTool) Execute(_ context.Context, _ json.RawMessage, _ struct{}, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("sunny")},
+	}, nil
+}
+
+func TestRunnerGradesScenarios(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	// First scenario: tool call then final answer.
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "get_weather", map[string]any{"city": "Paris"})},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("It is sunny in Paris")},
+		}),
+		// Second scenario: answers directly without the expected tool call.
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("It is probably raining in London")},
+		}),
+	)
+
+	judgeModel := llmsdktest.NewMockLanguageModel()
+	judgeModel.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(`{"score": 1, "reason": "matches the reference"}`)},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(`{"score": 0, "reason": "made up an answer"}`)},
+		}),
+	)
+
+	agent := llmagent.NewAgent[struct{}]("weather-agent", model,
+		llmagent.WithTools(llmagent.NewAgentFunctionTool[struct{}](&weatherTool{})),
+	)
+
+	runner := eval.NewRunner(agent,
+		eval.NewToolCallGrader[struct{}](),
+		eval.NewJudgeGrader[struct{}](judgeModel),
+	)
+
+	report, err := runner.Run(t.Context(), []eval.Scenario[struct{}]{
+		{
+			Name:              "paris-weather",
+			Input:             []llmagent.AgentItem{llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Weather in Paris?")))},
+			ExpectedToolCalls: []eval.ExpectedToolCall{{ToolName: "get_weather", Args: map[string]any{"city": "Paris"}}},
+			Reference:         "It is sunny in Paris",
+		},
+		{
+			Name:              "london-weather",
+			Input:             []llmagent.AgentItem{llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Weather in London?")))},
+			ExpectedToolCalls: []eval.ExpectedToolCall{{ToolName: "get_weather", Args: map[string]any{"city": "London"}}},
+			Reference:         "It is raining in London",
+		},
+	})
+	if err != nil {
+		t.Fatalf("run evaluation: %v", err)
+	}
+
+	if len(report.Results) != 2 {
+		t.Fatalf("expected two results, got %#v", report.Results)
+	}
+
+	first := report.Results[0]
+	if first.Error != "" || len(first.Scores) != 2 {
+		t.Fatalf("unexpected first result: %#v", first)
+	}
+	if first.Scores[0].Grader != "tool_calls" || first.Scores[0].Score.Value != 1 {
+		t.Fatalf("expected a full tool call score, got %#v", first.Scores[0])
+	}
+	if first.Scores[1].Grader != "judge" || first.Scores[1].Score.Value != 1 {
+		t.Fatalf("expected a full judge score, got %#v", first.Scores[1])
+	}
+
+	second := report.Results[1]
+	if second.Scores[0].Score.Value != 0 ||
+		!strings.Contains(second.Scores[0].Score.Reason, "get_weather") {
+		t.Fatalf("expected a missing tool call score, got %#v", second.Scores[0])
+	}
+	if second.Scores[1].Score.Value != 0 {
+		t.Fatalf("expected a zero judge score, got %#v", second.Scores[1])
+	}
+
+	summary := report.Summary()
+	if summary["tool_calls"] != 0.5 || summary["judge"] != 0.5 {
+		t.Fatalf("unexpected summary: %#v", summary)
+	}
+}
+
+func TestReportOutputs(t *testing.T) {
+	report := &eval.Report{
+		Agent: "weather-agent",
+		Results: []eval.ScenarioResult{
+			{
+				Scenario:   "paris-weather",
+				Trajectory: eval.Trajectory{FinalText: "It is sunny in Paris"},
+				Scores: []eval.GraderScore{
+					{Grader: "tool_calls", Score: eval.Score{Value: 1}},
+				},
+			},
+		},
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := report.WriteJSON(&jsonBuf); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	var decoded eval.Report
+	if err := json.Unmarshal(jsonBuf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode json report: %v", err)
+	}
+	if decoded.Agent != "weather-agent" || len(decoded.Results) != 1 {
+		t.Fatalf("unexpected decoded report: %#v", decoded)
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := report.WriteHTML(&htmlBuf); err != nil {
+		t.Fatalf("write html: %v", err)
+	}
+	html := htmlBuf.String()
+	if !strings.Contains(html, "paris-weather") || !strings.Contains(html, "100%") {
+		t.Fatalf("unexpected html report: %s", html)
+	}
+}
@@ -0,0 +1,155 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// ToolCallGrader scores how many of the scenario's expected tool calls were
+// made during the run. The score is the fraction of expected calls matched by
+// a recorded tool item with the same name and matching arguments.
+type ToolCallGrader[C any] struct{}
+
+// NewToolCallGrader creates a tool-call correctness grader.
+func NewToolCallGrader[C any]() *ToolCallGrader[C] {
+	return &ToolCallGrader[C]{}
+}
+
+func (g *ToolCallGrader[C]) Name() string { return "tool_calls" }
+
+func (g *ToolCallGrader[C]) Grade(ctx context.Context, scenario Scenario[C], trajectory Trajectory) (Score, error) {
+	if len(scenario.ExpectedToolCalls) == 0 {
+		return Score{Value: 1, Reason: "no expected tool calls"}, nil
+	}
+
+	var recorded []*llmagent.AgentItemTool
+	for _, item := range trajectory.Output {
+		if item.Tool != nil {
+			recorded = append(recorded, item.Tool)
+		}
+	}
+
+	matched := 0
+	var missing []string
+	used := make([]bool, len(recorded))
+	for _, expected := range scenario.ExpectedToolCalls {
+		found := false
+		for i, call := range recorded {
+			if used[i] || call.ToolName != expected.ToolName {
+				continue
+			}
+			if !argsMatch(expected.Args, call.Input) {
+				continue
+			}
+			used[i] = true
+			found = true
+			break
+		}
+		if found {
+			matched++
+		} else {
+			missing = append(missing, expected.ToolName)
+		}
+	}
+
+	score := Score{Value: float64(matched) / float64(len(scenario.ExpectedToolCalls))}
+	if len(missing) > 0 {
+		score.Reason = "missing tool calls: " + strings.Join(missing, ", ")
+	}
+	return score, nil
+}
+
+// argsMatch reports whether every expected key is present in the recorded
+// call arguments with an equal value. Nil expectations match any arguments.
+func argsMatch(expected map[string]any, input json.RawMessage) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	var args map[string]any
+	if err := json.Unmarshal(input, &args); err != nil {
+		return false
+	}
+	for key, want := range expected {
+		got, ok := args[key]
+		if !ok || !reflect.DeepEqual(normalizeJSON(want), got) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeJSON round-trips a value through JSON so expectations written with
+// Go types (e.g. int) compare equal to decoded arguments (float64).
+func normalizeJSON(value any) any {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized any
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+// JudgeGrader scores the final answer with a language model. The judge
+// receives the scenario reference and the agent's final text and must answer
+// with a JSON object {"score": 0..1, "reason": "..."}.
+type JudgeGrader[C any] struct {
+	model llmsdk.LanguageModel
+}
+
+// NewJudgeGrader creates an LLM-as-judge grader backed by the model.
+func NewJudgeGrader[C any](model llmsdk.LanguageModel) *JudgeGrader[C] {
+	return &JudgeGrader[C]{model: model}
+}
+
+func (g *JudgeGrader[C]) Name() string { return "judge" }
+
+const judgeSystemPrompt = `You grade an AI agent's final answer against a reference answer.
+Score 1 when the answer is correct and complete with respect to the reference, 0 when it is wrong, and values in between for partial credit.
+Respond with only a JSON object: {"score": <number between 0 and 1>, "reason": "<one sentence>"}.`
+
+func (g *JudgeGrader[C]) Grade(ctx context.Context, scenario Scenario[C], trajectory Trajectory) (Score, error) {
+	prompt := fmt.Sprintf("Reference answer:\n%s\n\nAgent answer:\n%s", scenario.Reference, trajectory.FinalText)
+	response, err := g.model.Generate(ctx, &llmsdk.LanguageModelInput{
+		SystemPrompt: ptr.To(judgeSystemPrompt),
+		Messages:     []llmsdk.Message{llmsdk.NewUserMessage(llmsdk.NewTextPart(prompt))},
+	})
+	if err != nil {
+		return Score{}, fmt.Errorf("judge model: %w", err)
+	}
+
+	var text strings.Builder
+	for _, part := range response.Content {
+		if part.TextPart != nil {
+			text.WriteString(part.TextPart.Text)
+		}
+	}
+
+	var verdict struct {
+		Score  float64 `json:"score"`
+		Reason string  `json:"reason"`
+	}
+	raw := text.String()
+	// Tolerate judges that wrap the JSON object in prose or code fences.
+	if start := strings.Index(raw, "{"); start >= 0 {
+		if end := strings.LastIndex(raw, "}"); end > start {
+			raw = raw[start : end+1]
+		}
+	}
+	if err := json.Unmarshal([]byte(raw), &verdict); err != nil {
+		return Score{}, fmt.Errorf("decode judge verdict %q: %w", text.String(), err)
+	}
+	if verdict.Score < 0 || verdict.Score > 1 {
+		return Score{}, fmt.Errorf("judge score %v out of range", verdict.Score)
+	}
+	return Score{Value: verdict.Score, Reason: verdict.Reason}, nil
+}
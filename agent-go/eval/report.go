@@ -0,0 +1,111 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+// Report collects the results of one evaluation run.
+type Report struct {
+	// Agent is the name of the evaluated agent.
+	Agent string `json:"agent"`
+	// CreatedAt is when the evaluation ran.
+	CreatedAt time.Time `json:"created_at"`
+	// Results holds one entry per scenario in dataset order.
+	Results []ScenarioResult `json:"results"`
+}
+
+// ScenarioResult is the recorded trajectory and scores for one scenario.
+type ScenarioResult struct {
+	// Scenario is the scenario name.
+	Scenario string `json:"scenario"`
+	// Error is set when the agent run failed.
+	Error string `json:"error,omitempty"`
+	// Trajectory records what the agent did.
+	Trajectory Trajectory `json:"trajectory"`
+	// Scores holds one entry per grader.
+	Scores []GraderScore `json:"scores"`
+}
+
+// GraderScore is the score one grader assigned to one scenario.
+type GraderScore struct {
+	// Grader is the grader name.
+	Grader string `json:"grader"`
+	// Score is the assigned score; zero when the grader failed.
+	Score Score `json:"score"`
+	// Error is set when grading failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Summary returns the average score per grader across all scenarios.
+func (r *Report) Summary() map[string]float64 {
+	totals := map[string]float64{}
+	counts := map[string]int{}
+	for _, result := range r.Results {
+		for _, score := range result.Scores {
+			totals[score.Grader] += score.Score.Value
+			counts[score.Grader]++
+		}
+	}
+	summary := make(map[string]float64, len(totals))
+	for grader, total := range totals {
+		summary[grader] = total / float64(counts[grader])
+	}
+	return summary
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(value float64) string {
+		return fmt.Sprintf("%.0f%%", value*100)
+	},
+}).Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Evaluation report: {{.Report.Agent}}</title>
+<style>
+body { font-family: sans-serif; margin: 2rem; }
+table { border-collapse: collapse; margin-bottom: 2rem; }
+th, td { border: 1px solid #ccc; padding: 0.4rem 0.8rem; text-align: left; }
+.error { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Evaluation report: {{.Report.Agent}}</h1>
+<p>Created at {{.Report.CreatedAt.Format "2006-01-02 15:04:05 MST"}}</p>
+<h2>Summary</h2>
+<table>
+<tr><th>Grader</th><th>Average score</th></tr>
+{{range $grader, $avg := .Summary}}<tr><td>{{$grader}}</td><td>{{percent $avg}}</td></tr>
+{{end}}</table>
+<h2>Scenarios</h2>
+<table>
+<tr><th>Scenario</th><th>Final answer</th><th>Scores</th><th>Error</th></tr>
+{{range .Report.Results}}<tr>
+<td>{{.Scenario}}</td>
+<td>{{.Trajectory.FinalText}}</td>
+<td>{{range .Scores}}{{.Grader}}: {{percent .Score.Value}}{{with .Score.Reason}} ({{.}}){{end}}{{with .Error}} <span class="error">{{.}}</span>{{end}}<br>{{end}}</td>
+<td class="error">{{.Error}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a standalone HTML page.
+func (r *Report) WriteHTML(w io.Writer) error {
+	return reportTemplate.Execute(w, struct {
+		Report  *Report
+		Summary map[string]float64
+	}{Report: r, Summary: r.Summary()})
+}
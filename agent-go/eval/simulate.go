@@ -0,0 +1,164 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// GoalAchievedMarker is the reply the simulated user gives when its goal has
+// been achieved, ending the conversation.
+const GoalAchievedMarker = "[DONE]"
+
+// SimulationParams configures a simulated conversation between an agent and
+// a model playing the user.
+type SimulationParams[C any] struct {
+	// Agent is the agent under test.
+	Agent *llmagent.Agent[C]
+	// Context is the context value for the agent runs.
+	Context C
+	// UserModel is the model that plays the user.
+	UserModel llmsdk.LanguageModel
+	// Persona describes who the simulated user is.
+	Persona string
+	// Goal is what the simulated user tries to achieve. The user model is
+	// instructed to reply with GoalAchievedMarker once it is met.
+	Goal string
+	// OpeningMessage optionally seeds the first user message instead of
+	// generating it with the user model.
+	OpeningMessage string
+	// MaxTurns bounds the number of user-agent exchanges. Zero defaults to 10.
+	MaxTurns int
+	// StopWhen optionally ends the conversation after an agent turn, for
+	// example when the agent completed a handoff or said goodbye.
+	StopWhen func(turn ConversationTurn) bool
+}
+
+// ConversationTurn is one user-agent exchange in a simulation.
+type ConversationTurn struct {
+	// UserText is the message the simulated user sent.
+	UserText string `json:"user_text"`
+	// AgentText is the agent's final text for the turn.
+	AgentText string `json:"agent_text"`
+	// Output contains the agent items generated during the turn, including
+	// tool calls made along the way.
+	Output []llmagent.AgentItem `json:"output"`
+}
+
+// SimulationResult records a finished simulation.
+type SimulationResult struct {
+	// Turns holds the exchanges in order.
+	Turns []ConversationTurn `json:"turns"`
+	// GoalAchieved reports whether the simulated user declared its goal met.
+	GoalAchieved bool `json:"goal_achieved"`
+	// Stopped reports whether StopWhen ended the conversation.
+	Stopped bool `json:"stopped"`
+}
+
+// Simulate converses the agent with a model playing the user until the goal
+// is achieved, StopWhen fires, or MaxTurns is reached. It returns the
+// recorded turns so tests can assert on the trajectory and termination.
+func Simulate[C any](ctx context.Context, params SimulationParams[C]) (*SimulationResult, error) {
+	if params.Agent == nil {
+		return nil, errors.New("simulation requires an agent")
+	}
+	if params.UserModel == nil {
+		return nil, errors.New("simulation requires a user model")
+	}
+	maxTurns := params.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = 10
+	}
+
+	result := &SimulationResult{}
+	var items []llmagent.AgentItem
+
+	for turn := 0; turn < maxTurns; turn++ {
+		userText, err := nextUserMessage(ctx, params, result.Turns)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(userText, GoalAchievedMarker) {
+			result.GoalAchieved = true
+			return result, nil
+		}
+
+		items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(userText))))
+		response, err := params.Agent.Run(ctx, llmagent.AgentRequest[C]{
+			Input:   items,
+			Context: params.Context,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("agent turn %d: %w", turn+1, err)
+		}
+		items = append(items, response.Output...)
+
+		conversationTurn := ConversationTurn{
+			UserText:  userText,
+			AgentText: response.Text(),
+			Output:    response.Output,
+		}
+		result.Turns = append(result.Turns, conversationTurn)
+
+		if params.StopWhen != nil && params.StopWhen(conversationTurn) {
+			result.Stopped = true
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+// nextUserMessage produces the next user message, either from the configured
+// opening message or by asking the user model to continue the conversation.
+func nextUserMessage[C any](ctx context.Context, params SimulationParams[C], turns []ConversationTurn) (string, error) {
+	if len(turns) == 0 && params.OpeningMessage != "" {
+		return params.OpeningMessage, nil
+	}
+
+	var prompt strings.Builder
+	prompt.WriteString("You are role-playing the user in a conversation with an AI assistant.\n")
+	if params.Persona != "" {
+		fmt.Fprintf(&prompt, "Persona: %s\n", params.Persona)
+	}
+	if params.Goal != "" {
+		fmt.Fprintf(&prompt, "Goal: %s\n", params.Goal)
+	}
+	fmt.Fprintf(&prompt, "Stay in character and write only the user's next message.\nWhen your goal has been achieved, reply with exactly %s instead.", GoalAchievedMarker)
+
+	var transcript strings.Builder
+	if len(turns) == 0 {
+		transcript.WriteString("The conversation has not started yet. Write the opening message.")
+	} else {
+		transcript.WriteString("Conversation so far:\n")
+		for _, turn := range turns {
+			fmt.Fprintf(&transcript, "User: %s\nAssistant: %s\n", turn.UserText, turn.AgentText)
+		}
+		transcript.WriteString("Write the user's next message.")
+	}
+
+	response, err := params.UserModel.Generate(ctx, &llmsdk.LanguageModelInput{
+		SystemPrompt: ptr.To(prompt.String()),
+		Messages:     []llmsdk.Message{llmsdk.NewUserMessage(llmsdk.NewTextPart(transcript.String()))},
+	})
+	if err != nil {
+		return "", fmt.Errorf("user model: %w", err)
+	}
+
+	var text strings.Builder
+	for _, part := range response.Content {
+		if part.TextPart != nil {
+			text.WriteString(part.TextPart.Text)
+		}
+	}
+	userText := strings.TrimSpace(text.String())
+	if userText == "" {
+		return "", errors.New("user model returned no text")
+	}
+	return userText, nil
+}
@@ -0,0 +1,101 @@
+package eval_test
+
+import (
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/eval"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestSimulateConversesUntilGoalAchieved(t *testing.T) {
+	agentModel := llmsdktest.NewMockLanguageModel()
+	agentModel.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("What color is the umbrella?")},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Found it! A red umbrella was turned in yesterday.")},
+		}),
+	)
+
+	userModel := llmsdktest.NewMockLanguageModel()
+	userModel.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("It is red.")},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("[DONE]")},
+		}),
+	)
+
+	agent := llmagent.NewAgent[struct{}]("lost-and-found", agentModel)
+
+	result, err := eval.Simulate(t.Context(), eval.SimulationParams[struct{}]{
+		Agent:          agent,
+		UserModel:      userModel,
+		Persona:        "A traveler who lost an umbrella at the station",
+		Goal:           "Find out whether the umbrella was turned in",
+		OpeningMessage: "Hi, I lost my umbrella yesterday.",
+		MaxTurns:       5,
+	})
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+
+	if !result.GoalAchieved || result.Stopped {
+		t.Fatalf("expected the goal to be achieved, got %#v", result)
+	}
+	if len(result.Turns) != 2 {
+		t.Fatalf("expected two turns, got %#v", result.Turns)
+	}
+	if result.Turns[0].UserText != "Hi, I lost my umbrella yesterday." ||
+		result.Turns[0].AgentText != "What color is the umbrella?" {
+		t.Fatalf("unexpected first turn: %#v", result.Turns[0])
+	}
+	if result.Turns[1].UserText != "It is red." ||
+		!strings.Contains(result.Turns[1].AgentText, "Found it!") {
+		t.Fatalf("unexpected second turn: %#v", result.Turns[1])
+	}
+
+	// The agent's second run must carry the full conversation so far.
+	inputs := agentModel.TrackedGenerateInputs()
+	if len(inputs) != 2 || len(inputs[1].Messages) != 3 {
+		t.Fatalf("expected the transcript to accumulate, got %#v", inputs)
+	}
+}
+
+func TestSimulateStopWhen(t *testing.T) {
+	agentModel := llmsdktest.NewMockLanguageModel()
+	agentModel.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Goodbye!")},
+		}),
+	)
+
+	userModel := llmsdktest.NewMockLanguageModel()
+	userModel.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("Thanks, that is all.")},
+		}),
+	)
+
+	agent := llmagent.NewAgent[struct{}]("assistant", agentModel)
+
+	result, err := eval.Simulate(t.Context(), eval.SimulationParams[struct{}]{
+		Agent:     agent,
+		UserModel: userModel,
+		MaxTurns:  5,
+		StopWhen: func(turn eval.ConversationTurn) bool {
+			return strings.Contains(turn.AgentText, "Goodbye")
+		},
+	})
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if !result.Stopped || result.GoalAchieved || len(result.Turns) != 1 {
+		t.Fatalf("expected StopWhen to end the conversation, got %#v", result)
+	}
+}
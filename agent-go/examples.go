@@ -0,0 +1,29 @@
+package llmagent
+
+import (
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// Example is a curated user/assistant exchange used as few-shot guidance.
+// Examples are injected as messages ahead of the live conversation on every
+// model call, but are managed separately from the real history: they never
+// appear in run outputs and history policies do not truncate them.
+type Example struct {
+	// User is the example user message.
+	User string
+	// Assistant is the example assistant reply.
+	Assistant string
+}
+
+// exampleMessages renders the examples as alternating user/assistant
+// messages to prepend to the turn messages.
+func exampleMessages(examples []Example) []llmsdk.Message {
+	messages := make([]llmsdk.Message, 0, len(examples)*2)
+	for _, example := range examples {
+		messages = append(messages,
+			llmsdk.NewUserMessage(llmsdk.NewTextPart(example.User)),
+			llmsdk.NewAssistantMessage(llmsdk.NewTextPart(example.Assistant)),
+		)
+	}
+	return messages
+}
@@ -17,77 +17,6 @@ import (
 	"github.com/sanity-io/litter"
 )
 
-type DelegateParams struct {
-	Task string `json:"task"`
-}
-
-// Implement the agent delegation pattern, where a main agent delegates tasks
-// to sub-agents. The main agent uses the results from the sub-agents'
-// execution to make informed decisions and coordinate overall behavior.
-type AgentTransferTool[C any] struct {
-	agent       *llmagent.Agent[C]
-	description string
-}
-
-func NewAgentTransferTool[C any](agent *llmagent.Agent[C], description string) *AgentTransferTool[C] {
-	return &AgentTransferTool[C]{
-		agent:       agent,
-		description: description,
-	}
-}
-
-func (t *AgentTransferTool[C]) Name() string {
-	return fmt.Sprintf("transfer_to_%s", t.agent.Name)
-}
-
-func (t *AgentTransferTool[C]) Description() string {
-	return fmt.Sprintf("Use this tool to transfer the task to %s, which can help with:\n%s",
-		t.agent.Name, t.description)
-}
-
-func (t *AgentTransferTool[C]) Parameters() llmsdk.JSONSchema {
-	return llmsdk.JSONSchema{
-		"type": "object",
-		"properties": map[string]any{
-			"task": map[string]any{
-				"type": "string",
-				"description": `A clear and concise description of the task the agent should achieve. 
-Replace any possessive pronouns or ambiguous terms with the actual entity names if possible
-so there is enough information for the agent to process without additional context`,
-			},
-		},
-		"required":             []string{"task"},
-		"additionalProperties": false,
-	}
-}
-
-func (t *AgentTransferTool[C]) Execute(ctx context.Context, paramsJSON json.RawMessage, contextVal C, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
-	var params DelegateParams
-	if err := json.Unmarshal(paramsJSON, &params); err != nil {
-		return llmagent.AgentToolResult{}, err
-	}
-
-	fmt.Printf("[-> %s agent]: %s\n", t.agent.Name, params.Task)
-
-	result, err := t.agent.Run(ctx, llmagent.AgentRequest[C]{
-		Input: []llmagent.AgentItem{
-			llmagent.NewAgentItemMessage(
-				llmsdk.NewUserMessage(
-					llmsdk.NewTextPart(params.Task),
-				)),
-		},
-		Context: contextVal,
-	})
-	if err != nil {
-		return llmagent.AgentToolResult{}, err
-	}
-
-	return llmagent.AgentToolResult{
-		Content: result.Content,
-		IsError: false,
-	}, nil
-}
-
 type Order struct {
 	CustomerName   string
 	Address        string
@@ -362,8 +291,8 @@ You should also poll the order status in every turn to send them for delivery on
 			llmagent.InstructionParam[*MyContext]{String: &coordInst3},
 		),
 		llmagent.WithTools(
-			llmagent.NewAgentFunctionTool(NewAgentTransferTool(orderAgent, "handling customer orders and get order statuses")),
-			llmagent.NewAgentFunctionTool(NewAgentTransferTool(deliveryAgent, "delivering processed orders")),
+			llmagent.NewHandoff(orderAgent, llmagent.WithHandoffDescription[*MyContext]("handling customer orders and get order statuses")),
+			llmagent.NewHandoff(deliveryAgent, llmagent.WithHandoffDescription[*MyContext]("delivering processed orders")),
 		),
 	)
 
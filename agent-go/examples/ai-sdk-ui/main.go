@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,245 +9,24 @@ import (
 	"log"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/aisdk"
 	"github.com/hoangvvo/llm-sdk/agent-go/examples"
 	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
-	"github.com/hoangvvo/llm-sdk/sdk-go/utils/partutil"
 )
 
-// ==== Vercel AI SDK types ====
-
-type uiMessageRole string
-
-const (
-	uiRoleSystem    uiMessageRole = "system"
-	uiRoleUser      uiMessageRole = "user"
-	uiRoleAssistant uiMessageRole = "assistant"
-)
-
-type providerMetadata = any
-
-type uiMessage struct {
-	ID       string        `json:"id"`
-	Role     uiMessageRole `json:"role"`
-	Parts    []uiPart      `json:"parts"`
-	Metadata any           `json:"metadata,omitempty"`
-}
-
-type uiPart struct {
-	Text          *textUIPart
-	Custom        *customUIPart
-	Reasoning     *reasoningUIPart
-	DynamicTool   *dynamicToolUIPart
-	Tool          *toolUIPart
-	File          *fileUIPart
-	ReasoningFile *reasoningFileUIPart
-}
-
-func (p *uiPart) UnmarshalJSON(data []byte) error {
-	var base baseUIPart
-	if err := json.Unmarshal(data, &base); err != nil {
-		return err
-	}
-	switch {
-	case base.Type == "text":
-		var part textUIPart
-		if err := json.Unmarshal(data, &part); err != nil {
-			return err
-		}
-		p.Text = &part
-	case base.Type == "custom":
-		var part customUIPart
-		if err := json.Unmarshal(data, &part); err != nil {
-			return err
-		}
-		p.Custom = &part
-	case base.Type == "reasoning":
-		var part reasoningUIPart
-		if err := json.Unmarshal(data, &part); err != nil {
-			return err
-		}
-		p.Reasoning = &part
-	case base.Type == "dynamic-tool":
-		var part dynamicToolUIPart
-		if err := json.Unmarshal(data, &part); err != nil {
-			return err
-		}
-		p.DynamicTool = &part
-	case base.Type == "file":
-		var part fileUIPart
-		if err := json.Unmarshal(data, &part); err != nil {
-			return err
-		}
-		p.File = &part
-	case base.Type == "reasoning-file":
-		var part reasoningFileUIPart
-		if err := json.Unmarshal(data, &part); err != nil {
-			return err
-		}
-		p.ReasoningFile = &part
-	default:
-		if strings.HasPrefix(base.Type, "tool-") {
-			var part toolUIPart
-			if err := json.Unmarshal(data, &part); err != nil {
-				return err
-			}
-			part.rawType = base.Type
-			if part.ToolName == "" {
-				part.ToolName = strings.TrimPrefix(base.Type, "tool-")
-			}
-			p.Tool = &part
-		} else {
-			p.Tool = nil
-		}
-	}
-	return nil
-}
-
-func (p uiPart) MarshalJSON() ([]byte, error) {
-	switch {
-	case p.Text != nil:
-		return json.Marshal(struct {
-			Type string `json:"type"`
-			*textUIPart
-		}{
-			Type:       "text",
-			textUIPart: p.Text,
-		})
-	case p.Custom != nil:
-		return json.Marshal(struct {
-			Type string `json:"type"`
-			*customUIPart
-		}{
-			Type:         "custom",
-			customUIPart: p.Custom,
-		})
-	case p.Reasoning != nil:
-		return json.Marshal(struct {
-			Type string `json:"type"`
-			*reasoningUIPart
-		}{
-			Type:            "reasoning",
-			reasoningUIPart: p.Reasoning,
-		})
-	case p.DynamicTool != nil:
-		return json.Marshal(struct {
-			Type string `json:"type"`
-			*dynamicToolUIPart
-		}{
-			Type:              "dynamic-tool",
-			dynamicToolUIPart: p.DynamicTool,
-		})
-	case p.File != nil:
-		return json.Marshal(struct {
-			Type string `json:"type"`
-			*fileUIPart
-		}{
-			Type:       "file",
-			fileUIPart: p.File,
-		})
-	case p.ReasoningFile != nil:
-		return json.Marshal(struct {
-			Type string `json:"type"`
-			*reasoningFileUIPart
-		}{
-			Type:                "reasoning-file",
-			reasoningFileUIPart: p.ReasoningFile,
-		})
-	case p.Tool != nil:
-		typeValue := p.Tool.rawType
-		if typeValue == "" {
-			if p.Tool.ToolName != "" {
-				typeValue = "tool-" + p.Tool.ToolName
-			} else {
-				typeValue = "tool"
-			}
-		}
-		return json.Marshal(struct {
-			Type string `json:"type"`
-			*toolUIPart
-		}{
-			Type:       typeValue,
-			toolUIPart: p.Tool,
-		})
-	default:
-		return nil, fmt.Errorf("uiPart marshal: no variant populated")
-	}
-}
-
 type chatRequestBody struct {
 	ID        string                        `json:"id,omitempty"`
 	Trigger   string                        `json:"trigger,omitempty"`
 	MessageID string                        `json:"messageId,omitempty"`
-	Messages  []uiMessage                   `json:"messages"`
+	Messages  []aisdk.UIMessage             `json:"messages"`
 	Provider  string                        `json:"provider,omitempty"`
 	ModelID   string                        `json:"modelId,omitempty"`
 	Metadata  *llmsdk.LanguageModelMetadata `json:"metadata,omitempty"`
 }
 
-type baseUIPart struct {
-	Type string `json:"type"`
-}
-
-type textUIPart struct {
-	Text             string           `json:"text"`
-	State            *string          `json:"state,omitempty"`
-	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
-}
-
-type customUIPart struct {
-	Kind             string           `json:"kind"`
-	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
-}
-
-type reasoningUIPart struct {
-	Text             string           `json:"text"`
-	State            *string          `json:"state,omitempty"`
-	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
-}
-
-type fileUIPart struct {
-	URL               string            `json:"url"`
-	MediaType         string            `json:"mediaType"`
-	Filename          *string           `json:"filename,omitempty"`
-	ProviderReference map[string]string `json:"providerReference,omitempty"`
-	ProviderMetadata  providerMetadata  `json:"providerMetadata,omitempty"`
-}
-
-type reasoningFileUIPart struct {
-	URL              string           `json:"url"`
-	MediaType        string           `json:"mediaType"`
-	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
-}
-
-type dynamicToolUIPart struct {
-	ToolName   string `json:"toolName"`
-	ToolCallID string `json:"toolCallId"`
-	Input      any    `json:"input,omitempty"`
-}
-
-type toolUIPart struct {
-	State            string           `json:"state"`
-	ToolCallID       string           `json:"toolCallId"`
-	ToolName         string           `json:"toolName,omitempty"`
-	Input            any              `json:"input,omitempty"`
-	Output           any              `json:"output,omitempty"`
-	ErrorText        string           `json:"errorText,omitempty"`
-	ProviderMetadata providerMetadata `json:"providerMetadata,omitempty"`
-	rawType          string           `json:"-"`
-}
-
-func (p *toolUIPart) resolvedToolName() string {
-	if p.ToolName != "" {
-		return p.ToolName
-	}
-	return strings.TrimPrefix(p.rawType, "tool-")
-}
-
 // ==== Agent setup ====
 
 type chatContext struct{}
@@ -356,712 +134,6 @@ func createAgent(provider, modelID string, metadata llmsdk.LanguageModelMetadata
 	)
 }
 
-// ==== Streaming helpers ====
-
-type textStreamState struct {
-	id string
-}
-
-type reasoningStreamState struct {
-	id string
-}
-
-type toolCallStreamState struct {
-	toolCallID   string
-	toolName     string
-	argsBuilder  strings.Builder
-	didEmitStart bool
-}
-
-type sseWriter struct {
-	w       http.ResponseWriter
-	flusher http.Flusher
-	mu      sync.Mutex
-}
-
-// newSSEWriter wraps the ResponseWriter with helpers for emitting Server-Sent
-// Events. The Vercel AI SDK data stream protocol uses SSE, so isolating the
-// transport details keeps the adapter focused on payload translation.
-func newSSEWriter(w http.ResponseWriter) (*sseWriter, error) {
-	flusher, ok := w.(http.Flusher)
-	if !ok {
-		return nil, errors.New("streaming unsupported by response writer")
-	}
-	return &sseWriter{w: w, flusher: flusher}, nil
-}
-
-func (w *sseWriter) Write(event any) error {
-	payload, err := json.Marshal(event)
-	if err != nil {
-		return err
-	}
-
-	w.mu.Lock()
-	defer w.mu.Unlock()
-
-	if _, err := w.w.Write([]byte("data: ")); err != nil {
-		return err
-	}
-	if _, err := w.w.Write(payload); err != nil {
-		return err
-	}
-	if _, err := w.w.Write([]byte("\n\n")); err != nil {
-		return err
-	}
-	w.flusher.Flush()
-	return nil
-}
-
-func (w *sseWriter) Close() error {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-
-	if _, err := w.w.Write([]byte("data: [DONE]\n\n")); err != nil {
-		return err
-	}
-	w.flusher.Flush()
-	return nil
-}
-
-type startChunk struct {
-	MessageID string `json:"messageId,omitempty"`
-}
-
-func (c startChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type      string `json:"type"`
-		MessageID string `json:"messageId,omitempty"`
-	}
-	return json.Marshal(alias{Type: "start", MessageID: c.MessageID})
-}
-
-type startStepChunk struct{}
-
-func (startStepChunk) MarshalJSON() ([]byte, error) {
-	return json.Marshal(struct {
-		Type string `json:"type"`
-	}{Type: "start-step"})
-}
-
-type finishStepChunk struct{}
-
-func (finishStepChunk) MarshalJSON() ([]byte, error) {
-	return json.Marshal(struct {
-		Type string `json:"type"`
-	}{Type: "finish-step"})
-}
-
-type textStartChunk struct {
-	ID string `json:"id"`
-}
-
-func (c textStartChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type string `json:"type"`
-		ID   string `json:"id"`
-	}
-	return json.Marshal(alias{Type: "text-start", ID: c.ID})
-}
-
-type textDeltaChunk struct {
-	ID    string `json:"id"`
-	Delta string `json:"delta"`
-}
-
-func (c textDeltaChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type  string `json:"type"`
-		ID    string `json:"id"`
-		Delta string `json:"delta"`
-	}
-	return json.Marshal(alias{Type: "text-delta", ID: c.ID, Delta: c.Delta})
-}
-
-type textEndChunk struct {
-	ID string `json:"id"`
-}
-
-func (c textEndChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type string `json:"type"`
-		ID   string `json:"id"`
-	}
-	return json.Marshal(alias{Type: "text-end", ID: c.ID})
-}
-
-type reasoningStartChunk struct {
-	ID string `json:"id"`
-}
-
-func (c reasoningStartChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type string `json:"type"`
-		ID   string `json:"id"`
-	}
-	return json.Marshal(alias{Type: "reasoning-start", ID: c.ID})
-}
-
-type reasoningDeltaChunk struct {
-	ID    string `json:"id"`
-	Delta string `json:"delta"`
-}
-
-func (c reasoningDeltaChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type  string `json:"type"`
-		ID    string `json:"id"`
-		Delta string `json:"delta"`
-	}
-	return json.Marshal(alias{Type: "reasoning-delta", ID: c.ID, Delta: c.Delta})
-}
-
-type reasoningEndChunk struct {
-	ID string `json:"id"`
-}
-
-func (c reasoningEndChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type string `json:"type"`
-		ID   string `json:"id"`
-	}
-	return json.Marshal(alias{Type: "reasoning-end", ID: c.ID})
-}
-
-type toolInputStartChunk struct {
-	ToolCallID string `json:"toolCallId"`
-	ToolName   string `json:"toolName"`
-}
-
-func (c toolInputStartChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type       string `json:"type"`
-		ToolCallID string `json:"toolCallId"`
-		ToolName   string `json:"toolName"`
-	}
-	return json.Marshal(alias{
-		Type:       "tool-input-start",
-		ToolCallID: c.ToolCallID,
-		ToolName:   c.ToolName,
-	})
-}
-
-type toolInputDeltaChunk struct {
-	ToolCallID     string `json:"toolCallId"`
-	InputTextDelta string `json:"inputTextDelta"`
-}
-
-func (c toolInputDeltaChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type           string `json:"type"`
-		ToolCallID     string `json:"toolCallId"`
-		InputTextDelta string `json:"inputTextDelta"`
-	}
-	return json.Marshal(alias{
-		Type:           "tool-input-delta",
-		ToolCallID:     c.ToolCallID,
-		InputTextDelta: c.InputTextDelta,
-	})
-}
-
-type toolInputAvailableChunk struct {
-	ToolCallID string `json:"toolCallId"`
-	ToolName   string `json:"toolName"`
-	Input      any    `json:"input"`
-}
-
-func (c toolInputAvailableChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type       string `json:"type"`
-		ToolCallID string `json:"toolCallId"`
-		ToolName   string `json:"toolName"`
-		Input      any    `json:"input"`
-	}
-	return json.Marshal(alias{
-		Type:       "tool-input-available",
-		ToolCallID: c.ToolCallID,
-		ToolName:   c.ToolName,
-		Input:      c.Input,
-	})
-}
-
-type toolOutputAvailableChunk struct {
-	ToolCallID string `json:"toolCallId"`
-	Output     any    `json:"output"`
-}
-
-func (c toolOutputAvailableChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type       string `json:"type"`
-		ToolCallID string `json:"toolCallId"`
-		Output     any    `json:"output"`
-	}
-	return json.Marshal(alias{
-		Type:       "tool-output-available",
-		ToolCallID: c.ToolCallID,
-		Output:     c.Output,
-	})
-}
-
-type errorChunk struct {
-	ErrorText string `json:"errorText"`
-}
-
-func (c errorChunk) MarshalJSON() ([]byte, error) {
-	type alias struct {
-		Type      string `json:"type"`
-		ErrorText string `json:"errorText"`
-	}
-	return json.Marshal(alias{Type: "error", ErrorText: c.ErrorText})
-}
-
-type finishChunk struct{}
-
-func (finishChunk) MarshalJSON() ([]byte, error) {
-	return json.Marshal(struct {
-		Type string `json:"type"`
-	}{Type: "finish"})
-}
-
-// dataStreamProtocolAdapter bridges AgentStreamEvent values to the Vercel AI
-// SDK data stream protocol. Feed every event emitted by Agent.RunStream into
-// Write so the frontend receives the expected stream chunks.
-type dataStreamProtocolAdapter struct {
-	writer            *sseWriter
-	textStateMap      map[int]textStreamState
-	reasoningStateMap map[int]reasoningStreamState
-	toolCallStateMap  map[int]*toolCallStreamState
-	stepStarted       bool
-	closed            bool
-}
-
-func newDataStreamProtocolAdapter(w http.ResponseWriter) (*dataStreamProtocolAdapter, error) {
-	writer, err := newSSEWriter(w)
-	if err != nil {
-		return nil, err
-	}
-
-	adapter := &dataStreamProtocolAdapter{
-		writer:            writer,
-		textStateMap:      make(map[int]textStreamState),
-		reasoningStateMap: make(map[int]reasoningStreamState),
-		toolCallStateMap:  make(map[int]*toolCallStreamState),
-	}
-
-	messageID := "msg_" + uuid.NewString()
-	if err := adapter.writer.Write(startChunk{MessageID: messageID}); err != nil {
-		return nil, err
-	}
-
-	return adapter, nil
-}
-
-func (a *dataStreamProtocolAdapter) Write(event *llmagent.AgentStreamEvent) error {
-	if a.closed {
-		return nil
-	}
-
-	switch {
-	case event.Partial != nil:
-		if event.Partial.Delta == nil {
-			return nil
-		}
-		if err := a.ensureStepStarted(); err != nil {
-			return err
-		}
-		return a.writeDelta(event.Partial.Delta)
-	case event.Item != nil:
-		if err := a.finishStep(); err != nil {
-			return err
-		}
-		if event.Item.Item.Tool != nil {
-			if err := a.ensureStepStarted(); err != nil {
-				return err
-			}
-			if err := a.writeForToolItem(event.Item.Item.Tool); err != nil {
-				return err
-			}
-			return a.finishStep()
-		}
-	case event.Response != nil:
-		// Final agent response does not emit an extra stream part.
-		return nil
-	}
-
-	return nil
-}
-
-func (a *dataStreamProtocolAdapter) EmitError(errorText string) error {
-	if a.closed {
-		return nil
-	}
-	return a.writer.Write(errorChunk{ErrorText: errorText})
-}
-
-func (a *dataStreamProtocolAdapter) Close() error {
-	if a.closed {
-		return nil
-	}
-	if err := a.finishStep(); err != nil {
-		return err
-	}
-	if err := a.writer.Write(finishChunk{}); err != nil {
-		return err
-	}
-	if err := a.writer.Close(); err != nil {
-		return err
-	}
-	a.closed = true
-	return nil
-}
-
-func (a *dataStreamProtocolAdapter) ensureStepStarted() error {
-	if a.stepStarted {
-		return nil
-	}
-	if err := a.writer.Write(startStepChunk{}); err != nil {
-		return err
-	}
-	a.stepStarted = true
-	return nil
-}
-
-func (a *dataStreamProtocolAdapter) finishStep() error {
-	if !a.stepStarted {
-		return nil
-	}
-	if err := a.flushStates(); err != nil {
-		return err
-	}
-	if err := a.writer.Write(finishStepChunk{}); err != nil {
-		return err
-	}
-	a.stepStarted = false
-	return nil
-}
-
-func (a *dataStreamProtocolAdapter) flushStates() error {
-	for index, state := range a.textStateMap {
-		if err := a.writer.Write(textEndChunk{ID: state.id}); err != nil {
-			return err
-		}
-		delete(a.textStateMap, index)
-	}
-
-	for index, state := range a.reasoningStateMap {
-		if err := a.writer.Write(reasoningEndChunk{ID: state.id}); err != nil {
-			return err
-		}
-		delete(a.reasoningStateMap, index)
-	}
-
-	for index, state := range a.toolCallStateMap {
-		if state.toolCallID != "" && state.toolName != "" && state.argsBuilder.Len() > 0 {
-			input := safeJSONParse(state.argsBuilder.String())
-			if err := a.writer.Write(toolInputAvailableChunk{
-				ToolCallID: state.toolCallID,
-				ToolName:   state.toolName,
-				Input:      input,
-			}); err != nil {
-				return err
-			}
-		}
-		delete(a.toolCallStateMap, index)
-	}
-
-	return nil
-}
-
-func (a *dataStreamProtocolAdapter) writeDelta(delta *llmsdk.ContentDelta) error {
-	switch {
-	case delta.Part.TextPartDelta != nil:
-		return a.writeForTextPartDelta(delta.Index, delta.Part.TextPartDelta)
-	case delta.Part.ReasoningPartDelta != nil:
-		return a.writeForReasoningPartDelta(delta.Index, delta.Part.ReasoningPartDelta)
-	case delta.Part.ToolCallPartDelta != nil:
-		return a.writeForToolCallPartDelta(delta.Index, delta.Part.ToolCallPartDelta)
-	case delta.Part.AudioPartDelta != nil:
-		return a.flushStates()
-	case delta.Part.ImagePartDelta != nil:
-		return a.flushStates()
-	default:
-		return nil
-	}
-}
-
-func (a *dataStreamProtocolAdapter) writeForTextPartDelta(index int, part *llmsdk.TextPartDelta) error {
-	state, ok := a.textStateMap[index]
-	if !ok {
-		if err := a.flushStates(); err != nil {
-			return err
-		}
-		state = textStreamState{id: "text_" + uuid.NewString()}
-		a.textStateMap[index] = state
-		if err := a.writer.Write(textStartChunk{ID: state.id}); err != nil {
-			return err
-		}
-	}
-
-	return a.writer.Write(textDeltaChunk{ID: state.id, Delta: part.Text})
-}
-
-func (a *dataStreamProtocolAdapter) writeForReasoningPartDelta(index int, part *llmsdk.ReasoningPartDelta) error {
-	state, ok := a.reasoningStateMap[index]
-	if !ok {
-		if err := a.flushStates(); err != nil {
-			return err
-		}
-		id := "reasoning_" + uuid.NewString()
-		if part.ID != nil && *part.ID != "" {
-			id = "reasoning_" + *part.ID
-		}
-		state = reasoningStreamState{id: id}
-		a.reasoningStateMap[index] = state
-		if err := a.writer.Write(reasoningStartChunk{ID: state.id}); err != nil {
-			return err
-		}
-	}
-
-	return a.writer.Write(reasoningDeltaChunk{ID: state.id, Delta: part.Text})
-}
-
-func (a *dataStreamProtocolAdapter) writeForToolCallPartDelta(index int, part *llmsdk.ToolCallPartDelta) error {
-	call := part.Call.Function
-	if call == nil {
-		return nil
-	}
-	state, ok := a.toolCallStateMap[index]
-	if !ok {
-		if err := a.flushStates(); err != nil {
-			return err
-		}
-		state = &toolCallStreamState{}
-		a.toolCallStateMap[index] = state
-	}
-
-	if part.ToolCallID != nil && *part.ToolCallID != "" {
-		state.toolCallID = *part.ToolCallID
-	}
-	if call.Name != nil && *call.Name != "" {
-		state.toolName = *call.Name
-	}
-
-	if !state.didEmitStart && state.toolCallID != "" && state.toolName != "" {
-		state.didEmitStart = true
-		if err := a.writer.Write(toolInputStartChunk{
-			ToolCallID: state.toolCallID,
-			ToolName:   state.toolName,
-		}); err != nil {
-			return err
-		}
-	}
-
-	if call.Args != nil && *call.Args != "" {
-		state.argsBuilder.WriteString(*call.Args)
-		return a.writer.Write(toolInputDeltaChunk{
-			ToolCallID:     state.toolCallID,
-			InputTextDelta: *call.Args,
-		})
-	}
-
-	return nil
-}
-
-func (a *dataStreamProtocolAdapter) writeForToolItem(item *llmagent.AgentItemTool) error {
-	if err := a.flushStates(); err != nil {
-		return err
-	}
-
-	var textBuffer strings.Builder
-	for _, part := range item.Output {
-		if part.TextPart != nil {
-			textBuffer.WriteString(part.TextPart.Text)
-		}
-	}
-
-	var output any
-	if textBuffer.Len() > 0 {
-		output = safeJSONParse(textBuffer.String())
-	} else {
-		output = item.Output
-	}
-
-	return a.writer.Write(toolOutputAvailableChunk{
-		ToolCallID: item.ToolCallID,
-		Output:     output,
-	})
-}
-
-// ==== Adapter layers ====
-
-// uiPartToParts converts UI message parts produced by the Vercel AI SDK back
-// into llm-sdk Part values so the agent can reconstruct history, tool calls,
-// and intermediate reasoning steps.
-func uiPartToParts(part uiPart) []llmsdk.Part {
-	switch {
-	case part.Text != nil:
-		return []llmsdk.Part{llmsdk.NewTextPart(part.Text.Text)}
-	case part.Custom != nil:
-		// Provider-specific custom content has no portable llm-sdk equivalent.
-		return nil
-	case part.Reasoning != nil:
-		return []llmsdk.Part{llmsdk.NewReasoningPart(part.Reasoning.Text)}
-	case part.DynamicTool != nil:
-		if part.DynamicTool.ToolCallID == "" || part.DynamicTool.ToolName == "" {
-			return nil
-		}
-		return []llmsdk.Part{llmsdk.NewToolCallPart(part.DynamicTool.ToolCallID, part.DynamicTool.ToolName, part.DynamicTool.Input)}
-	case part.File != nil:
-		return convertFilePart(part.File)
-	case part.ReasoningFile != nil:
-		return convertFileData(part.ReasoningFile.URL, part.ReasoningFile.MediaType)
-	case part.Tool != nil:
-		return convertToolPart(part.Tool)
-	default:
-		return nil
-	}
-}
-
-func convertFilePart(part *fileUIPart) []llmsdk.Part {
-	return convertFileData(part.URL, part.MediaType)
-}
-
-func convertFileData(url, declaredMediaType string) []llmsdk.Part {
-	data := extractDataPayload(url)
-	mediaType := resolveMediaType(url, declaredMediaType)
-	switch {
-	case isMediaType(mediaType, "image"):
-		imagePart := llmsdk.NewImagePart(data, mediaType)
-		return []llmsdk.Part{imagePart}
-	case isMediaType(mediaType, "audio"):
-		format, err := partutil.MapMimeTypeToAudioFormat(mediaType)
-		if err != nil {
-			return nil
-		}
-		return []llmsdk.Part{llmsdk.NewAudioPart(data, format)}
-	case isMediaType(mediaType, "text"):
-		decoded, err := base64.StdEncoding.DecodeString(data)
-		if err != nil {
-			return nil
-		}
-		return []llmsdk.Part{llmsdk.NewTextPart(string(decoded))}
-	default:
-		return nil
-	}
-}
-
-// resolveMediaType handles the top-level media types accepted by AI SDK 7 by
-// recovering the full MIME type from an uploaded data URL when available.
-func resolveMediaType(url, declaredMediaType string) string {
-	normalized := strings.TrimSpace(strings.SplitN(declaredMediaType, ";", 2)[0])
-	if strings.Contains(normalized, "/") && !strings.HasSuffix(normalized, "/*") {
-		return declaredMediaType
-	}
-
-	if comma := strings.IndexByte(url, ','); comma > len("data:") && strings.EqualFold(url[:len("data:")], "data:") {
-		header := url[len("data:"):comma]
-		if mediaType := strings.SplitN(header, ";", 2)[0]; mediaType != "" {
-			return mediaType
-		}
-	}
-	return declaredMediaType
-}
-
-func isMediaType(mediaType, topLevelType string) bool {
-	normalized := strings.ToLower(mediaType)
-	return normalized == topLevelType || strings.HasPrefix(normalized, topLevelType+"/")
-}
-
-func convertToolPart(part *toolUIPart) []llmsdk.Part {
-	name := part.resolvedToolName()
-	if part.ToolCallID == "" || name == "" {
-		return nil
-	}
-	switch part.State {
-	case "input-available":
-		return []llmsdk.Part{llmsdk.NewToolCallPart(part.ToolCallID, name, part.Input)}
-	case "output-available":
-		call := llmsdk.NewToolCallPart(part.ToolCallID, name, part.Input)
-		result := llmsdk.NewToolResultPart(part.ToolCallID, name, []llmsdk.Part{
-			llmsdk.NewTextPart(safeJSONMarshal(part.Output)),
-		})
-		return []llmsdk.Part{call, result}
-	case "output-error":
-		call := llmsdk.NewToolCallPart(part.ToolCallID, name, part.Input)
-		result := llmsdk.NewToolResultPart(part.ToolCallID, name, []llmsdk.Part{
-			llmsdk.NewTextPart(part.ErrorText),
-		})
-		return []llmsdk.Part{call, result}
-	default:
-		return nil
-	}
-}
-
-func uiMessagesToMessages(messages []uiMessage) ([]llmsdk.Message, error) {
-	history := make([]llmsdk.Message, 0, len(messages))
-
-	for _, message := range messages {
-		switch message.Role {
-		case uiRoleUser:
-			var parts []llmsdk.Part
-			for _, part := range message.Parts {
-				parts = append(parts, uiPartToParts(part)...)
-			}
-			if len(parts) == 0 {
-				continue
-			}
-			history = append(history, llmsdk.NewUserMessage(parts...))
-		case uiRoleAssistant:
-			for _, part := range message.Parts {
-				for _, converted := range uiPartToParts(part) {
-					switch converted.Type() {
-					case llmsdk.PartTypeText, llmsdk.PartTypeReasoning, llmsdk.PartTypeAudio, llmsdk.PartTypeImage, llmsdk.PartTypeToolCall:
-						appendAssistantMessage(&history, converted)
-					case llmsdk.PartTypeToolResult:
-						appendToolMessage(&history, converted)
-					}
-				}
-			}
-		default:
-			// ignore unsupported roles
-		}
-	}
-
-	return history, nil
-}
-
-func appendAssistantMessage(history *[]llmsdk.Message, part llmsdk.Part) {
-	n := len(*history)
-	if n > 0 {
-		last := &(*history)[n-1]
-		if msg := last.AssistantMessage; msg != nil {
-			msg.Content = append(msg.Content, part)
-			return
-		}
-		if last.ToolMessage != nil && n >= 2 {
-			prev := &(*history)[n-2]
-			if msg := prev.AssistantMessage; msg != nil {
-				msg.Content = append(msg.Content, part)
-				return
-			}
-		}
-	}
-
-	*history = append(*history, llmsdk.NewAssistantMessage(part))
-}
-
-func appendToolMessage(history *[]llmsdk.Message, part llmsdk.Part) {
-	n := len(*history)
-	if n > 0 {
-		last := &(*history)[n-1]
-		if msg := last.ToolMessage; msg != nil {
-			msg.Content = append(msg.Content, part)
-			return
-		}
-	}
-
-	*history = append(*history, llmsdk.NewToolMessage(part))
-}
-
 // ==== HTTP handlers ====
 
 func handleChatRequest(w http.ResponseWriter, r *http.Request) {
@@ -1106,7 +178,7 @@ func handleChatRequest(w http.ResponseWriter, r *http.Request) {
 
 	agent := createAgent(provider, modelID, metadata)
 
-	messages, err := uiMessagesToMessages(body.Messages)
+	messages, err := aisdk.UIMessagesToMessages(body.Messages)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("invalid messages payload: %v", err), http.StatusBadRequest)
 		return
@@ -1116,13 +188,10 @@ func handleChatRequest(w http.ResponseWriter, r *http.Request) {
 		items = append(items, llmagent.NewAgentItemMessage(message))
 	}
 
-	w.Header().Set("Content-Type", "text/event-stream")
-	w.Header().Set("Cache-Control", "no-cache, no-transform")
-	w.Header().Set("Connection", "keep-alive")
-	w.Header().Set("x-vercel-ai-ui-message-stream", "v1")
+	aisdk.SetStreamHeaders(w)
 	w.WriteHeader(http.StatusOK)
 
-	adapter, err := newDataStreamProtocolAdapter(w)
+	adapter, err := aisdk.NewDataStreamProtocolAdapter(w)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1173,47 +242,6 @@ func readRequestBody(r *http.Request) ([]byte, error) {
 	return io.ReadAll(r.Body)
 }
 
-// ==== Utility helpers ====
-
-// safeJSONParse attempts to decode tool arguments or results as JSON. When a
-// payload is not valid JSON we fall back to the original string so the UI can
-// still surface something meaningful to the user.
-func safeJSONParse(raw string) any {
-	if strings.TrimSpace(raw) == "" {
-		return raw
-	}
-
-	decoder := json.NewDecoder(strings.NewReader(raw))
-	decoder.UseNumber()
-
-	var value any
-	if err := decoder.Decode(&value); err != nil {
-		return raw
-	}
-	if decoder.More() {
-		return raw
-	}
-	return value
-}
-
-func safeJSONMarshal(value any) string {
-	if value == nil {
-		return "null"
-	}
-	data, err := json.Marshal(value)
-	if err != nil {
-		return fmt.Sprintf("%v", value)
-	}
-	return string(data)
-}
-
-func extractDataPayload(dataURL string) string {
-	if idx := strings.Index(dataURL, ","); idx != -1 {
-		return dataURL[idx+1:]
-	}
-	return dataURL
-}
-
 // ==== Server bootstrap ====
 
 func main() {
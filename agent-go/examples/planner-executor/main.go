@@ -2,73 +2,44 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
 	"github.com/hoangvvo/llm-sdk/agent-go/examples"
+	"github.com/hoangvvo/llm-sdk/agent-go/planner"
 	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
 	"github.com/joho/godotenv"
 )
 
-// Internal TODO model and store
-type Todo struct {
-	Status string `json:"status"`
-	Step   string `json:"step"`
-}
-
-type Store struct {
-	m           map[string]*Todo
-	explanation string
-}
-
-func NewStore() *Store { return &Store{m: map[string]*Todo{}, explanation: ""} }
-func (s *Store) List() []*Todo {
-	out := make([]*Todo, 0, len(s.m))
-	for _, t := range s.m {
-		out = append(out, t)
-	}
-	return out
-}
-func (s *Store) Explanation() string { return s.explanation }
-func (s *Store) ResetWith(plan []Todo, explanation string) {
-	s.m = map[string]*Todo{}
-	for i := range plan {
-		it := plan[i]
-		key := fmt.Sprintf("%d", i)
-		itCopy := it
-		s.m[key] = &itCopy
-	}
-	s.explanation = explanation
-}
+// No context for this example
+type Ctx = struct{}
 
-func formatTodos(s *Store) string {
-	list := s.List()
+func formatPlan(plan planner.Plan) string {
 	var b strings.Builder
-	fmt.Fprintf(&b, "\n─ PLAN (internal) · %d items\n", len(list))
-	if s.Explanation() != "" {
-		fmt.Fprintf(&b, "Explanation: %s\n", s.Explanation())
+	fmt.Fprintf(&b, "\n─ PLAN (internal) · %d items\n", len(plan.Steps))
+	if plan.Explanation != "" {
+		fmt.Fprintf(&b, "Explanation: %s\n", plan.Explanation)
 	}
-	if len(list) == 0 {
+	if len(plan.Steps) == 0 {
 		b.WriteString("(empty)\n")
 		return b.String()
 	}
-	for _, t := range list {
+	for _, step := range plan.Steps {
 		sym := "○"
-		if strings.EqualFold(strings.TrimSpace(t.Status), "in_progress") {
+		switch step.Status {
+		case planner.StepStatusInProgress:
 			sym = "▸"
-		}
-		if strings.EqualFold(strings.TrimSpace(t.Status), "complete") {
+		case planner.StepStatusCompleted:
 			sym = "✓"
 		}
-		fmt.Fprintf(&b, "%s %s\n", sym, t.Step)
+		fmt.Fprintf(&b, "%s %s\n", sym, step.Title)
 	}
 	return b.String()
 }
 
-func clearAndRender(messages []string, s *Store) {
+func clearAndRender(messages []string, plan planner.Plan) {
 	// Clear console
 	// Prefer console clear; fallback to ANSI
 	fmt.Print("\033[2J\033[H")
@@ -76,51 +47,7 @@ func clearAndRender(messages []string, s *Store) {
 		fmt.Println(strings.Join(messages, "\n\n"))
 		fmt.Println()
 	}
-	fmt.Print(formatTodos(s))
-}
-
-// No context for this example
-type Ctx = struct{}
-
-// Tools
-// Single tool: update_plan
-type UpdatePlanTool struct{ S *Store }
-
-func (t *UpdatePlanTool) Name() string { return "update_plan" }
-func (t *UpdatePlanTool) Description() string {
-	return "Replace internal plan with explanation and steps"
-}
-func (t *UpdatePlanTool) Parameters() llmsdk.JSONSchema {
-	// Strict schema: all properties required, no additional
-	item := map[string]any{
-		"type": "object",
-		"properties": map[string]any{
-			"status": map[string]any{"type": "string", "enum": []string{"pending", "in_progress", "complete"}},
-			"step":   map[string]any{"type": "string"},
-		},
-		"required":             []string{"status", "step"},
-		"additionalProperties": false,
-	}
-	m := llmsdk.JSONSchema{"type": "object"}
-	m["properties"] = map[string]any{
-		"explanation": map[string]any{"type": "string"},
-		"plan":        map[string]any{"type": "array", "items": item},
-	}
-	m["required"] = []string{"explanation", "plan"}
-	m["additionalProperties"] = false
-	return m
-}
-func (t *UpdatePlanTool) Execute(_ context.Context, params json.RawMessage, _ Ctx, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
-	var p struct {
-		Explanation string `json:"explanation"`
-		Plan        []Todo `json:"plan"`
-	}
-	if err := json.Unmarshal(params, &p); err != nil {
-		return llmagent.AgentToolResult{}, err
-	}
-	t.S.ResetWith(p.Plan, p.Explanation)
-	body, _ := json.Marshal(map[string]any{"ok": true, "explanation": p.Explanation, "plan": t.S.List()})
-	return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart(string(body))}, IsError: false}, nil
+	fmt.Print(formatPlan(plan))
 }
 
 func main() {
@@ -138,34 +65,15 @@ func main() {
 		panic(err)
 	}
 
-	store := NewStore()
-
-	// Build agent
-	overview := `You are a planner–executor assistant.
-Break the user's goal into clear, actionable steps using the tool update_plan (explanation, plan: [{status, step}]).
-Use the plan strictly as your internal plan: NEVER reveal or enumerate plan items to the user. Do not mention the words TODO, task list, or the names of tools.
-Keep user-visible replies concise and focused on results and next-step confirmations.
-Work iteratively: plan an initial set of high-level steps, then refine/execute one major step per turn, marking completed items along the way via tools.
-When the work is complete, respond with the final deliverable and a brief one-paragraph summary of what you did.`
+	store := planner.NewInMemoryStore()
 
+	prompt := planner.PlannerPrompt
 	agent := llmagent.NewAgent("planner-executor", model,
 		llmagent.WithInstructions(
-			llmagent.InstructionParam[Ctx]{String: &overview},
-			// Dynamic instruction: inject internal plan
-			llmagent.InstructionParam[Ctx]{Func: func(_ context.Context, _ Ctx) (string, error) {
-				var b strings.Builder
-				b.WriteString("INTERNAL PLAN:\n")
-				list := store.List()
-				for i, it := range list {
-					fmt.Fprintf(&b, "%d. [%s] %s\n", i+1, it.Status, it.Step)
-				}
-				if store.Explanation() != "" {
-					fmt.Fprintf(&b, "Explanation: %s\n", store.Explanation())
-				}
-				return b.String(), nil
-			}},
+			llmagent.InstructionParam[Ctx]{String: &prompt},
+			planner.PlanInstruction[Ctx](store),
 		),
-		llmagent.WithTools(llmagent.NewAgentFunctionTool(&UpdatePlanTool{S: store})),
+		llmagent.WithTools(planner.Tools[Ctx](store)...),
 		llmagent.WithMaxTurns[Ctx](20),
 	)
 
@@ -179,10 +87,12 @@ When the work is complete, respond with the final deliverable and a brief one-pa
 		))),
 	}
 
+	ctx := context.Background()
+
 	var messages []string
-	clearAndRender(messages, store)
+	plan, _ := store.Fetch(ctx)
+	clearAndRender(messages, plan)
 
-	ctx := context.Background()
 	for turn := 1; ; turn++ {
 		res, err := agent.Run(ctx, llmagent.AgentRequest[Ctx]{Context: Ctx{}, Input: items})
 		if err != nil {
@@ -198,22 +108,19 @@ When the work is complete, respond with the final deliverable and a brief one-pa
 		if len(visible) > 0 {
 			messages = append(messages, strings.TrimSpace(strings.Join(visible, "\n")))
 		}
-		clearAndRender(messages, store)
+		plan, _ = store.Fetch(ctx)
+		clearAndRender(messages, plan)
 
 		// Append output
 		items = append(items, res.Output...)
 
-		list := store.List()
-		allDone := len(list) > 0
-		for _, t := range list {
-			allDone = allDone && strings.EqualFold(strings.TrimSpace(t.Status), "DONE")
-		}
-		if allDone {
+		if plan.Completed() {
 			break
 		}
 
 		items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("NEXT"))))
 	}
 
-	clearAndRender(messages, store)
+	plan, _ = store.Fetch(ctx)
+	clearAndRender(messages, plan)
 }
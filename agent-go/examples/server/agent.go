@@ -57,7 +57,6 @@ var availableToolkits = []availableToolkit{
 }
 
 type AgentOptions struct {
-	EnabledTools     []string
 	EnabledToolkits  []string
 	WebSearch        *llmsdk.WebSearchTool
 	MCPServers       []llmmcp.MCPParams
@@ -72,20 +71,9 @@ type AgentOptions struct {
 }
 
 func createAgent(model llmsdk.LanguageModel, options *AgentOptions) *llmagent.Agent[*MyContext] {
-	var tools []llmagent.AgentTool[*MyContext]
-	if options.EnabledTools != nil {
-		toolNameSet := make(map[string]bool)
-		for _, name := range options.EnabledTools {
-			toolNameSet[name] = true
-		}
-		for _, tool := range availableTools {
-			if toolNameSet[tool.Name()] {
-				tools = append(tools, tool)
-			}
-		}
-	} else {
-		tools = append(tools, availableTools...)
-	}
+	// Per-request tool selection is handled natively by the run loop via
+	// AgentRequest.EnabledTools, so the agent always registers every tool.
+	tools := append([]llmagent.AgentTool[*MyContext]{}, availableTools...)
 
 	if options.WebSearch != nil {
 		tools = append(tools, llmagent.NewAgentWebSearchTool[*MyContext](*options.WebSearch))
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -13,6 +14,7 @@ import (
 	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
 	"github.com/hoangvvo/llm-sdk/agent-go/examples"
 	llmmcp "github.com/hoangvvo/llm-sdk/agent-go/mcp"
+	"github.com/hoangvvo/llm-sdk/agent-go/sse"
 	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
 )
 
@@ -67,9 +69,8 @@ func runStreamHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var enabledTools []string
 	if req.EnabledTools != nil {
-		enabledTools = slices.Compact(req.EnabledTools)
+		req.Input.EnabledTools = slices.Compact(req.EnabledTools)
 	}
 	var enabledToolkits []string
 	if req.EnabledToolkits != nil {
@@ -84,7 +85,6 @@ func runStreamHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	options := &AgentOptions{
-		EnabledTools:     enabledTools,
 		EnabledToolkits:  enabledToolkits,
 		WebSearch:        req.WebSearch,
 		MCPServers:       req.MCPServers,
@@ -106,35 +106,36 @@ func runStreamHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Set SSE headers
-	w.Header().Set("Content-Type", "text/event-stream")
-	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("Connection", "keep-alive")
+	sse.SetHeaders(w)
 
-	flusher, ok := w.(http.Flusher)
-	if !ok {
+	writer, err := sse.NewWriter(w)
+	if err != nil {
 		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
 		return
 	}
 
 	for stream.Next() {
 		event := stream.Current()
-		data, err := json.Marshal(event)
-		if err != nil {
-			errorData, _ := json.Marshal(map[string]string{"event": "error", "error": err.Error()})
-			fmt.Fprintf(w, "data: %s\n\n", errorData)
-			flusher.Flush()
+		if err := writer.Send(event); err != nil {
+			_ = writer.SendError(err.Error())
 			return
 		}
-		fmt.Fprintf(w, "data: %s\n\n", data)
-		flusher.Flush()
 	}
 
 	if err := stream.Err(); err != nil {
-		errorData, _ := json.Marshal(map[string]string{"event": "error", "error": err.Error()})
-		fmt.Fprintf(w, "data: %s\n\n", errorData)
-		flusher.Flush()
+		sendStreamError(writer, err)
+	}
+}
+
+// sendStreamError serializes agent errors with their stable code so clients
+// can branch on the failure kind; other errors fall back to a plain message.
+func sendStreamError(writer *sse.Writer, err error) {
+	var agentErr *llmagent.AgentError
+	if errors.As(err, &agentErr) {
+		_ = writer.Send(map[string]any{"event": "error", "error": agentErr})
+		return
 	}
+	_ = writer.SendError(err.Error())
 }
 
 func listToolsHandler(w http.ResponseWriter) {
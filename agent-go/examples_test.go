@@ -0,0 +1,69 @@
+package llmagent_test
+
+import (
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRun_InjectsExamplesAheadOfConversation(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("medium")},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "sizer",
+		Model:    model,
+		MaxTurns: 2,
+		Examples: []llmagent.Example{
+			{User: "12oz coffee", Assistant: "small"},
+			{User: "20oz coffee", Assistant: "large"},
+		},
+	}, nil)
+
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("16oz coffee"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected 1 model call, got %d", len(inputs))
+	}
+	messages := inputs[0].Messages
+	if len(messages) != 5 {
+		t.Fatalf("expected 4 example messages ahead of the conversation, got %d messages", len(messages))
+	}
+	wantTexts := []string{"12oz coffee", "small", "20oz coffee", "large", "16oz coffee"}
+	for i, want := range wantTexts {
+		var parts []llmsdk.Part
+		switch {
+		case messages[i].UserMessage != nil:
+			parts = messages[i].UserMessage.Content
+		case messages[i].AssistantMessage != nil:
+			parts = messages[i].AssistantMessage.Content
+		default:
+			t.Fatalf("unexpected message %+v at index %d", messages[i], i)
+		}
+		if len(parts) != 1 || parts[0].TextPart == nil || parts[0].TextPart.Text != want {
+			t.Errorf("expected message %d to be %q, got %+v", i, want, parts)
+		}
+	}
+
+	// Alternating roles: user examples at even indices, assistant at odd.
+	for i := 0; i < 4; i++ {
+		if i%2 == 0 && messages[i].UserMessage == nil {
+			t.Errorf("expected message %d to be a user message", i)
+		}
+		if i%2 == 1 && messages[i].AssistantMessage == nil {
+			t.Errorf("expected message %d to be an assistant message", i)
+		}
+	}
+}
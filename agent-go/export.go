@@ -0,0 +1,126 @@
+package llmagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// sessionExportVersion identifies the export format so future changes can
+// keep reading old archives.
+const sessionExportVersion = 1
+
+// SessionExport is the stable JSON representation of a run session produced
+// by Export and consumed by RestoreSession, for archival and migration
+// between stores.
+type SessionExport struct {
+	// Version is the export format version.
+	Version int `json:"version"`
+	// SessionID is the identifier of the exported session. It seeds tool
+	// idempotency keys, so restoring it keeps recorded executions valid.
+	SessionID string `json:"session_id"`
+	// AgentName is the name of the agent the session belongs to.
+	AgentName string `json:"agent_name"`
+	// Items is the conversation transcript accumulated by Send.
+	Items []AgentItem `json:"items"`
+	// ExecutedTools are the recorded tool executions by idempotency key,
+	// present when tool idempotency is enabled.
+	ExecutedTools map[string]AgentItem `json:"executed_tools,omitempty"`
+	// ToolkitState holds the exported state of each toolkit session by
+	// toolkit position; entries are null for sessions without exportable
+	// state.
+	ToolkitState []json.RawMessage `json:"toolkit_state,omitempty"`
+}
+
+// ExportableToolkitSession is implemented by toolkit sessions whose state
+// should survive Export/RestoreSession round trips.
+type ExportableToolkitSession interface {
+	// ExportState returns the session state as JSON.
+	ExportState() (json.RawMessage, error)
+	// RestoreState applies previously exported state to a fresh session.
+	RestoreState(state json.RawMessage) error
+}
+
+// Export serializes the session transcript, recorded tool executions, and
+// exportable toolkit session state as stable JSON.
+func (s *RunSession[C]) Export() ([]byte, error) {
+	export := SessionExport{
+		Version:   sessionExportVersion,
+		SessionID: s.id,
+		AgentName: s.params.Name,
+	}
+
+	s.sendMu.Lock()
+	export.Items = slices.Clone(s.transcript)
+	s.sendMu.Unlock()
+
+	s.executedToolsMu.RLock()
+	if len(s.executedTools) > 0 {
+		export.ExecutedTools = make(map[string]AgentItem, len(s.executedTools))
+		for key, item := range s.executedTools {
+			export.ExecutedTools[key] = item
+		}
+	}
+	s.executedToolsMu.RUnlock()
+
+	for _, toolkitSession := range s.toolkitSessions {
+		var state json.RawMessage
+		if exportable, ok := toolkitSession.(ExportableToolkitSession); ok {
+			exported, err := exportable.ExportState()
+			if err != nil {
+				return nil, fmt.Errorf("failed to export toolkit session state: %w", err)
+			}
+			state = exported
+		}
+		export.ToolkitState = append(export.ToolkitState, state)
+	}
+
+	return json.Marshal(export)
+}
+
+// RestoreSession creates an initialized session from exported data. The
+// params must describe the same agent configuration the session was exported
+// with; toolkit sessions are re-created through the configured toolkits and
+// their exported state is restored by position.
+func RestoreSession[C any](ctx context.Context, params *AgentParams[C], contextVal C, data []byte) (*RunSession[C], error) {
+	var export SessionExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, NewInitError(fmt.Errorf("invalid session export: %w", err))
+	}
+	if export.Version != sessionExportVersion {
+		return nil, NewInitError(fmt.Errorf("unsupported session export version %d", export.Version))
+	}
+
+	session, err := NewRunSession(ctx, params, contextVal)
+	if err != nil {
+		return nil, err
+	}
+
+	session.id = export.SessionID
+	session.transcript = slices.Clone(export.Items)
+	if len(export.ExecutedTools) > 0 {
+		session.executedToolsMu.Lock()
+		session.executedTools = make(map[string]AgentItem, len(export.ExecutedTools))
+		for key, item := range export.ExecutedTools {
+			session.executedTools[key] = item
+		}
+		session.executedToolsMu.Unlock()
+	}
+
+	for i, state := range export.ToolkitState {
+		if state == nil || i >= len(session.toolkitSessions) {
+			continue
+		}
+		exportable, ok := session.toolkitSessions[i].(ExportableToolkitSession)
+		if !ok {
+			continue
+		}
+		if err := exportable.RestoreState(state); err != nil {
+			_ = session.Close(context.WithoutCancel(ctx))
+			return nil, NewInitError(fmt.Errorf("failed to restore toolkit session state: %w", err))
+		}
+	}
+
+	return session, nil
+}
@@ -0,0 +1,140 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// statefulToolkit is a toolkit whose session carries exportable state.
+type statefulToolkit struct{}
+
+type statefulToolkitSession struct {
+	Notes []string `json:"notes"`
+}
+
+func (tk *statefulToolkit) CreateSession(context.Context, any) (llmagent.ToolkitSession[any], error) {
+	return &statefulToolkitSession{}, nil
+}
+
+func (s *statefulToolkitSession) SystemPrompt() *string            { return nil }
+func (s *statefulToolkitSession) Tools() []llmagent.AgentTool[any] { return nil }
+func (s *statefulToolkitSession) Close(context.Context) error      { return nil }
+func (s *statefulToolkitSession) ExportState() (json.RawMessage, error) {
+	return json.Marshal(s)
+}
+func (s *statefulToolkitSession) RestoreState(state json.RawMessage) error {
+	return json.Unmarshal(state, s)
+}
+
+func TestSessionExportRestoreRoundTrip(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hello there")},
+	}))
+
+	toolkit := &statefulToolkit{}
+	params := &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+		Toolkits: []llmagent.Toolkit[any]{toolkit},
+	}
+
+	session := mustNewRunSession(t, params, nil)
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("hi")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := session.Export()
+	if err != nil {
+		t.Fatalf("failed to export session: %v", err)
+	}
+
+	var export llmagent.SessionExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("export is not valid JSON: %v", err)
+	}
+	if export.AgentName != "assistant" || export.SessionID != session.ID() {
+		t.Errorf("unexpected export metadata %+v", export)
+	}
+
+	restored, err := llmagent.RestoreSession(t.Context(), params, nil, data)
+	if err != nil {
+		t.Fatalf("failed to restore session: %v", err)
+	}
+	defer restored.Close(t.Context())
+
+	if restored.ID() != session.ID() {
+		t.Errorf("expected restored session to keep ID %q, got %q", session.ID(), restored.ID())
+	}
+	history := restored.History()
+	if len(history) != len(session.History()) {
+		t.Fatalf("expected %d history items, got %d", len(session.History()), len(history))
+	}
+	last := history[len(history)-1]
+	if last.Model == nil || len(last.Model.Content) != 1 || last.Model.Content[0].TextPart.Text != "hello there" {
+		t.Errorf("unexpected restored history tail %+v", last)
+	}
+}
+
+func TestSessionExportRestoresToolkitState(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	params := &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+		Toolkits: []llmagent.Toolkit[any]{&statefulToolkit{}},
+	}
+
+	session := mustNewRunSession(t, params, nil)
+	data, err := session.Export()
+	if err != nil {
+		t.Fatalf("failed to export session: %v", err)
+	}
+
+	// Edit the exported toolkit state to prove Restore applies it.
+	var export llmagent.SessionExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatal(err)
+	}
+	if len(export.ToolkitState) != 1 {
+		t.Fatalf("expected one toolkit state entry, got %+v", export.ToolkitState)
+	}
+	export.ToolkitState[0] = json.RawMessage(`{"notes":["remember this"]}`)
+	data, err = json.Marshal(export)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := llmagent.RestoreSession(t.Context(), params, nil, data)
+	if err != nil {
+		t.Fatalf("failed to restore session: %v", err)
+	}
+	defer restored.Close(t.Context())
+
+	roundTrip, err := restored.Export()
+	if err != nil {
+		t.Fatalf("failed to re-export session: %v", err)
+	}
+	var reExport llmagent.SessionExport
+	if err := json.Unmarshal(roundTrip, &reExport); err != nil {
+		t.Fatal(err)
+	}
+	if string(reExport.ToolkitState[0]) != `{"notes":["remember this"]}` {
+		t.Errorf("expected restored toolkit state, got %s", reExport.ToolkitState[0])
+	}
+}
+
+func TestRestoreSessionRejectsUnknownVersion(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	params := &llmagent.AgentParams[any]{Name: "assistant", Model: model, MaxTurns: 2}
+
+	if _, err := llmagent.RestoreSession(t.Context(), params, nil, []byte(`{"version":99}`)); err == nil {
+		t.Error("expected an error for an unsupported export version")
+	}
+}
@@ -0,0 +1,229 @@
+// Package fetchtool provides an HTTP fetch tool for agents.
+//
+// The http_fetch tool performs GET and POST requests against an optional
+// domain allowlist, bounds the response body by a size limit, and extracts
+// HTML responses to markdown so the model receives readable page content
+// instead of raw markup.
+package fetchtool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+const (
+	// defaultMaxBodySize bounds response bodies when no limit is configured.
+	defaultMaxBodySize = 1 << 20 // 1 MiB
+	// defaultTimeout bounds requests when no client is configured.
+	defaultTimeout = 30 * time.Second
+)
+
+// Options configures the http_fetch tool.
+type Options struct {
+	// AllowedDomains lists the hosts that may be fetched. A request is
+	// allowed when its hostname equals an entry or is a subdomain of it. An
+	// empty list allows every host.
+	AllowedDomains []string
+	// MaxBodySize bounds the response body in bytes. Zero defaults to 1 MiB.
+	MaxBodySize int64
+	// Client is the HTTP client used for requests. Defaults to a client
+	// with a 30 second timeout.
+	Client *http.Client
+}
+
+func (o Options) maxBodySize() int64 {
+	if o.MaxBodySize <= 0 {
+		return defaultMaxBodySize
+	}
+	return o.MaxBodySize
+}
+
+func (o Options) client() *http.Client {
+	base := o.Client
+	if base == nil {
+		base = &http.Client{Timeout: defaultTimeout}
+	}
+	// Copy the client so the caller's is not mutated, and re-run the scheme
+	// and allowlist checks on every redirect hop: an allowed domain must not
+	// be able to redirect the tool to a disallowed host.
+	client := *base
+	next := base.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return fmt.Errorf("unsupported scheme %q", req.URL.Scheme)
+		}
+		if err := o.allowed(req.URL.Hostname()); err != nil {
+			return err
+		}
+		if next != nil {
+			return next(req, via)
+		}
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &client
+}
+
+// allowed reports whether the hostname passes the domain allowlist.
+func (o Options) allowed(host string) error {
+	if len(o.AllowedDomains) == 0 {
+		return nil
+	}
+	for _, domain := range o.AllowedDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q is not in the allowed domains", host)
+}
+
+// FetchTool performs HTTP requests as the "http_fetch" function tool.
+type FetchTool[C any] struct {
+	options Options
+}
+
+// NewFetchTool creates the http_fetch tool.
+func NewFetchTool[C any](options Options) *FetchTool[C] {
+	return &FetchTool[C]{options: options}
+}
+
+func (t *FetchTool[C]) Name() string { return "http_fetch" }
+
+func (t *FetchTool[C]) Description() string {
+	return "Fetch a URL over HTTP. HTML responses are converted to markdown; other text responses are returned as-is."
+}
+
+func (t *FetchTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"url": map[string]any{
+				"type":        "string",
+				"description": "The URL to fetch.",
+			},
+			"method": map[string]any{
+				"type":        "string",
+				"enum":        []string{http.MethodGet, http.MethodPost},
+				"description": "The HTTP method. Defaults to GET.",
+			},
+			"body": map[string]any{
+				"type":        "string",
+				"description": "The request body for POST requests.",
+			},
+			"content_type": map[string]any{
+				"type":        "string",
+				"description": "The Content-Type of the request body, e.g. \"application/json\".",
+			},
+		},
+		"required":             []string{"url"},
+		"additionalProperties": false,
+	}
+}
+
+type fetchArgs struct {
+	URL         string `json:"url"`
+	Method      string `json:"method"`
+	Body        string `json:"body"`
+	ContentType string `json:"content_type"`
+}
+
+func (t *FetchTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args fetchArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+
+	parsed, err := url.Parse(args.URL)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return errorResult(fmt.Errorf("unsupported scheme %q", parsed.Scheme)), nil
+	}
+	if err := t.options.allowed(parsed.Hostname()); err != nil {
+		return errorResult(err), nil
+	}
+
+	method := args.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	if method != http.MethodGet && method != http.MethodPost {
+		return errorResult(fmt.Errorf("unsupported method %q", method)), nil
+	}
+
+	var body io.Reader
+	if args.Body != "" {
+		body = strings.NewReader(args.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, args.URL, body)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	if args.ContentType != "" {
+		req.Header.Set("Content-Type", args.ContentType)
+	}
+
+	resp, err := t.options.client().Do(req)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	defer resp.Body.Close()
+
+	limit := t.options.maxBodySize()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return errorResult(err), nil
+	}
+	truncated := int64(len(data)) > limit
+	if truncated {
+		data = data[:limit]
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	text := string(data)
+	if strings.Contains(contentType, "text/html") {
+		if markdown, err := htmlToMarkdown(strings.NewReader(text)); err == nil {
+			text = markdown
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "HTTP %d", resp.StatusCode)
+	if contentType != "" {
+		fmt.Fprintf(&b, " (%s)", contentType)
+	}
+	b.WriteString("\n\n")
+	b.WriteString(text)
+	if truncated {
+		fmt.Fprintf(&b, "\n[truncated at %d bytes]", limit)
+	}
+
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(b.String())},
+		IsError: resp.StatusCode >= 400,
+	}, nil
+}
+
+func errorResult(err error) llmagent.AgentToolResult {
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(err.Error())},
+		IsError: true,
+	}
+}
+
+// Tools returns the agent tools for HTTP fetching.
+func Tools[C any](options Options) []llmagent.AgentTool[C] {
+	return llmagent.FunctionTools[C](NewFetchTool[C](options))
+}
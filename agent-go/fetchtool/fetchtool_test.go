@@ -0,0 +1,148 @@
+package fetchtool_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/fetchtool"
+)
+
+func execute(t *testing.T, tool *fetchtool.FetchTool[struct{}], args any) llmagent.AgentToolResult {
+	t.Helper()
+	params, err := json.Marshal(args)
+	if err != nil {
+		t.Fatalf("failed to marshal args: %v", err)
+	}
+	result, err := tool.Execute(t.Context(), params, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected execute error: %v", err)
+	}
+	return result
+}
+
+func resultText(t *testing.T, result llmagent.AgentToolResult) string {
+	t.Helper()
+	if len(result.Content) != 1 || result.Content[0].TextPart == nil {
+		t.Fatalf("expected a single text part, got %+v", result.Content)
+	}
+	return result.Content[0].TextPart.Text
+}
+
+func TestFetchConvertsHTMLToMarkdown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, `<html><head><script>evil()</script></head><body>
+			<h1>Title</h1>
+			<p>Some <strong>bold</strong> text and a <a href="https://example.com">link</a>.</p>
+			<ul><li>one</li><li>two</li></ul>
+		</body></html>`)
+	}))
+	defer server.Close()
+
+	tool := fetchtool.NewFetchTool[struct{}](fetchtool.Options{})
+	result := execute(t, tool, map[string]string{"url": server.URL})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %+v", result)
+	}
+
+	text := resultText(t, result)
+	for _, want := range []string{
+		"HTTP 200",
+		"# Title",
+		"Some **bold** text and a [link](https://example.com).",
+		"- one\n- two",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "evil") {
+		t.Errorf("expected script content to be dropped, got:\n%s", text)
+	}
+}
+
+func TestFetchPostSendsBody(t *testing.T) {
+	var gotMethod, gotContentType, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotContentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	tool := fetchtool.NewFetchTool[struct{}](fetchtool.Options{})
+	result := execute(t, tool, map[string]string{
+		"url":          server.URL,
+		"method":       "POST",
+		"body":         `{"q":"hello"}`,
+		"content_type": "application/json",
+	})
+
+	if gotMethod != http.MethodPost || gotContentType != "application/json" || gotBody != `{"q":"hello"}` {
+		t.Errorf("unexpected request: method=%q content-type=%q body=%q", gotMethod, gotContentType, gotBody)
+	}
+	if !strings.Contains(resultText(t, result), `{"ok":true}`) {
+		t.Errorf("expected JSON body in output, got %q", resultText(t, result))
+	}
+}
+
+func TestFetchEnforcesDomainAllowlist(t *testing.T) {
+	tool := fetchtool.NewFetchTool[struct{}](fetchtool.Options{
+		AllowedDomains: []string{"example.com"},
+	})
+
+	result := execute(t, tool, map[string]string{"url": "https://evil.test/steal"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "allowed domains") {
+		t.Errorf("expected allowlist rejection, got %+v", result)
+	}
+}
+
+func TestFetchEnforcesAllowlistOnRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://metadata.internal/latest", http.StatusFound)
+	}))
+	defer server.Close()
+
+	tool := fetchtool.NewFetchTool[struct{}](fetchtool.Options{
+		AllowedDomains: []string{"127.0.0.1"},
+	})
+
+	result := execute(t, tool, map[string]string{"url": server.URL})
+	if !result.IsError || !strings.Contains(resultText(t, result), "allowed domains") {
+		t.Errorf("expected allowlist rejection on redirect, got %+v", result)
+	}
+}
+
+func TestFetchTruncatesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, strings.Repeat("a", 64))
+	}))
+	defer server.Close()
+
+	tool := fetchtool.NewFetchTool[struct{}](fetchtool.Options{MaxBodySize: 16})
+	text := resultText(t, execute(t, tool, map[string]string{"url": server.URL}))
+	if !strings.Contains(text, strings.Repeat("a", 16)+"\n[truncated at 16 bytes]") {
+		t.Errorf("expected truncated body, got %q", text)
+	}
+}
+
+func TestFetchReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tool := fetchtool.NewFetchTool[struct{}](fetchtool.Options{})
+	result := execute(t, tool, map[string]string{"url": server.URL})
+	if !result.IsError || !strings.Contains(resultText(t, result), "HTTP 404") {
+		t.Errorf("expected error result with status, got %+v", result)
+	}
+}
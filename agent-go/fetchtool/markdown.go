@@ -0,0 +1,130 @@
+package fetchtool
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlToMarkdown extracts the readable content of an HTML document as
+// markdown. It is intentionally small: headings, paragraphs, links, lists,
+// emphasis, and code are converted; scripts, styles, and markup it does not
+// know are dropped while their text is kept.
+func htmlToMarkdown(r io.Reader) (string, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	renderMarkdown(&b, doc, false)
+	return tidyMarkdown(b.String()), nil
+}
+
+var (
+	markdownSpaces   = regexp.MustCompile(`[ \t]+`)
+	markdownNewlines = regexp.MustCompile(`\n{3,}`)
+)
+
+// tidyMarkdown collapses the whitespace runs left behind by dropped markup.
+func tidyMarkdown(s string) string {
+	lines := strings.Split(markdownSpaces.ReplaceAllString(s, " "), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(markdownNewlines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+func renderMarkdown(b *strings.Builder, node *html.Node, preformatted bool) {
+	switch node.Type {
+	case html.TextNode:
+		if preformatted {
+			b.WriteString(node.Data)
+		} else {
+			b.WriteString(strings.ReplaceAll(node.Data, "\n", " "))
+		}
+		return
+	case html.ElementNode:
+		switch node.Data {
+		case "script", "style", "noscript", "template", "head":
+			return
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			b.WriteString("\n\n" + strings.Repeat("#", int(node.Data[1]-'0')) + " ")
+			renderChildren(b, node, preformatted)
+			b.WriteString("\n\n")
+			return
+		case "p", "div", "section", "article", "main", "header", "footer", "table", "tr", "blockquote":
+			b.WriteString("\n\n")
+			renderChildren(b, node, preformatted)
+			b.WriteString("\n\n")
+			return
+		case "br":
+			b.WriteString("\n")
+			return
+		case "li":
+			b.WriteString("\n- ")
+			renderChildren(b, node, preformatted)
+			return
+		case "ul", "ol":
+			renderChildren(b, node, preformatted)
+			b.WriteString("\n\n")
+			return
+		case "a":
+			href := attrValue(node, "href")
+			if href == "" {
+				renderChildren(b, node, preformatted)
+				return
+			}
+			b.WriteString("[")
+			renderChildren(b, node, preformatted)
+			b.WriteString("](" + href + ")")
+			return
+		case "img":
+			if alt := attrValue(node, "alt"); alt != "" {
+				b.WriteString("![" + alt + "](" + attrValue(node, "src") + ")")
+			}
+			return
+		case "strong", "b":
+			b.WriteString("**")
+			renderChildren(b, node, preformatted)
+			b.WriteString("**")
+			return
+		case "em", "i":
+			b.WriteString("*")
+			renderChildren(b, node, preformatted)
+			b.WriteString("*")
+			return
+		case "code":
+			if preformatted {
+				renderChildren(b, node, preformatted)
+				return
+			}
+			b.WriteString("`")
+			renderChildren(b, node, preformatted)
+			b.WriteString("`")
+			return
+		case "pre":
+			b.WriteString("\n\n```\n")
+			renderChildren(b, node, true)
+			b.WriteString("\n```\n\n")
+			return
+		}
+	}
+	renderChildren(b, node, preformatted)
+}
+
+func renderChildren(b *strings.Builder, node *html.Node, preformatted bool) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		renderMarkdown(b, child, preformatted)
+	}
+}
+
+func attrValue(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package llmagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// followUpPrompt instructs the suggestion model to answer with nothing but the
+// JSON array of questions.
+const followUpPrompt = "You suggest follow-up messages for a chat conversation. Given the transcript, " +
+	"reply with a JSON array of %d short questions the user is likely to ask next, written in the " +
+	"user's voice. Reply with only the JSON array."
+
+// defaultFollowUpCount is the number of suggestions requested when no explicit
+// value is configured.
+const defaultFollowUpCount = 3
+
+// FollowUpParams configure suggested follow-up questions. After a streamed run
+// completes, the model produces follow-up prompts from the final transcript
+// and the session emits them as a follow_up stream event, so chat UIs can
+// offer quick replies without implementing their own suggestion step.
+type FollowUpParams struct {
+	// Model used to produce the suggestions. Nil uses the session model; chat
+	// deployments typically point this at a cheap model.
+	Model llmsdk.LanguageModel
+	// Count is the number of suggestions requested. Zero uses a default of 3.
+	Count int
+}
+
+// followUpEvent produces the follow_up stream event for a finished run.
+// Suggestions are a nicety: generation failures and unparseable output return
+// nil rather than failing a run that already completed.
+func (s *RunSession[C]) followUpEvent(ctx context.Context, state *RunState, status AgentResponseStatus) *AgentStreamEvent {
+	params := s.params.FollowUp
+	if params == nil || status != AgentResponseStatusCompleted {
+		return nil
+	}
+
+	count := params.Count
+	if count <= 0 {
+		count = defaultFollowUpCount
+	}
+	model := params.Model
+	if model == nil {
+		model = s.model
+	}
+
+	response, err := model.Generate(ctx, &llmsdk.LanguageModelInput{
+		SystemPrompt: ptr.To(fmt.Sprintf(followUpPrompt, count)),
+		Messages: []llmsdk.Message{
+			llmsdk.NewUserMessage(llmsdk.NewTextPart(renderItemsForSummary(state.Items()))),
+		},
+		ResponseFormat: llmsdk.NewResponseFormatJSON("follow_up_questions", nil, &llmsdk.JSONSchema{
+			"type":     "array",
+			"items":    map[string]any{"type": "string"},
+			"maxItems": count,
+		}),
+	})
+	if err != nil {
+		return nil
+	}
+	state.recordUsage(response)
+
+	var suggestions []string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(partsToText(response.Content))), &suggestions); err != nil {
+		return nil
+	}
+	if len(suggestions) > count {
+		suggestions = suggestions[:count]
+	}
+	if len(suggestions) == 0 {
+		return nil
+	}
+	return &AgentStreamEvent{FollowUp: &AgentStreamFollowUpEvent{Suggestions: suggestions}}
+}
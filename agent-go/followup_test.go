@@ -0,0 +1,117 @@
+package llmagent_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func runFollowUpStream(t *testing.T, session *llmagent.RunSession[struct{}]) []*llmagent.AgentStreamEvent {
+	t.Helper()
+	stream, err := session.RunStream(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Tell me about Mars"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("create stream: %v", err)
+	}
+	var events []*llmagent.AgentStreamEvent
+	for stream.Next() {
+		events = append(events, stream.Current())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	return events
+}
+
+func TestFollowUpSuggestionsEmittedBeforeResponse(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("Mars is the fourth planet.")}},
+	}))
+	followUpModel := llmsdktest.NewMockLanguageModel()
+	followUpModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(`["How long is a day on Mars?", "Has Mars ever had water?"]`)},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+		FollowUp: &llmagent.FollowUpParams{Model: followUpModel, Count: 2},
+	}, struct{}{})
+
+	events := runFollowUpStream(t, session)
+	followUpIndex := slices.IndexFunc(events, func(e *llmagent.AgentStreamEvent) bool { return e.FollowUp != nil })
+	if followUpIndex < 0 {
+		t.Fatal("expected a follow_up event in the stream")
+	}
+	suggestions := events[followUpIndex].FollowUp.Suggestions
+	if len(suggestions) != 2 || suggestions[0] != "How long is a day on Mars?" {
+		t.Errorf("unexpected suggestions: %v", suggestions)
+	}
+	if events[len(events)-1].Response == nil {
+		t.Error("expected the response event to stay last in the stream")
+	}
+
+	inputs := followUpModel.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected one suggestion model call, got %d", len(inputs))
+	}
+	prompt := inputs[0].Messages[0].UserMessage.Content[0].TextPart.Text
+	if !strings.Contains(prompt, "fourth planet") {
+		t.Errorf("expected the final transcript in the suggestion prompt, got %q", prompt)
+	}
+}
+
+func TestFollowUpSuggestionFailureDoesNotFailRun(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("Mars is the fourth planet.")}},
+	}))
+	followUpModel := llmsdktest.NewMockLanguageModel()
+	followUpModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultError(errors.New("suggestion model is down")))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+		FollowUp: &llmagent.FollowUpParams{Model: followUpModel},
+	}, struct{}{})
+
+	events := runFollowUpStream(t, session)
+	for _, event := range events {
+		if event.FollowUp != nil {
+			t.Fatal("expected no follow_up event when the suggestion call fails")
+		}
+	}
+	if events[len(events)-1].Response == nil {
+		t.Error("expected the run to complete despite the failed suggestion call")
+	}
+}
+
+func TestFollowUpDisabledByDefault(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("Mars is the fourth planet.")}},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+	}, struct{}{})
+
+	for _, event := range runFollowUpStream(t, session) {
+		if event.FollowUp != nil {
+			t.Fatal("expected no follow_up event without FollowUp params")
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package llmagent_test
+
+import (
+	"context"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func textResponse(text string) llmsdktest.MockGenerateResult {
+	return llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(text)},
+	})
+}
+
+func TestFork_BranchesWithoutAffectingOriginal(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		textResponse("first answer"),
+		textResponse("original continuation"),
+		textResponse("forked continuation"),
+	)
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+	}, nil)
+
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("first question")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	baseLen := len(session.History())
+
+	fork, err := session.Fork(t.Context(), baseLen)
+	if err != nil {
+		t.Fatalf("failed to fork: %v", err)
+	}
+	defer fork.Close(context.Background())
+
+	if fork.ID() == session.ID() {
+		t.Error("expected the fork to have its own session ID")
+	}
+
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("original follow-up")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fork.Send(t.Context(), llmsdk.NewTextPart("what if I had asked differently?")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	original := session.History()
+	branched := fork.History()
+	if len(original) != baseLen+2 || len(branched) != baseLen+2 {
+		t.Fatalf("unexpected history lengths %d and %d", len(original), len(branched))
+	}
+
+	lastOriginal := original[len(original)-1].Model
+	lastBranched := branched[len(branched)-1].Model
+	if lastOriginal == nil || lastOriginal.Content[0].TextPart.Text != "original continuation" {
+		t.Errorf("unexpected original tail %+v", lastOriginal)
+	}
+	if lastBranched == nil || lastBranched.Content[0].TextPart.Text != "forked continuation" {
+		t.Errorf("unexpected fork tail %+v", lastBranched)
+	}
+}
+
+func TestFork_AtEarlierIndexDropsLaterItems(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(textResponse("answer"))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+	}, nil)
+
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("question")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fork, err := session.Fork(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("failed to fork: %v", err)
+	}
+	defer fork.Close(context.Background())
+
+	history := fork.History()
+	if len(history) != 1 || history[0].Message == nil {
+		t.Fatalf("expected only the user message in the fork, got %+v", history)
+	}
+	if len(session.History()) != 2 {
+		t.Error("expected the original transcript unchanged")
+	}
+}
+
+func TestFork_IndexOutOfRange(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+	}, nil)
+
+	if _, err := session.Fork(t.Context(), 1); err == nil {
+		t.Error("expected an error for an out-of-range index")
+	}
+	if _, err := session.Fork(t.Context(), -1); err == nil {
+		t.Error("expected an error for a negative index")
+	}
+}
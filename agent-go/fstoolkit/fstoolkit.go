@@ -0,0 +1,91 @@
+// Package fstoolkit provides a filesystem toolkit for agents.
+//
+// The toolkit exposes read_file, write_file, and list_dir tools that are
+// jailed to a configured root directory: every path the model supplies is
+// resolved relative to the root and rejected if it escapes it, including
+// through symlinks. Reads and writes are bounded by a size limit, and glob
+// ignore rules hide files (such as secrets) from every tool.
+package fstoolkit
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// defaultMaxFileSize bounds reads and writes when no limit is configured.
+	defaultMaxFileSize = 1 << 20 // 1 MiB
+)
+
+// Options configures the filesystem toolkit.
+type Options struct {
+	// Root is the directory the tools are confined to. Required.
+	Root string
+	// MaxFileSize bounds the size of reads and writes in bytes.
+	// Zero defaults to 1 MiB.
+	MaxFileSize int64
+	// Ignore lists glob patterns (path.Match syntax, matched against the
+	// slash-separated path relative to the root and against each path
+	// segment) that are hidden from every tool, e.g. ".env" or "*.pem".
+	Ignore []string
+	// ReadOnly disables the write_file tool.
+	ReadOnly bool
+}
+
+func (o Options) maxFileSize() int64 {
+	if o.MaxFileSize <= 0 {
+		return defaultMaxFileSize
+	}
+	return o.MaxFileSize
+}
+
+// PathError reports a path that is outside the jail or hidden by the ignore
+// rules. It is returned to the model as a failed tool result.
+type PathError struct {
+	Path   string
+	Reason string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("path %q is not allowed: %s", e.Path, e.Reason)
+}
+
+// resolve turns a model-supplied path into an absolute path inside the root,
+// rejecting escapes and ignored paths. It returns the absolute path and the
+// slash-separated path relative to the root.
+func (o Options) resolve(relPath string) (string, string, error) {
+	cleaned := path.Clean("/" + filepath.ToSlash(relPath))
+	rel := strings.TrimPrefix(cleaned, "/")
+	abs := filepath.Join(o.Root, filepath.FromSlash(rel))
+
+	// Re-check the relationship after joining: on success abs is the root
+	// itself or a descendant of it.
+	if relCheck, err := filepath.Rel(o.Root, abs); err != nil || relCheck == ".." || strings.HasPrefix(relCheck, ".."+string(filepath.Separator)) {
+		return "", "", &PathError{Path: relPath, Reason: "outside the root directory"}
+	}
+	if o.ignored(rel) {
+		return "", "", &PathError{Path: relPath, Reason: "matches an ignore rule"}
+	}
+	return abs, rel, nil
+}
+
+// ignored reports whether the relative slash-separated path matches any
+// ignore pattern, either as a whole or in one of its segments.
+func (o Options) ignored(rel string) bool {
+	if rel == "" {
+		return false
+	}
+	for _, pattern := range o.Ignore {
+		if ok, err := path.Match(pattern, rel); err == nil && ok {
+			return true
+		}
+		for _, segment := range strings.Split(rel, "/") {
+			if ok, err := path.Match(pattern, segment); err == nil && ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package fstoolkit_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/fstoolkit"
+)
+
+func newSession(t *testing.T, options fstoolkit.Options) llmagent.ToolkitSession[struct{}] {
+	t.Helper()
+	toolkit, err := fstoolkit.New[struct{}](options)
+	if err != nil {
+		t.Fatalf("failed to create toolkit: %v", err)
+	}
+	session, err := toolkit.CreateSession(t.Context(), struct{}{})
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := session.Close(t.Context()); err != nil {
+			t.Errorf("failed to close session: %v", err)
+		}
+	})
+	return session
+}
+
+func findTool(t *testing.T, session llmagent.ToolkitSession[struct{}], name string) llmagent.AgentFunctionTool[struct{}] {
+	t.Helper()
+	for _, tool := range session.Tools() {
+		if tool.Name() == name {
+			return tool.AsFunctionTool()
+		}
+	}
+	t.Fatalf("tool %q not found", name)
+	return nil
+}
+
+func execute(t *testing.T, tool llmagent.AgentFunctionTool[struct{}], args any) llmagent.AgentToolResult {
+	t.Helper()
+	params, err := json.Marshal(args)
+	if err != nil {
+		t.Fatalf("failed to marshal args: %v", err)
+	}
+	result, err := tool.Execute(t.Context(), params, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected execute error: %v", err)
+	}
+	return result
+}
+
+func resultText(t *testing.T, result llmagent.AgentToolResult) string {
+	t.Helper()
+	if len(result.Content) != 1 || result.Content[0].TextPart == nil {
+		t.Fatalf("expected a single text part, got %+v", result.Content)
+	}
+	return result.Content[0].TextPart.Text
+}
+
+func TestReadWriteListRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	session := newSession(t, fstoolkit.Options{Root: root})
+
+	write := execute(t, findTool(t, session, "write_file"), map[string]string{
+		"path":    "notes/hello.txt",
+		"content": "hello world",
+	})
+	if write.IsError {
+		t.Fatalf("unexpected write error: %+v", write)
+	}
+
+	read := execute(t, findTool(t, session, "read_file"), map[string]string{
+		"path": "notes/hello.txt",
+	})
+	if read.IsError {
+		t.Fatalf("unexpected read error: %+v", read)
+	}
+	if got := resultText(t, read); got != "hello world" {
+		t.Errorf("unexpected file content %q", got)
+	}
+
+	list := execute(t, findTool(t, session, "list_dir"), map[string]string{
+		"path": ".",
+	})
+	if got := resultText(t, list); got != "notes/" {
+		t.Errorf("unexpected root listing %q", got)
+	}
+}
+
+func TestRejectsPathsOutsideRoot(t *testing.T) {
+	root := t.TempDir()
+	secret := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(secret, []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	session := newSession(t, fstoolkit.Options{Root: root})
+	readFile := findTool(t, session, "read_file")
+
+	for _, path := range []string{"../secret.txt", "../../etc/passwd", "/etc/passwd"} {
+		result := execute(t, readFile, map[string]string{"path": path})
+		if !result.IsError && strings.Contains(resultText(t, result), "secret") {
+			t.Errorf("path %q escaped the root: %+v", path, result)
+		}
+	}
+
+	// A symlink inside the root must not provide a way out either.
+	if err := os.Symlink(secret, filepath.Join(root, "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+	result := execute(t, readFile, map[string]string{"path": "link.txt"})
+	if !result.IsError {
+		t.Errorf("expected symlink escape to be rejected, got %+v", result)
+	}
+}
+
+func TestWriteRejectsSymlinkEscape(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	target := filepath.Join(outside, "target.txt")
+	if err := os.WriteFile(target, []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	session := newSession(t, fstoolkit.Options{Root: root})
+	writeFile := findTool(t, session, "write_file")
+
+	// A file symlink must not let a write overwrite its target outside.
+	if err := os.Symlink(target, filepath.Join(root, "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+	result := execute(t, writeFile, map[string]string{"path": "link.txt", "content": "overwritten"})
+	if !result.IsError {
+		t.Errorf("expected file symlink write to be rejected, got %+v", result)
+	}
+
+	// A directory symlink must not let a write create files outside.
+	if err := os.Symlink(outside, filepath.Join(root, "dir")); err != nil {
+		t.Fatal(err)
+	}
+	result = execute(t, writeFile, map[string]string{"path": "dir/new.txt", "content": "escaped"})
+	if !result.IsError {
+		t.Errorf("expected directory symlink write to be rejected, got %+v", result)
+	}
+
+	// A dangling symlink must not let a write create its target elsewhere.
+	if err := os.Symlink(filepath.Join(outside, "dangling.txt"), filepath.Join(root, "dangling.txt")); err != nil {
+		t.Fatal(err)
+	}
+	result = execute(t, writeFile, map[string]string{"path": "dangling.txt", "content": "escaped"})
+	if !result.IsError {
+		t.Errorf("expected dangling symlink write to be rejected, got %+v", result)
+	}
+
+	if data, err := os.ReadFile(target); err != nil || string(data) != "original" {
+		t.Errorf("outside target was modified: %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "new.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected no file created outside the root, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "dangling.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected no dangling target created outside the root, got %v", err)
+	}
+}
+
+func TestIgnorePatternsHideFiles(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		".env":      "API_KEY=1",
+		"key.pem":   "---",
+		"readme.md": "hi",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	session := newSession(t, fstoolkit.Options{
+		Root:   root,
+		Ignore: []string{".env", "*.pem"},
+	})
+
+	list := execute(t, findTool(t, session, "list_dir"), map[string]string{"path": "."})
+	if got := resultText(t, list); got != "readme.md" {
+		t.Errorf("expected ignored files to be hidden, got %q", got)
+	}
+
+	read := execute(t, findTool(t, session, "read_file"), map[string]string{"path": ".env"})
+	if !read.IsError {
+		t.Errorf("expected ignored file read to fail, got %+v", read)
+	}
+}
+
+func TestEnforcesSizeLimit(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "big.txt"), []byte(strings.Repeat("a", 32)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	session := newSession(t, fstoolkit.Options{Root: root, MaxFileSize: 16})
+
+	read := execute(t, findTool(t, session, "read_file"), map[string]string{"path": "big.txt"})
+	if !read.IsError || !strings.Contains(resultText(t, read), "limit") {
+		t.Errorf("expected oversized read to fail, got %+v", read)
+	}
+
+	write := execute(t, findTool(t, session, "write_file"), map[string]string{
+		"path":    "out.txt",
+		"content": strings.Repeat("b", 32),
+	})
+	if !write.IsError {
+		t.Errorf("expected oversized write to fail, got %+v", write)
+	}
+}
+
+func TestReadOnlyOmitsWriteFile(t *testing.T) {
+	session := newSession(t, fstoolkit.Options{Root: t.TempDir(), ReadOnly: true})
+	for _, tool := range session.Tools() {
+		if tool.Name() == "write_file" {
+			t.Fatal("expected write_file to be omitted in read-only mode")
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package fstoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// Toolkit exposes the jailed filesystem tools. It implements
+// llmagent.Toolkit.
+type Toolkit[C any] struct {
+	options Options
+}
+
+// New creates a filesystem toolkit confined to options.Root.
+func New[C any](options Options) (*Toolkit[C], error) {
+	if options.Root == "" {
+		return nil, errors.New("fstoolkit: root directory is required")
+	}
+	root, err := filepath.Abs(options.Root)
+	if err != nil {
+		return nil, err
+	}
+	options.Root = root
+	return &Toolkit[C]{options: options}, nil
+}
+
+// CreateSession implements llmagent.Toolkit.
+func (t *Toolkit[C]) CreateSession(_ context.Context, _ C) (llmagent.ToolkitSession[C], error) {
+	return &session[C]{options: t.options}, nil
+}
+
+type session[C any] struct {
+	options Options
+}
+
+func (s *session[C]) SystemPrompt() *string { return nil }
+
+func (s *session[C]) Tools() []llmagent.AgentTool[C] {
+	tools := []llmagent.AgentFunctionTool[C]{
+		&readFileTool[C]{options: s.options},
+		&listDirTool[C]{options: s.options},
+	}
+	if !s.options.ReadOnly {
+		tools = append(tools, &writeFileTool[C]{options: s.options})
+	}
+	return llmagent.FunctionTools(tools...)
+}
+
+func (s *session[C]) Close(context.Context) error { return nil }
+
+// errorResult returns the failed tool result for a jail or IO error so the
+// model can correct the path instead of crashing the run.
+func errorResult(err error) llmagent.AgentToolResult {
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(err.Error())},
+		IsError: true,
+	}
+}
+
+// verifyResolved ensures an existing path does not escape the root through
+// symlinks.
+func verifyResolved(root, abs string) error {
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return err
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return &PathError{Path: abs, Reason: "resolves outside the root directory"}
+	}
+	return nil
+}
+
+// verifyResolvedForWrite ensures the target of a write cannot escape the root
+// through symlinks. The path itself may not exist yet, so the check resolves
+// the deepest existing ancestor — and the target itself when it is a symlink,
+// rejecting dangling links that would create the file elsewhere.
+func verifyResolvedForWrite(root, abs string) error {
+	if info, err := os.Lstat(abs); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := verifyResolved(root, abs); err != nil {
+			return err
+		}
+	}
+	dir := filepath.Dir(abs)
+	for {
+		if _, err := os.Lstat(dir); err == nil {
+			break
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return verifyResolved(root, dir)
+}
+
+type pathArgs struct {
+	Path string `json:"path"`
+}
+
+func pathParameters(description string) llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"path": map[string]any{
+				"type":        "string",
+				"description": description,
+			},
+		},
+		"required":             []string{"path"},
+		"additionalProperties": false,
+	}
+}
+
+type readFileTool[C any] struct {
+	options Options
+}
+
+func (t *readFileTool[C]) Name() string { return "read_file" }
+
+func (t *readFileTool[C]) Description() string {
+	return "Read a text file. The path is relative to the workspace root."
+}
+
+func (t *readFileTool[C]) Parameters() llmsdk.JSONSchema {
+	return pathParameters("The file path relative to the workspace root.")
+}
+
+func (t *readFileTool[C]) Execute(_ context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args pathArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	abs, _, err := t.options.resolve(args.Path)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	if err := verifyResolved(t.options.Root, abs); err != nil {
+		return errorResult(err), nil
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	if info.Size() > t.options.maxFileSize() {
+		return errorResult(fmt.Errorf("file %q is %d bytes, larger than the %d byte limit", args.Path, info.Size(), t.options.maxFileSize())), nil
+	}
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(string(data))},
+	}, nil
+}
+
+type writeFileTool[C any] struct {
+	options Options
+}
+
+type writeFileArgs struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+func (t *writeFileTool[C]) Name() string { return "write_file" }
+
+func (t *writeFileTool[C]) Description() string {
+	return "Write a text file, creating parent directories as needed. The path is relative to the workspace root."
+}
+
+func (t *writeFileTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"path": map[string]any{
+				"type":        "string",
+				"description": "The file path relative to the workspace root.",
+			},
+			"content": map[string]any{
+				"type":        "string",
+				"description": "The full content to write.",
+			},
+		},
+		"required":             []string{"path", "content"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *writeFileTool[C]) Execute(_ context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args writeFileArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	abs, rel, err := t.options.resolve(args.Path)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	if int64(len(args.Content)) > t.options.maxFileSize() {
+		return errorResult(fmt.Errorf("content is %d bytes, larger than the %d byte limit", len(args.Content), t.options.maxFileSize())), nil
+	}
+	if err := verifyResolvedForWrite(t.options.Root, abs); err != nil {
+		return errorResult(err), nil
+	}
+	if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+		return errorResult(err), nil
+	}
+	if err := os.WriteFile(abs, []byte(args.Content), 0o644); err != nil {
+		return errorResult(err), nil
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(fmt.Sprintf("wrote %d bytes to %s", len(args.Content), rel))},
+	}, nil
+}
+
+type listDirTool[C any] struct {
+	options Options
+}
+
+func (t *listDirTool[C]) Name() string { return "list_dir" }
+
+func (t *listDirTool[C]) Description() string {
+	return "List the entries of a directory. The path is relative to the workspace root; use \".\" for the root itself."
+}
+
+func (t *listDirTool[C]) Parameters() llmsdk.JSONSchema {
+	return pathParameters("The directory path relative to the workspace root.")
+}
+
+func (t *listDirTool[C]) Execute(_ context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args pathArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	abs, rel, err := t.options.resolve(args.Path)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	if err := verifyResolved(t.options.Root, abs); err != nil {
+		return errorResult(err), nil
+	}
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return errorResult(err), nil
+	}
+
+	var lines []string
+	for _, entry := range entries {
+		entryRel := entry.Name()
+		if rel != "" {
+			entryRel = rel + "/" + entry.Name()
+		}
+		if t.options.ignored(entryRel) {
+			continue
+		}
+		if entry.IsDir() {
+			lines = append(lines, entry.Name()+"/")
+		} else {
+			lines = append(lines, entry.Name())
+		}
+	}
+	sort.Strings(lines)
+	if len(lines) == 0 {
+		return llmagent.AgentToolResult{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("(empty)")},
+		}, nil
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(strings.Join(lines, "\n"))},
+	}, nil
+}
@@ -0,0 +1,241 @@
+// Package grpcservice embeds an Agent in a gRPC server. The service is
+// defined in agent.proto (llmagent.v1.AgentService) and carries run payloads
+// as JSON strings in the agent-go wire format, so the hand-written bindings
+// here stay in lockstep with the SDK types without a protoc toolchain. The
+// server registers a "json" codec; clients dial with the
+// application/grpc+json content subtype.
+package grpcservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceName is the fully qualified gRPC service name from agent.proto.
+const ServiceName = "llmagent.v1.AgentService"
+
+// CodecName is the gRPC content subtype used by this service. Clients dial
+// with grpc.CallContentSubtype(CodecName).
+const CodecName = "json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec marshals gRPC messages with encoding/json, matching the protojson
+// encoding of agent.proto.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return CodecName }
+
+// RunAgentRequest mirrors llmagent.v1.RunAgentRequest.
+type RunAgentRequest struct {
+	// Input items for the run, each JSON-encoded in the AgentItem format.
+	Input []string `json:"input,omitempty"`
+	// Context is an opaque JSON value decoded into the agent context.
+	Context string `json:"context,omitempty"`
+	// Sampling overrides agent-level sampling defaults for this run.
+	Sampling *llmagent.SamplingOverrides `json:"sampling,omitempty"`
+}
+
+// RunAgentResponse mirrors llmagent.v1.RunAgentResponse.
+type RunAgentResponse struct {
+	// Response is the JSON-encoded llmagent.AgentResponse.
+	Response string `json:"response,omitempty"`
+}
+
+// AgentStreamEvent mirrors llmagent.v1.AgentStreamEvent.
+type AgentStreamEvent struct {
+	// Event is the JSON-encoded llmagent.AgentStreamEvent.
+	Event string `json:"event,omitempty"`
+}
+
+// ContextDecoder decodes the request context JSON into the agent context
+// value. The default decoder unmarshals the JSON directly into C.
+type ContextDecoder[C any] func(ctx context.Context, data json.RawMessage) (C, error)
+
+// ServerOption customises the gRPC agent server.
+type ServerOption[C any] func(*Server[C])
+
+// WithContextDecoder overrides how the request context JSON is decoded.
+func WithContextDecoder[C any](decoder ContextDecoder[C]) ServerOption[C] {
+	return func(s *Server[C]) {
+		s.decodeContext = decoder
+	}
+}
+
+// Server implements llmagent.v1.AgentService for an Agent.
+type Server[C any] struct {
+	agent         *llmagent.Agent[C]
+	decodeContext ContextDecoder[C]
+}
+
+// NewServer wraps the agent in a gRPC service implementation.
+func NewServer[C any](agent *llmagent.Agent[C], options ...ServerOption[C]) *Server[C] {
+	s := &Server[C]{agent: agent}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Register adds the agent service to a gRPC server or other service
+// registrar.
+func (s *Server[C]) Register(registrar grpc.ServiceRegistrar) {
+	registrar.RegisterService(&serviceDesc, s)
+}
+
+// agentService is the untyped handler surface used by the service
+// descriptor; Server[C] implements it for any C.
+type agentService interface {
+	runAgent(ctx context.Context, req *RunAgentRequest) (*RunAgentResponse, error)
+	runAgentStream(req *RunAgentRequest, stream grpc.ServerStream) error
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*agentService)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RunAgent",
+			Handler:    runAgentHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunAgentStream",
+			Handler:       runAgentStreamHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "agent.proto",
+}
+
+func runAgentHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(RunAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(agentService).runAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/RunAgent",
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(agentService).runAgent(ctx, req.(*RunAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func runAgentStreamHandler(srv any, stream grpc.ServerStream) error {
+	in := new(RunAgentRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(agentService).runAgentStream(in, stream)
+}
+
+func (s *Server[C]) runAgent(ctx context.Context, req *RunAgentRequest) (*RunAgentResponse, error) {
+	agentReq, err := s.toAgentRequest(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := s.agent.Run(ctx, agentReq)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("encode agent response: %v", err))
+	}
+	return &RunAgentResponse{Response: string(data)}, nil
+}
+
+func (s *Server[C]) runAgentStream(req *RunAgentRequest, stream grpc.ServerStream) error {
+	ctx := stream.Context()
+	agentReq, err := s.toAgentRequest(ctx, req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	agentStream, err := s.agent.RunStream(ctx, agentReq)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for agentStream.Next() {
+		event := agentStream.Current()
+		data, err := json.Marshal(event)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("encode stream event: %v", err))
+		}
+		if err := stream.SendMsg(&AgentStreamEvent{Event: string(data)}); err != nil {
+			return err
+		}
+	}
+
+	if err := agentStream.Err(); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// toAgentRequest decodes the wire request into an agent request.
+func (s *Server[C]) toAgentRequest(ctx context.Context, req *RunAgentRequest) (llmagent.AgentRequest[C], error) {
+	items := make([]llmagent.AgentItem, 0, len(req.Input))
+	for i, raw := range req.Input {
+		var item llmagent.AgentItem
+		if err := json.Unmarshal([]byte(raw), &item); err != nil {
+			return llmagent.AgentRequest[C]{}, fmt.Errorf("decode input item %d: %w", i, err)
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return llmagent.AgentRequest[C]{}, fmt.Errorf("input must not be empty")
+	}
+
+	var contextVal C
+	if req.Context != "" {
+		if s.decodeContext != nil {
+			decoded, err := s.decodeContext(ctx, json.RawMessage(req.Context))
+			if err != nil {
+				return llmagent.AgentRequest[C]{}, fmt.Errorf("decode context: %w", err)
+			}
+			contextVal = decoded
+		} else if err := json.Unmarshal([]byte(req.Context), &contextVal); err != nil {
+			return llmagent.AgentRequest[C]{}, fmt.Errorf("decode context: %w", err)
+		}
+	}
+
+	return llmagent.AgentRequest[C]{
+		Input:    items,
+		Context:  contextVal,
+		Sampling: req.Sampling,
+	}, nil
+}
+
+// StreamDesc is the client-side stream descriptor for RunAgentStream.
+var StreamDesc = grpc.StreamDesc{
+	StreamName:    "RunAgentStream",
+	ServerStreams: true,
+}
+
+// Fully qualified method names for use with grpc.ClientConn.Invoke and
+// NewStream.
+const (
+	RunAgentMethod       = "/" + ServiceName + "/RunAgent"
+	RunAgentStreamMethod = "/" + ServiceName + "/RunAgentStream"
+)
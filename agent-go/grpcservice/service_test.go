@@ -0,0 +1,144 @@
+package grpcservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/grpcservice"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func startAgentService(t *testing.T, model *llmsdktest.MockLanguageModel) *grpc.ClientConn {
+	t.Helper()
+
+	agent := llmagent.NewAgent[map[string]string]("assistant", model)
+	server := grpc.NewServer()
+	grpcservice.NewServer(agent).Register(server)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(grpcservice.CodecName)),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func userInputItem(t *testing.T, text string) string {
+	t.Helper()
+	item := llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text)))
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("marshal input item: %v", err)
+	}
+	return string(data)
+}
+
+func TestRunAgent(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Hello over gRPC")},
+	}))
+	conn := startAgentService(t, model)
+
+	req := &grpcservice.RunAgentRequest{
+		Input:   []string{userInputItem(t, "Hi")},
+		Context: `{"user":"alice"}`,
+	}
+	var resp grpcservice.RunAgentResponse
+	if err := conn.Invoke(t.Context(), grpcservice.RunAgentMethod, req, &resp); err != nil {
+		t.Fatalf("invoke: %v", err)
+	}
+
+	var agentResponse llmagent.AgentResponse
+	if err := json.Unmarshal([]byte(resp.Response), &agentResponse); err != nil {
+		t.Fatalf("decode agent response: %v", err)
+	}
+	if len(agentResponse.Content) != 1 || agentResponse.Content[0].TextPart.Text != "Hello over gRPC" {
+		t.Fatalf("expected the agent text, got %+v", agentResponse)
+	}
+}
+
+func TestRunAgentRejectsEmptyInput(t *testing.T) {
+	conn := startAgentService(t, llmsdktest.NewMockLanguageModel())
+
+	var resp grpcservice.RunAgentResponse
+	err := conn.Invoke(t.Context(), grpcservice.RunAgentMethod, &grpcservice.RunAgentRequest{}, &resp)
+	if err == nil || !strings.Contains(err.Error(), "input must not be empty") {
+		t.Fatalf("expected an invalid argument error, got %v", err)
+	}
+}
+
+func TestRunAgentStream(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("Hel")}},
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("lo")}},
+	}))
+	conn := startAgentService(t, model)
+
+	stream, err := conn.NewStream(t.Context(), &grpcservice.StreamDesc, grpcservice.RunAgentStreamMethod)
+	if err != nil {
+		t.Fatalf("new stream: %v", err)
+	}
+	req := &grpcservice.RunAgentRequest{Input: []string{userInputItem(t, "Hi")}}
+	if err := stream.SendMsg(req); err != nil {
+		t.Fatalf("send request: %v", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("close send: %v", err)
+	}
+
+	var (
+		text     strings.Builder
+		response *llmagent.AgentResponse
+	)
+	for {
+		var msg grpcservice.AgentStreamEvent
+		if err := stream.RecvMsg(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			t.Fatalf("recv: %v", err)
+		}
+		var event llmagent.AgentStreamEvent
+		if err := json.Unmarshal([]byte(msg.Event), &event); err != nil {
+			t.Fatalf("decode event: %v", err)
+		}
+		if event.Partial != nil && event.Partial.Delta != nil && event.Partial.Delta.Part.TextPartDelta != nil {
+			text.WriteString(event.Partial.Delta.Part.TextPartDelta.Text)
+		}
+		if event.Response != nil {
+			response = event.Response
+		}
+	}
+
+	if text.String() != "Hello" {
+		t.Fatalf("expected streamed text %q, got %q", "Hello", text.String())
+	}
+	if response == nil || len(response.Content) != 1 || response.Content[0].TextPart.Text != "Hello" {
+		t.Fatalf("expected the final response event, got %+v", response)
+	}
+}
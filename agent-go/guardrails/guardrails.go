@@ -0,0 +1,78 @@
+// Package guardrails provides ready-made content checks that block or flag an
+// agent run: a moderation guardrail over a pluggable moderation API, and a
+// prompt injection detector for tool results and retrieved documents. A
+// tripped guardrail surfaces as a llmagent.GuardrailTrippedErrorKind error, so
+// callers branch on it the same way as any other agent error.
+package guardrails
+
+import (
+	"context"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+// TextGuardrail screens one piece of text. CheckText returns a guardrail
+// tripped error to block the content and nil to let it through.
+type TextGuardrail interface {
+	// Name identifies the guardrail in errors and flags.
+	Name() string
+	// CheckText screens the text.
+	CheckText(ctx context.Context, text string) error
+}
+
+// CheckItems screens the user-visible text of the items: user message text
+// parts. Model responses, tool results, and system content are not screened;
+// use CheckResponse for final output and the prompt injection guardrail for
+// tool results.
+func CheckItems(ctx context.Context, guardrail TextGuardrail, items []llmagent.AgentItem) error {
+	for _, item := range items {
+		if item.Message == nil || item.Message.UserMessage == nil {
+			continue
+		}
+		var texts []string
+		for _, part := range item.Message.UserMessage.Content {
+			if part.TextPart != nil && part.TextPart.Text != "" {
+				texts = append(texts, part.TextPart.Text)
+			}
+		}
+		if len(texts) == 0 {
+			continue
+		}
+		if err := guardrail.CheckText(ctx, strings.Join(texts, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckResponse screens the final text content of the response.
+func CheckResponse(ctx context.Context, guardrail TextGuardrail, response *llmagent.AgentResponse) error {
+	text := response.Text()
+	if text == "" {
+		return nil
+	}
+	return guardrail.CheckText(ctx, text)
+}
+
+// Run executes the session request with the guardrails applied to the user
+// input before the run and to the final output after it. A guardrail tripped
+// on input blocks the run before any model call; one tripped on output
+// withholds the response and returns the error instead.
+func Run[C any](ctx context.Context, session *llmagent.RunSession[C], request llmagent.RunSessionRequest, guards ...TextGuardrail) (*llmagent.AgentResponse, error) {
+	for _, guard := range guards {
+		if err := CheckItems(ctx, guard, request.Input); err != nil {
+			return nil, err
+		}
+	}
+	response, err := session.Run(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	for _, guard := range guards {
+		if err := CheckResponse(ctx, guard, response); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
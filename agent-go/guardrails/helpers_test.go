@@ -0,0 +1,21 @@
+package guardrails_test
+
+import (
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+func mustNewRunSession(t *testing.T, params *llmagent.AgentParams[any]) *llmagent.RunSession[any] {
+	t.Helper()
+	session, err := llmagent.NewRunSession(t.Context(), params, nil)
+	if err != nil {
+		t.Fatalf("failed to create run session: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := session.Close(t.Context()); err != nil {
+			t.Fatalf("failed to close session: %v", err)
+		}
+	})
+	return session
+}
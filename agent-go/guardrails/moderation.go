@@ -0,0 +1,108 @@
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+// Category labels one axis of a moderation result, e.g. "hate" or "violence".
+// The set of categories is defined by the moderation API in use.
+type Category string
+
+// ModerationResult is the verdict of a moderation API for one piece of text.
+type ModerationResult struct {
+	// Flagged reports whether the API considers the text objectionable at
+	// all, independent of the configured thresholds.
+	Flagged bool
+	// Scores holds the per-category confidence, from 0 to 1.
+	Scores map[Category]float64
+}
+
+// ModerationAPI scores text against moderation categories. Implementations
+// wrap a provider moderation endpoint or a local classifier.
+type ModerationAPI interface {
+	Moderate(ctx context.Context, text string) (*ModerationResult, error)
+}
+
+// Flag describes content that a moderation guardrail flagged without
+// blocking.
+type Flag struct {
+	Category Category
+	Score    float64
+	Text     string
+}
+
+// ModerationParams configures a moderation guardrail.
+type ModerationParams struct {
+	// API scores the screened text. Required.
+	API ModerationAPI
+	// Thresholds maps categories to the minimum score that blocks the run.
+	// Categories without a threshold never block. Required to be non-empty
+	// unless OnFlag is set, since a guardrail that can neither block nor
+	// flag does nothing.
+	Thresholds map[Category]float64
+	// OnFlag is invoked for content the API flagged that no threshold
+	// blocked, so borderline content can be logged or reviewed without
+	// failing the run. Optional.
+	OnFlag func(flag Flag)
+}
+
+// Moderation blocks or flags content based on per-category moderation scores.
+// It implements TextGuardrail.
+type Moderation struct {
+	params ModerationParams
+}
+
+// NewModeration creates a moderation guardrail.
+func NewModeration(params ModerationParams) (*Moderation, error) {
+	if params.API == nil {
+		return nil, errors.New("moderation guardrail requires an API")
+	}
+	if len(params.Thresholds) == 0 && params.OnFlag == nil {
+		return nil, errors.New("moderation guardrail requires thresholds or an OnFlag handler")
+	}
+	return &Moderation{params: params}, nil
+}
+
+func (m *Moderation) Name() string {
+	return "moderation"
+}
+
+// CheckText scores the text and returns a guardrail tripped error when any
+// category meets its configured threshold. Content flagged by the API below
+// every threshold is reported to OnFlag and allowed through.
+func (m *Moderation) CheckText(ctx context.Context, text string) error {
+	result, err := m.params.API.Moderate(ctx, text)
+	if err != nil {
+		return fmt.Errorf("moderation check failed: %w", err)
+	}
+
+	var worst *Flag
+	for category, threshold := range m.params.Thresholds {
+		score, ok := result.Scores[category]
+		if !ok || score < threshold {
+			continue
+		}
+		if worst == nil || score > worst.Score {
+			worst = &Flag{Category: category, Score: score, Text: text}
+		}
+	}
+	if worst != nil {
+		return llmagent.NewGuardrailTrippedError(m.Name(), fmt.Sprintf("category %s scored %.2f at or above threshold %.2f", worst.Category, worst.Score, m.params.Thresholds[worst.Category]))
+	}
+
+	if result.Flagged && m.params.OnFlag != nil {
+		flag := Flag{Text: text}
+		for category, score := range result.Scores {
+			if score > flag.Score {
+				flag.Category = category
+				flag.Score = score
+			}
+		}
+		m.params.OnFlag(flag)
+	}
+	return nil
+}
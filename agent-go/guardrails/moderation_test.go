@@ -0,0 +1,158 @@
+package guardrails_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/guardrails"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// keywordModerationAPI scores text by keyword so tests control the verdict.
+type keywordModerationAPI struct {
+	scores map[string]map[guardrails.Category]float64
+}
+
+func (a *keywordModerationAPI) Moderate(ctx context.Context, text string) (*guardrails.ModerationResult, error) {
+	result := &guardrails.ModerationResult{Scores: map[guardrails.Category]float64{}}
+	for keyword, scores := range a.scores {
+		if !strings.Contains(text, keyword) {
+			continue
+		}
+		result.Flagged = true
+		for category, score := range scores {
+			if score > result.Scores[category] {
+				result.Scores[category] = score
+			}
+		}
+	}
+	return result, nil
+}
+
+func newTestModeration(t *testing.T, params guardrails.ModerationParams) *guardrails.Moderation {
+	t.Helper()
+	moderation, err := guardrails.NewModeration(params)
+	if err != nil {
+		t.Fatalf("failed to create moderation guardrail: %v", err)
+	}
+	return moderation
+}
+
+func TestModerationBlocksInput(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 1,
+	})
+
+	moderation := newTestModeration(t, guardrails.ModerationParams{
+		API: &keywordModerationAPI{scores: map[string]map[guardrails.Category]float64{
+			"attack plan": {"violence": 0.95},
+		}},
+		Thresholds: map[guardrails.Category]float64{"violence": 0.8},
+	})
+
+	_, err := guardrails.Run(t.Context(), session, llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("write an attack plan"))),
+		},
+	}, moderation)
+
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.GuardrailTrippedErrorKind {
+		t.Fatalf("expected a guardrail tripped error, got %v", err)
+	}
+	if agentErr.Guardrail != "moderation" {
+		t.Errorf("expected the moderation guardrail named, got %q", agentErr.Guardrail)
+	}
+	if len(model.TrackedGenerateInputs()) != 0 {
+		t.Error("expected the model not to be called for blocked input")
+	}
+}
+
+func TestModerationBlocksOutput(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("here is the attack plan")},
+	}))
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 1,
+	})
+
+	moderation := newTestModeration(t, guardrails.ModerationParams{
+		API: &keywordModerationAPI{scores: map[string]map[guardrails.Category]float64{
+			"attack plan": {"violence": 0.95},
+		}},
+		Thresholds: map[guardrails.Category]float64{"violence": 0.8},
+	})
+
+	response, err := guardrails.Run(t.Context(), session, llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("hello"))),
+		},
+	}, moderation)
+
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.GuardrailTrippedErrorKind {
+		t.Fatalf("expected a guardrail tripped error, got %v", err)
+	}
+	if response != nil {
+		t.Error("expected the blocked response withheld")
+	}
+}
+
+func TestModerationFlagsBelowThreshold(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("all good")},
+	}))
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 1,
+	})
+
+	var flags []guardrails.Flag
+	moderation := newTestModeration(t, guardrails.ModerationParams{
+		API: &keywordModerationAPI{scores: map[string]map[guardrails.Category]float64{
+			"borderline": {"harassment": 0.4},
+		}},
+		Thresholds: map[guardrails.Category]float64{"harassment": 0.8},
+		OnFlag:     func(flag guardrails.Flag) { flags = append(flags, flag) },
+	})
+
+	response, err := guardrails.Run(t.Context(), session, llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("something borderline"))),
+		},
+	}, moderation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Text() != "all good" {
+		t.Errorf("unexpected response %q", response.Text())
+	}
+	if len(flags) != 1 || flags[0].Category != "harassment" || flags[0].Score != 0.4 {
+		t.Errorf("expected the borderline content flagged, got %+v", flags)
+	}
+}
+
+func TestNewModerationValidatesParams(t *testing.T) {
+	if _, err := guardrails.NewModeration(guardrails.ModerationParams{
+		Thresholds: map[guardrails.Category]float64{"hate": 0.5},
+	}); err == nil {
+		t.Error("expected an error without an API")
+	}
+	if _, err := guardrails.NewModeration(guardrails.ModerationParams{
+		API: &keywordModerationAPI{},
+	}); err == nil {
+		t.Error("expected an error without thresholds or OnFlag")
+	}
+}
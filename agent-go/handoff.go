@@ -0,0 +1,161 @@
+package llmagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// HandoffInput is the arguments the model provides when delegating to a
+// sub-agent.
+type HandoffInput struct {
+	Task string `json:"task"`
+}
+
+// HandoffInputFilter maps the delegation arguments into the input items for
+// the sub-agent run. It can rewrite the task or seed additional context items.
+type HandoffInputFilter[C any] func(ctx context.Context, input HandoffInput, contextVal C, runState *RunState) ([]AgentItem, error)
+
+// Handoff delegates a task to a sub-agent as a function tool. The parent
+// agent's context value is propagated to the sub-agent run, and the sub-agent
+// final content is returned as the tool result.
+type Handoff[C any] struct {
+	agent       *Agent[C]
+	description string
+	inputFilter HandoffInputFilter[C]
+	onEvent     func(ctx context.Context, event *AgentStreamEvent)
+}
+
+// HandoffOption customizes a Handoff.
+type HandoffOption[C any] func(*Handoff[C])
+
+// WithHandoffDescription sets the capability description included in the
+// transfer tool description.
+func WithHandoffDescription[C any](description string) HandoffOption[C] {
+	return func(h *Handoff[C]) {
+		h.description = description
+	}
+}
+
+// WithHandoffInputFilter sets the filter used to build the sub-agent input
+// items from the delegation arguments.
+func WithHandoffInputFilter[C any](filter HandoffInputFilter[C]) HandoffOption[C] {
+	return func(h *Handoff[C]) {
+		h.inputFilter = filter
+	}
+}
+
+// WithHandoffOnEvent forwards stream events from the nested sub-agent run to
+// the callback. When set, the sub-agent runs in streaming mode.
+func WithHandoffOnEvent[C any](onEvent func(ctx context.Context, event *AgentStreamEvent)) HandoffOption[C] {
+	return func(h *Handoff[C]) {
+		h.onEvent = onEvent
+	}
+}
+
+// NewHandoff creates a function tool that transfers a task to the sub-agent.
+func NewHandoff[C any](agent *Agent[C], options ...HandoffOption[C]) AgentTool[C] {
+	handoff := &Handoff[C]{agent: agent}
+	for _, option := range options {
+		option(handoff)
+	}
+	return NewAgentFunctionTool[C](handoff)
+}
+
+func (h *Handoff[C]) Name() string {
+	return fmt.Sprintf("transfer_to_%s", h.agent.Name)
+}
+
+func (h *Handoff[C]) Description() string {
+	if h.description != "" {
+		return fmt.Sprintf("Use this tool to transfer the task to %s, which can help with:\n%s",
+			h.agent.Name, h.description)
+	}
+	return fmt.Sprintf("Use this tool to transfer the task to %s", h.agent.Name)
+}
+
+func (h *Handoff[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"task": map[string]any{
+				"type": "string",
+				"description": `A clear and concise description of the task the agent should achieve.
+Replace any possessive pronouns or ambiguous terms with the actual entity names if possible
+so there is enough information for the agent to process without additional context`,
+			},
+		},
+		"required":             []string{"task"},
+		"additionalProperties": false,
+	}
+}
+
+func (h *Handoff[C]) Execute(ctx context.Context, params json.RawMessage, contextVal C, runState *RunState) (AgentToolResult, error) {
+	var input HandoffInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return AgentToolResult{}, err
+	}
+
+	items, err := h.buildInput(ctx, input, contextVal, runState)
+	if err != nil {
+		return AgentToolResult{}, err
+	}
+
+	request := AgentRequest[C]{Input: items, Context: contextVal}
+
+	// The delegation span nests the sub-agent run under the transfer tool
+	// span and links it to the parent run, so traces show the agent tree.
+	ctx, span := startDelegationSpan(ctx, h.agent.Name, input.Task)
+
+	var result AgentToolResult
+	if h.onEvent != nil {
+		result, err = h.executeStream(ctx, request)
+	} else {
+		var response *AgentResponse
+		response, err = h.agent.Run(ctx, request)
+		if err == nil {
+			result = AgentToolResult{Content: response.Content}
+		}
+	}
+	endDelegationSpan(span, err)
+	if err != nil {
+		return AgentToolResult{}, err
+	}
+	return result, nil
+}
+
+func (h *Handoff[C]) buildInput(ctx context.Context, input HandoffInput, contextVal C, runState *RunState) ([]AgentItem, error) {
+	if h.inputFilter != nil {
+		return h.inputFilter(ctx, input, contextVal, runState)
+	}
+	return []AgentItem{
+		NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(input.Task))),
+	}, nil
+}
+
+// executeStream runs the sub-agent in streaming mode, forwarding each nested
+// event to the onEvent callback before returning the final content.
+func (h *Handoff[C]) executeStream(ctx context.Context, request AgentRequest[C]) (AgentToolResult, error) {
+	agentStream, err := h.agent.RunStream(ctx, request)
+	if err != nil {
+		return AgentToolResult{}, err
+	}
+
+	var response *AgentResponse
+	for agentStream.Next() {
+		event := agentStream.Current()
+		if event.Response != nil {
+			response = event.Response
+		}
+		h.onEvent(ctx, event)
+	}
+	if err := agentStream.Err(); err != nil {
+		return AgentToolResult{}, err
+	}
+	if response == nil {
+		return AgentToolResult{}, NewInvariantError("sub-agent stream ended without a response")
+	}
+	return AgentToolResult{Content: response.Content}, nil
+}
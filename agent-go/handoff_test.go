@@ -0,0 +1,108 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestHandoff(t *testing.T) {
+	t.Run("delegates task to sub-agent with shared context", func(t *testing.T) {
+		type testContext struct{ Tenant string }
+		subModel := llmsdktest.NewMockLanguageModel()
+		subModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("sub-agent result")},
+		}))
+		subAgent := llmagent.NewAgent("billing", subModel,
+			llmagent.WithInstructions(llmagent.InstructionParam[testContext]{Func: func(_ context.Context, ctx testContext) (string, error) {
+				return "Tenant: " + ctx.Tenant, nil
+			}}),
+		)
+
+		handoff := llmagent.NewHandoff(subAgent, llmagent.WithHandoffDescription[testContext]("billing questions"))
+		if handoff.Name() != "transfer_to_billing" {
+			t.Fatalf("unexpected tool name: %q", handoff.Name())
+		}
+
+		params, _ := json.Marshal(map[string]string{"task": "refund order 42"})
+		result, err := handoff.AsFunctionTool().Execute(t.Context(), params, testContext{Tenant: "acme"}, nil)
+		if err != nil {
+			t.Fatalf("execute handoff: %v", err)
+		}
+		if len(result.Content) != 1 || result.Content[0].TextPart == nil || result.Content[0].TextPart.Text != "sub-agent result" {
+			t.Fatalf("unexpected handoff result: %#v", result.Content)
+		}
+
+		inputs := subModel.TrackedGenerateInputs()
+		if len(inputs) != 1 {
+			t.Fatalf("expected one sub-agent call, got %d", len(inputs))
+		}
+		if inputs[0].SystemPrompt == nil || *inputs[0].SystemPrompt != "Tenant: acme" {
+			t.Fatalf("expected context to propagate to sub-agent instructions, got %#v", inputs[0].SystemPrompt)
+		}
+		if len(inputs[0].Messages) != 1 || inputs[0].Messages[0].UserMessage == nil {
+			t.Fatalf("expected delegated task as user message, got %#v", inputs[0].Messages)
+		}
+	})
+
+	t.Run("applies input filter", func(t *testing.T) {
+		subModel := llmsdktest.NewMockLanguageModel()
+		subModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("filtered")},
+		}))
+		subAgent := llmagent.NewAgent[struct{}]("helper", subModel)
+
+		handoff := llmagent.NewHandoff(subAgent,
+			llmagent.WithHandoffInputFilter[struct{}](func(_ context.Context, input llmagent.HandoffInput, _ struct{}, _ *llmagent.RunState) ([]llmagent.AgentItem, error) {
+				return []llmagent.AgentItem{
+					llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("context item"))),
+					llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(input.Task))),
+				}, nil
+			}),
+		)
+
+		params, _ := json.Marshal(map[string]string{"task": "do the thing"})
+		if _, err := handoff.AsFunctionTool().Execute(t.Context(), params, struct{}{}, nil); err != nil {
+			t.Fatalf("execute handoff: %v", err)
+		}
+
+		inputs := subModel.TrackedGenerateInputs()
+		if len(inputs) != 1 || len(inputs[0].Messages) != 2 {
+			t.Fatalf("expected filtered input messages, got %#v", inputs)
+		}
+	})
+
+	t.Run("forwards nested stream events", func(t *testing.T) {
+		subModel := llmsdktest.NewMockLanguageModel()
+		subModel.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+			{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("streamed")}},
+		}))
+		subAgent := llmagent.NewAgent[struct{}]("streamer", subModel)
+
+		var events []*llmagent.AgentStreamEvent
+		handoff := llmagent.NewHandoff(subAgent,
+			llmagent.WithHandoffOnEvent[struct{}](func(_ context.Context, event *llmagent.AgentStreamEvent) {
+				events = append(events, event)
+			}),
+		)
+
+		params, _ := json.Marshal(map[string]string{"task": "stream it"})
+		result, err := handoff.AsFunctionTool().Execute(t.Context(), params, struct{}{}, nil)
+		if err != nil {
+			t.Fatalf("execute handoff: %v", err)
+		}
+		if len(result.Content) != 1 || result.Content[0].TextPart == nil || result.Content[0].TextPart.Text != "streamed" {
+			t.Fatalf("unexpected handoff result: %#v", result.Content)
+		}
+		if len(events) < 2 {
+			t.Fatalf("expected forwarded nested events, got %#v", events)
+		}
+		if events[len(events)-1].Response == nil {
+			t.Fatalf("expected final forwarded event to be the response, got %#v", events[len(events)-1])
+		}
+	})
+}
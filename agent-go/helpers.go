@@ -24,6 +24,14 @@ func NewAgentItemTool(toolCallID, toolName string, input json.RawMessage, output
 	}}
 }
 
+func NewAgentItemProviderTool(providerTool AgentItemProviderTool) AgentItem {
+	return AgentItem{ProviderTool: &providerTool}
+}
+
+func NewAgentItemFile(file AgentItemFile) AgentItem {
+	return AgentItem{File: &file}
+}
+
 func NewAgentStreamItemEvent(index int, item AgentItem) *AgentStreamEvent {
 	return &AgentStreamEvent{Item: &AgentStreamItemEvent{
 		Index: index,
@@ -38,3 +46,18 @@ func NewAgentStreamEventPartial(partial *llmsdk.PartialModelResponse) *AgentStre
 func NewAgentStreamEventResponse(response *AgentResponse) *AgentStreamEvent {
 	return &AgentStreamEvent{Response: response}
 }
+
+func NewAgentStreamEventToolCallStart(toolCallID, toolName string, input json.RawMessage) *AgentStreamEvent {
+	return &AgentStreamEvent{ToolCallStart: &AgentStreamToolCallStartEvent{
+		ToolCallID: toolCallID,
+		ToolName:   toolName,
+		Input:      input,
+	}}
+}
+
+func NewAgentStreamEventData(name string, data json.RawMessage) *AgentStreamEvent {
+	return &AgentStreamEvent{Data: &AgentStreamDataEvent{
+		Name: name,
+		Data: data,
+	}}
+}
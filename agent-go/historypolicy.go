@@ -0,0 +1,106 @@
+package llmagent
+
+import (
+	"encoding/json"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// HistoryPolicy truncates the conversation history before each model call so
+// long sessions do not blow the context window. The first user message is
+// always kept so the model retains the original task, and a truncated window
+// never starts with orphaned tool results. System prompts are supplied
+// separately from instructions and are unaffected by truncation.
+type HistoryPolicy struct {
+	// KeepLastTurns keeps only the messages from the last N user turns, where
+	// a turn starts at a user message. Zero means unlimited.
+	KeepLastTurns int
+	// MaxTokens drops the oldest messages once the estimated token count of
+	// the history exceeds this budget. Zero means unlimited.
+	MaxTokens int
+	// CountTokens measures text for the MaxTokens budget. Nil uses a rough
+	// four-characters-per-token heuristic; pass a tokenizer's Count method
+	// (see the tokenizer package) for model-accurate budgets.
+	CountTokens func(text string) int
+}
+
+// apply returns the truncated view of messages for a single model call.
+// The underlying messages are not modified.
+func (p *HistoryPolicy) apply(messages []llmsdk.Message) []llmsdk.Message {
+	if p == nil || (p.KeepLastTurns <= 0 && p.MaxTokens <= 0) {
+		return messages
+	}
+	if len(messages) == 0 {
+		return messages
+	}
+
+	firstUserIndex := -1
+	for i, message := range messages {
+		if message.UserMessage != nil {
+			firstUserIndex = i
+			break
+		}
+	}
+
+	start := 0
+
+	if p.KeepLastTurns > 0 {
+		turns := 0
+		for i := len(messages) - 1; i >= 0; i-- {
+			if messages[i].UserMessage != nil {
+				turns++
+				if turns == p.KeepLastTurns {
+					start = i
+					break
+				}
+			}
+		}
+	}
+
+	if p.MaxTokens > 0 {
+		budget := p.MaxTokens
+		if firstUserIndex >= 0 && firstUserIndex < start {
+			// Reserve room for the first user message that is re-added below.
+			budget -= estimateMessageTokens(messages[firstUserIndex], p.CountTokens)
+		}
+		tokens := 0
+		for i := len(messages) - 1; i >= start; i-- {
+			tokens += estimateMessageTokens(messages[i], p.CountTokens)
+			if tokens > budget && i < len(messages)-1 {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	// Never start the window in the middle of a tool call exchange.
+	for start < len(messages) && messages[start].ToolMessage != nil {
+		start++
+	}
+
+	if start == 0 {
+		return messages
+	}
+
+	window := messages[start:]
+	if firstUserIndex >= 0 && firstUserIndex < start {
+		result := make([]llmsdk.Message, 0, len(window)+1)
+		result = append(result, messages[firstUserIndex])
+		return append(result, window...)
+	}
+	return window
+}
+
+// estimateMessageTokens approximates the token count of a message over its
+// JSON encoding, using the provided counter or, when nil, a rough heuristic
+// of four characters per token.
+func estimateMessageTokens(message llmsdk.Message, count func(text string) int) int {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return 0
+	}
+	if count != nil {
+		return count(string(data))
+	}
+	return len(data)/4 + 1
+}
@@ -0,0 +1,98 @@
+package llmagent_test
+
+import (
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRun_HistoryPolicyKeepsLastTurnsAndFirstUser(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("final")},
+	}))
+
+	agent := llmagent.NewAgent[struct{}](
+		"test_agent",
+		model,
+		llmagent.WithHistoryPolicy[struct{}](llmagent.HistoryPolicy{KeepLastTurns: 2}),
+	)
+
+	_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("first question"))),
+			llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart("first answer"))),
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("second question"))),
+			llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart("second answer"))),
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("third question"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected one model call, got %d", len(inputs))
+	}
+
+	messages := inputs[0].Messages
+	if len(messages) != 4 {
+		t.Fatalf("expected 4 messages after truncation, got %d", len(messages))
+	}
+	if messages[0].UserMessage == nil || messages[0].UserMessage.Content[0].TextPart.Text != "first question" {
+		t.Fatalf("expected the first user message to be kept, got %+v", messages[0])
+	}
+	if messages[1].UserMessage == nil || messages[1].UserMessage.Content[0].TextPart.Text != "second question" {
+		t.Fatalf("expected truncation to start at the second user turn, got %+v", messages[1])
+	}
+}
+
+func TestRun_HistoryPolicyAppliesTokenBudget(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("final")},
+	}))
+
+	agent := llmagent.NewAgent[struct{}](
+		"test_agent",
+		model,
+		llmagent.WithHistoryPolicy[struct{}](llmagent.HistoryPolicy{MaxTokens: 120}),
+	)
+
+	filler := strings.Repeat("lorem ipsum ", 40)
+	_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("original task"))),
+			llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart(filler))),
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(filler))),
+			llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart("short answer"))),
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("latest question"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected one model call, got %d", len(inputs))
+	}
+
+	messages := inputs[0].Messages
+	if len(messages) >= 5 {
+		t.Fatalf("expected the token budget to drop messages, got %d", len(messages))
+	}
+	if messages[0].UserMessage == nil || messages[0].UserMessage.Content[0].TextPart.Text != "original task" {
+		t.Fatalf("expected the first user message to be kept, got %+v", messages[0])
+	}
+	last := messages[len(messages)-1]
+	if last.UserMessage == nil || last.UserMessage.Content[0].TextPart.Text != "latest question" {
+		t.Fatalf("expected the latest user message to be kept, got %+v", last)
+	}
+}
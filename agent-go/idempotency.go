@@ -0,0 +1,59 @@
+package llmagent
+
+import (
+	"context"
+	"fmt"
+)
+
+// toolIdempotencyKeyContextKey carries the idempotency key of the current
+// tool call execution in the context passed to AgentFunctionTool.Execute.
+type toolIdempotencyKeyContextKey struct{}
+
+// ToolIdempotencyKeyFromContext returns the idempotency key assigned to the
+// current tool call execution. The key is derived from the session ID and the
+// tool call ID, so tools can forward it to external services that support
+// idempotent requests (e.g. payment or ordering APIs).
+func ToolIdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(toolIdempotencyKeyContextKey{}).(string)
+	return key, ok
+}
+
+func withToolIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, toolIdempotencyKeyContextKey{}, key)
+}
+
+// toolIdempotencyKey derives the stable key for a tool call in this session.
+func (s *RunSession[C]) toolIdempotencyKey(toolCallID string) string {
+	return fmt.Sprintf("%s:%s", s.id, toolCallID)
+}
+
+// replayToolResult returns the recorded item for a call that already executed
+// in this session, so a resumed or retried run that replays the same call
+// does not repeat its side effects.
+func (s *RunSession[C]) replayToolResult(key string) (*AgentItem, bool) {
+	if !s.params.ToolIdempotency {
+		return nil, false
+	}
+	s.executedToolsMu.RLock()
+	defer s.executedToolsMu.RUnlock()
+	item, ok := s.executedTools[key]
+	if !ok {
+		return nil, false
+	}
+	replay := item
+	return &replay, true
+}
+
+// recordToolResult remembers a finished execution under its idempotency key.
+// Cancelled executions are not recorded so a resumed run retries them.
+func (s *RunSession[C]) recordToolResult(key string, item AgentItem) {
+	if !s.params.ToolIdempotency {
+		return
+	}
+	s.executedToolsMu.Lock()
+	defer s.executedToolsMu.Unlock()
+	if s.executedTools == nil {
+		s.executedTools = map[string]AgentItem{}
+	}
+	s.executedTools[key] = item
+}
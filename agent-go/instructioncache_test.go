@@ -0,0 +1,141 @@
+package llmagent_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestInstructionsResolvedOncePerSession(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("one")}}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("two")}}),
+	)
+
+	var resolved atomic.Int32
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name: "test_agent", Model: model, MaxTurns: 10,
+			Instructions: []llmagent.InstructionParam[struct{}]{
+				{Func: func(ctx context.Context, _ struct{}) (string, error) {
+					return fmt.Sprintf("Profile version %d.", resolved.Add(1)), nil
+				}},
+			},
+		},
+		struct{}{},
+	)
+
+	request := llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hi"))),
+	}}
+	for range 2 {
+		if _, err := session.Run(t.Context(), request); err != nil {
+			t.Fatalf("run session: %v", err)
+		}
+	}
+
+	if got := resolved.Load(); got != 1 {
+		t.Errorf("expected the instruction func resolved once per session, resolved %d times", got)
+	}
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 model calls, got %d", len(inputs))
+	}
+	for i, input := range inputs {
+		if input.SystemPrompt == nil || *input.SystemPrompt != "Profile version 1." {
+			t.Errorf("unexpected system prompt on call %d: %v", i, input.SystemPrompt)
+		}
+	}
+}
+
+func TestRefreshInstructionsInvalidatesCache(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("one")}}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("two")}}),
+	)
+
+	var resolved atomic.Int32
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name: "test_agent", Model: model, MaxTurns: 10,
+			Instructions: []llmagent.InstructionParam[struct{}]{
+				{Func: func(ctx context.Context, _ struct{}) (string, error) {
+					return fmt.Sprintf("Profile version %d.", resolved.Add(1)), nil
+				}},
+			},
+		},
+		struct{}{},
+	)
+
+	request := llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hi"))),
+	}}
+	if _, err := session.Run(t.Context(), request); err != nil {
+		t.Fatalf("run session: %v", err)
+	}
+	if err := session.RefreshInstructions(t.Context()); err != nil {
+		t.Fatalf("refresh instructions: %v", err)
+	}
+	if _, err := session.Run(t.Context(), request); err != nil {
+		t.Fatalf("run session: %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 model calls, got %d", len(inputs))
+	}
+	if inputs[0].SystemPrompt == nil || *inputs[0].SystemPrompt != "Profile version 1." {
+		t.Errorf("unexpected system prompt before refresh: %v", inputs[0].SystemPrompt)
+	}
+	if inputs[1].SystemPrompt == nil || *inputs[1].SystemPrompt != "Profile version 2." {
+		t.Errorf("expected the refreshed prompt after invalidation, got %v", inputs[1].SystemPrompt)
+	}
+}
+
+func TestRefreshInstructionsKeepsCacheOnError(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("one")}}),
+	)
+
+	calls := 0
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name: "test_agent", Model: model, MaxTurns: 10,
+			Instructions: []llmagent.InstructionParam[struct{}]{
+				{Func: func(ctx context.Context, _ struct{}) (string, error) {
+					calls++
+					if calls > 1 {
+						return "", fmt.Errorf("profile service unavailable")
+					}
+					return "Stable instructions.", nil
+				}},
+			},
+		},
+		struct{}{},
+	)
+
+	if err := session.RefreshInstructions(t.Context()); err == nil {
+		t.Fatal("expected the refresh error surfaced")
+	}
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hi"))),
+	}})
+	if err != nil {
+		t.Fatalf("run session: %v", err)
+	}
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 || inputs[0].SystemPrompt == nil || *inputs[0].SystemPrompt != "Stable instructions." {
+		t.Errorf("expected the cached prompt kept after a failed refresh, got %+v", inputs)
+	}
+}
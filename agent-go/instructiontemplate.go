@@ -0,0 +1,47 @@
+package llmagent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// NewInstructionTemplate creates an instruction that renders a text/template
+// before each session. dataFn produces the template data from the context
+// value; when it is nil, the context value itself is the data. Referencing a
+// variable the data does not provide fails the render instead of silently
+// emitting "<no value>", so typos in templates surface as errors. Literal
+// braces can be escaped with the usual text/template syntax, e.g.
+// {{"{{"}}.
+func NewInstructionTemplate[C any](text string, dataFn func(ctx context.Context, contextVal C) (any, error)) InstructionParam[C] {
+	tmpl, parseErr := template.New("instruction").Option("missingkey=error").Parse(text)
+	return InstructionParam[C]{
+		Func: func(ctx context.Context, contextVal C) (string, error) {
+			if parseErr != nil {
+				return "", fmt.Errorf("invalid instruction template: %w", parseErr)
+			}
+			var data any = contextVal
+			if dataFn != nil {
+				var err error
+				data, err = dataFn(ctx, contextVal)
+				if err != nil {
+					return "", err
+				}
+			}
+			var b strings.Builder
+			if err := tmpl.Execute(&b, data); err != nil {
+				return "", fmt.Errorf("failed to render instruction template: %w", err)
+			}
+			return b.String(), nil
+		},
+	}
+}
+
+// WithInstructionTemplate appends a template-based instruction rendered from
+// the context value on each session. See NewInstructionTemplate.
+func WithInstructionTemplate[C any](text string, dataFn func(ctx context.Context, contextVal C) (any, error)) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.Instructions = append(p.Instructions, NewInstructionTemplate(text, dataFn))
+	}
+}
@@ -0,0 +1,67 @@
+package llmagent_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+type templateContext struct {
+	UserName string
+}
+
+func TestRun_RendersInstructionTemplate(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hi")},
+	}))
+
+	params := &llmagent.AgentParams[templateContext]{
+		Name:     "greeter",
+		Model:    model,
+		MaxTurns: 2,
+	}
+	llmagent.WithInstructionTemplate[templateContext]("Hello {{.UserName}}, be concise.", nil)(params)
+
+	session := mustNewRunSession(t, params, templateContext{UserName: "Ada"})
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("hello"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 || inputs[0].SystemPrompt == nil {
+		t.Fatalf("expected a model call with a system prompt, got %+v", inputs)
+	}
+	if got := *inputs[0].SystemPrompt; got != "Hello Ada, be concise." {
+		t.Errorf("unexpected system prompt %q", got)
+	}
+}
+
+func TestInstructionTemplate_ErrorsOnMissingVariable(t *testing.T) {
+	instruction := llmagent.NewInstructionTemplate("Hello {{.Missing}}", func(context.Context, struct{}) (any, error) {
+		return map[string]any{"UserName": "Ada"}, nil
+	})
+
+	_, err := instruction.Func(t.Context(), struct{}{})
+	if err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("expected missing-variable error, got %v", err)
+	}
+}
+
+func TestInstructionTemplate_ErrorsOnInvalidTemplate(t *testing.T) {
+	instruction := llmagent.NewInstructionTemplate[struct{}]("Hello {{.Unclosed", nil)
+
+	_, err := instruction.Func(t.Context(), struct{}{})
+	if err == nil || !strings.Contains(err.Error(), "invalid instruction template") {
+		t.Errorf("expected parse error, got %v", err)
+	}
+}
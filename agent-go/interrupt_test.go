@@ -0,0 +1,94 @@
+package llmagent_test
+
+import (
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestInterruptCancelsStreamedTurn(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("Hel")}},
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("lo there")}},
+	}))
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+	}, struct{}{})
+
+	stream, err := session.RunStream(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Tell me a story"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("create stream: %v", err)
+	}
+
+	interrupted := false
+	var response *llmagent.AgentResponse
+	for stream.Next() {
+		event := stream.Current()
+		if event.Partial != nil && !interrupted {
+			session.Interrupt()
+			interrupted = true
+		}
+		if event.Response != nil {
+			response = event.Response
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("expected a clean cancellation, got %v", err)
+	}
+
+	if response == nil || response.Status != llmagent.AgentResponseStatusCancelled {
+		t.Fatalf("expected a cancelled response, got %+v", response)
+	}
+	foundTruncated := false
+	for _, item := range response.Output {
+		if item.Model != nil && len(item.Model.Content) > 0 {
+			foundTruncated = true
+		}
+	}
+	if !foundTruncated {
+		t.Error("expected the content emitted so far recorded as a truncated model item")
+	}
+
+	// The next user input proceeds immediately on the same session.
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Once upon a time")},
+	}))
+	next, err := session.Send(t.Context(), llmsdk.NewTextPart("Actually, make it short"))
+	if err != nil {
+		t.Fatalf("send after interrupt: %v", err)
+	}
+	if next.Text() != "Once upon a time" {
+		t.Errorf("unexpected response after interrupt: %q", next.Text())
+	}
+}
+
+func TestInterruptWithoutActiveRunIsNoOp(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+	}))
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+	}, struct{}{})
+
+	session.Interrupt()
+
+	response, err := session.Send(t.Context(), llmsdk.NewTextPart("Hi"))
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if response.Text() != "hello" {
+		t.Errorf("unexpected response: %q", response.Text())
+	}
+}
@@ -0,0 +1,150 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmmcp "github.com/hoangvvo/llm-sdk/agent-go/mcp"
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// startFeatureStubServer serves a resource and a prompt so the toolkit's
+// resource and prompt features can be exercised end to end.
+func startFeatureStubServer() (string, func(), error) {
+	server := gomcp.NewServer(&gomcp.Implementation{Name: "stub-mcp-features", Version: "1.0.0"}, nil)
+
+	server.AddResource(&gomcp.Resource{
+		URI:         "docs://shuttle-policy",
+		Name:        "shuttle-policy",
+		Description: "Shuttle operating policy",
+		MIMEType:    "text/plain",
+	}, func(context.Context, *gomcp.ReadResourceRequest) (*gomcp.ReadResourceResult, error) {
+		return &gomcp.ReadResourceResult{
+			Contents: []*gomcp.ResourceContents{
+				{URI: "docs://shuttle-policy", MIMEType: "text/plain", Text: "Shuttles pause during lightning storms."},
+			},
+		}, nil
+	})
+
+	server.AddPrompt(&gomcp.Prompt{
+		Name:        "summarize_shift",
+		Description: "Summarize a shuttle shift",
+		Arguments:   []*gomcp.PromptArgument{{Name: "shift", Required: true}},
+	}, func(_ context.Context, req *gomcp.GetPromptRequest) (*gomcp.GetPromptResult, error) {
+		shift := req.Params.Arguments["shift"]
+		return &gomcp.GetPromptResult{
+			Messages: []*gomcp.PromptMessage{
+				{Role: "user", Content: &gomcp.TextContent{Text: "Summarize the " + shift + " shift."}},
+			},
+		}, nil
+	})
+
+	handler := gomcp.NewStreamableHTTPHandler(
+		func(*http.Request) *gomcp.Server { return server },
+		&gomcp.StreamableHTTPOptions{Stateless: false, JSONResponse: true},
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	addr := listener.Addr().(*net.TCPAddr)
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", addr.Port)
+
+	srv := &http.Server{Handler: handler}
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}
+
+	return baseURL, cleanup, nil
+}
+
+func TestMCPToolkitSessionExposesResourcesAndPrompts(t *testing.T) {
+	url, stop, err := startFeatureStubServer()
+	if err != nil {
+		t.Fatalf("start stub server: %v", err)
+	}
+	t.Cleanup(stop)
+
+	ctx := t.Context()
+
+	toolkit := llmmcp.NewMCPToolkit(
+		llmmcp.StaticMCPInit[struct{}](llmmcp.NewMCPStreamableHTTPParams(url, "")),
+		llmmcp.WithResources[struct{}](),
+		llmmcp.WithPrompts[struct{}](),
+		llmmcp.WithInstructionResources[struct{}]("docs://shuttle-policy"),
+	)
+
+	session, err := toolkit.CreateSession(ctx, struct{}{})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	t.Cleanup(func() {
+		if cerr := session.Close(ctx); cerr != nil {
+			t.Errorf("close session: %v", cerr)
+		}
+	})
+
+	if prompt := session.SystemPrompt(); prompt == nil || !strings.Contains(*prompt, "lightning storms") {
+		t.Fatalf("expected instruction resource content in the system prompt, got %#v", prompt)
+	}
+
+	toolsByName := make(map[string]llmagent.AgentFunctionTool[struct{}])
+	for _, tool := range session.Tools() {
+		if functionTool := tool.AsFunctionTool(); functionTool != nil {
+			toolsByName[functionTool.Name()] = functionTool
+		}
+	}
+	for _, name := range []string{"list_resources", "read_resource", "list_prompts", "get_prompt"} {
+		if _, ok := toolsByName[name]; !ok {
+			t.Fatalf("expected synthetic tool %s, got %v", name, toolsByName)
+		}
+	}
+
+	listResult, err := toolsByName["list_resources"].Execute(ctx, nil, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("list_resources: %v", err)
+	}
+	if len(listResult.Content) != 1 || !strings.Contains(listResult.Content[0].TextPart.Text, "docs://shuttle-policy") {
+		t.Fatalf("expected the resource catalog, got %+v", listResult.Content)
+	}
+
+	readArgs, _ := json.Marshal(map[string]string{"uri": "docs://shuttle-policy"})
+	readResult, err := toolsByName["read_resource"].Execute(ctx, readArgs, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("read_resource: %v", err)
+	}
+	if len(readResult.Content) != 1 || readResult.Content[0].TextPart.Text != "Shuttles pause during lightning storms." {
+		t.Fatalf("expected the resource content, got %+v", readResult.Content)
+	}
+
+	promptsResult, err := toolsByName["list_prompts"].Execute(ctx, nil, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("list_prompts: %v", err)
+	}
+	if len(promptsResult.Content) != 1 || !strings.Contains(promptsResult.Content[0].TextPart.Text, "summarize_shift") {
+		t.Fatalf("expected the prompt catalog, got %+v", promptsResult.Content)
+	}
+
+	getArgs, _ := json.Marshal(map[string]any{"name": "summarize_shift", "arguments": map[string]string{"shift": "evening"}})
+	getResult, err := toolsByName["get_prompt"].Execute(ctx, getArgs, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("get_prompt: %v", err)
+	}
+	if len(getResult.Content) != 1 || getResult.Content[0].TextPart.Text != "Summarize the evening shift." {
+		t.Fatalf("expected the rendered prompt, got %+v", getResult.Content)
+	}
+}
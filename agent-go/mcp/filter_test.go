@@ -0,0 +1,99 @@
+package mcp_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	llmmcp "github.com/hoangvvo/llm-sdk/agent-go/mcp"
+)
+
+func TestMCPToolkitSessionFiltersAndPrefixesTools(t *testing.T) {
+	stub, err := startStubMCPServer()
+	if err != nil {
+		t.Fatalf("start stub server: %v", err)
+	}
+	t.Cleanup(stub.stop)
+
+	ctx := t.Context()
+
+	t.Run("excluded tools are hidden", func(t *testing.T) {
+		toolkit := llmmcp.NewMCPToolkit(llmmcp.StaticMCPInit[struct{}](
+			llmmcp.NewMCPStreamableHTTPParams(stub.url, authToken).WithExcludedTools("list_shuttles"),
+		))
+		session, err := toolkit.CreateSession(ctx, struct{}{})
+		if err != nil {
+			t.Fatalf("create session: %v", err)
+		}
+		t.Cleanup(func() { _ = session.Close(ctx) })
+
+		if tools := session.Tools(); len(tools) != 0 {
+			t.Fatalf("expected no tools, got %+v", tools)
+		}
+	})
+
+	t.Run("allow list restricts to named tools", func(t *testing.T) {
+		toolkit := llmmcp.NewMCPToolkit(llmmcp.StaticMCPInit[struct{}](
+			llmmcp.NewMCPStreamableHTTPParams(stub.url, authToken).WithAllowedTools("other_tool"),
+		))
+		session, err := toolkit.CreateSession(ctx, struct{}{})
+		if err != nil {
+			t.Fatalf("create session: %v", err)
+		}
+		t.Cleanup(func() { _ = session.Close(ctx) })
+
+		if tools := session.Tools(); len(tools) != 0 {
+			t.Fatalf("expected no tools for a non-matching allow list, got %+v", tools)
+		}
+	})
+
+	t.Run("prefix renames surfaced tools but calls the original name", func(t *testing.T) {
+		toolkit := llmmcp.NewMCPToolkit(llmmcp.StaticMCPInit[struct{}](
+			llmmcp.NewMCPStreamableHTTPParams(stub.url, authToken).WithToolPrefix("shuttle_"),
+		))
+		session, err := toolkit.CreateSession(ctx, struct{}{})
+		if err != nil {
+			t.Fatalf("create session: %v", err)
+		}
+		t.Cleanup(func() { _ = session.Close(ctx) })
+
+		tools := session.Tools()
+		if len(tools) != 1 || tools[0].Name() != "shuttle_list_shuttles" {
+			t.Fatalf("expected the prefixed tool name, got %+v", tools)
+		}
+
+		args, _ := json.Marshal(map[string]string{"shift": "evening"})
+		result, err := tools[0].AsFunctionTool().Execute(ctx, args, struct{}{}, nil)
+		if err != nil {
+			t.Fatalf("execute prefixed tool: %v", err)
+		}
+		if len(result.Content) == 0 || result.Content[0].TextPart == nil ||
+			result.Content[0].TextPart.Text != "Shuttle summary for evening shift." {
+			t.Fatalf("expected the remote tool to execute under its original name, got %+v", result.Content)
+		}
+	})
+}
+
+func TestMCPParamsFilterRoundTripsJSON(t *testing.T) {
+	params := llmmcp.NewMCPStdioParams("uvx", []string{"server"}).
+		WithAllowedTools("a", "b").
+		WithExcludedTools("b").
+		WithToolPrefix("srv_")
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded llmmcp.MCPParams
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	reencoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("re-marshal: %v", err)
+	}
+	if string(reencoded) != string(data) {
+		t.Fatalf("expected filter to round-trip, got %s vs %s", reencoded, data)
+	}
+}
@@ -0,0 +1,425 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MCPOAuthParams configures the MCP authorization flow for streamable HTTP
+// servers per the MCP authorization spec. The client discovers the
+// authorization server from the protected resource metadata, registers itself
+// dynamically when no pre-registered credentials are supplied, and refreshes
+// access tokens automatically.
+type MCPOAuthParams struct {
+	// RedirectURL is the URL the authorization server redirects to after
+	// authorization. The caller handles the redirect out of band.
+	RedirectURL string `json:"redirect_url"`
+	// ClientID and ClientSecret identify a pre-registered client. When empty,
+	// dynamic client registration (RFC 7591) is used instead.
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"-"`
+	// ClientName is the human-readable name sent during dynamic client
+	// registration. Defaults to "llm-agent-go".
+	ClientName string `json:"client_name,omitempty"`
+	// Scopes optionally restricts the requested scopes. When empty, the scopes
+	// advertised in the WWW-Authenticate challenge are requested.
+	Scopes []string `json:"scopes,omitempty"`
+	// Authorize is called to start the authorization flow. It should open the
+	// URL for the user and return the authorization code and state once the
+	// authorization server redirects back to RedirectURL.
+	Authorize func(ctx context.Context, authorizationURL string) (code, state string, err error) `json:"-"`
+	// HTTPClient optionally overrides the client used for metadata discovery,
+	// registration, and token exchange.
+	HTTPClient *http.Client `json:"-"`
+}
+
+func (p *MCPOAuthParams) validate() error {
+	if p.Authorize == nil {
+		return errors.New("mcp oauth params missing Authorize callback")
+	}
+	if p.RedirectURL == "" {
+		return errors.New("mcp oauth params missing redirect url")
+	}
+	return nil
+}
+
+func (p *MCPOAuthParams) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// oauthToken is the token state shared across requests.
+type oauthToken struct {
+	accessToken  string
+	refreshToken string
+	expiry       time.Time
+}
+
+func (t *oauthToken) expired() bool {
+	return !t.expiry.IsZero() && time.Now().After(t.expiry.Add(-30*time.Second))
+}
+
+// oauthRoundTripper injects Bearer tokens obtained through the MCP
+// authorization flow and transparently runs the flow on 401 responses.
+type oauthRoundTripper struct {
+	params *MCPOAuthParams
+	base   http.RoundTripper
+
+	mu sync.Mutex
+	// endpoints discovered from the authorization server metadata.
+	authorizationEndpoint string
+	tokenEndpoint         string
+	registrationEndpoint  string
+	// clientID and clientSecret are either pre-registered or obtained via
+	// dynamic client registration.
+	clientID     string
+	clientSecret string
+	token        *oauthToken
+}
+
+func newOAuthRoundTripper(params *MCPOAuthParams) (*oauthRoundTripper, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	return &oauthRoundTripper{
+		params:       params,
+		base:         http.DefaultTransport,
+		clientID:     params.ClientID,
+		clientSecret: params.ClientSecret,
+	}, nil
+}
+
+func (rt *oauthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Buffer the body so the request can be replayed after authorization.
+	var bodyBytes []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		bodyBytes, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	send := func() (*http.Response, error) {
+		clone := req.Clone(req.Context())
+		if bodyBytes != nil {
+			clone.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+			clone.ContentLength = int64(len(bodyBytes))
+		}
+		if token := rt.currentAccessToken(req.Context()); token != "" {
+			clone.Header.Set("Authorization", "Bearer "+token)
+		}
+		return rt.base.RoundTrip(clone)
+	}
+
+	resp, err := send()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	_ = resp.Body.Close()
+
+	if err := rt.authorize(req.Context(), req.URL, challenge); err != nil {
+		return nil, fmt.Errorf("mcp oauth authorization: %w", err)
+	}
+	return send()
+}
+
+// currentAccessToken returns a valid access token, refreshing it when expired.
+func (rt *oauthRoundTripper) currentAccessToken(ctx context.Context) string {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.token == nil {
+		return ""
+	}
+	if rt.token.expired() && rt.token.refreshToken != "" {
+		if err := rt.refreshLocked(ctx); err != nil {
+			// Let the request proceed; a 401 restarts the full flow.
+			return ""
+		}
+	}
+	return rt.token.accessToken
+}
+
+// authorize runs the full authorization code flow with PKCE, registering the
+// client dynamically when needed.
+func (rt *oauthRoundTripper) authorize(ctx context.Context, serverURL *url.URL, challenge string) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if err := rt.discoverLocked(ctx, serverURL, challenge); err != nil {
+		return err
+	}
+	if rt.clientID == "" {
+		if err := rt.registerLocked(ctx); err != nil {
+			return err
+		}
+	}
+
+	verifier := randomURLSafe(32)
+	sum := sha256.Sum256([]byte(verifier))
+	challengeCode := base64.RawURLEncoding.EncodeToString(sum[:])
+	state := randomURLSafe(16)
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {rt.clientID},
+		"redirect_uri":          {rt.params.RedirectURL},
+		"code_challenge":        {challengeCode},
+		"code_challenge_method": {"S256"},
+		"state":                 {state},
+		"resource":              {serverURL.String()},
+	}
+	scopes := rt.params.Scopes
+	if len(scopes) == 0 {
+		scopes = scopesFromChallenge(challenge)
+	}
+	if len(scopes) > 0 {
+		query.Set("scope", strings.Join(scopes, " "))
+	}
+
+	authorizationURL := rt.authorizationEndpoint
+	if strings.Contains(authorizationURL, "?") {
+		authorizationURL += "&" + query.Encode()
+	} else {
+		authorizationURL += "?" + query.Encode()
+	}
+
+	code, gotState, err := rt.params.Authorize(ctx, authorizationURL)
+	if err != nil {
+		return err
+	}
+	if gotState != state {
+		return errors.New("authorization state mismatch")
+	}
+
+	return rt.exchangeLocked(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"code_verifier": {verifier},
+		"redirect_uri":  {rt.params.RedirectURL},
+		"resource":      {serverURL.String()},
+	})
+}
+
+// discoverLocked resolves the authorization server endpoints from the
+// protected resource metadata (RFC 9728) and the authorization server
+// metadata (RFC 8414).
+func (rt *oauthRoundTripper) discoverLocked(ctx context.Context, serverURL *url.URL, challenge string) error {
+	if rt.tokenEndpoint != "" && rt.authorizationEndpoint != "" {
+		return nil
+	}
+
+	issuer := serverURL.Scheme + "://" + serverURL.Host
+	if metadataURL := resourceMetadataURLFromChallenge(challenge); metadataURL != "" {
+		var resourceMetadata struct {
+			AuthorizationServers []string `json:"authorization_servers"`
+		}
+		if err := rt.getJSON(ctx, metadataURL, &resourceMetadata); err != nil {
+			return fmt.Errorf("fetch protected resource metadata: %w", err)
+		}
+		if len(resourceMetadata.AuthorizationServers) > 0 {
+			issuer = strings.TrimSuffix(resourceMetadata.AuthorizationServers[0], "/")
+		}
+	}
+
+	var serverMetadata struct {
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		RegistrationEndpoint  string `json:"registration_endpoint"`
+	}
+	var lastErr error
+	for _, metadataURL := range []string{
+		issuer + "/.well-known/oauth-authorization-server",
+		issuer + "/.well-known/openid-configuration",
+	} {
+		if lastErr = rt.getJSON(ctx, metadataURL, &serverMetadata); lastErr == nil && serverMetadata.TokenEndpoint != "" {
+			break
+		}
+	}
+	if serverMetadata.TokenEndpoint == "" || serverMetadata.AuthorizationEndpoint == "" {
+		if lastErr != nil {
+			return fmt.Errorf("fetch authorization server metadata: %w", lastErr)
+		}
+		return errors.New("authorization server metadata missing endpoints")
+	}
+
+	rt.authorizationEndpoint = serverMetadata.AuthorizationEndpoint
+	rt.tokenEndpoint = serverMetadata.TokenEndpoint
+	rt.registrationEndpoint = serverMetadata.RegistrationEndpoint
+	return nil
+}
+
+// registerLocked performs dynamic client registration (RFC 7591).
+func (rt *oauthRoundTripper) registerLocked(ctx context.Context) error {
+	if rt.registrationEndpoint == "" {
+		return errors.New("no client_id configured and the authorization server does not support dynamic client registration")
+	}
+
+	clientName := rt.params.ClientName
+	if clientName == "" {
+		clientName = "llm-agent-go"
+	}
+	payload, err := json.Marshal(map[string]any{
+		"client_name":                clientName,
+		"redirect_uris":              []string{rt.params.RedirectURL},
+		"grant_types":                []string{"authorization_code", "refresh_token"},
+		"response_types":             []string{"code"},
+		"token_endpoint_auth_method": "none",
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rt.registrationEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := rt.params.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("register client: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("register client: unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	var registration struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&registration); err != nil {
+		return fmt.Errorf("decode client registration: %w", err)
+	}
+	if registration.ClientID == "" {
+		return errors.New("client registration returned no client_id")
+	}
+	rt.clientID = registration.ClientID
+	rt.clientSecret = registration.ClientSecret
+	return nil
+}
+
+// refreshLocked exchanges the refresh token for a new access token.
+func (rt *oauthRoundTripper) refreshLocked(ctx context.Context) error {
+	return rt.exchangeLocked(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {rt.token.refreshToken},
+	})
+}
+
+// exchangeLocked posts to the token endpoint and stores the resulting token.
+func (rt *oauthRoundTripper) exchangeLocked(ctx context.Context, form url.Values) error {
+	form.Set("client_id", rt.clientID)
+	if rt.clientSecret != "" {
+		form.Set("client_secret", rt.clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rt.tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := rt.params.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("token request: unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	var tokenResponse struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return fmt.Errorf("decode token response: %w", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return errors.New("token response missing access_token")
+	}
+
+	token := &oauthToken{
+		accessToken:  tokenResponse.AccessToken,
+		refreshToken: tokenResponse.RefreshToken,
+	}
+	if token.refreshToken == "" && rt.token != nil {
+		token.refreshToken = rt.token.refreshToken
+	}
+	if tokenResponse.ExpiresIn > 0 {
+		token.expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	rt.token = token
+	return nil
+}
+
+func (rt *oauthRoundTripper) getJSON(ctx context.Context, rawURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := rt.params.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// resourceMetadataURLFromChallenge extracts the resource_metadata parameter
+// from a WWW-Authenticate Bearer challenge.
+func resourceMetadataURLFromChallenge(challenge string) string {
+	return challengeParam(challenge, "resource_metadata")
+}
+
+// scopesFromChallenge extracts the advertised scopes from a WWW-Authenticate
+// Bearer challenge.
+func scopesFromChallenge(challenge string) []string {
+	if scope := challengeParam(challenge, "scope"); scope != "" {
+		return strings.Fields(scope)
+	}
+	return nil
+}
+
+func challengeParam(challenge, key string) string {
+	for _, part := range strings.Split(challenge, ",") {
+		part = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part), "Bearer "))
+		if value, ok := strings.CutPrefix(part, key+"="); ok {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+func randomURLSafe(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
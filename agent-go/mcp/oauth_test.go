@@ -0,0 +1,184 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	llmmcp "github.com/hoangvvo/llm-sdk/agent-go/mcp"
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const oauthAccessToken = "oauth-access-token"
+
+// startOAuthStubServer runs an MCP server and a minimal authorization server
+// on the same listener: unauthorized MCP requests get a 401 with a
+// WWW-Authenticate challenge, and the well-known endpoints implement
+// discovery, dynamic client registration, and the token exchange.
+func startOAuthStubServer(t *testing.T) string {
+	t.Helper()
+
+	server := gomcp.NewServer(&gomcp.Implementation{Name: "stub-mcp-oauth", Version: "1.0.0"}, nil)
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:        "ping",
+		Description: "Ping the server",
+	}, func(context.Context, *gomcp.CallToolRequest, struct{}) (*gomcp.CallToolResult, any, error) {
+		return &gomcp.CallToolResult{Content: []gomcp.Content{&gomcp.TextContent{Text: "pong"}}}, nil, nil
+	})
+	mcpHandler := gomcp.NewStreamableHTTPHandler(
+		func(*http.Request) *gomcp.Server { return server },
+		&gomcp.StreamableHTTPOptions{Stateless: false, JSONResponse: true},
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", listener.Addr().(*net.TCPAddr).Port)
+
+	registeredClientID := "dyn-client-1"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-protected-resource", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"resource":              baseURL,
+			"authorization_servers": []string{baseURL},
+		})
+	})
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 baseURL,
+			"authorization_endpoint": baseURL + "/authorize",
+			"token_endpoint":         baseURL + "/token",
+			"registration_endpoint":  baseURL + "/register",
+		})
+	})
+	mux.HandleFunc("/register", func(w http.ResponseWriter, r *http.Request) {
+		var metadata map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&metadata); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"client_id": registeredClientID})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Form.Get("client_id") != registeredClientID ||
+			r.Form.Get("grant_type") != "authorization_code" ||
+			r.Form.Get("code") != "stub-auth-code" ||
+			r.Form.Get("code_verifier") == "" {
+			http.Error(w, "invalid token request", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  oauthAccessToken,
+			"refresh_token": "stub-refresh-token",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+oauthAccessToken {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf("Bearer resource_metadata=%q", baseURL+"/.well-known/oauth-protected-resource"))
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mcpHandler.ServeHTTP(w, r)
+	})
+
+	srv := &http.Server{Handler: mux}
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	})
+
+	return baseURL
+}
+
+func TestMCPOAuthFlowAuthorizesSession(t *testing.T) {
+	baseURL := startOAuthStubServer(t)
+
+	var authorizationURL string
+	toolkit := llmmcp.NewMCPToolkit(llmmcp.StaticMCPInit[struct{}](
+		llmmcp.NewMCPStreamableHTTPParamsWithOAuth(baseURL, llmmcp.MCPOAuthParams{
+			RedirectURL: "http://localhost:8976/callback",
+			Authorize: func(_ context.Context, authURL string) (string, string, error) {
+				authorizationURL = authURL
+				parsed, err := url.Parse(authURL)
+				if err != nil {
+					return "", "", err
+				}
+				return "stub-auth-code", parsed.Query().Get("state"), nil
+			},
+		}),
+	))
+
+	session, err := toolkit.CreateSession(t.Context(), struct{}{})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	t.Cleanup(func() {
+		if cerr := session.Close(t.Context()); cerr != nil {
+			t.Errorf("close session: %v", cerr)
+		}
+	})
+
+	tools := session.Tools()
+	if len(tools) != 1 || tools[0].Name() != "ping" {
+		t.Fatalf("expected the ping tool after authorization, got %+v", tools)
+	}
+
+	parsed, err := url.Parse(authorizationURL)
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("client_id") != "dyn-client-1" {
+		t.Fatalf("expected dynamically registered client_id, got %q", query.Get("client_id"))
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("expected a PKCE challenge in the authorization url, got %q", authorizationURL)
+	}
+}
+
+func TestMCPOAuthParamsRequireAuthorizeCallback(t *testing.T) {
+	toolkit := llmmcp.NewMCPToolkit(llmmcp.StaticMCPInit[struct{}](
+		llmmcp.NewMCPStreamableHTTPParamsWithOAuth("http://127.0.0.1:1/mcp", llmmcp.MCPOAuthParams{
+			RedirectURL: "http://localhost:8976/callback",
+		}),
+	))
+
+	_, err := toolkit.CreateSession(t.Context(), struct{}{})
+	if err == nil || !strings.Contains(err.Error(), "Authorize") {
+		t.Fatalf("expected missing Authorize callback error, got %v", err)
+	}
+}
+
+func TestMCPOAuthParamsRequireRedirectURL(t *testing.T) {
+	toolkit := llmmcp.NewMCPToolkit(llmmcp.StaticMCPInit[struct{}](
+		llmmcp.NewMCPStreamableHTTPParamsWithOAuth("http://127.0.0.1:1/mcp", llmmcp.MCPOAuthParams{
+			Authorize: func(context.Context, string) (string, string, error) {
+				return "", "", nil
+			},
+		}),
+	))
+
+	_, err := toolkit.CreateSession(t.Context(), struct{}{})
+	if err == nil || !strings.Contains(err.Error(), "redirect url") {
+		t.Fatalf("expected missing redirect url error, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// listPromptsTool exposes the MCP prompt catalog to the model so it can
+// discover the templates the server ships.
+type listPromptsTool[C any] struct {
+	session *mcp.ClientSession
+}
+
+func (t *listPromptsTool[C]) Name() string {
+	return "list_prompts"
+}
+
+func (t *listPromptsTool[C]) Description() string {
+	return "List the prompt templates available on the connected MCP server. Use get_prompt to render a template with arguments."
+}
+
+func (t *listPromptsTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type":                 "object",
+		"properties":           map[string]any{},
+		"additionalProperties": false,
+	}
+}
+
+func (t *listPromptsTool[C]) Execute(ctx context.Context, _ json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	prompts, err := fetchPrompts(ctx, t.session)
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+
+	type argumentInfo struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Required    bool   `json:"required,omitempty"`
+	}
+	type promptInfo struct {
+		Name        string         `json:"name"`
+		Description string         `json:"description,omitempty"`
+		Arguments   []argumentInfo `json:"arguments,omitempty"`
+	}
+	infos := make([]promptInfo, 0, len(prompts))
+	for _, prompt := range prompts {
+		info := promptInfo{Name: prompt.Name, Description: prompt.Description}
+		for _, argument := range prompt.Arguments {
+			info.Arguments = append(info.Arguments, argumentInfo{
+				Name:        argument.Name,
+				Description: argument.Description,
+				Required:    argument.Required,
+			})
+		}
+		infos = append(infos, info)
+	}
+
+	encoded, err := json.Marshal(infos)
+	if err != nil {
+		return llmagent.AgentToolResult{}, fmt.Errorf("serialise MCP prompt list: %w", err)
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(string(encoded))},
+	}, nil
+}
+
+// getPromptTool renders an MCP prompt template with the supplied arguments and
+// returns the resulting messages as parts.
+type getPromptTool[C any] struct {
+	session *mcp.ClientSession
+}
+
+type getPromptInput struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments"`
+}
+
+func (t *getPromptTool[C]) Name() string {
+	return "get_prompt"
+}
+
+func (t *getPromptTool[C]) Description() string {
+	return "Render an MCP prompt template by name with the given arguments. Use list_prompts to discover template names and arguments."
+}
+
+func (t *getPromptTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"name": map[string]any{
+				"type":        "string",
+				"description": "The name of the prompt template to render.",
+			},
+			"arguments": map[string]any{
+				"type":                 "object",
+				"description":          "Arguments used to template the prompt.",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+		},
+		"required":             []string{"name"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *getPromptTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var input getPromptInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return llmagent.AgentToolResult{}, fmt.Errorf("decode get_prompt args: %w", err)
+	}
+
+	result, err := t.session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      input.Name,
+		Arguments: input.Arguments,
+	})
+	if err != nil {
+		return llmagent.AgentToolResult{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(fmt.Sprintf("get MCP prompt %s: %v", input.Name, err))},
+			IsError: true,
+		}, nil
+	}
+
+	var parts []llmsdk.Part
+	for _, message := range result.Messages {
+		messageParts, convErr := convertMCPContentToParts([]mcp.Content{message.Content})
+		if convErr != nil {
+			return llmagent.AgentToolResult{}, convErr
+		}
+		parts = append(parts, messageParts...)
+	}
+	return llmagent.AgentToolResult{Content: parts}, nil
+}
+
+// fetchPrompts walks the MCP pagination API to build the full prompt collection.
+func fetchPrompts(ctx context.Context, session *mcp.ClientSession) ([]*mcp.Prompt, error) {
+	var (
+		cursor    *string
+		collected []*mcp.Prompt
+	)
+
+	for {
+		var params *mcp.ListPromptsParams
+		if cursor != nil {
+			params = &mcp.ListPromptsParams{Cursor: *cursor}
+		}
+
+		result, err := session.ListPrompts(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("list MCP prompts: %w", err)
+		}
+
+		collected = append(collected, result.Prompts...)
+
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = &result.NextCursor
+	}
+
+	return collected, nil
+}
@@ -0,0 +1,194 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/partutil"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// listResourcesTool exposes the MCP resource catalog to the model so it can
+// discover what the server publishes before reading it.
+type listResourcesTool[C any] struct {
+	session *mcp.ClientSession
+}
+
+func (t *listResourcesTool[C]) Name() string {
+	return "list_resources"
+}
+
+func (t *listResourcesTool[C]) Description() string {
+	return "List the resources available on the connected MCP server. Use read_resource to retrieve the content of a resource by its URI."
+}
+
+func (t *listResourcesTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type":                 "object",
+		"properties":           map[string]any{},
+		"additionalProperties": false,
+	}
+}
+
+func (t *listResourcesTool[C]) Execute(ctx context.Context, _ json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	resources, err := fetchResources(ctx, t.session)
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+
+	type resourceInfo struct {
+		URI         string `json:"uri"`
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		MIMEType    string `json:"mime_type,omitempty"`
+	}
+	infos := make([]resourceInfo, 0, len(resources))
+	for _, resource := range resources {
+		infos = append(infos, resourceInfo{
+			URI:         resource.URI,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MIMEType:    resource.MIMEType,
+		})
+	}
+
+	encoded, err := json.Marshal(infos)
+	if err != nil {
+		return llmagent.AgentToolResult{}, fmt.Errorf("serialise MCP resource list: %w", err)
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(string(encoded))},
+	}, nil
+}
+
+// readResourceTool retrieves a single MCP resource and surfaces its content as
+// parts the model can consume directly.
+type readResourceTool[C any] struct {
+	session *mcp.ClientSession
+}
+
+type readResourceInput struct {
+	URI string `json:"uri"`
+}
+
+func (t *readResourceTool[C]) Name() string {
+	return "read_resource"
+}
+
+func (t *readResourceTool[C]) Description() string {
+	return "Read the content of an MCP resource by its URI. Use list_resources to discover available URIs."
+}
+
+func (t *readResourceTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"uri": map[string]any{
+				"type":        "string",
+				"description": "The URI of the resource to read.",
+			},
+		},
+		"required":             []string{"uri"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *readResourceTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var input readResourceInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return llmagent.AgentToolResult{}, fmt.Errorf("decode read_resource args: %w", err)
+	}
+
+	result, err := t.session.ReadResource(ctx, &mcp.ReadResourceParams{URI: input.URI})
+	if err != nil {
+		return llmagent.AgentToolResult{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(fmt.Sprintf("read MCP resource %s: %v", input.URI, err))},
+			IsError: true,
+		}, nil
+	}
+
+	parts, err := convertResourceContentsToParts(result.Contents)
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	return llmagent.AgentToolResult{Content: parts}, nil
+}
+
+// fetchResources walks the MCP pagination API to build the full resource collection.
+func fetchResources(ctx context.Context, session *mcp.ClientSession) ([]*mcp.Resource, error) {
+	var (
+		cursor    *string
+		collected []*mcp.Resource
+	)
+
+	for {
+		var params *mcp.ListResourcesParams
+		if cursor != nil {
+			params = &mcp.ListResourcesParams{Cursor: *cursor}
+		}
+
+		result, err := session.ListResources(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("list MCP resources: %w", err)
+		}
+
+		collected = append(collected, result.Resources...)
+
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = &result.NextCursor
+	}
+
+	return collected, nil
+}
+
+// convertResourceContentsToParts maps MCP resource contents to parts.
+// Text contents become text parts; binary blobs are mapped by MIME type, and
+// unrepresentable blobs are skipped so partial results still surface.
+func convertResourceContentsToParts(contents []*mcp.ResourceContents) ([]llmsdk.Part, error) {
+	parts := make([]llmsdk.Part, 0, len(contents))
+
+	for _, content := range contents {
+		switch {
+		case content.Text != "":
+			parts = append(parts, llmsdk.NewTextPart(content.Text))
+		case len(content.Blob) > 0:
+			encoded := base64.StdEncoding.EncodeToString(content.Blob)
+			switch {
+			case strings.HasPrefix(content.MIMEType, "image/"):
+				parts = append(parts, llmsdk.NewImagePart(encoded, content.MIMEType))
+			case strings.HasPrefix(content.MIMEType, "audio/"):
+				format, err := partutil.MapMimeTypeToAudioFormat(content.MIMEType)
+				if err != nil {
+					return nil, fmt.Errorf("unsupported MCP resource audio format %q: %w", content.MIMEType, err)
+				}
+				parts = append(parts, llmsdk.NewAudioPart(encoded, format))
+			}
+		}
+	}
+
+	return parts, nil
+}
+
+// readResourceText reads a resource and joins its text contents, used when a
+// resource is pinned into the session instructions.
+func readResourceText(ctx context.Context, session *mcp.ClientSession, uri string) (string, error) {
+	result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		return "", fmt.Errorf("read MCP resource %s: %w", uri, err)
+	}
+
+	var texts []string
+	for _, content := range result.Contents {
+		if content.Text != "" {
+			texts = append(texts, content.Text)
+		}
+	}
+	return strings.Join(texts, "\n"), nil
+}
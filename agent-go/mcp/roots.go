@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MCPRoot is a filesystem root advertised to the MCP server. File-oriented
+// servers query roots via roots/list to learn which directories they may
+// operate on.
+type MCPRoot struct {
+	// Name is an optional human-readable identifier for the root.
+	Name string `json:"name,omitempty"`
+	// URI identifies the root and must use the file:// scheme.
+	URI string `json:"uri"`
+}
+
+// MCPRootsResolver derives the advertised roots from the agent context value
+// when the session is created (e.g. per-user workspace directories).
+type MCPRootsResolver[C any] func(ctx context.Context, contextVal C) ([]MCPRoot, error)
+
+// WithRoots advertises a static set of filesystem roots to the MCP server.
+func WithRoots[C any](roots ...MCPRoot) MCPToolkitOption[C] {
+	return WithRootsResolver(func(context.Context, C) ([]MCPRoot, error) {
+		return roots, nil
+	})
+}
+
+// WithRootsResolver advertises filesystem roots derived from the agent
+// context value.
+func WithRootsResolver[C any](resolver MCPRootsResolver[C]) MCPToolkitOption[C] {
+	return func(t *toolkit[C]) {
+		t.rootsResolver = resolver
+	}
+}
+
+// validateRoots ensures every advertised root uses the file:// scheme, which
+// the MCP specification currently requires.
+func validateRoots(roots []MCPRoot) error {
+	for _, root := range roots {
+		if !strings.HasPrefix(root.URI, "file://") {
+			return fmt.Errorf("mcp root %q must use the file:// scheme", root.URI)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	llmmcp "github.com/hoangvvo/llm-sdk/agent-go/mcp"
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// startRootsStubServer exposes a tool that queries the client's roots via
+// roots/list and reports them back.
+func startRootsStubServer(t *testing.T) string {
+	t.Helper()
+
+	server := gomcp.NewServer(&gomcp.Implementation{Name: "stub-mcp-roots", Version: "1.0.0"}, nil)
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:        "list_client_roots",
+		Description: "Report the filesystem roots advertised by the client",
+	}, func(ctx context.Context, req *gomcp.CallToolRequest, _ struct{}) (*gomcp.CallToolResult, any, error) {
+		result, err := req.Session.ListRoots(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		var uris []string
+		for _, root := range result.Roots {
+			uris = append(uris, root.URI)
+		}
+		return &gomcp.CallToolResult{
+			Content: []gomcp.Content{&gomcp.TextContent{Text: strings.Join(uris, ",")}},
+		}, nil, nil
+	})
+
+	handler := gomcp.NewStreamableHTTPHandler(
+		func(*http.Request) *gomcp.Server { return server },
+		&gomcp.StreamableHTTPOptions{Stateless: false, JSONResponse: true},
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", listener.Addr().(*net.TCPAddr).Port)
+
+	srv := &http.Server{Handler: handler}
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	})
+
+	return baseURL
+}
+
+func TestMCPToolkitSessionAdvertisesRoots(t *testing.T) {
+	baseURL := startRootsStubServer(t)
+	ctx := t.Context()
+
+	type workspaceContext struct {
+		Dir string
+	}
+
+	toolkit := llmmcp.NewMCPToolkit(
+		llmmcp.StaticMCPInit[workspaceContext](llmmcp.NewMCPStreamableHTTPParams(baseURL, "")),
+		llmmcp.WithRootsResolver(func(_ context.Context, contextVal workspaceContext) ([]llmmcp.MCPRoot, error) {
+			return []llmmcp.MCPRoot{{Name: "workspace", URI: "file://" + contextVal.Dir}}, nil
+		}),
+	)
+
+	session, err := toolkit.CreateSession(ctx, workspaceContext{Dir: "/srv/workspace"})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	t.Cleanup(func() {
+		if cerr := session.Close(ctx); cerr != nil {
+			t.Errorf("close session: %v", cerr)
+		}
+	})
+
+	tools := session.Tools()
+	if len(tools) != 1 {
+		t.Fatalf("expected one tool, got %+v", tools)
+	}
+
+	result, err := tools[0].AsFunctionTool().Execute(ctx, nil, workspaceContext{}, nil)
+	if err != nil {
+		t.Fatalf("execute tool: %v", err)
+	}
+	if len(result.Content) != 1 || result.Content[0].TextPart.Text != "file:///srv/workspace" {
+		t.Fatalf("expected the advertised root, got %+v", result.Content)
+	}
+}
+
+func TestMCPToolkitRejectsNonFileRoots(t *testing.T) {
+	toolkit := llmmcp.NewMCPToolkit(
+		llmmcp.StaticMCPInit[struct{}](llmmcp.NewMCPStdioParams("server", nil)),
+		llmmcp.WithRoots[struct{}](llmmcp.MCPRoot{URI: "https://example.com"}),
+	)
+
+	_, err := toolkit.CreateSession(t.Context(), struct{}{})
+	if err == nil || !strings.Contains(err.Error(), "file://") {
+		t.Fatalf("expected file:// scheme error, got %v", err)
+	}
+}
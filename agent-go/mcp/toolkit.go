@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
@@ -14,13 +15,48 @@ import (
 
 // toolkit wires the MCP integration into the llmagent toolkit primitive so the agent can hydrate remote tools on demand.
 type toolkit[C any] struct {
-	init MCPInit[C]
+	init            MCPInit[C]
+	exposeResources bool
+	exposePrompts   bool
+	instructionURIs []string
+	rootsResolver   MCPRootsResolver[C]
+}
+
+// MCPToolkitOption customises which MCP server features the toolkit exposes.
+type MCPToolkitOption[C any] func(*toolkit[C])
+
+// WithResources exposes the server's resources to the model through the
+// synthetic list_resources and read_resource tools.
+func WithResources[C any]() MCPToolkitOption[C] {
+	return func(t *toolkit[C]) {
+		t.exposeResources = true
+	}
+}
+
+// WithPrompts exposes the server's prompt templates to the model through the
+// synthetic list_prompts and get_prompt tools.
+func WithPrompts[C any]() MCPToolkitOption[C] {
+	return func(t *toolkit[C]) {
+		t.exposePrompts = true
+	}
+}
+
+// WithInstructionResources reads the given resource URIs when the session is
+// created and pins their text content into the session system prompt.
+func WithInstructionResources[C any](uris ...string) MCPToolkitOption[C] {
+	return func(t *toolkit[C]) {
+		t.instructionURIs = uris
+	}
 }
 
 // NewMCPToolkit returns an implementation of llmagent.Toolkit that sources tools from the Model Context Protocol.
 // The init resolver can inspect the agent context (e.g., pull user-specific auth data) before the session connects.
-func NewMCPToolkit[C any](init MCPInit[C]) llmagent.Toolkit[C] {
-	return &toolkit[C]{init: init}
+func NewMCPToolkit[C any](init MCPInit[C], options ...MCPToolkitOption[C]) llmagent.Toolkit[C] {
+	t := &toolkit[C]{init: init}
+	for _, option := range options {
+		option(t)
+	}
+	return t
 }
 
 // CreateSession resolves the per-run MCP configuration and bootstraps an MCP-backed toolkit session.
@@ -33,7 +69,18 @@ func (t *toolkit[C]) CreateSession(ctx context.Context, contextVal C) (llmagent.
 		return nil, errors.New("mcp params missing variant")
 	}
 
-	session, err := newToolkitSession[C](ctx, params)
+	var roots []MCPRoot
+	if t.rootsResolver != nil {
+		roots, err = t.rootsResolver(ctx, contextVal)
+		if err != nil {
+			return nil, fmt.Errorf("resolve MCP roots: %w", err)
+		}
+		if err := validateRoots(roots); err != nil {
+			return nil, err
+		}
+	}
+
+	session, err := newToolkitSession[C](ctx, params, t, roots)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +93,14 @@ type toolkitSession[C any] struct {
 	transport mcp.Transport
 	session   *mcp.ClientSession
 
+	exposeResources bool
+	exposePrompts   bool
+	instructionURIs []string
+	// filter narrows and renames the surfaced tools per the resolved params.
+	filter mcpToolFilter
+	// systemPrompt caches instruction resource content resolved at session creation.
+	systemPrompt *string
+
 	mu sync.RWMutex
 	// tools caches the latest snapshot surfaced to the agent runtime.
 	tools []llmagent.AgentTool[C]
@@ -54,14 +109,18 @@ type toolkitSession[C any] struct {
 }
 
 // newToolkitSession prepares transport + client scaffolding and completes the MCP handshake.
-func newToolkitSession[C any](ctx context.Context, params MCPParams) (*toolkitSession[C], error) {
+func newToolkitSession[C any](ctx context.Context, params MCPParams, t *toolkit[C], roots []MCPRoot) (*toolkitSession[C], error) {
 	transport, err := buildTransport(params)
 	if err != nil {
 		return nil, err
 	}
 	s := &toolkitSession[C]{
-		transport: transport,
-		tools:     make([]llmagent.AgentTool[C], 0),
+		transport:       transport,
+		tools:           make([]llmagent.AgentTool[C], 0),
+		exposeResources: t.exposeResources,
+		exposePrompts:   t.exposePrompts,
+		instructionURIs: t.instructionURIs,
+		filter:          params.filter,
 	}
 	clientOpts := &mcp.ClientOptions{
 		ToolListChangedHandler: func(ctx context.Context, _ *mcp.ToolListChangedRequest) {
@@ -70,6 +129,16 @@ func newToolkitSession[C any](ctx context.Context, params MCPParams) (*toolkitSe
 	}
 	s.client = mcp.NewClient(&mcp.Implementation{Name: "llm-agent-go", Version: "0.1.0"}, clientOpts)
 
+	if len(roots) > 0 {
+		mcpRoots := make([]*mcp.Root, 0, len(roots))
+		for _, root := range roots {
+			mcpRoots = append(mcpRoots, &mcp.Root{Name: root.Name, URI: root.URI})
+		}
+		// Register roots before connecting so the server can query roots/list
+		// immediately after the handshake.
+		s.client.AddRoots(mcpRoots...)
+	}
+
 	if err := s.initialize(ctx); err != nil {
 		_ = s.Close(ctx)
 		return nil, err
@@ -91,12 +160,30 @@ func (s *toolkitSession[C]) initialize(ctx context.Context) error {
 	if err := s.reloadTools(ctx); err != nil {
 		return err
 	}
+
+	if len(s.instructionURIs) > 0 {
+		var texts []string
+		for _, uri := range s.instructionURIs {
+			text, err := readResourceText(ctx, s.session, uri)
+			if err != nil {
+				return err
+			}
+			if text != "" {
+				texts = append(texts, text)
+			}
+		}
+		if len(texts) > 0 {
+			prompt := strings.Join(texts, "\n")
+			s.systemPrompt = &prompt
+		}
+	}
 	return nil
 }
 
-// SystemPrompt keeps parity with the Toolkit contract; MCP does not expose instructions so we return nil.
+// SystemPrompt surfaces instruction resource content when configured; MCP does
+// not expose instructions natively so it is nil otherwise.
 func (s *toolkitSession[C]) SystemPrompt() *string {
-	return nil
+	return s.systemPrompt
 }
 
 // Tools exposes the latest cached tool list, surfacing asynchronous discovery errors immediately.
@@ -108,9 +195,21 @@ func (s *toolkitSession[C]) Tools() []llmagent.AgentTool[C] {
 		panic(fmt.Errorf("mcp tool discovery failed: %w", s.toolListErr))
 	}
 
-	out := make([]llmagent.AgentTool[C], len(s.tools))
+	out := make([]llmagent.AgentTool[C], 0, len(s.tools)+4)
 	// Copy the tools into the output slice so we don't leak the internal slice.
-	copy(out, s.tools)
+	out = append(out, s.tools...)
+	if s.exposeResources {
+		out = append(out,
+			llmagent.NewAgentFunctionTool[C](&listResourcesTool[C]{session: s.session}),
+			llmagent.NewAgentFunctionTool[C](&readResourceTool[C]{session: s.session}),
+		)
+	}
+	if s.exposePrompts {
+		out = append(out,
+			llmagent.NewAgentFunctionTool[C](&listPromptsTool[C]{session: s.session}),
+			llmagent.NewAgentFunctionTool[C](&getPromptTool[C]{session: s.session}),
+		)
+	}
 	return out
 }
 
@@ -163,6 +262,9 @@ func (s *toolkitSession[C]) fetchTools(ctx context.Context) ([]llmagent.AgentToo
 		}
 
 		for _, tool := range result.Tools {
+			if !s.filter.allows(tool.Name) {
+				continue
+			}
 			agentTool, convErr := s.toAgentTool(tool)
 			if convErr != nil {
 				return nil, convErr
@@ -194,15 +296,19 @@ func (s *toolkitSession[C]) toAgentTool(tool *mcp.Tool) (llmagent.AgentTool[C],
 
 	return llmagent.NewAgentFunctionTool(&agentTool[C]{
 		session:     s.session,
-		name:        tool.Name,
+		name:        s.filter.ToolPrefix + tool.Name,
+		remoteName:  tool.Name,
 		description: tool.Description,
 		parameters:  schema,
 	}), nil
 }
 
 type agentTool[C any] struct {
-	session     *mcp.ClientSession
+	session *mcp.ClientSession
+	// name is the surfaced tool name, possibly prefixed; remoteName is the
+	// name the server knows the tool by.
 	name        string
+	remoteName  string
 	description string
 	parameters  llmsdk.JSONSchema
 }
@@ -234,11 +340,11 @@ func (t *agentTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C,
 	}
 
 	result, err := t.session.CallTool(ctx, &mcp.CallToolParams{
-		Name:      t.name,
+		Name:      t.remoteName,
 		Arguments: arguments,
 	})
 	if err != nil {
-		return llmagent.AgentToolResult{}, fmt.Errorf("call MCP tool %s: %w", t.name, err)
+		return llmagent.AgentToolResult{}, fmt.Errorf("call MCP tool %s: %w", t.remoteName, err)
 	}
 
 	parts, err := convertMCPContentToParts(result.Content)
@@ -41,6 +41,15 @@ var (
 
 const authToken = "mcp-test-token"
 
+// modelItemFrom builds the model item the session records for a response,
+// stamped with the producing model's provenance.
+func modelItemFrom(model llmsdk.LanguageModel, response llmsdk.ModelResponse) llmagent.AgentItem {
+	item := llmagent.NewAgentItemModelResponse(response)
+	item.Model.Provider = model.Provider()
+	item.Model.ModelID = model.ModelID()
+	return item
+}
+
 func startStubMCPServer() (*stubServer, error) {
 	server := gomcp.NewServer(&gomcp.Implementation{Name: "stub-mcp", Version: "1.0.0"}, nil)
 
@@ -175,7 +184,7 @@ func TestMCPToolkitSessionHydratesToolsAndExecutes(t *testing.T) {
 		Status:  llmagent.AgentResponseStatusCompleted,
 		Content: []llmsdk.Part{llmsdk.NewTextPart("Ready to roll.")},
 		Output: []llmagent.AgentItem{
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart("call_1", "list_shuttles", toolCallArgs),
 				},
@@ -191,7 +200,7 @@ func TestMCPToolkitSessionHydratesToolsAndExecutes(t *testing.T) {
 				},
 				llmsdk.ToolResultStatusCompleted,
 			),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{llmsdk.NewTextPart("Ready to roll.")},
 			}),
 		},
@@ -269,7 +278,7 @@ func TestMCPToolkitSessionRefreshesToolsOnChange(t *testing.T) {
 		Status:  llmagent.AgentResponseStatusCompleted,
 		Content: []llmsdk.Part{llmsdk.NewTextPart("Ready to roll.")},
 		Output: []llmagent.AgentItem{
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart("call_1", "list_shuttles", toolCallArgs),
 				},
@@ -285,7 +294,7 @@ func TestMCPToolkitSessionRefreshesToolsOnChange(t *testing.T) {
 				},
 				llmsdk.ToolResultStatusCompleted,
 			),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{llmsdk.NewTextPart("Ready to roll.")},
 			}),
 		},
@@ -321,7 +330,7 @@ func TestMCPToolkitSessionRefreshesToolsOnChange(t *testing.T) {
 		Status:  llmagent.AgentResponseStatusCompleted,
 		Content: []llmsdk.Part{llmsdk.NewTextPart("Routes synced.")},
 		Output: []llmagent.AgentItem{
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart("call_2", "list_shuttles_v2", toolCallArgs),
 				},
@@ -333,7 +342,7 @@ func TestMCPToolkitSessionRefreshesToolsOnChange(t *testing.T) {
 				[]llmsdk.Part{llmsdk.NewTextPart("Updated shuttle roster for evening shift.")},
 				llmsdk.ToolResultStatusCompleted,
 			),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{llmsdk.NewTextPart("Routes synced.")},
 			}),
 		},
@@ -26,6 +26,14 @@ func buildTransport(params MCPParams) (mcp.Transport, error) {
 		transport := &mcp.StreamableClientTransport{
 			Endpoint: httpParams.URL,
 		}
+		if httpParams.OAuth != nil {
+			oauthTransport, err := newOAuthRoundTripper(httpParams.OAuth)
+			if err != nil {
+				return nil, err
+			}
+			transport.HTTPClient = &http.Client{Transport: oauthTransport}
+			return transport, nil
+		}
 		if token := strings.TrimSpace(httpParams.Authorization); token != "" {
 			client := &http.Client{Transport: &authHeaderRoundTripper{
 				base:  http.DefaultTransport,
@@ -23,6 +23,58 @@ func StaticMCPInit[C any](params MCPParams) MCPInit[C] {
 type MCPParams struct {
 	stdio          *MCPStdioParams
 	streamableHTTP *MCPStreamableHTTPParams
+	filter         mcpToolFilter
+}
+
+// mcpToolFilter narrows and renames the tools surfaced from an MCP server so
+// large servers don't flood the model with irrelevant tools. The filter is
+// applied to the cached tool snapshot on every reload, including reloads
+// triggered by tool list change notifications.
+type mcpToolFilter struct {
+	// AllowedTools restricts the surfaced tools to the named ones. Empty means all.
+	AllowedTools []string `json:"allowed_tools,omitempty"`
+	// ExcludedTools hides the named tools. Applied after AllowedTools.
+	ExcludedTools []string `json:"excluded_tools,omitempty"`
+	// ToolPrefix is prepended to surfaced tool names (e.g. "linear_"). Calls
+	// are forwarded to the server under the original name.
+	ToolPrefix string `json:"tool_prefix,omitempty"`
+}
+
+// allows reports whether a tool with the given remote name should be surfaced.
+func (f mcpToolFilter) allows(name string) bool {
+	for _, excluded := range f.ExcludedTools {
+		if excluded == name {
+			return false
+		}
+	}
+	if len(f.AllowedTools) == 0 {
+		return true
+	}
+	for _, allowed := range f.AllowedTools {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// WithAllowedTools returns params that only surface the named tools.
+func (p MCPParams) WithAllowedTools(names ...string) MCPParams {
+	p.filter.AllowedTools = names
+	return p
+}
+
+// WithExcludedTools returns params that hide the named tools.
+func (p MCPParams) WithExcludedTools(names ...string) MCPParams {
+	p.filter.ExcludedTools = names
+	return p
+}
+
+// WithToolPrefix returns params that prepend the prefix to surfaced tool
+// names, namespacing servers whose tool names would otherwise clash.
+func (p MCPParams) WithToolPrefix(prefix string) MCPParams {
+	p.filter.ToolPrefix = prefix
+	return p
 }
 
 // MCPStdioParams executes a local MCP server over stdio.
@@ -37,9 +89,12 @@ type MCPStdioParams struct {
 type MCPStreamableHTTPParams struct {
 	// URL is the base endpoint of the MCP server.
 	URL string `json:"url"`
-	// Authorization is an optional header value. OAuth flows are not handled automatically,
-	// so callers should supply a ready-to-use token when required.
+	// Authorization is an optional static header value, used as-is without any
+	// OAuth flow. Prefer OAuth for servers implementing the authorization spec.
 	Authorization string `json:"authorization,omitempty"`
+	// OAuth enables the MCP authorization flow (discovery, dynamic client
+	// registration, token refresh). Takes precedence over Authorization.
+	OAuth *MCPOAuthParams `json:"-"`
 }
 
 const (
@@ -57,6 +112,12 @@ func NewMCPStreamableHTTPParams(url, authorization string) MCPParams {
 	return MCPParams{streamableHTTP: &MCPStreamableHTTPParams{URL: url, Authorization: authorization}}
 }
 
+// NewMCPStreamableHTTPParamsWithOAuth constructs an MCPParams pointing at a
+// streamable HTTP server protected by the MCP authorization spec.
+func NewMCPStreamableHTTPParamsWithOAuth(url string, oauth MCPOAuthParams) MCPParams {
+	return MCPParams{streamableHTTP: &MCPStreamableHTTPParams{URL: url, OAuth: &oauth}}
+}
+
 // StdioParams returns the stdio configuration if this MCPParams targets a local process.
 func (p MCPParams) StdioParams() (*MCPStdioParams, bool) {
 	if p.stdio == nil {
@@ -84,14 +145,16 @@ func (p MCPParams) MarshalJSON() ([]byte, error) {
 		type alias struct {
 			Type string `json:"type"`
 			*MCPStdioParams
+			mcpToolFilter
 		}
-		return json.Marshal(alias{Type: paramTypeStdio, MCPStdioParams: p.stdio})
+		return json.Marshal(alias{Type: paramTypeStdio, MCPStdioParams: p.stdio, mcpToolFilter: p.filter})
 	case p.streamableHTTP != nil:
 		type alias struct {
 			Type string `json:"type"`
 			*MCPStreamableHTTPParams
+			mcpToolFilter
 		}
-		return json.Marshal(alias{Type: paramTypeStreamableHTTP, MCPStreamableHTTPParams: p.streamableHTTP})
+		return json.Marshal(alias{Type: paramTypeStreamableHTTP, MCPStreamableHTTPParams: p.streamableHTTP, mcpToolFilter: p.filter})
 	default:
 		return nil, errors.New("mcp params missing variant")
 	}
@@ -110,6 +173,7 @@ func (p *MCPParams) UnmarshalJSON(data []byte) error {
 	case paramTypeStdio:
 		var payload struct {
 			*MCPStdioParams
+			mcpToolFilter
 		}
 		if err := json.Unmarshal(data, &payload); err != nil {
 			return fmt.Errorf("decode MCP stdio params: %w", err)
@@ -119,10 +183,12 @@ func (p *MCPParams) UnmarshalJSON(data []byte) error {
 		}
 		p.stdio = payload.MCPStdioParams
 		p.streamableHTTP = nil
+		p.filter = payload.mcpToolFilter
 		return nil
 	case paramTypeStreamableHTTP:
 		var payload struct {
 			*MCPStreamableHTTPParams
+			mcpToolFilter
 		}
 		if err := json.Unmarshal(data, &payload); err != nil {
 			return fmt.Errorf("decode MCP streamable-http params: %w", err)
@@ -132,6 +198,7 @@ func (p *MCPParams) UnmarshalJSON(data []byte) error {
 		}
 		p.streamableHTTP = payload.MCPStreamableHTTPParams
 		p.stdio = nil
+		p.filter = payload.mcpToolFilter
 		return nil
 	default:
 		return fmt.Errorf("unknown mcp params type %q", probe.Type)
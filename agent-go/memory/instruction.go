@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+// MemoryPrompt explains the two-tier memory model to the agent. Combine it
+// with CoreMemoryInstruction so the agent knows when to store and search
+// memories.
+const MemoryPrompt = `You can remember information learned from interactions with the user in two types of memory called core memory and archival memory.
+Core memory is always available in your conversation context, providing essential, foundational context for keeping track of key details about the user.
+As core memory is limited in size, it is important to only store the most important information. For other less important details, use archival memory.
+Archival memory is infinite size, but is held outside of your immediate context, so you must explicitly run a search operation to see data inside it.
+Archival memory is used to remember less significant details about the user or information found during the conversation. When the user mentions a name, topic, or details you don't know, search your archival memory to see if you have any information about it.`
+
+// CoreMemoryInstruction returns an instruction that injects the current core
+// memory blocks into the system prompt. The store is queried each time the
+// instructions are resolved so the prompt reflects the latest state.
+func CoreMemoryInstruction[C any](store CoreStore) llmagent.InstructionParam[C] {
+	return llmagent.InstructionParam[C]{
+		Func: func(ctx context.Context, _ C) (string, error) {
+			blocks, err := store.Fetch(ctx)
+			if err != nil {
+				return "", err
+			}
+			b, err := json.Marshal(blocks)
+			if err != nil {
+				return "", err
+			}
+			return "Core memories (JSON list):\n" + string(b), nil
+		},
+	}
+}
@@ -0,0 +1,112 @@
+// Package memory provides core and archival memory primitives for agents.
+//
+// Core memory is small and always injected into the system prompt, giving the
+// agent foundational context about the user across runs. Archival memory is
+// unbounded and held outside the context window; the agent queries it
+// explicitly through a search tool. Both stores are defined as interfaces so
+// applications can plug in custom backends (e.g., databases or vector stores)
+// while reusing the default tools and instruction provider.
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// MemoryBlock is a single unit of remembered information.
+type MemoryBlock struct {
+	ID      string         `json:"id"`
+	Content string         `json:"content"`
+	Meta    map[string]any `json:"metadata,omitempty"`
+}
+
+// CoreStore stores the core memory blocks that are injected into the system
+// prompt on every run.
+type CoreStore interface {
+	// Fetch returns all core memory blocks.
+	Fetch(ctx context.Context) ([]MemoryBlock, error)
+	// Update adds or replaces a core memory block. An empty content deletes
+	// the block. It returns all core memory blocks after the update.
+	Update(ctx context.Context, block MemoryBlock) ([]MemoryBlock, error)
+}
+
+// ArchivalStore stores the archival memory blocks that the agent searches
+// explicitly.
+type ArchivalStore interface {
+	// Search returns the archival memory blocks matching the query.
+	Search(ctx context.Context, query string) ([]MemoryBlock, error)
+	// Update adds or replaces an archival memory block. An empty content
+	// deletes the block.
+	Update(ctx context.Context, block MemoryBlock) error
+}
+
+// InMemoryCoreStore is a CoreStore backed by an in-process map.
+// It is safe for concurrent use.
+type InMemoryCoreStore struct {
+	mu     sync.RWMutex
+	blocks map[string]MemoryBlock
+}
+
+// NewInMemoryCoreStore creates an empty in-memory core store.
+func NewInMemoryCoreStore() *InMemoryCoreStore {
+	return &InMemoryCoreStore{blocks: map[string]MemoryBlock{}}
+}
+
+func (s *InMemoryCoreStore) Fetch(ctx context.Context) ([]MemoryBlock, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := make([]MemoryBlock, 0, len(s.blocks))
+	for _, block := range s.blocks {
+		res = append(res, block)
+	}
+	return res, nil
+}
+
+func (s *InMemoryCoreStore) Update(ctx context.Context, block MemoryBlock) ([]MemoryBlock, error) {
+	s.mu.Lock()
+	if strings.TrimSpace(block.Content) == "" {
+		delete(s.blocks, block.ID)
+	} else {
+		s.blocks[block.ID] = block
+	}
+	s.mu.Unlock()
+	return s.Fetch(ctx)
+}
+
+// InMemoryArchivalStore is an ArchivalStore backed by an in-process map with
+// case-insensitive substring search. It is safe for concurrent use.
+type InMemoryArchivalStore struct {
+	mu     sync.RWMutex
+	blocks map[string]MemoryBlock
+}
+
+// NewInMemoryArchivalStore creates an empty in-memory archival store.
+func NewInMemoryArchivalStore() *InMemoryArchivalStore {
+	return &InMemoryArchivalStore{blocks: map[string]MemoryBlock{}}
+}
+
+func (s *InMemoryArchivalStore) Search(ctx context.Context, query string) ([]MemoryBlock, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	q := strings.ToLower(query)
+	res := []MemoryBlock{}
+	for _, block := range s.blocks {
+		if strings.Contains(strings.ToLower(block.ID), q) ||
+			strings.Contains(strings.ToLower(block.Content), q) {
+			res = append(res, block)
+		}
+	}
+	return res, nil
+}
+
+func (s *InMemoryArchivalStore) Update(ctx context.Context, block MemoryBlock) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if strings.TrimSpace(block.Content) == "" {
+		delete(s.blocks, block.ID)
+	} else {
+		s.blocks[block.ID] = block
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package memory_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/memory"
+)
+
+func TestInMemoryCoreStore(t *testing.T) {
+	store := memory.NewInMemoryCoreStore()
+	ctx := t.Context()
+
+	blocks, err := store.Update(ctx, memory.MemoryBlock{ID: "color", Content: "favorite color is blue"})
+	if err != nil {
+		t.Fatalf("update core store: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].ID != "color" {
+		t.Fatalf("expected one core block, got %#v", blocks)
+	}
+
+	blocks, err = store.Update(ctx, memory.MemoryBlock{ID: "color", Content: ""})
+	if err != nil {
+		t.Fatalf("delete core block: %v", err)
+	}
+	if len(blocks) != 0 {
+		t.Fatalf("expected empty content to delete the block, got %#v", blocks)
+	}
+}
+
+func TestInMemoryArchivalStore(t *testing.T) {
+	store := memory.NewInMemoryArchivalStore()
+	ctx := t.Context()
+
+	if err := store.Update(ctx, memory.MemoryBlock{ID: "q3-report", Content: "Key data sources for the Q3 report"}); err != nil {
+		t.Fatalf("update archival store: %v", err)
+	}
+	if err := store.Update(ctx, memory.MemoryBlock{ID: "other", Content: "unrelated"}); err != nil {
+		t.Fatalf("update archival store: %v", err)
+	}
+
+	results, err := store.Search(ctx, "Q3 Report")
+	if err != nil {
+		t.Fatalf("search archival store: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "q3-report" {
+		t.Fatalf("expected case-insensitive match on q3-report, got %#v", results)
+	}
+}
+
+func TestMemoryTools(t *testing.T) {
+	core := memory.NewInMemoryCoreStore()
+	archival := memory.NewInMemoryArchivalStore()
+	tools := memory.Tools[struct{}](core, archival)
+	if len(tools) != 3 {
+		t.Fatalf("expected 3 tools, got %d", len(tools))
+	}
+
+	updateTool := memory.NewCoreMemoryUpdateTool[struct{}](core)
+	params, _ := json.Marshal(map[string]string{"id": "color", "content": "blue"})
+	result, err := updateTool.Execute(t.Context(), params, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("execute core_memory_update: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected tool error: %#v", result)
+	}
+	if len(result.Content) != 1 || result.Content[0].TextPart == nil ||
+		!strings.Contains(result.Content[0].TextPart.Text, "core_memories") {
+		t.Fatalf("unexpected tool result: %#v", result.Content)
+	}
+
+	instruction := memory.CoreMemoryInstruction[struct{}](core)
+	prompt, err := instruction.Func(t.Context(), struct{}{})
+	if err != nil {
+		t.Fatalf("resolve core memory instruction: %v", err)
+	}
+	if !strings.Contains(prompt, "blue") {
+		t.Fatalf("expected instruction to include the stored block, got %q", prompt)
+	}
+}
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// CoreMemoryUpdateTool lets the agent add, replace, or delete core memory blocks.
+type CoreMemoryUpdateTool[C any] struct {
+	store CoreStore
+}
+
+// NewCoreMemoryUpdateTool creates the core_memory_update tool backed by the store.
+func NewCoreMemoryUpdateTool[C any](store CoreStore) *CoreMemoryUpdateTool[C] {
+	return &CoreMemoryUpdateTool[C]{store: store}
+}
+
+func (t *CoreMemoryUpdateTool[C]) Name() string { return "core_memory_update" }
+
+func (t *CoreMemoryUpdateTool[C]) Description() string {
+	return "Update or add a core memory block. Provide an empty content to delete the block. Returns all core memories after the update."
+}
+
+func (t *CoreMemoryUpdateTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"id":      map[string]any{"type": "string"},
+			"content": map[string]any{"type": "string"},
+		},
+		"required":             []string{"id", "content"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *CoreMemoryUpdateTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var in struct {
+		ID      string `json:"id"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(params, &in); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	id := strings.TrimSpace(in.ID)
+	if id == "" {
+		id = uuid.NewString()
+	}
+	updated, err := t.store.Update(ctx, MemoryBlock{ID: id, Content: in.Content})
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	b, err := json.Marshal(map[string]any{"core_memories": updated})
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart(string(b))}}, nil
+}
+
+// ArchivalMemorySearchTool lets the agent search the archival memory.
+type ArchivalMemorySearchTool[C any] struct {
+	store ArchivalStore
+}
+
+// NewArchivalMemorySearchTool creates the archival_memory_search tool backed by the store.
+func NewArchivalMemorySearchTool[C any](store ArchivalStore) *ArchivalMemorySearchTool[C] {
+	return &ArchivalMemorySearchTool[C]{store: store}
+}
+
+func (t *ArchivalMemorySearchTool[C]) Name() string { return "archival_memory_search" }
+
+func (t *ArchivalMemorySearchTool[C]) Description() string {
+	return "Search for memories in the archival memory"
+}
+
+func (t *ArchivalMemorySearchTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type":                 "object",
+		"properties":           map[string]any{"query": map[string]any{"type": "string"}},
+		"required":             []string{"query"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *ArchivalMemorySearchTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var in struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(params, &in); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	results, err := t.store.Search(ctx, in.Query)
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	b, err := json.Marshal(map[string]any{"results": results})
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart(string(b))}}, nil
+}
+
+// ArchivalMemoryUpdateTool lets the agent add, replace, or delete archival memory blocks.
+type ArchivalMemoryUpdateTool[C any] struct {
+	store ArchivalStore
+}
+
+// NewArchivalMemoryUpdateTool creates the archival_memory_update tool backed by the store.
+func NewArchivalMemoryUpdateTool[C any](store ArchivalStore) *ArchivalMemoryUpdateTool[C] {
+	return &ArchivalMemoryUpdateTool[C]{store: store}
+}
+
+func (t *ArchivalMemoryUpdateTool[C]) Name() string { return "archival_memory_update" }
+
+func (t *ArchivalMemoryUpdateTool[C]) Description() string {
+	return "Update or add a memory block in the archival memory. Provide an empty content to delete the block."
+}
+
+func (t *ArchivalMemoryUpdateTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"id":      map[string]any{"type": "string"},
+			"content": map[string]any{"type": "string"},
+		},
+		"required":             []string{"id", "content"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *ArchivalMemoryUpdateTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var in struct {
+		ID      string `json:"id"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(params, &in); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	id := strings.TrimSpace(in.ID)
+	if id == "" {
+		id = uuid.NewString()
+	}
+	if err := t.store.Update(ctx, MemoryBlock{ID: id, Content: in.Content}); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	var resp map[string]any
+	if strings.TrimSpace(in.Content) == "" {
+		resp = map[string]any{"success": true, "action": "deleted"}
+	} else {
+		resp = map[string]any{"success": true, "action": "updated", "memory": map[string]any{"id": id, "content": in.Content}}
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart(string(b))}}, nil
+}
+
+// Tools returns the default memory tools for the provided stores.
+// A nil store omits the corresponding tools.
+func Tools[C any](core CoreStore, archival ArchivalStore) []llmagent.AgentTool[C] {
+	var tools []llmagent.AgentTool[C]
+	if core != nil {
+		tools = append(tools, llmagent.NewAgentFunctionTool[C](NewCoreMemoryUpdateTool[C](core)))
+	}
+	if archival != nil {
+		tools = append(tools,
+			llmagent.NewAgentFunctionTool[C](NewArchivalMemorySearchTool[C](archival)),
+			llmagent.NewAgentFunctionTool[C](NewArchivalMemoryUpdateTool[C](archival)),
+		)
+	}
+	return tools
+}
@@ -0,0 +1,119 @@
+package llmagent
+
+import (
+	"errors"
+	"time"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/stream"
+)
+
+// MetricsRecorder receives metrics from finished agent runs. Implementations
+// must be safe for concurrent use. See the prommetrics package for a
+// Prometheus implementation.
+type MetricsRecorder interface {
+	// RecordRun records one finished run, successful or not.
+	RecordRun(metrics RunMetrics)
+}
+
+// RunMetrics summarizes one finished agent run.
+type RunMetrics struct {
+	// AgentName is the name of the agent that ran.
+	AgentName string
+	// Method is "run" or "run_stream".
+	Method string
+	// Status is the terminal status: "completed", "cancelled", or "error".
+	Status string
+	// Duration is how long the run took.
+	Duration time.Duration
+	// Turns is the number of model responses generated during the run.
+	Turns int
+	// ToolCalls is the number of tool executions recorded during the run.
+	ToolCalls int
+	// ToolErrors is the number of tool executions that reported an error.
+	ToolErrors int
+	// InputTokens and OutputTokens are the cumulative token usage, when the
+	// model reported usage.
+	InputTokens  int
+	OutputTokens int
+}
+
+// recordRunMetrics derives the run metrics from the outcome and forwards them
+// to the configured recorder. Failed runs contribute the partial items from
+// the error snapshot.
+func (s *RunSession[C]) recordRunMetrics(method string, duration time.Duration, response *AgentResponse, err error) {
+	recorder := s.params.Metrics
+	if recorder == nil {
+		return
+	}
+
+	metrics := RunMetrics{
+		AgentName: s.params.Name,
+		Method:    method,
+		Duration:  duration,
+	}
+
+	var output []AgentItem
+	switch {
+	case err != nil:
+		metrics.Status = "error"
+		var agentErr *AgentError
+		if errors.As(err, &agentErr) && agentErr.Snapshot != nil {
+			output = agentErr.Snapshot.Output
+		}
+	case response != nil:
+		metrics.Status = string(response.Status)
+		output = response.Output
+		if response.Usage != nil {
+			metrics.InputTokens = response.Usage.InputTokens
+			metrics.OutputTokens = response.Usage.OutputTokens
+		}
+	}
+
+	for _, item := range output {
+		if item.Model != nil {
+			metrics.Turns++
+		}
+		if item.Tool != nil {
+			metrics.ToolCalls++
+			if item.Tool.Status == llmsdk.ToolResultStatusFailed {
+				metrics.ToolErrors++
+			}
+		}
+	}
+
+	recorder.RecordRun(metrics)
+}
+
+// observeRunMetrics wraps a run stream so the run metrics are recorded when
+// the stream finishes.
+func (s *RunSession[C]) observeRunMetrics(method string, start time.Time, inner *AgentStream) *AgentStream {
+	if s.params.Metrics == nil {
+		return inner
+	}
+
+	eventCh := make(chan *AgentStreamEvent)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(eventCh)
+		defer close(errCh)
+
+		var finalResponse *AgentResponse
+		for inner.Next() {
+			event := inner.Current()
+			if event.Response != nil {
+				finalResponse = event.Response
+			}
+			eventCh <- event
+		}
+
+		err := inner.Err()
+		if err != nil {
+			errCh <- err
+		}
+		s.recordRunMetrics(method, time.Since(start), finalResponse, err)
+	}()
+
+	return stream.New(eventCh, errCh)
+}
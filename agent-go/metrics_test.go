@@ -0,0 +1,141 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+type captureMetricsRecorder struct {
+	mu      sync.Mutex
+	records []llmagent.RunMetrics
+}
+
+func (r *captureMetricsRecorder) RecordRun(metrics llmagent.RunMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, metrics)
+}
+
+func (r *captureMetricsRecorder) all() []llmagent.RunMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]llmagent.RunMetrics{}, r.records...)
+}
+
+func TestRun_RecordsMetricsForFinishedRuns(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	recorder := &captureMetricsRecorder{}
+
+	failingTool := llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("boom")},
+		IsError: true,
+	}
+	tool := NewMockTool[any]("lookup", failingTool, func(ctx context.Context, params json.RawMessage, contextVal any, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		return failingTool, nil
+	})
+
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "lookup", map[string]any{})},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 10, OutputTokens: 5},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 20, OutputTokens: 7},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "metered",
+		Model:    model,
+		Tools:    llmagent.FunctionTools[any](tool),
+		MaxTurns: 10,
+		Metrics:  recorder,
+	}, nil)
+
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("look it up"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := recorder.all()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 recorded run, got %d", len(records))
+	}
+	metrics := records[0]
+	if metrics.AgentName != "metered" || metrics.Method != "run" {
+		t.Errorf("unexpected identity: %+v", metrics)
+	}
+	if metrics.Status != string(llmagent.AgentResponseStatusCompleted) {
+		t.Errorf("expected completed status, got %q", metrics.Status)
+	}
+	if metrics.Turns != 2 {
+		t.Errorf("expected 2 turns, got %d", metrics.Turns)
+	}
+	if metrics.ToolCalls != 1 || metrics.ToolErrors != 1 {
+		t.Errorf("expected 1 tool call with 1 error, got %d/%d", metrics.ToolCalls, metrics.ToolErrors)
+	}
+	if metrics.InputTokens != 30 || metrics.OutputTokens != 12 {
+		t.Errorf("unexpected token totals: %d/%d", metrics.InputTokens, metrics.OutputTokens)
+	}
+	if metrics.Duration <= 0 {
+		t.Errorf("expected positive duration, got %v", metrics.Duration)
+	}
+}
+
+func TestRunStream_RecordsMetricsWhenStreamEnds(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	recorder := &captureMetricsRecorder{}
+
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("hello")}},
+		{Usage: &llmsdk.ModelUsage{InputTokens: 4, OutputTokens: 2}},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "metered",
+		Model:    model,
+		MaxTurns: 10,
+		Metrics:  recorder,
+	}, nil)
+
+	stream, err := session.RunStream(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("hi"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for stream.Next() {
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+
+	records := recorder.all()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 recorded run, got %d", len(records))
+	}
+	metrics := records[0]
+	if metrics.Method != "run_stream" {
+		t.Errorf("expected run_stream method, got %q", metrics.Method)
+	}
+	if metrics.Status != string(llmagent.AgentResponseStatusCompleted) {
+		t.Errorf("expected completed status, got %q", metrics.Status)
+	}
+	if metrics.Turns != 1 {
+		t.Errorf("expected 1 turn, got %d", metrics.Turns)
+	}
+	if metrics.InputTokens != 4 || metrics.OutputTokens != 2 {
+		t.Errorf("unexpected token totals: %d/%d", metrics.InputTokens, metrics.OutputTokens)
+	}
+}
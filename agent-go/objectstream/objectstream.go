@@ -0,0 +1,73 @@
+// Package objectstream incrementally parses streamed structured output into
+// progressively-complete typed objects, in the spirit of the AI SDK's
+// streamObject. When an agent runs with a JSON response format, wrap its
+// stream with Stream and render each partial object as it arrives instead of
+// waiting for the run to finish. Truncated JSON is repaired with RepairJSON
+// before decoding, so every emitted object unmarshals cleanly.
+package objectstream
+
+import (
+	"bytes"
+	"encoding/json"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/stream"
+)
+
+// Event is one update of an object stream. Exactly one field is non-nil:
+// Object carries a progressively more complete value while the model streams,
+// and Response carries the final agent response once the run finishes.
+type Event[T any] struct {
+	Object   *T
+	Response *llmagent.AgentResponse
+}
+
+// Stream wraps the agent stream and emits a typed partial object every time
+// the accumulated structured output parses to something new. Events that do
+// not carry text deltas (tool progress, items) are skipped; the final
+// response is re-parsed from the full text and emitted as an Object followed
+// by a Response event. Decode failures are silently skipped: mid-token
+// prefixes frequently repair to the previous object, which is not an error.
+func Stream[T any](agentStream *llmagent.AgentStream) *stream.Stream[*Event[T]] {
+	eventChan := make(chan *Event[T])
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(eventChan)
+		defer close(errChan)
+
+		var accumulated bytes.Buffer
+		var lastEmitted string
+		emit := func(raw string) {
+			repaired, ok := RepairJSON(raw)
+			if !ok || repaired == lastEmitted {
+				return
+			}
+			object := new(T)
+			if err := json.Unmarshal([]byte(repaired), object); err != nil {
+				return
+			}
+			lastEmitted = repaired
+			eventChan <- &Event[T]{Object: object}
+		}
+
+		for agentStream.Next() {
+			event := agentStream.Current()
+			switch {
+			case event.Partial != nil:
+				if delta := event.Partial.Delta; delta != nil && delta.Part.TextPartDelta != nil {
+					accumulated.WriteString(delta.Part.TextPartDelta.Text)
+					emit(accumulated.String())
+				}
+			case event.Response != nil:
+				emit(event.Response.Text())
+				eventChan <- &Event[T]{Response: event.Response}
+			}
+		}
+		if err := agentStream.Err(); err != nil {
+			errChan <- err
+		}
+	}()
+
+	return stream.New(eventChan, errChan)
+}
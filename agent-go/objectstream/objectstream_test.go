@@ -0,0 +1,133 @@
+package objectstream_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/objectstream"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRepairJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"complete object", `{"a":1}`, `{"a":1}`},
+		{"open string value", `{"name":"Ali`, `{"name":"Ali"}`},
+		{"dangling key", `{"name":"Alice","ag`, `{"name":"Alice"}`},
+		{"dangling colon", `{"name":"Alice","age":`, `{"name":"Alice"}`},
+		{"truncated number", `{"age":12`, `{"age":12}`},
+		{"truncated exponent", `{"age":12e`, `{"age":12}`},
+		{"partial literal", `{"done":tr`, `{"done":true}`},
+		{"nested object", `{"user":{"name":"Bo`, `{"user":{"name":"Bo"}}`},
+		{"array of objects", `[{"a":1},{"b":`, `[{"a":1},{}]`},
+		{"open array", `{"tags":["x","y`, `{"tags":["x","y"]}`},
+		{"mid escape", `{"s":"a\`, `{"s":"a"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := objectstream.RepairJSON(tc.in)
+			if !ok {
+				t.Fatalf("RepairJSON(%q) not ok", tc.in)
+			}
+			if got != tc.want {
+				t.Errorf("RepairJSON(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Errorf("RepairJSON(%q) = %q is not valid JSON", tc.in, got)
+			}
+		})
+	}
+
+	if _, ok := objectstream.RepairJSON("not json"); ok {
+		t.Error("expected RepairJSON to reject non-JSON input")
+	}
+}
+
+type recipe struct {
+	Name  string   `json:"name"`
+	Steps []string `json:"steps"`
+}
+
+func TestStreamEmitsProgressiveObjects(t *testing.T) {
+	chunks := []string{
+		`{"name":"Pho`,
+		`ng","steps":["boil `,
+		`broth","add noodles"`,
+		`]}`,
+	}
+	partials := make([]llmsdk.PartialModelResponse, len(chunks))
+	for i, chunk := range chunks {
+		partials[i] = llmsdk.PartialModelResponse{
+			Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta(chunk)},
+		}
+	}
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials(partials))
+
+	agent := llmagent.NewAgent[any]("recipes", model)
+	agentStream, err := agent.RunStream(t.Context(), llmagent.AgentRequest[any]{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("pho recipe"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to start stream: %v", err)
+	}
+
+	objects := []recipe{}
+	var response *llmagent.AgentResponse
+	objectStream := objectstream.Stream[recipe](agentStream)
+	for objectStream.Next() {
+		event := objectStream.Current()
+		if event.Object != nil {
+			objects = append(objects, *event.Object)
+		}
+		if event.Response != nil {
+			response = event.Response
+		}
+	}
+	if err := objectStream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	if len(objects) < 2 {
+		t.Fatalf("expected progressively complete objects, got %d", len(objects))
+	}
+	if objects[0].Name != "Pho" {
+		t.Errorf("expected the first partial to carry the repaired name, got %+v", objects[0])
+	}
+	final := objects[len(objects)-1]
+	if final.Name != "Phong" || len(final.Steps) != 2 || final.Steps[1] != "add noodles" {
+		t.Errorf("unexpected final object %+v", final)
+	}
+	if response == nil {
+		t.Fatal("expected the final response event")
+	}
+}
+
+func TestStreamPropagatesErrors(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultError(llmsdk.NewInvariantError("mock", "stream failed")))
+
+	agent := llmagent.NewAgent[any]("recipes", model)
+	agentStream, err := agent.RunStream(t.Context(), llmagent.AgentRequest[any]{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("pho recipe"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to start stream: %v", err)
+	}
+
+	objectStream := objectstream.Stream[recipe](agentStream)
+	for objectStream.Next() {
+	}
+	if err := objectStream.Err(); err == nil {
+		t.Error("expected the stream error propagated")
+	}
+}
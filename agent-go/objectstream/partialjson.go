@@ -0,0 +1,204 @@
+package objectstream
+
+import "strings"
+
+// RepairJSON completes a truncated JSON document so it can be unmarshalled
+// while the model is still streaming: open strings and brackets are closed,
+// partial literals are finished, and dangling keys or half-written tokens
+// that cannot be completed are dropped. It returns false when the input does
+// not look like the prefix of a JSON value at all.
+func RepairJSON(raw string) (string, bool) {
+	i := skipSpace(raw, 0)
+	if i >= len(raw) {
+		return "", false
+	}
+	var b strings.Builder
+	if _, ok := repairValue(raw, i, &b); !ok {
+		return "", false
+	}
+	return b.String(), true
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// repairValue parses one JSON value starting at i, writing the repaired value
+// to b. It returns the index after the value, or ok=false when no value can
+// be recovered from the input (e.g. a lone "-" or an unexpected character).
+func repairValue(s string, i int, b *strings.Builder) (int, bool) {
+	switch c := s[i]; {
+	case c == '"':
+		return repairString(s, i, b)
+	case c == '{':
+		return repairObject(s, i, b)
+	case c == '[':
+		return repairArray(s, i, b)
+	case c == '-' || (c >= '0' && c <= '9'):
+		return repairNumber(s, i, b)
+	case c == 't' || c == 'f' || c == 'n':
+		return repairLiteral(s, i, b)
+	default:
+		return i, false
+	}
+}
+
+// repairString consumes the string starting at the opening quote, closing it
+// when the input ends mid-string.
+func repairString(s string, i int, b *strings.Builder) (int, bool) {
+	start := i
+	i++
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			if i+1 >= len(s) {
+				// Ends mid-escape: drop the dangling backslash.
+				b.WriteString(s[start:i])
+				b.WriteByte('"')
+				return len(s), true
+			}
+			i += 2
+		case '"':
+			b.WriteString(s[start : i+1])
+			return i + 1, true
+		default:
+			i++
+		}
+	}
+	b.WriteString(s[start:])
+	b.WriteByte('"')
+	return len(s), true
+}
+
+func repairNumber(s string, i int, b *strings.Builder) (int, bool) {
+	start := i
+	for i < len(s) && strings.IndexByte("0123456789+-.eE", s[i]) >= 0 {
+		i++
+	}
+	token := s[start:i]
+	if i < len(s) {
+		// The number is complete; emit it as written.
+		b.WriteString(token)
+		return i, true
+	}
+	// Truncated: trim characters that need more digits to be valid.
+	token = strings.TrimRight(token, "+-.eE")
+	if token == "" || !strings.ContainsAny(token, "0123456789") {
+		return i, false
+	}
+	b.WriteString(token)
+	return i, true
+}
+
+var literals = []string{"true", "false", "null"}
+
+func repairLiteral(s string, i int, b *strings.Builder) (int, bool) {
+	rest := s[i:]
+	for _, literal := range literals {
+		if strings.HasPrefix(rest, literal) {
+			b.WriteString(literal)
+			return i + len(literal), true
+		}
+		// A truncated prefix at the end of input completes unambiguously.
+		if strings.HasPrefix(literal, rest) {
+			b.WriteString(literal)
+			return len(s), true
+		}
+	}
+	return i, false
+}
+
+func repairObject(s string, i int, b *strings.Builder) (int, bool) {
+	b.WriteByte('{')
+	i = skipSpace(s, i+1)
+	first := true
+	for i < len(s) {
+		if s[i] == '}' {
+			b.WriteByte('}')
+			return i + 1, true
+		}
+		if s[i] != '"' {
+			return i, false
+		}
+		// Parse the key into a scratch buffer so an unfinished pair can be
+		// dropped without un-writing output.
+		var pair strings.Builder
+		j, _ := repairString(s, i, &pair)
+		if j >= len(s) {
+			// Key truncated, or input ended before the colon: drop the pair.
+			break
+		}
+		j = skipSpace(s, j)
+		if j >= len(s) {
+			break
+		}
+		if s[j] != ':' {
+			return j, false
+		}
+		j = skipSpace(s, j+1)
+		if j >= len(s) {
+			// Dangling "key": with no value yet: drop the pair.
+			break
+		}
+		pair.WriteByte(':')
+		j, ok := repairValue(s, j, &pair)
+		if !ok {
+			// The value cannot be recovered; drop the pair.
+			break
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		b.WriteString(pair.String())
+		first = false
+
+		j = skipSpace(s, j)
+		if j >= len(s) {
+			break
+		}
+		if s[j] == ',' {
+			i = skipSpace(s, j+1)
+			continue
+		}
+		i = j
+	}
+	b.WriteByte('}')
+	return len(s), true
+}
+
+func repairArray(s string, i int, b *strings.Builder) (int, bool) {
+	b.WriteByte('[')
+	i = skipSpace(s, i+1)
+	first := true
+	for i < len(s) {
+		if s[i] == ']' {
+			b.WriteByte(']')
+			return i + 1, true
+		}
+		var element strings.Builder
+		j, ok := repairValue(s, i, &element)
+		if !ok {
+			break
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		b.WriteString(element.String())
+		first = false
+
+		j = skipSpace(s, j)
+		if j >= len(s) {
+			break
+		}
+		if s[j] == ',' {
+			i = skipSpace(s, j+1)
+			continue
+		}
+		i = j
+	}
+	b.WriteByte(']')
+	return len(s), true
+}
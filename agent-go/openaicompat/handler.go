@@ -0,0 +1,283 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// ContextResolver derives the agent context value from the incoming HTTP
+// request (e.g. from the Authorization header). The zero value of C is used
+// when no resolver is configured.
+type ContextResolver[C any] func(ctx context.Context, r *http.Request) (C, error)
+
+// HandlerOption customises the Chat Completions handler.
+type HandlerOption[C any] func(*Handler[C])
+
+// WithContextResolver resolves the agent context value per request.
+func WithContextResolver[C any](resolver ContextResolver[C]) HandlerOption[C] {
+	return func(h *Handler[C]) {
+		h.resolveContext = resolver
+	}
+}
+
+// Handler serves an Agent as an OpenAI Chat Completions endpoint. Mount it at
+// /v1/chat/completions (or any path) on an http.ServeMux.
+type Handler[C any] struct {
+	agent          *llmagent.Agent[C]
+	resolveContext ContextResolver[C]
+}
+
+// NewHandler wraps the agent in a Chat Completions-compatible handler.
+func NewHandler[C any](agent *llmagent.Agent[C], options ...HandlerOption[C]) *Handler[C] {
+	h := &Handler[C]{agent: agent}
+	for _, option := range options {
+		option(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler[C]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeChatError(w, http.StatusMethodNotAllowed, "method not allowed", "invalid_request_error")
+		return
+	}
+
+	var req ChatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeChatError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "invalid_request_error")
+		return
+	}
+	if len(req.Messages) == 0 {
+		writeChatError(w, http.StatusBadRequest, "messages must not be empty", "invalid_request_error")
+		return
+	}
+
+	items, err := chatMessagesToItems(req.Messages)
+	if err != nil {
+		writeChatError(w, http.StatusBadRequest, err.Error(), "invalid_request_error")
+		return
+	}
+
+	var contextVal C
+	if h.resolveContext != nil {
+		contextVal, err = h.resolveContext(r.Context(), r)
+		if err != nil {
+			writeChatError(w, http.StatusInternalServerError, "resolve context: "+err.Error(), "server_error")
+			return
+		}
+	}
+
+	agentReq := llmagent.AgentRequest[C]{
+		Input:   items,
+		Context: contextVal,
+	}
+	if req.Temperature != nil || req.TopP != nil || req.MaxTokens != nil {
+		agentReq.Sampling = &llmagent.SamplingOverrides{
+			Temperature: req.Temperature,
+			TopP:        req.TopP,
+			MaxTokens:   req.MaxTokens,
+		}
+	}
+
+	if req.Stream {
+		h.serveStream(w, r, req, agentReq)
+		return
+	}
+	h.serveCompletion(w, r, req, agentReq)
+}
+
+func (h *Handler[C]) serveCompletion(w http.ResponseWriter, r *http.Request, req ChatCompletionRequest, agentReq llmagent.AgentRequest[C]) {
+	response, err := h.agent.Run(r.Context(), agentReq)
+	if err != nil {
+		writeChatError(w, http.StatusInternalServerError, err.Error(), "server_error")
+		return
+	}
+
+	completion := ChatCompletionResponse{
+		ID:      completionID(),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+		Choices: []ChatCompletionChoice{{
+			Message: ChatCompletionMessage{
+				Role:    "assistant",
+				Content: partsText(response.Content),
+			},
+			FinishReason: "stop",
+		}},
+		Usage: usageFromModel(response.Usage),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(completion)
+}
+
+func (h *Handler[C]) serveStream(w http.ResponseWriter, r *http.Request, req ChatCompletionRequest, agentReq llmagent.AgentRequest[C]) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeChatError(w, http.StatusInternalServerError, "streaming unsupported", "server_error")
+		return
+	}
+
+	stream, err := h.agent.RunStream(r.Context(), agentReq)
+	if err != nil {
+		writeChatError(w, http.StatusInternalServerError, err.Error(), "server_error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	id := completionID()
+	created := time.Now().Unix()
+	emit := func(chunk ChatCompletionChunk) {
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+	newChunk := func(choices []ChatCompletionChunkChoice) ChatCompletionChunk {
+		return ChatCompletionChunk{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   req.Model,
+			Choices: choices,
+		}
+	}
+
+	emit(newChunk([]ChatCompletionChunkChoice{{Delta: ChatCompletionDelta{Role: "assistant"}}}))
+
+	var response *llmagent.AgentResponse
+	for stream.Next() {
+		event := stream.Current()
+		switch {
+		case event.Partial != nil:
+			if event.Partial.Delta == nil || event.Partial.Delta.Part.TextPartDelta == nil {
+				continue
+			}
+			emit(newChunk([]ChatCompletionChunkChoice{{
+				Delta: ChatCompletionDelta{Content: event.Partial.Delta.Part.TextPartDelta.Text},
+			}}))
+		case event.Response != nil:
+			response = event.Response
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		// The OpenAI stream format has no error chunk; surface the error in
+		// the standard error envelope before terminating the stream.
+		data, _ := json.Marshal(chatErrorResponse{Error: chatError{Message: err.Error(), Type: "server_error"}})
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+		return
+	}
+
+	finishReason := "stop"
+	emit(newChunk([]ChatCompletionChunkChoice{{FinishReason: &finishReason}}))
+
+	if req.StreamOptions != nil && req.StreamOptions.IncludeUsage && response != nil {
+		usageChunk := newChunk([]ChatCompletionChunkChoice{})
+		usageChunk.Usage = usageFromModel(response.Usage)
+		emit(usageChunk)
+	}
+
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// chatMessagesToItems converts the Chat Completions transcript into agent
+// input items. System and developer messages are folded into the conversation
+// as user messages since agent instructions are configured on the Agent.
+func chatMessagesToItems(messages []ChatMessage) ([]llmagent.AgentItem, error) {
+	items := make([]llmagent.AgentItem, 0, len(messages))
+	// Tool messages identify their call by ID only; remember call names from
+	// preceding assistant messages to rebuild the tool results.
+	toolNames := map[string]string{}
+
+	for _, message := range messages {
+		switch message.Role {
+		case "system", "developer", "user":
+			text := message.Content.Text()
+			if text == "" {
+				continue
+			}
+			items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text))))
+		case "assistant":
+			var parts []llmsdk.Part
+			if text := message.Content.Text(); text != "" {
+				parts = append(parts, llmsdk.NewTextPart(text))
+			}
+			for _, call := range message.ToolCalls {
+				if call.ID == "" || call.Function.Name == "" {
+					return nil, fmt.Errorf("assistant tool call missing id or function name")
+				}
+				toolNames[call.ID] = call.Function.Name
+				parts = append(parts, llmsdk.NewToolCallPart(call.ID, call.Function.Name, json.RawMessage(call.Function.Arguments)))
+			}
+			if len(parts) == 0 {
+				continue
+			}
+			items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(parts...)))
+		case "tool":
+			if message.ToolCallID == "" {
+				return nil, fmt.Errorf("tool message missing tool_call_id")
+			}
+			name := toolNames[message.ToolCallID]
+			if name == "" {
+				name = message.Name
+			}
+			items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewToolMessage(
+				llmsdk.NewToolResultPart(message.ToolCallID, name, []llmsdk.Part{
+					llmsdk.NewTextPart(message.Content.Text()),
+				}),
+			)))
+		default:
+			return nil, fmt.Errorf("unsupported message role %q", message.Role)
+		}
+	}
+
+	return items, nil
+}
+
+func partsText(parts []llmsdk.Part) string {
+	text := ""
+	for _, part := range parts {
+		if part.TextPart != nil {
+			text += part.TextPart.Text
+		}
+	}
+	return text
+}
+
+func usageFromModel(usage *llmsdk.ModelUsage) *ChatCompletionUsage {
+	if usage == nil {
+		return nil
+	}
+	return &ChatCompletionUsage{
+		PromptTokens:     usage.InputTokens,
+		CompletionTokens: usage.OutputTokens,
+		TotalTokens:      usage.InputTokens + usage.OutputTokens,
+	}
+}
+
+func completionID() string {
+	return "chatcmpl-" + uuid.NewString()
+}
+
+func writeChatError(w http.ResponseWriter, status int, message, errType string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(chatErrorResponse{Error: chatError{Message: message, Type: errType}})
+}
@@ -0,0 +1,220 @@
+package openaicompat_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/openaicompat"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func newTestServer(t *testing.T, model *llmsdktest.MockLanguageModel) *httptest.Server {
+	t.Helper()
+	agent := llmagent.NewAgent[struct{}]("assistant", model)
+	ts := httptest.NewServer(openaicompat.NewHandler(agent))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestChatCompletions(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Hello there")},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 12, OutputTokens: 3},
+	}))
+	ts := newTestServer(t, model)
+
+	body, _ := json.Marshal(map[string]any{
+		"model": "agent",
+		"messages": []map[string]any{
+			{"role": "system", "content": "Be terse."},
+			{"role": "user", "content": "Hi"},
+		},
+		"temperature": 0.2,
+	})
+	resp, err := http.Post(ts.URL+"/v1/chat/completions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var completion openaicompat.ChatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if completion.Object != "chat.completion" || len(completion.Choices) != 1 {
+		t.Fatalf("expected a single-choice completion, got %+v", completion)
+	}
+	choice := completion.Choices[0]
+	if choice.Message.Content != "Hello there" || choice.FinishReason != "stop" {
+		t.Fatalf("expected the assistant text, got %+v", choice)
+	}
+	if completion.Usage == nil || completion.Usage.TotalTokens != 15 {
+		t.Fatalf("expected mapped usage, got %+v", completion.Usage)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 || len(inputs[0].Messages) != 2 {
+		t.Fatalf("expected system and user messages forwarded, got %+v", inputs)
+	}
+	if inputs[0].Temperature == nil || *inputs[0].Temperature != 0.2 {
+		t.Fatalf("expected the temperature override, got %+v", inputs[0].Temperature)
+	}
+}
+
+func TestChatCompletionsToolHistory(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Sunny")},
+	}))
+	ts := newTestServer(t, model)
+
+	body, _ := json.Marshal(map[string]any{
+		"model": "agent",
+		"messages": []map[string]any{
+			{"role": "user", "content": "Weather?"},
+			{"role": "assistant", "tool_calls": []map[string]any{{
+				"id":   "call_1",
+				"type": "function",
+				"function": map[string]any{
+					"name":      "get_weather",
+					"arguments": `{"location":"Hanoi"}`,
+				},
+			}}},
+			{"role": "tool", "tool_call_id": "call_1", "content": `{"condition":"sunny"}`},
+		},
+	})
+	resp, err := http.Post(ts.URL+"/v1/chat/completions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 || len(inputs[0].Messages) != 3 {
+		t.Fatalf("expected three forwarded messages, got %+v", inputs)
+	}
+	assistant := inputs[0].Messages[1].AssistantMessage
+	if assistant == nil || assistant.Content[0].ToolCallPart == nil ||
+		assistant.Content[0].ToolCallPart.Call.Function.Name != "get_weather" {
+		t.Fatalf("expected the rebuilt tool call, got %+v", inputs[0].Messages[1])
+	}
+	tool := inputs[0].Messages[2].ToolMessage
+	if tool == nil || tool.Content[0].ToolResultPart == nil ||
+		tool.Content[0].ToolResultPart.Result.Function.Name != "get_weather" {
+		t.Fatalf("expected the tool result with the recovered name, got %+v", inputs[0].Messages[2])
+	}
+}
+
+func TestChatCompletionsStream(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("Hel")}},
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("lo")}},
+	}))
+	ts := newTestServer(t, model)
+
+	body, _ := json.Marshal(map[string]any{
+		"model":          "agent",
+		"messages":       []map[string]any{{"role": "user", "content": "Hi"}},
+		"stream":         true,
+		"stream_options": map[string]any{"include_usage": true},
+	})
+	resp, err := http.Post(ts.URL+"/v1/chat/completions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected SSE content type, got %q", ct)
+	}
+
+	var (
+		content      strings.Builder
+		sawRole      bool
+		sawFinish    bool
+		sawDone      bool
+		usageChunk   *openaicompat.ChatCompletionUsage
+		totalObjects int
+	)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		payload := strings.TrimPrefix(line, "data: ")
+		if payload == "[DONE]" {
+			sawDone = true
+			continue
+		}
+		var chunk openaicompat.ChatCompletionChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			t.Fatalf("decode chunk %q: %v", payload, err)
+		}
+		if chunk.Object != "chat.completion.chunk" {
+			t.Fatalf("expected chunk object, got %+v", chunk)
+		}
+		totalObjects++
+		if chunk.Usage != nil {
+			usageChunk = chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Role == "assistant" {
+				sawRole = true
+			}
+			content.WriteString(choice.Delta.Content)
+			if choice.FinishReason != nil && *choice.FinishReason == "stop" {
+				sawFinish = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+
+	if !sawRole || !sawFinish || !sawDone {
+		t.Fatalf("expected role, finish, and [DONE] markers (role=%v finish=%v done=%v)", sawRole, sawFinish, sawDone)
+	}
+	if content.String() != "Hello" {
+		t.Fatalf("expected streamed content %q, got %q", "Hello", content.String())
+	}
+	if totalObjects < 3 {
+		t.Fatalf("expected at least role, content, and finish chunks, got %d", totalObjects)
+	}
+	_ = usageChunk // usage is only present when the mock reports it
+}
+
+func TestChatCompletionsRejectsEmptyMessages(t *testing.T) {
+	ts := newTestServer(t, llmsdktest.NewMockLanguageModel())
+
+	body := []byte(`{"model":"agent","messages":[]}`)
+	resp, err := http.Post(ts.URL+"/v1/chat/completions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	var errResp struct {
+		Error struct {
+			Type string `json:"type"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if errResp.Error.Type != "invalid_request_error" {
+		t.Fatalf("expected invalid_request_error, got %+v", errResp)
+	}
+}
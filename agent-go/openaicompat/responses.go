@@ -0,0 +1,377 @@
+package openaicompat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// ResponsesRequest is the subset of the OpenAI Responses API request body the
+// adapter understands.
+type ResponsesRequest struct {
+	Model           string         `json:"model"`
+	Input           ResponsesInput `json:"input"`
+	Stream          bool           `json:"stream,omitempty"`
+	Temperature     *float64       `json:"temperature,omitempty"`
+	TopP            *float64       `json:"top_p,omitempty"`
+	MaxOutputTokens *uint32        `json:"max_output_tokens,omitempty"`
+}
+
+// ResponsesInput is the Responses API input value: either a plain string
+// (treated as a user message) or an array of input items.
+type ResponsesInput struct {
+	Items []ResponseInputItem
+}
+
+func (i *ResponsesInput) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		i.Items = []ResponseInputItem{{
+			Type:    "message",
+			Role:    "user",
+			Content: NewMessageContent(text),
+		}}
+		return nil
+	}
+	return json.Unmarshal(data, &i.Items)
+}
+
+func (i ResponsesInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Items)
+}
+
+// ResponseInputItem is a single Responses API input item. Message items use
+// Role and Content; function_call items use CallID, Name, and Arguments;
+// function_call_output items use CallID and Output.
+type ResponseInputItem struct {
+	Type      string         `json:"type,omitempty"`
+	Role      string         `json:"role,omitempty"`
+	Content   MessageContent `json:"content,omitempty"`
+	CallID    string         `json:"call_id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Arguments string         `json:"arguments,omitempty"`
+	Output    string         `json:"output,omitempty"`
+}
+
+// ResponseObject is a completed Responses API response.
+type ResponseObject struct {
+	ID        string               `json:"id"`
+	Object    string               `json:"object"`
+	CreatedAt int64                `json:"created_at"`
+	Model     string               `json:"model"`
+	Status    string               `json:"status"`
+	Output    []ResponseOutputItem `json:"output"`
+	Usage     *ResponseUsage       `json:"usage,omitempty"`
+}
+
+// ResponseOutputItem is a single output item: an assistant message, a
+// function call executed during the run, or its output.
+type ResponseOutputItem struct {
+	Type      string                `json:"type"`
+	ID        string                `json:"id,omitempty"`
+	Status    string                `json:"status,omitempty"`
+	Role      string                `json:"role,omitempty"`
+	Content   []ResponseContentPart `json:"content,omitempty"`
+	CallID    string                `json:"call_id,omitempty"`
+	Name      string                `json:"name,omitempty"`
+	Arguments string                `json:"arguments,omitempty"`
+	Output    string                `json:"output,omitempty"`
+}
+
+// ResponseContentPart is output message content; only output_text is
+// produced.
+type ResponseContentPart struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ResponseUsage mirrors the Responses API usage object.
+type ResponseUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+// responsesStreamEvent is an SSE event in the Responses streaming protocol.
+type responsesStreamEvent struct {
+	Type     string              `json:"type"`
+	Delta    string              `json:"delta,omitempty"`
+	Item     *ResponseOutputItem `json:"item,omitempty"`
+	Response *ResponseObject     `json:"response,omitempty"`
+	Message  string              `json:"message,omitempty"`
+}
+
+// ResponsesHandler serves an Agent as an OpenAI Responses API endpoint.
+// Mount it at /v1/responses (or any path) on an http.ServeMux.
+type ResponsesHandler[C any] struct {
+	agent          *llmagent.Agent[C]
+	resolveContext ContextResolver[C]
+}
+
+// ResponsesHandlerOption customises the Responses handler.
+type ResponsesHandlerOption[C any] func(*ResponsesHandler[C])
+
+// WithResponsesContextResolver resolves the agent context value per request.
+func WithResponsesContextResolver[C any](resolver ContextResolver[C]) ResponsesHandlerOption[C] {
+	return func(h *ResponsesHandler[C]) {
+		h.resolveContext = resolver
+	}
+}
+
+// NewResponsesHandler wraps the agent in a Responses API-compatible handler.
+func NewResponsesHandler[C any](agent *llmagent.Agent[C], options ...ResponsesHandlerOption[C]) *ResponsesHandler[C] {
+	h := &ResponsesHandler[C]{agent: agent}
+	for _, option := range options {
+		option(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *ResponsesHandler[C]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeChatError(w, http.StatusMethodNotAllowed, "method not allowed", "invalid_request_error")
+		return
+	}
+
+	var req ResponsesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeChatError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "invalid_request_error")
+		return
+	}
+	if len(req.Input.Items) == 0 {
+		writeChatError(w, http.StatusBadRequest, "input must not be empty", "invalid_request_error")
+		return
+	}
+
+	items, err := responseInputToItems(req.Input.Items)
+	if err != nil {
+		writeChatError(w, http.StatusBadRequest, err.Error(), "invalid_request_error")
+		return
+	}
+
+	var contextVal C
+	if h.resolveContext != nil {
+		contextVal, err = h.resolveContext(r.Context(), r)
+		if err != nil {
+			writeChatError(w, http.StatusInternalServerError, "resolve context: "+err.Error(), "server_error")
+			return
+		}
+	}
+
+	agentReq := llmagent.AgentRequest[C]{
+		Input:   items,
+		Context: contextVal,
+	}
+	if req.Temperature != nil || req.TopP != nil || req.MaxOutputTokens != nil {
+		agentReq.Sampling = &llmagent.SamplingOverrides{
+			Temperature: req.Temperature,
+			TopP:        req.TopP,
+			MaxTokens:   req.MaxOutputTokens,
+		}
+	}
+
+	if req.Stream {
+		h.serveStream(w, r, req, agentReq)
+		return
+	}
+
+	response, err := h.agent.Run(r.Context(), agentReq)
+	if err != nil {
+		writeChatError(w, http.StatusInternalServerError, err.Error(), "server_error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(responseObjectFromAgent(req.Model, response))
+}
+
+func (h *ResponsesHandler[C]) serveStream(w http.ResponseWriter, r *http.Request, req ResponsesRequest, agentReq llmagent.AgentRequest[C]) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeChatError(w, http.StatusInternalServerError, "streaming unsupported", "server_error")
+		return
+	}
+
+	stream, err := h.agent.RunStream(r.Context(), agentReq)
+	if err != nil {
+		writeChatError(w, http.StatusInternalServerError, err.Error(), "server_error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	emit := func(event responsesStreamEvent) {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		flusher.Flush()
+	}
+
+	emit(responsesStreamEvent{Type: "response.created"})
+
+	for stream.Next() {
+		event := stream.Current()
+		switch {
+		case event.Partial != nil:
+			if event.Partial.Delta == nil || event.Partial.Delta.Part.TextPartDelta == nil {
+				continue
+			}
+			emit(responsesStreamEvent{
+				Type:  "response.output_text.delta",
+				Delta: event.Partial.Delta.Part.TextPartDelta.Text,
+			})
+		case event.Item != nil:
+			for _, outputItem := range outputItemsFromAgentItem(event.Item.Item) {
+				item := outputItem
+				emit(responsesStreamEvent{Type: "response.output_item.done", Item: &item})
+			}
+		case event.Response != nil:
+			response := responseObjectFromAgent(req.Model, event.Response)
+			emit(responsesStreamEvent{Type: "response.completed", Response: &response})
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		emit(responsesStreamEvent{Type: "error", Message: err.Error()})
+	}
+}
+
+// responseInputToItems converts Responses API input items into agent input
+// items. System and developer messages are folded into the conversation as
+// user messages since agent instructions are configured on the Agent.
+func responseInputToItems(input []ResponseInputItem) ([]llmagent.AgentItem, error) {
+	items := make([]llmagent.AgentItem, 0, len(input))
+	toolNames := map[string]string{}
+
+	for _, item := range input {
+		itemType := item.Type
+		if itemType == "" && item.Role != "" {
+			itemType = "message"
+		}
+		switch itemType {
+		case "message":
+			text := item.Content.Text()
+			if text == "" {
+				continue
+			}
+			switch item.Role {
+			case "system", "developer", "user":
+				items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text))))
+			case "assistant":
+				items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart(text))))
+			default:
+				return nil, fmt.Errorf("unsupported message role %q", item.Role)
+			}
+		case "function_call":
+			if item.CallID == "" || item.Name == "" {
+				return nil, fmt.Errorf("function_call item missing call_id or name")
+			}
+			toolNames[item.CallID] = item.Name
+			items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(
+				llmsdk.NewToolCallPart(item.CallID, item.Name, json.RawMessage(item.Arguments)),
+			)))
+		case "function_call_output":
+			if item.CallID == "" {
+				return nil, fmt.Errorf("function_call_output item missing call_id")
+			}
+			items = append(items, llmagent.NewAgentItemMessage(llmsdk.NewToolMessage(
+				llmsdk.NewToolResultPart(item.CallID, toolNames[item.CallID], []llmsdk.Part{
+					llmsdk.NewTextPart(item.Output),
+				}),
+			)))
+		default:
+			return nil, fmt.Errorf("unsupported input item type %q", item.Type)
+		}
+	}
+
+	return items, nil
+}
+
+// responseObjectFromAgent maps the agent response to a Responses API object,
+// surfacing tool calls executed during the run as output items.
+func responseObjectFromAgent(model string, response *llmagent.AgentResponse) ResponseObject {
+	var output []ResponseOutputItem
+	for _, item := range response.Output {
+		output = append(output, outputItemsFromAgentItem(item)...)
+	}
+
+	object := ResponseObject{
+		ID:        "resp_" + uuid.NewString(),
+		Object:    "response",
+		CreatedAt: time.Now().Unix(),
+		Model:     model,
+		Status:    "completed",
+		Output:    output,
+	}
+	if response.Usage != nil {
+		object.Usage = &ResponseUsage{
+			InputTokens:  response.Usage.InputTokens,
+			OutputTokens: response.Usage.OutputTokens,
+			TotalTokens:  response.Usage.InputTokens + response.Usage.OutputTokens,
+		}
+	}
+	return object
+}
+
+// outputItemsFromAgentItem maps a run output item to Responses API output
+// items: assistant text becomes a message item, tool call parts become
+// function_call items, and tool items become function_call plus
+// function_call_output items.
+func outputItemsFromAgentItem(item llmagent.AgentItem) []ResponseOutputItem {
+	switch {
+	case item.Model != nil:
+		return outputItemsFromParts(item.Model.Content)
+	case item.Message != nil && item.Message.AssistantMessage != nil:
+		return outputItemsFromParts(item.Message.AssistantMessage.Content)
+	case item.Tool != nil:
+		return []ResponseOutputItem{{
+			Type:   "function_call_output",
+			ID:     "fco_" + uuid.NewString(),
+			CallID: item.Tool.ToolCallID,
+			Output: partsText(item.Tool.Output),
+			Status: string(item.Tool.Status),
+		}}
+	default:
+		return nil
+	}
+}
+
+func outputItemsFromParts(parts []llmsdk.Part) []ResponseOutputItem {
+	var items []ResponseOutputItem
+	var content []ResponseContentPart
+	for _, part := range parts {
+		switch {
+		case part.TextPart != nil:
+			content = append(content, ResponseContentPart{Type: "output_text", Text: part.TextPart.Text})
+		case part.ToolCallPart != nil && part.ToolCallPart.Call.Function != nil:
+			items = append(items, ResponseOutputItem{
+				Type:      "function_call",
+				ID:        "fc_" + uuid.NewString(),
+				Status:    "completed",
+				CallID:    part.ToolCallPart.ToolCallID,
+				Name:      part.ToolCallPart.Call.Function.Name,
+				Arguments: string(part.ToolCallPart.Call.Function.Args),
+			})
+		}
+	}
+	if len(content) > 0 {
+		items = append([]ResponseOutputItem{{
+			Type:    "message",
+			ID:      "msg_" + uuid.NewString(),
+			Status:  "completed",
+			Role:    "assistant",
+			Content: content,
+		}}, items...)
+	}
+	return items
+}
@@ -0,0 +1,184 @@
+package openaicompat_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/openaicompat"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+type echoTool struct{}
+
+func (t *echoTool) Name() string        { return "echo" }
+func (t *echoTool) Description() string { return "Echo the input back." }
+func (t *echoTool) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{"type": "object"}
+}
+
+func (t *echoTool) Execute(_ context.Context, params json.RawMessage, _ struct{}, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(string(params))},
+	}, nil
+}
+
+func newResponsesTestServer(t *testing.T, model *llmsdktest.MockLanguageModel) *httptest.Server {
+	t.Helper()
+	agent := llmagent.NewAgent("assistant", model,
+		llmagent.WithTools(llmagent.NewAgentFunctionTool[struct{}](&echoTool{})),
+	)
+	ts := httptest.NewServer(openaicompat.NewResponsesHandler(agent))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestResponsesEndpoint(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "echo", map[string]any{"text": "hi"})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Echoed!")},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 10, OutputTokens: 5},
+	}))
+	ts := newResponsesTestServer(t, model)
+
+	body := []byte(`{"model":"agent","input":"Say hi via the tool"}`)
+	resp, err := http.Post(ts.URL+"/v1/responses", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var response openaicompat.ResponseObject
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if response.Object != "response" || response.Status != "completed" {
+		t.Fatalf("expected a completed response object, got %+v", response)
+	}
+
+	var types []string
+	for _, item := range response.Output {
+		types = append(types, item.Type)
+	}
+	want := []string{"function_call", "function_call_output", "message"}
+	if strings.Join(types, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected output item types %v, got %v", want, types)
+	}
+
+	call := response.Output[0]
+	if call.CallID != "call_1" || call.Name != "echo" {
+		t.Fatalf("expected the echo function call, got %+v", call)
+	}
+	output := response.Output[1]
+	if output.CallID != "call_1" || !strings.Contains(output.Output, "hi") {
+		t.Fatalf("expected the function call output, got %+v", output)
+	}
+	message := response.Output[2]
+	if message.Role != "assistant" || message.Content[0].Text != "Echoed!" {
+		t.Fatalf("expected the assistant message, got %+v", message)
+	}
+	if response.Usage == nil || response.Usage.TotalTokens != 15 {
+		t.Fatalf("expected aggregated usage, got %+v", response.Usage)
+	}
+}
+
+func TestResponsesEndpointReplaysFunctionCallHistory(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Done")},
+	}))
+	ts := newResponsesTestServer(t, model)
+
+	body := []byte(`{"model":"agent","input":[
+		{"type":"message","role":"user","content":"Run echo"},
+		{"type":"function_call","call_id":"call_9","name":"echo","arguments":"{\"text\":\"prior\"}"},
+		{"type":"function_call_output","call_id":"call_9","output":"{\"text\":\"prior\"}"}
+	]}`)
+	resp, err := http.Post(ts.URL+"/v1/responses", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 || len(inputs[0].Messages) != 3 {
+		t.Fatalf("expected three forwarded messages, got %+v", inputs)
+	}
+	assistant := inputs[0].Messages[1].AssistantMessage
+	if assistant == nil || assistant.Content[0].ToolCallPart == nil ||
+		assistant.Content[0].ToolCallPart.ToolCallID != "call_9" {
+		t.Fatalf("expected the replayed function call, got %+v", inputs[0].Messages[1])
+	}
+	tool := inputs[0].Messages[2].ToolMessage
+	if tool == nil || tool.Content[0].ToolResultPart == nil ||
+		tool.Content[0].ToolResultPart.Result.Function.Name != "echo" {
+		t.Fatalf("expected the replayed function call output, got %+v", inputs[0].Messages[2])
+	}
+}
+
+func TestResponsesEndpointStream(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("Hel")}},
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("lo")}},
+	}))
+	ts := newResponsesTestServer(t, model)
+
+	body := []byte(`{"model":"agent","input":"Hi","stream":true}`)
+	resp, err := http.Post(ts.URL+"/v1/responses", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var (
+		eventTypes []string
+		deltas     strings.Builder
+		completed  *openaicompat.ResponseObject
+	)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var event struct {
+			Type     string                           `json:"type"`
+			Delta    string                           `json:"delta"`
+			Response *openaicompat.ResponseObject     `json:"response"`
+			Item     *openaicompat.ResponseOutputItem `json:"item"`
+		}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			t.Fatalf("decode event %q: %v", line, err)
+		}
+		eventTypes = append(eventTypes, event.Type)
+		deltas.WriteString(event.Delta)
+		if event.Type == "response.completed" {
+			completed = event.Response
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+
+	if eventTypes[0] != "response.created" {
+		t.Fatalf("expected the created event first, got %v", eventTypes)
+	}
+	if deltas.String() != "Hello" {
+		t.Fatalf("expected streamed text %q, got %q", "Hello", deltas.String())
+	}
+	if completed == nil || len(completed.Output) == 0 ||
+		completed.Output[0].Content[0].Text != "Hello" {
+		t.Fatalf("expected the completed response with the final message, got %+v", completed)
+	}
+}
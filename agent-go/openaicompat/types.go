@@ -0,0 +1,173 @@
+// Package openaicompat mounts an Agent behind an OpenAI Chat
+// Completions-compatible endpoint so existing OpenAI clients can talk to a
+// local agent. The handler supports both streaming and non-streaming calls;
+// tool calls are executed by the agent itself, so clients only receive the
+// final assistant message and aggregated usage.
+package openaicompat
+
+import "encoding/json"
+
+// ChatCompletionRequest is the subset of the Chat Completions request body
+// the adapter understands.
+type ChatCompletionRequest struct {
+	Model         string         `json:"model"`
+	Messages      []ChatMessage  `json:"messages"`
+	Stream        bool           `json:"stream,omitempty"`
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+	Temperature   *float64       `json:"temperature,omitempty"`
+	TopP          *float64       `json:"top_p,omitempty"`
+	MaxTokens     *uint32        `json:"max_tokens,omitempty"`
+}
+
+// StreamOptions mirrors the Chat Completions stream_options object.
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
+// ChatMessage is a single Chat Completions message. Content accepts either a
+// plain string or an array of content parts.
+type ChatMessage struct {
+	Role       string         `json:"role"`
+	Content    MessageContent `json:"content"`
+	Name       string         `json:"name,omitempty"`
+	ToolCalls  []ChatToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string         `json:"tool_call_id,omitempty"`
+}
+
+// MessageContent is a Chat Completions content value: either a string or an
+// array of typed content parts. Only text parts are supported.
+type MessageContent struct {
+	parts []string
+}
+
+// NewMessageContent constructs content from plain text.
+func NewMessageContent(text string) MessageContent {
+	return MessageContent{parts: []string{text}}
+}
+
+// Text joins the textual content parts.
+func (c MessageContent) Text() string {
+	switch len(c.parts) {
+	case 0:
+		return ""
+	case 1:
+		return c.parts[0]
+	default:
+		joined := ""
+		for _, part := range c.parts {
+			joined += part
+		}
+		return joined
+	}
+}
+
+func (c MessageContent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Text())
+}
+
+func (c *MessageContent) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		c.parts = []string{text}
+		return nil
+	}
+	var null any
+	if err := json.Unmarshal(data, &null); err == nil && null == nil {
+		c.parts = nil
+		return nil
+	}
+	var typedParts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &typedParts); err != nil {
+		return err
+	}
+	c.parts = c.parts[:0]
+	for _, part := range typedParts {
+		switch part.Type {
+		// input_text and output_text are the Responses API spellings.
+		case "text", "input_text", "output_text":
+			c.parts = append(c.parts, part.Text)
+		}
+	}
+	return nil
+}
+
+// ChatToolCall is an assistant tool call echoed back by the client as part of
+// the conversation history.
+type ChatToolCall struct {
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function ChatToolFunction `json:"function"`
+}
+
+// ChatToolFunction carries the function name and JSON-encoded arguments.
+type ChatToolFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ChatCompletionResponse is a non-streaming Chat Completions response.
+type ChatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []ChatCompletionChoice `json:"choices"`
+	Usage   *ChatCompletionUsage   `json:"usage,omitempty"`
+}
+
+// ChatCompletionChoice is a single response choice. The adapter always
+// produces exactly one.
+type ChatCompletionChoice struct {
+	Index        int                   `json:"index"`
+	Message      ChatCompletionMessage `json:"message"`
+	FinishReason string                `json:"finish_reason"`
+}
+
+// ChatCompletionMessage is the assistant message of a completed response.
+type ChatCompletionMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatCompletionUsage mirrors the Chat Completions usage object.
+type ChatCompletionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// ChatCompletionChunk is a streaming response chunk.
+type ChatCompletionChunk struct {
+	ID      string                      `json:"id"`
+	Object  string                      `json:"object"`
+	Created int64                       `json:"created"`
+	Model   string                      `json:"model"`
+	Choices []ChatCompletionChunkChoice `json:"choices"`
+	Usage   *ChatCompletionUsage        `json:"usage,omitempty"`
+}
+
+// ChatCompletionChunkChoice is a delta choice in a streaming chunk.
+type ChatCompletionChunkChoice struct {
+	Index        int                 `json:"index"`
+	Delta        ChatCompletionDelta `json:"delta"`
+	FinishReason *string             `json:"finish_reason"`
+}
+
+// ChatCompletionDelta carries the incremental assistant content.
+type ChatCompletionDelta struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// chatErrorResponse matches the OpenAI error envelope.
+type chatErrorResponse struct {
+	Error chatError `json:"error"`
+}
+
+type chatError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
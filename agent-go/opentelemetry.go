@@ -38,6 +38,20 @@ func NewAgentSpan(ctx context.Context, agentName string, method string) (*AgentS
 	}, ctx
 }
 
+// OnInput attaches the resolved system prompt and input items to the span as
+// a prompt event, subject to the configured trace content policy.
+func (s *AgentSpan) OnInput(systemPrompt *string, input []AgentItem) {
+	payload := map[string]any{"input": input}
+	if systemPrompt != nil {
+		payload["system_prompt"] = *systemPrompt
+	}
+	if value, ok := renderTraceContent(payload); ok {
+		s.span.AddEvent("gen_ai.content.prompt", trace.WithAttributes(
+			attribute.String("gen_ai.prompt", value),
+		))
+	}
+}
+
 // OnResponse updates the span with response information
 func (s *AgentSpan) OnResponse(response *AgentResponse) {
 	for _, item := range response.Output {
@@ -53,6 +67,15 @@ func (s *AgentSpan) OnResponse(response *AgentResponse) {
 			}
 		}
 	}
+
+	if value, ok := renderTraceContent(map[string]any{
+		"content": response.Content,
+		"output":  response.Output,
+	}); ok {
+		s.span.AddEvent("gen_ai.content.completion", trace.WithAttributes(
+			attribute.String("gen_ai.completion", value),
+		))
+	}
 }
 
 // OnEnd ends the span and sets the final attributes
@@ -113,14 +136,44 @@ func startActiveToolSpan(
 	return res, nil
 }
 
+// startDelegationSpan creates a span for a sub-agent delegation made through
+// a handoff tool. The span carries the sub-agent name and delegation reason,
+// and links back to the parent run span so trace viewers show the delegation
+// tree even when the nested run is rendered as a separate subtree.
+func startDelegationSpan(ctx context.Context, subAgentName, task string) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(
+			attribute.String("gen_ai.operation.name", "invoke_agent"),
+			attribute.String("llm_agent.delegation.agent", subAgentName),
+			attribute.String("llm_agent.delegation.task", task),
+		),
+	}
+	if parent := trace.SpanFromContext(ctx); parent.SpanContext().IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: parent.SpanContext()}))
+	}
+	return tracer.Start(ctx, "llm_agent.delegate", opts...)
+}
+
+// endDelegationSpan records the delegation outcome and ends the span.
+func endDelegationSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
 func traceRun(
 	ctx context.Context,
 	agentName string,
 	method string,
+	request RunSessionRequest,
+	systemPrompt *string,
 	fn func(context.Context) (*AgentResponse, error),
 ) (*AgentResponse, error) {
 	span, ctx := NewAgentSpan(ctx, agentName, method)
 	defer span.OnEnd()
+	span.OnInput(systemPrompt, request.Input)
 
 	response, err := fn(ctx)
 	if err != nil {
@@ -139,9 +192,12 @@ func traceRunStream(
 	ctx context.Context,
 	agentName string,
 	method string,
+	request RunSessionRequest,
+	systemPrompt *string,
 	fn func(context.Context) (*AgentStream, error),
 ) (*AgentStream, error) {
 	span, ctx := NewAgentSpan(ctx, agentName, method)
+	span.OnInput(systemPrompt, request.Input)
 
 	innerStream, err := fn(ctx)
 	if err != nil {
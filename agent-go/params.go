@@ -1,24 +1,119 @@
 package llmagent
 
-import llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+import (
+	"context"
+	"maps"
+	"time"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
 
 // Parameters required to create a new agent.
 type AgentParams[C any] struct {
 	Name string
 	// The default language model to use for the agent.
 	Model llmsdk.LanguageModel
+	// Optional resolver that chooses the language model per session from the
+	// context value (e.g., tier-based routing or per-tenant providers). When
+	// it returns nil, the default model is used.
+	ModelResolver func(ctx context.Context, contextVal C) (llmsdk.LanguageModel, error)
 	// Instructions to be added to system messages when executing the agent.
 	// This can include formatting instructions or other guidance for the
 	// agent.
 	Instructions []InstructionParam[C]
+	// Curated user/assistant example exchanges injected ahead of the live
+	// conversation on every model call, managed separately from real history.
+	Examples []Example
 	// The tools that the agent can use to perform tasks.
 	Tools []AgentTool[C]
 	// Optional toolkits that can provide dynamic tools and system prompts for each session.
 	Toolkits []Toolkit[C]
+	// Optional control over how the instructions and toolkit system prompts
+	// are ordered and joined into the final system prompt. Nil applies the
+	// default composition: instructions first, toolkit prompts in toolkit
+	// order, joined with a newline.
+	PromptComposition *PromptComposition
+	// Optional per-turn tool choice mapped to the model input. Called with
+	// the zero-based turn number before each model call; returning nil
+	// leaves the provider default.
+	ToolChoice func(turn uint) *llmsdk.ToolChoiceOption
 	// The expected format of the response. Either text or structured output.
 	ResponseFormat *llmsdk.ResponseFormatOption
+	// Max number of automatic correction attempts when the agent has a JSON
+	// response format and the final output fails schema validation. Each
+	// attempt appends the validation errors as a corrective user message and
+	// reruns the model; once exhausted, the run fails with a structured
+	// output error. Zero disables output validation.
+	StructuredOutputRetries uint
 	// Max number of turns for agent to run to protect against infinite loops.
 	MaxTurns uint
+	// Max wall-clock duration of a single turn — one model call plus the
+	// tool executions it triggers. A turn that overruns fails the run with a
+	// turn timeout error carrying the partial turn, independent of any
+	// deadline on the request context. Zero means unlimited.
+	TurnTimeout time.Duration
+	// Max number of tool calls executed concurrently within a turn.
+	// Zero or negative means unlimited. Set to 1 for sequential execution.
+	ToolConcurrency int
+	// Execution guards (timeout, retries, fallback) applied to registered
+	// tools by name.
+	ToolOptions map[string]ToolExecutionOptions
+	// Agent-wide behavior when a tool execution fails after its configured
+	// retries and no fallback result applies: abort the run (default) or
+	// report the error to the model as a failed tool result. Overridable per
+	// tool via ToolOptions.OnError.
+	ToolErrorBehavior ToolErrorBehavior
+	// Max number of tool calls executed across a run before the run stops
+	// with an error, protecting against runaway tool loops. Zero means
+	// unlimited.
+	MaxToolCalls uint
+	// Per-tool overrides of MaxToolCalls keyed by tool name. Zero values
+	// mean unlimited for that tool.
+	MaxToolCallsPerTool map[string]uint
+	// ToolArgumentCoercion leniently coerces model-produced tool arguments
+	// toward the declared parameters schema before validation (numeric
+	// strings to numbers, boolean strings to booleans, single values to
+	// one-element arrays), since smaller models frequently emit
+	// slightly-wrong types.
+	ToolArgumentCoercion bool
+	// ToolIdempotency records finished tool executions per session under a key
+	// derived from (session ID, tool call ID) and replays the recorded result
+	// when a resumed or retried run repeats the same call, preventing double
+	// side effects such as duplicate orders.
+	ToolIdempotency bool
+	// Max number of cumulative input+output tokens across turns before the
+	// run stops with a budget exceeded error. Zero means unlimited.
+	MaxTokensBudget uint
+	// Optional usage quotas charged per subject (tenant or user) across runs
+	// and sessions, backed by a quota store. Runs for a subject that has
+	// exhausted its daily or monthly quota are rejected.
+	Quota *QuotaParams[C]
+	// Optional hook invoked before each model call with the fully composed
+	// input for that turn (messages, system prompt, tools, sampling). The
+	// hook may mutate the input in place, e.g. to inject the freshest
+	// context or drop stale tool results. Returning an error fails the run.
+	PrepareStep func(ctx context.Context, turn uint, input *llmsdk.LanguageModelInput) error
+	// Optional condition evaluated after each finished turn. Returning true
+	// ends the run with the content of the last assistant message, e.g. once
+	// a specific tool has been called or the output contains a marker.
+	StopCondition func(state *RunState) bool
+	// Optional recorder that receives counters and histograms for finished
+	// runs (status, turns, tool errors, latency, tokens).
+	Metrics MetricsRecorder
+	// Optional append-only sink that receives a record for every tool
+	// execution, for compliance audit trails.
+	Audit AuditSink
+	// Optional policy that truncates the conversation history before each
+	// model call so long sessions fit the context window.
+	HistoryPolicy *HistoryPolicy
+	// Optional automatic summarization of older run items when the
+	// conversation approaches the context window.
+	Summarization *SummarizationParams
+	// Optional configuration for conversation title generation on sessions.
+	TitleGeneration *TitleGenerationParams
+	// Optional suggested follow-up questions emitted as a stream event after
+	// a streamed run completes.
+	FollowUp *FollowUpParams
 	// Amount of randomness injected into the response.
 	Temperature *float64
 	// An alternative to sampling with temperature, called nucleus sampling,
@@ -45,8 +140,38 @@ type AgentParams[C any] struct {
 	Reasoning *llmsdk.ReasoningOptions
 }
 
+// clone returns a copy of the params with its own slices and maps so options
+// applied to the copy do not mutate the original.
+func (p *AgentParams[C]) clone() *AgentParams[C] {
+	cloned := *p
+	cloned.Instructions = append([]InstructionParam[C](nil), p.Instructions...)
+	cloned.Examples = append([]Example(nil), p.Examples...)
+	cloned.Tools = append([]AgentTool[C](nil), p.Tools...)
+	cloned.Toolkits = append([]Toolkit[C](nil), p.Toolkits...)
+	cloned.Modalities = append([]llmsdk.Modality(nil), p.Modalities...)
+	cloned.ToolOptions = maps.Clone(p.ToolOptions)
+	cloned.MaxToolCallsPerTool = maps.Clone(p.MaxToolCallsPerTool)
+	return &cloned
+}
+
 type AgentParamsOption[C any] func(*AgentParams[C])
 
+// WithModel sets the default language model, primarily useful for overriding
+// the model of a cloned agent.
+func WithModel[C any](model llmsdk.LanguageModel) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.Model = model
+	}
+}
+
+// WithName sets the agent name, primarily useful for distinguishing cloned
+// variants in traces and metrics.
+func WithName[C any](name string) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.Name = name
+	}
+}
+
 // WithInstructions sets the instructions to be added to system messages when executing the agent.
 // This can include formatting instructions or other guidance for the agent.
 func WithInstructions[C any](instructions ...InstructionParam[C]) AgentParamsOption[C] {
@@ -55,6 +180,16 @@ func WithInstructions[C any](instructions ...InstructionParam[C]) AgentParamsOpt
 	}
 }
 
+// WithExamples sets curated user/assistant example exchanges that are
+// injected ahead of the live conversation on every model call. They are
+// managed separately from real history: they never appear in run outputs and
+// history policies do not truncate them.
+func WithExamples[C any](examples ...Example) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.Examples = examples
+	}
+}
+
 // WithTools sets the tools that the agent can use to perform tasks.
 func WithTools[C any](tools ...AgentTool[C]) AgentParamsOption[C] {
 	return func(p *AgentParams[C]) {
@@ -62,6 +197,15 @@ func WithTools[C any](tools ...AgentTool[C]) AgentParamsOption[C] {
 	}
 }
 
+// WithModelResolver sets a resolver that chooses the language model for each
+// session from the context value, taking precedence over the default model.
+// Returning a nil model falls back to the default model.
+func WithModelResolver[C any](resolver func(ctx context.Context, contextVal C) (llmsdk.LanguageModel, error)) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.ModelResolver = resolver
+	}
+}
+
 // WithToolkits sets the toolkits that can provide dynamic tools and prompts per session.
 func WithToolkits[C any](toolkits ...Toolkit[C]) AgentParamsOption[C] {
 	return func(p *AgentParams[C]) {
@@ -69,6 +213,37 @@ func WithToolkits[C any](toolkits ...Toolkit[C]) AgentParamsOption[C] {
 	}
 }
 
+// WithPromptComposition sets how the instructions and toolkit system prompts
+// are ordered and joined into the final system prompt, overriding the default
+// composition of instructions first, toolkit prompts in toolkit order, joined
+// with a newline.
+func WithPromptComposition[C any](composition PromptComposition) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.PromptComposition = &composition
+	}
+}
+
+// WithToolChoice sets a per-turn tool choice. The function is called with the
+// zero-based turn number before each model call; returning nil leaves the
+// provider default for that turn.
+func WithToolChoice[C any](toolChoice func(turn uint) *llmsdk.ToolChoiceOption) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.ToolChoice = toolChoice
+	}
+}
+
+// WithFirstTurnToolChoice forces the given tool choice on the first turn of
+// every run (e.g. a specific planning tool) and leaves subsequent turns on
+// the provider default.
+func WithFirstTurnToolChoice[C any](choice llmsdk.ToolChoiceOption) AgentParamsOption[C] {
+	return WithToolChoice[C](func(turn uint) *llmsdk.ToolChoiceOption {
+		if turn == 0 {
+			return &choice
+		}
+		return nil
+	})
+}
+
 // WithResponseFormat sets the expected format of the response. Either text or structured output.
 func WithResponseFormat[C any](format llmsdk.ResponseFormatOption) AgentParamsOption[C] {
 	return func(p *AgentParams[C]) {
@@ -76,6 +251,16 @@ func WithResponseFormat[C any](format llmsdk.ResponseFormatOption) AgentParamsOp
 	}
 }
 
+// WithStructuredOutputRetries enables automatic correction of structured
+// output: when the final output fails validation against the JSON response
+// format schema, the validation errors are appended as a corrective user
+// message and the model reruns, up to the given number of attempts.
+func WithStructuredOutputRetries[C any](retries uint) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.StructuredOutputRetries = retries
+	}
+}
+
 // WithMaxTurns sets the max number of turns for agent to run to protect against infinite loops.
 func WithMaxTurns[C any](maxTurns uint) AgentParamsOption[C] {
 	return func(p *AgentParams[C]) {
@@ -83,6 +268,158 @@ func WithMaxTurns[C any](maxTurns uint) AgentParamsOption[C] {
 	}
 }
 
+// WithTurnTimeout bounds each turn — one model call plus the tool executions
+// it triggers — to the given duration, independent of any deadline on the
+// request context. An overrunning turn fails the run with a turn timeout
+// error carrying the partial turn.
+func WithTurnTimeout[C any](turnTimeout time.Duration) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.TurnTimeout = turnTimeout
+	}
+}
+
+// WithToolConcurrency sets the max number of tool calls executed concurrently
+// within a turn. Zero or negative means unlimited. Set to 1 for sequential
+// execution. Tool results are always reported in tool call order regardless of
+// the concurrency limit.
+func WithToolConcurrency[C any](toolConcurrency int) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.ToolConcurrency = toolConcurrency
+	}
+}
+
+// WithMaxToolCalls sets the max number of tool calls executed across a run so
+// runaway loops calling the same tool repeatedly terminate with a clear error.
+func WithMaxToolCalls[C any](maxToolCalls uint) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.MaxToolCalls = maxToolCalls
+	}
+}
+
+// WithMaxToolCallsPerTool sets per-tool call limits keyed by tool name,
+// applied in addition to the run-wide MaxToolCalls limit.
+func WithMaxToolCallsPerTool[C any](limits map[string]uint) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.MaxToolCallsPerTool = limits
+	}
+}
+
+// WithToolErrorBehavior sets the agent-wide behavior when a tool execution
+// fails after its configured retries and no fallback result applies, e.g.
+// ToolErrorReport to surface the error to the model as a failed tool result
+// instead of aborting the run. Individual tools override it via
+// ToolOptions.OnError.
+func WithToolErrorBehavior[C any](behavior ToolErrorBehavior) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.ToolErrorBehavior = behavior
+	}
+}
+
+// WithToolArgumentCoercion enables lenient coercion of tool arguments toward
+// the declared parameters schema before validation and execution.
+func WithToolArgumentCoercion[C any]() AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.ToolArgumentCoercion = true
+	}
+}
+
+// WithToolIdempotency enables replaying recorded tool results when a resumed
+// or retried run repeats an already-executed call. Tools can read the
+// assigned key with ToolIdempotencyKeyFromContext to make their own external
+// requests idempotent.
+func WithToolIdempotency[C any]() AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.ToolIdempotency = true
+	}
+}
+
+// WithPrepareStep sets a hook invoked before each model call with the
+// zero-based turn number and the fully composed input for that turn. The hook
+// may mutate the input in place; returning an error fails the run.
+func WithPrepareStep[C any](prepareStep func(ctx context.Context, turn uint, input *llmsdk.LanguageModelInput) error) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.PrepareStep = prepareStep
+	}
+}
+
+// WithStopCondition sets a condition evaluated after each finished turn.
+// When it returns true, the run ends with the content of the last assistant
+// message instead of continuing to the next model call, complementing the
+// MaxTurns guard with content-based stopping.
+func WithStopCondition[C any](condition func(state *RunState) bool) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.StopCondition = condition
+	}
+}
+
+// WithMaxTokensBudget sets the max number of cumulative input+output tokens
+// across turns. When the budget is exhausted, the run stops with a budget
+// exceeded error carrying the partial results in its snapshot.
+func WithMaxTokensBudget[C any](maxTokensBudget uint) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.MaxTokensBudget = maxTokensBudget
+	}
+}
+
+// WithQuota enables usage quotas charged per subject. Each finished run's
+// tokens and cost are recorded in the store under the subject derived from
+// the context value; runs for a subject over its quota are rejected with a
+// quota exceeded error.
+func WithQuota[C any](quota QuotaParams[C]) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.Quota = &quota
+	}
+}
+
+// WithMetrics sets the recorder that receives metrics for finished runs.
+func WithMetrics[C any](recorder MetricsRecorder) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.Metrics = recorder
+	}
+}
+
+// WithAudit sets the sink that receives a record for every tool execution.
+func WithAudit[C any](sink AuditSink) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.Audit = sink
+	}
+}
+
+// WithHistoryPolicy sets the policy that truncates the conversation history
+// before each model call. The first user message is always kept and system
+// prompts are unaffected.
+func WithHistoryPolicy[C any](policy HistoryPolicy) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.HistoryPolicy = &policy
+	}
+}
+
+// WithSummarization enables automatic mid-run summarization. When the
+// estimated token count of the conversation exceeds the threshold, older run
+// items are summarized and replaced with a single summary item.
+func WithSummarization[C any](params SummarizationParams) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.Summarization = &params
+	}
+}
+
+// WithTitleGeneration configures conversation title generation on sessions,
+// typically pointing it at a cheap model.
+func WithTitleGeneration[C any](params TitleGenerationParams) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.TitleGeneration = &params
+	}
+}
+
+// WithFollowUp enables suggested follow-up questions: after a streamed run
+// completes, suggestions produced from the final transcript are emitted as a
+// follow_up stream event.
+func WithFollowUp[C any](params FollowUpParams) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		p.FollowUp = &params
+	}
+}
+
 // WithTemperature sets the sampling temperature for the model.
 // Amount of randomness injected into the response. Ranges from 0.0 to 1.0
 func WithTemperature[C any](temperature float64) AgentParamsOption[C] {
@@ -0,0 +1,37 @@
+package planner
+
+import (
+	"context"
+	"encoding/json"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+// PlannerPrompt explains the planner-executor working style to the agent.
+// Combine it with PlanInstruction so the agent maintains the plan through the
+// update tools while keeping it internal.
+const PlannerPrompt = `You work as a planner-executor: break the user's goal into clear, actionable steps with the update_plan tool, then execute the plan across turns, marking progress with update_plan_step.
+The plan is strictly internal: never reveal or enumerate plan items to the user, and do not mention the plan or the tool names.
+Keep user-visible replies concise and focused on results. When every step is completed, respond with the final deliverable.`
+
+// PlanInstruction returns an instruction that injects the current plan into
+// the system prompt. The store is queried each time the instructions are
+// resolved so the prompt reflects the latest state.
+func PlanInstruction[C any](store Store) llmagent.InstructionParam[C] {
+	return llmagent.InstructionParam[C]{
+		Func: func(ctx context.Context, _ C) (string, error) {
+			plan, err := store.Fetch(ctx)
+			if err != nil {
+				return "", err
+			}
+			if len(plan.Steps) == 0 {
+				return "Internal plan: (empty; draft one with update_plan)", nil
+			}
+			b, err := json.Marshal(plan)
+			if err != nil {
+				return "", err
+			}
+			return "Internal plan (JSON):\n" + string(b), nil
+		},
+	}
+}
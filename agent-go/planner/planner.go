@@ -0,0 +1,118 @@
+// Package planner provides a planner-executor primitive for agents.
+//
+// The agent maintains an internal plan in a managed store: it drafts and
+// revises the plan through the update tools, the plan is injected into the
+// system prompt through a dynamic instruction so every turn sees the latest
+// state, and the driver loop checks Plan.Completed to detect when all steps
+// are done. The store is defined as an interface so applications can persist
+// plans in their own backend while reusing the default tools and instruction
+// provider.
+package planner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// StepStatus is the execution state of a single plan step.
+type StepStatus string
+
+const (
+	StepStatusPending    StepStatus = "pending"
+	StepStatusInProgress StepStatus = "in_progress"
+	StepStatusCompleted  StepStatus = "completed"
+)
+
+// Step is a single actionable item in the plan.
+type Step struct {
+	Status StepStatus `json:"status"`
+	Title  string     `json:"title"`
+}
+
+// Plan is the internal plan the agent maintains across turns.
+type Plan struct {
+	// Explanation summarizes the overall approach behind the steps.
+	Explanation string `json:"explanation,omitempty"`
+	// Steps are the plan items in execution order.
+	Steps []Step `json:"steps"`
+}
+
+// Completed reports whether the plan has steps and every step is completed.
+// An empty plan is not complete, so a run cannot finish before planning.
+func (p Plan) Completed() bool {
+	if len(p.Steps) == 0 {
+		return false
+	}
+	for _, step := range p.Steps {
+		if step.Status != StepStatusCompleted {
+			return false
+		}
+	}
+	return true
+}
+
+// Store holds the plan that the agent updates through the tools.
+type Store interface {
+	// Fetch returns the current plan.
+	Fetch(ctx context.Context) (Plan, error)
+	// Replace swaps the whole plan and returns the plan after the update.
+	Replace(ctx context.Context, plan Plan) (Plan, error)
+	// UpdateStep sets the status of the step at the given index and returns
+	// the plan after the update.
+	UpdateStep(ctx context.Context, index int, status StepStatus) (Plan, error)
+}
+
+// InMemoryStore is a Store backed by an in-process plan value.
+// It is safe for concurrent use.
+type InMemoryStore struct {
+	mu   sync.RWMutex
+	plan Plan
+}
+
+// NewInMemoryStore creates an empty in-memory plan store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{}
+}
+
+func (s *InMemoryStore) Fetch(ctx context.Context) (Plan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clonePlan(), nil
+}
+
+func (s *InMemoryStore) Replace(ctx context.Context, plan Plan) (Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plan = plan
+	return s.clonePlan(), nil
+}
+
+func (s *InMemoryStore) UpdateStep(ctx context.Context, index int, status StepStatus) (Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if index < 0 || index >= len(s.plan.Steps) {
+		return Plan{}, &StepIndexError{Index: index, Len: len(s.plan.Steps)}
+	}
+	s.plan.Steps[index].Status = status
+	return s.clonePlan(), nil
+}
+
+// clonePlan copies the plan so callers cannot mutate the stored steps.
+// Callers must hold the store lock.
+func (s *InMemoryStore) clonePlan() Plan {
+	plan := s.plan
+	plan.Steps = append([]Step(nil), s.plan.Steps...)
+	return plan
+}
+
+// StepIndexError reports an UpdateStep call targeting a step that does not
+// exist in the current plan.
+type StepIndexError struct {
+	Index int
+	Len   int
+}
+
+func (e *StepIndexError) Error() string {
+	return fmt.Sprintf("plan step index %d out of range (plan has %d steps)", e.Index, e.Len)
+}
@@ -0,0 +1,104 @@
+package planner_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/planner"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	store := planner.NewInMemoryStore()
+	ctx := t.Context()
+
+	plan, err := store.Replace(ctx, planner.Plan{
+		Explanation: "Draft then refine",
+		Steps: []planner.Step{
+			{Status: planner.StepStatusPending, Title: "Outline the document"},
+			{Status: planner.StepStatusPending, Title: "Write the sections"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("replace plan: %v", err)
+	}
+	if len(plan.Steps) != 2 || plan.Completed() {
+		t.Fatalf("unexpected plan after replace: %#v", plan)
+	}
+
+	plan, err = store.UpdateStep(ctx, 0, planner.StepStatusCompleted)
+	if err != nil {
+		t.Fatalf("update step: %v", err)
+	}
+	if plan.Steps[0].Status != planner.StepStatusCompleted || plan.Completed() {
+		t.Fatalf("unexpected plan after step update: %#v", plan)
+	}
+
+	plan, err = store.UpdateStep(ctx, 1, planner.StepStatusCompleted)
+	if err != nil {
+		t.Fatalf("update step: %v", err)
+	}
+	if !plan.Completed() {
+		t.Fatalf("expected a completed plan, got %#v", plan)
+	}
+
+	if _, err := store.UpdateStep(ctx, 5, planner.StepStatusCompleted); err == nil {
+		t.Fatal("expected an out of range error")
+	}
+}
+
+func TestPlanCompleted(t *testing.T) {
+	if (planner.Plan{}).Completed() {
+		t.Fatal("an empty plan must not count as completed")
+	}
+}
+
+func TestPlannerTools(t *testing.T) {
+	store := planner.NewInMemoryStore()
+	tools := planner.Tools[struct{}](store)
+	if len(tools) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(tools))
+	}
+
+	updateTool := planner.NewUpdatePlanTool[struct{}](store)
+	params, _ := json.Marshal(map[string]any{
+		"explanation": "One step",
+		"steps":       []map[string]string{{"status": "pending", "title": "Do the thing"}},
+	})
+	result, err := updateTool.Execute(t.Context(), params, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("execute update_plan: %v", err)
+	}
+	if result.IsError || len(result.Content) != 1 || result.Content[0].TextPart == nil ||
+		!strings.Contains(result.Content[0].TextPart.Text, `"completed":false`) {
+		t.Fatalf("unexpected tool result: %#v", result)
+	}
+
+	stepTool := planner.NewUpdatePlanStepTool[struct{}](store)
+	params, _ = json.Marshal(map[string]any{"index": 0, "status": "completed"})
+	result, err = stepTool.Execute(t.Context(), params, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("execute update_plan_step: %v", err)
+	}
+	if result.IsError || !strings.Contains(result.Content[0].TextPart.Text, `"completed":true`) {
+		t.Fatalf("unexpected tool result: %#v", result)
+	}
+
+	params, _ = json.Marshal(map[string]any{"index": 9, "status": "completed"})
+	result, err = stepTool.Execute(t.Context(), params, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("execute update_plan_step: %v", err)
+	}
+	if !result.IsError {
+		t.Fatalf("expected an is_error result for an out of range index, got %#v", result)
+	}
+
+	instruction := planner.PlanInstruction[struct{}](store)
+	prompt, err := instruction.Func(t.Context(), struct{}{})
+	if err != nil {
+		t.Fatalf("resolve plan instruction: %v", err)
+	}
+	if !strings.Contains(prompt, "Do the thing") {
+		t.Fatalf("expected instruction to include the plan, got %q", prompt)
+	}
+}
@@ -0,0 +1,139 @@
+package planner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// UpdatePlanTool lets the agent replace the internal plan with a new
+// explanation and set of steps.
+type UpdatePlanTool[C any] struct {
+	store Store
+}
+
+// NewUpdatePlanTool creates the update_plan tool backed by the store.
+func NewUpdatePlanTool[C any](store Store) *UpdatePlanTool[C] {
+	return &UpdatePlanTool[C]{store: store}
+}
+
+func (t *UpdatePlanTool[C]) Name() string { return "update_plan" }
+
+func (t *UpdatePlanTool[C]) Description() string {
+	return "Replace the internal plan with an explanation and an ordered list of steps. Returns the plan after the update."
+}
+
+func (t *UpdatePlanTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"explanation": map[string]any{"type": "string"},
+			"steps": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"status": map[string]any{
+							"type": "string",
+							"enum": []string{string(StepStatusPending), string(StepStatusInProgress), string(StepStatusCompleted)},
+						},
+						"title": map[string]any{"type": "string"},
+					},
+					"required":             []string{"status", "title"},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"required":             []string{"explanation", "steps"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *UpdatePlanTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var in Plan
+	if err := json.Unmarshal(params, &in); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	plan, err := t.store.Replace(ctx, in)
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	return planToolResult(plan)
+}
+
+// UpdatePlanStepTool lets the agent change the status of a single plan step
+// as it works through the plan.
+type UpdatePlanStepTool[C any] struct {
+	store Store
+}
+
+// NewUpdatePlanStepTool creates the update_plan_step tool backed by the store.
+func NewUpdatePlanStepTool[C any](store Store) *UpdatePlanStepTool[C] {
+	return &UpdatePlanStepTool[C]{store: store}
+}
+
+func (t *UpdatePlanStepTool[C]) Name() string { return "update_plan_step" }
+
+func (t *UpdatePlanStepTool[C]) Description() string {
+	return "Set the status of a single plan step by its zero-based index. Returns the plan after the update."
+}
+
+func (t *UpdatePlanStepTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"index": map[string]any{"type": "integer", "minimum": 0},
+			"status": map[string]any{
+				"type": "string",
+				"enum": []string{string(StepStatusPending), string(StepStatusInProgress), string(StepStatusCompleted)},
+			},
+		},
+		"required":             []string{"index", "status"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *UpdatePlanStepTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var in struct {
+		Index  int        `json:"index"`
+		Status StepStatus `json:"status"`
+	}
+	if err := json.Unmarshal(params, &in); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	plan, err := t.store.UpdateStep(ctx, in.Index, in.Status)
+	if err != nil {
+		var indexErr *StepIndexError
+		if errors.As(err, &indexErr) {
+			return llmagent.AgentToolResult{
+				Content: []llmsdk.Part{llmsdk.NewTextPart(indexErr.Error())},
+				IsError: true,
+			}, nil
+		}
+		return llmagent.AgentToolResult{}, err
+	}
+	return planToolResult(plan)
+}
+
+// Tools returns the plan update tools backed by the store, ready to be passed
+// to llmagent.WithTools.
+func Tools[C any](store Store) []llmagent.AgentTool[C] {
+	return []llmagent.AgentTool[C]{
+		llmagent.NewAgentFunctionTool[C](NewUpdatePlanTool[C](store)),
+		llmagent.NewAgentFunctionTool[C](NewUpdatePlanStepTool[C](store)),
+	}
+}
+
+// planToolResult encodes the plan and completion state as the tool result.
+func planToolResult(plan Plan) (llmagent.AgentToolResult, error) {
+	b, err := json.Marshal(map[string]any{"plan": plan, "completed": plan.Completed()})
+	if err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(string(b))},
+	}, nil
+}
@@ -0,0 +1,82 @@
+package llmagent_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+func TestRun_PrepareStepMutatesTurnInput(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hi")},
+	}))
+
+	var turns []uint
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+		PrepareStep: func(_ context.Context, turn uint, input *llmsdk.LanguageModelInput) error {
+			turns = append(turns, turn)
+			input.SystemPrompt = ptr.To("Injected: today is 2026-08-30.")
+			input.Messages = append(input.Messages, llmsdk.NewUserMessage(llmsdk.NewTextPart("(freshest context)")))
+			return nil
+		},
+	}, nil)
+
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("hello"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(turns) != 1 || turns[0] != 0 {
+		t.Errorf("expected the hook to run once for turn 0, got %v", turns)
+	}
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected 1 model call, got %d", len(inputs))
+	}
+	if inputs[0].SystemPrompt == nil || !strings.Contains(*inputs[0].SystemPrompt, "Injected") {
+		t.Errorf("expected the mutated system prompt, got %v", inputs[0].SystemPrompt)
+	}
+	last := inputs[0].Messages[len(inputs[0].Messages)-1]
+	if last.UserMessage == nil || last.UserMessage.Content[0].TextPart.Text != "(freshest context)" {
+		t.Errorf("expected the injected message, got %+v", last)
+	}
+}
+
+func TestRun_PrepareStepErrorFailsRun(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+		PrepareStep: func(context.Context, uint, *llmsdk.LanguageModelInput) error {
+			return errors.New("stale context")
+		},
+	}, nil)
+
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("hello"))),
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "prepare step failed: stale context") {
+		t.Errorf("expected prepare step error, got %v", err)
+	}
+	if len(model.TrackedGenerateInputs()) != 0 {
+		t.Error("expected no model call after a prepare step failure")
+	}
+}
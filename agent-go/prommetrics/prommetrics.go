@@ -0,0 +1,189 @@
+// Package prommetrics provides a llmagent.MetricsRecorder that aggregates
+// run metrics and exposes them in the Prometheus text exposition format.
+//
+// The recorder is dependency-free: it serves the metrics itself via
+// ServeHTTP, so it can be mounted on any mux without pulling in the
+// Prometheus client library.
+//
+//	recorder := prommetrics.NewRecorder()
+//	agent := llmagent.NewAgent("assistant", model, llmagent.WithMetrics[any](recorder))
+//	http.Handle("/metrics", recorder)
+package prommetrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+)
+
+var (
+	// runDurationBuckets are the histogram bucket upper bounds, in seconds,
+	// for run latency.
+	runDurationBuckets = []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60}
+	// turnsBuckets are the histogram bucket upper bounds for model turns per
+	// run.
+	turnsBuckets = []float64{1, 2, 3, 5, 8, 13, 21}
+)
+
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) observe(value float64) {
+	for i, bound := range h.bounds {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// Recorder implements llmagent.MetricsRecorder and serves the aggregated
+// metrics as a Prometheus scrape endpoint. The zero value is not usable; use
+// NewRecorder.
+type Recorder struct {
+	mu           sync.Mutex
+	runs         map[string]uint64     // key: agent \x00 method \x00 status
+	runDurations map[string]*histogram // key: agent
+	turnsPerRun  map[string]*histogram // key: agent
+	toolCalls    map[string]uint64     // key: agent
+	toolErrors   map[string]uint64     // key: agent
+	tokens       map[string]uint64     // key: agent \x00 direction
+}
+
+// NewRecorder creates an empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		runs:         make(map[string]uint64),
+		runDurations: make(map[string]*histogram),
+		turnsPerRun:  make(map[string]*histogram),
+		toolCalls:    make(map[string]uint64),
+		toolErrors:   make(map[string]uint64),
+		tokens:       make(map[string]uint64),
+	}
+}
+
+// RecordRun implements llmagent.MetricsRecorder.
+func (r *Recorder) RecordRun(metrics llmagent.RunMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agent := metrics.AgentName
+	r.runs[labelKey(agent, metrics.Method, metrics.Status)]++
+
+	duration, ok := r.runDurations[agent]
+	if !ok {
+		duration = newHistogram(runDurationBuckets)
+		r.runDurations[agent] = duration
+	}
+	duration.observe(metrics.Duration.Seconds())
+
+	turns, ok := r.turnsPerRun[agent]
+	if !ok {
+		turns = newHistogram(turnsBuckets)
+		r.turnsPerRun[agent] = turns
+	}
+	turns.observe(float64(metrics.Turns))
+
+	r.toolCalls[agent] += uint64(metrics.ToolCalls)
+	r.toolErrors[agent] += uint64(metrics.ToolErrors)
+	r.tokens[labelKey(agent, "input")] += uint64(metrics.InputTokens)
+	r.tokens[labelKey(agent, "output")] += uint64(metrics.OutputTokens)
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(r.render()))
+}
+
+func (r *Recorder) render() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP llm_agent_runs_total Total number of finished agent runs.\n")
+	b.WriteString("# TYPE llm_agent_runs_total counter\n")
+	for _, key := range sortedKeys(r.runs) {
+		labels := splitLabelKey(key)
+		fmt.Fprintf(&b, "llm_agent_runs_total{agent=%q,method=%q,status=%q} %d\n",
+			labels[0], labels[1], labels[2], r.runs[key])
+	}
+
+	b.WriteString("# HELP llm_agent_run_duration_seconds Agent run latency.\n")
+	b.WriteString("# TYPE llm_agent_run_duration_seconds histogram\n")
+	for _, agent := range sortedKeys(r.runDurations) {
+		writeHistogram(&b, "llm_agent_run_duration_seconds", agent, r.runDurations[agent])
+	}
+
+	b.WriteString("# HELP llm_agent_turns_per_run Model turns per agent run.\n")
+	b.WriteString("# TYPE llm_agent_turns_per_run histogram\n")
+	for _, agent := range sortedKeys(r.turnsPerRun) {
+		writeHistogram(&b, "llm_agent_turns_per_run", agent, r.turnsPerRun[agent])
+	}
+
+	b.WriteString("# HELP llm_agent_tool_calls_total Total number of tool executions.\n")
+	b.WriteString("# TYPE llm_agent_tool_calls_total counter\n")
+	for _, agent := range sortedKeys(r.toolCalls) {
+		fmt.Fprintf(&b, "llm_agent_tool_calls_total{agent=%q} %d\n", agent, r.toolCalls[agent])
+	}
+
+	b.WriteString("# HELP llm_agent_tool_errors_total Total number of failed tool executions.\n")
+	b.WriteString("# TYPE llm_agent_tool_errors_total counter\n")
+	for _, agent := range sortedKeys(r.toolErrors) {
+		fmt.Fprintf(&b, "llm_agent_tool_errors_total{agent=%q} %d\n", agent, r.toolErrors[agent])
+	}
+
+	b.WriteString("# HELP llm_agent_tokens_total Cumulative token usage reported by the model.\n")
+	b.WriteString("# TYPE llm_agent_tokens_total counter\n")
+	for _, key := range sortedKeys(r.tokens) {
+		labels := splitLabelKey(key)
+		fmt.Fprintf(&b, "llm_agent_tokens_total{agent=%q,direction=%q} %d\n",
+			labels[0], labels[1], r.tokens[key])
+	}
+
+	return b.String()
+}
+
+func writeHistogram(b *strings.Builder, name, agent string, h *histogram) {
+	for i, bound := range h.bounds {
+		fmt.Fprintf(b, "%s_bucket{agent=%q,le=%q} %d\n", name, agent, formatBound(bound), h.counts[i])
+	}
+	fmt.Fprintf(b, "%s_bucket{agent=%q,le=\"+Inf\"} %d\n", name, agent, h.count)
+	fmt.Fprintf(b, "%s_sum{agent=%q} %g\n", name, agent, h.sum)
+	fmt.Fprintf(b, "%s_count{agent=%q} %d\n", name, agent, h.count)
+}
+
+func formatBound(bound float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.3f", bound), "0"), ".")
+}
+
+func labelKey(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+func splitLabelKey(key string) []string {
+	return strings.Split(key, "\x00")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
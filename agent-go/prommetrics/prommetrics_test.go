@@ -0,0 +1,69 @@
+package prommetrics_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/prommetrics"
+)
+
+func TestRecorderServesPrometheusText(t *testing.T) {
+	recorder := prommetrics.NewRecorder()
+	recorder.RecordRun(llmagent.RunMetrics{
+		AgentName:    "assistant",
+		Method:       "run",
+		Status:       "completed",
+		Duration:     700 * time.Millisecond,
+		Turns:        2,
+		ToolCalls:    3,
+		ToolErrors:   1,
+		InputTokens:  120,
+		OutputTokens: 45,
+	})
+	recorder.RecordRun(llmagent.RunMetrics{
+		AgentName: "assistant",
+		Method:    "run",
+		Status:    "error",
+		Duration:  3 * time.Second,
+		Turns:     1,
+	})
+
+	server := httptest.NewServer(recorder)
+	t.Cleanup(server.Close)
+
+	res, err := server.Client().Get(server.URL)
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	if contentType := res.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain") {
+		t.Errorf("unexpected content type %q", contentType)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	body := string(data)
+
+	for _, line := range []string{
+		`llm_agent_runs_total{agent="assistant",method="run",status="completed"} 1`,
+		`llm_agent_runs_total{agent="assistant",method="run",status="error"} 1`,
+		`llm_agent_run_duration_seconds_bucket{agent="assistant",le="1"} 1`,
+		`llm_agent_run_duration_seconds_count{agent="assistant"} 2`,
+		`llm_agent_turns_per_run_bucket{agent="assistant",le="2"} 2`,
+		`llm_agent_tool_calls_total{agent="assistant"} 3`,
+		`llm_agent_tool_errors_total{agent="assistant"} 1`,
+		`llm_agent_tokens_total{agent="assistant",direction="input"} 120`,
+		`llm_agent_tokens_total{agent="assistant",direction="output"} 45`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("scrape output missing %q\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package llmagent
+
+import (
+	"strings"
+
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// PromptComposition controls how the resolved agent instructions and the
+// toolkit-provided system prompts are combined into the system prompt sent to
+// the model. The zero value reproduces the default composition: instructions
+// first, toolkit prompts in toolkit order, joined with a newline.
+type PromptComposition struct {
+	// Separator joins the prompt sections. Empty means a single newline.
+	Separator string
+	// ToolkitPromptsFirst places the toolkit prompts ahead of the agent
+	// instructions instead of after them.
+	ToolkitPromptsFirst bool
+	// Dedup drops sections that repeat an earlier section verbatim, which
+	// happens when several toolkits ship the same boilerplate guidance.
+	Dedup bool
+	// Compose, when set, takes over entirely: it receives the resolved
+	// instructions (nil when the agent has none) and the non-empty toolkit
+	// prompts in toolkit order and returns the final system prompt, or nil
+	// for no system prompt. The other fields are ignored.
+	Compose func(instructions *string, toolkitPrompts []string) *string
+}
+
+// compose builds the final system prompt from the resolved instructions and
+// the non-empty toolkit prompts. A nil receiver applies the default
+// composition.
+func (c *PromptComposition) compose(instructions *string, toolkitPrompts []string) *string {
+	if c != nil && c.Compose != nil {
+		return c.Compose(instructions, toolkitPrompts)
+	}
+
+	separator := "\n"
+	toolkitFirst, dedup := false, false
+	if c != nil {
+		if c.Separator != "" {
+			separator = c.Separator
+		}
+		toolkitFirst, dedup = c.ToolkitPromptsFirst, c.Dedup
+	}
+
+	sections := make([]string, 0, len(toolkitPrompts)+1)
+	appendSection := func(section string) {
+		if section == "" {
+			return
+		}
+		if dedup {
+			for _, existing := range sections {
+				if existing == section {
+					return
+				}
+			}
+		}
+		sections = append(sections, section)
+	}
+
+	if toolkitFirst {
+		for _, prompt := range toolkitPrompts {
+			appendSection(prompt)
+		}
+		if instructions != nil {
+			appendSection(*instructions)
+		}
+	} else {
+		if instructions != nil {
+			appendSection(*instructions)
+		}
+		for _, prompt := range toolkitPrompts {
+			appendSection(prompt)
+		}
+	}
+
+	if len(sections) == 0 {
+		return nil
+	}
+	return ptr.To(strings.Join(sections, separator))
+}
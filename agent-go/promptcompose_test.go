@@ -0,0 +1,93 @@
+package llmagent_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// runPromptComposition runs one turn with the given composition and toolkit
+// prompts and returns the system prompt the model received.
+func runPromptComposition(t *testing.T, composition *llmagent.PromptComposition, toolkitPrompts ...string) *string {
+	t.Helper()
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("ok")},
+	}))
+
+	toolkits := make([]llmagent.Toolkit[struct{}], 0, len(toolkitPrompts))
+	for _, prompt := range toolkitPrompts {
+		toolkits = append(toolkits, &mockToolkit[struct{}]{createFn: func(context.Context, struct{}) (llmagent.ToolkitSession[struct{}], error) {
+			return &mockToolkitSession[struct{}]{systemPrompt: ptr.To(prompt)}, nil
+		}})
+	}
+
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name: "test_agent", Model: model, MaxTurns: 10,
+			Instructions:      []llmagent.InstructionParam[struct{}]{{String: ptr.To("Be helpful.")}},
+			Toolkits:          toolkits,
+			PromptComposition: composition,
+		},
+		struct{}{},
+	)
+
+	if _, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hi"))),
+	}}); err != nil {
+		t.Fatalf("run session: %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected 1 model call, got %d", len(inputs))
+	}
+	return inputs[0].SystemPrompt
+}
+
+func TestPromptCompositionDefault(t *testing.T) {
+	prompt := runPromptComposition(t, nil, "toolkit a", "toolkit b")
+	if prompt == nil || *prompt != "Be helpful.\ntoolkit a\ntoolkit b" {
+		t.Errorf("unexpected system prompt: %v", prompt)
+	}
+}
+
+func TestPromptCompositionSeparatorAndOrder(t *testing.T) {
+	prompt := runPromptComposition(t, &llmagent.PromptComposition{
+		Separator:           "\n\n---\n\n",
+		ToolkitPromptsFirst: true,
+	}, "toolkit a", "toolkit b")
+	if prompt == nil || *prompt != "toolkit a\n\n---\n\ntoolkit b\n\n---\n\nBe helpful." {
+		t.Errorf("unexpected system prompt: %v", prompt)
+	}
+}
+
+func TestPromptCompositionDedup(t *testing.T) {
+	prompt := runPromptComposition(t, &llmagent.PromptComposition{
+		Dedup: true,
+	}, "shared guidance", "shared guidance", "toolkit b")
+	if prompt == nil || *prompt != "Be helpful.\nshared guidance\ntoolkit b" {
+		t.Errorf("unexpected system prompt: %v", prompt)
+	}
+}
+
+func TestPromptCompositionComposeOverride(t *testing.T) {
+	prompt := runPromptComposition(t, &llmagent.PromptComposition{
+		Compose: func(instructions *string, toolkitPrompts []string) *string {
+			sections := append([]string{"# Toolkits"}, toolkitPrompts...)
+			if instructions != nil {
+				sections = append(sections, "# Agent", *instructions)
+			}
+			return ptr.To(strings.Join(sections, "\n"))
+		},
+	}, "toolkit a")
+	if prompt == nil || *prompt != "# Toolkits\ntoolkit a\n# Agent\nBe helpful." {
+		t.Errorf("unexpected system prompt: %v", prompt)
+	}
+}
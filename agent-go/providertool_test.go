@@ -0,0 +1,99 @@
+package llmagent_test
+
+import (
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRun_SurfacesProviderToolCallsAsItems(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	lookup := NewMockTool[any]("lookup", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("found")},
+	}, nil)
+
+	completed := llmsdk.WebSearchToolCallStatusCompleted
+	// The provider executed a web search server-side and also asked for a
+	// local function tool in the same turn.
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{
+			{ToolCallPart: &llmsdk.ToolCallPart{
+				ToolCallID: "ws_1",
+				Call: llmsdk.ToolCall{WebSearch: &llmsdk.WebSearchToolCall{
+					Action: &llmsdk.WebSearchAction{Type: "search", Queries: []string{"weather paris"}},
+					Status: &completed,
+				}},
+			}},
+			{ToolResultPart: &llmsdk.ToolResultPart{
+				ToolCallID: "ws_1",
+				Result: llmsdk.ToolResult{WebSearch: &llmsdk.WebSearchToolResult{
+					Sources: []llmsdk.WebSearchSource{{URL: "https://example.com"}},
+				}},
+				Status: llmsdk.ToolResultStatusCompleted,
+			}},
+			llmsdk.NewToolCallPart("call_1", "lookup", map[string]any{}),
+		},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "test_agent",
+		Model:    model,
+		Tools:    llmagent.FunctionTools[any](lookup),
+		MaxTurns: 10,
+	}, nil)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("search and look up"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Text() != "done" {
+		t.Errorf("unexpected final text %q", response.Text())
+	}
+
+	var providerItems []*llmagent.AgentItemProviderTool
+	for _, item := range response.Output {
+		if item.ProviderTool != nil {
+			providerItems = append(providerItems, item.ProviderTool)
+		}
+	}
+	if len(providerItems) != 1 {
+		t.Fatalf("expected 1 provider tool item, got %d", len(providerItems))
+	}
+	providerItem := providerItems[0]
+	if providerItem.ToolCallID != "ws_1" || providerItem.ToolName != "web_search" {
+		t.Errorf("unexpected provider tool identity: %+v", providerItem)
+	}
+	if providerItem.Status != llmsdk.ToolResultStatusCompleted {
+		t.Errorf("expected completed status, got %q", providerItem.Status)
+	}
+	if providerItem.Result == nil || providerItem.Result.WebSearch == nil ||
+		len(providerItem.Result.WebSearch.Sources) != 1 {
+		t.Errorf("expected paired web search result, got %+v", providerItem.Result)
+	}
+
+	// The provider call stays embedded in the assistant content; it is not
+	// replayed as a function tool result on the next turn.
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 model calls, got %d", len(inputs))
+	}
+	for _, message := range inputs[1].Messages {
+		if message.ToolMessage == nil {
+			continue
+		}
+		for _, part := range message.ToolMessage.Content {
+			if part.ToolResultPart != nil && part.ToolResultPart.ToolCallID == "ws_1" {
+				t.Error("provider tool result must not be replayed as a tool message")
+			}
+		}
+	}
+}
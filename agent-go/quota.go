@@ -0,0 +1,167 @@
+package llmagent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/stream"
+)
+
+// QuotaPeriod is the window a usage quota applies to.
+type QuotaPeriod string
+
+const (
+	QuotaPeriodDaily   QuotaPeriod = "daily"
+	QuotaPeriodMonthly QuotaPeriod = "monthly"
+)
+
+// QuotaUsage is the cumulative usage recorded for a subject in one period.
+type QuotaUsage struct {
+	// Tokens is the cumulative input+output token count.
+	Tokens int
+	// Cost is the cumulative cost reported by the models, when available.
+	Cost float64
+}
+
+// QuotaStore persists cumulative usage per subject and period key.
+// Implementations must be safe for concurrent use. The in-memory store suits
+// single-process deployments; shared deployments back it with their database.
+type QuotaStore interface {
+	// Usage returns the usage recorded under the subject and period key.
+	Usage(ctx context.Context, subject, periodKey string) (QuotaUsage, error)
+	// Record adds the usage under the subject and period key.
+	Record(ctx context.Context, subject, periodKey string, usage QuotaUsage) error
+}
+
+// QuotaParams configures usage quotas charged per subject — typically the
+// tenant or user carried by the context value.
+type QuotaParams[C any] struct {
+	// Store persists the cumulative usage. Required.
+	Store QuotaStore
+	// Subject derives the subject to charge from the bound context value.
+	// Required.
+	Subject func(contextVal C) string
+	// Period is the quota window. Defaults to monthly.
+	Period QuotaPeriod
+	// MaxTokens caps the input+output tokens per subject per period. Zero
+	// means unlimited.
+	MaxTokens int
+	// MaxCost caps the cost per subject per period. Zero means unlimited.
+	MaxCost float64
+}
+
+// periodKey returns the store key for the period containing now, e.g.
+// "2025-07-14" for daily and "2025-07" for monthly quotas.
+func (p *QuotaParams[C]) periodKey(now time.Time) string {
+	if p.Period == QuotaPeriodDaily {
+		return now.UTC().Format("2006-01-02")
+	}
+	return now.UTC().Format("2006-01")
+}
+
+// MemoryQuotaStore is an in-memory QuotaStore for single-process deployments
+// and tests.
+type MemoryQuotaStore struct {
+	mu    sync.Mutex
+	usage map[string]QuotaUsage
+}
+
+// NewMemoryQuotaStore creates an empty in-memory quota store.
+func NewMemoryQuotaStore() *MemoryQuotaStore {
+	return &MemoryQuotaStore{usage: map[string]QuotaUsage{}}
+}
+
+func (s *MemoryQuotaStore) Usage(_ context.Context, subject, periodKey string) (QuotaUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usage[subject+"\x00"+periodKey], nil
+}
+
+func (s *MemoryQuotaStore) Record(_ context.Context, subject, periodKey string, usage QuotaUsage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := subject + "\x00" + periodKey
+	recorded := s.usage[key]
+	recorded.Tokens += usage.Tokens
+	recorded.Cost += usage.Cost
+	s.usage[key] = recorded
+	return nil
+}
+
+// checkQuota rejects the run when the subject's recorded usage has already
+// exhausted the configured quota. Usage is known only after a run finishes,
+// so a subject is rejected once over budget rather than mid-overrun.
+func (s *RunSession[C]) checkQuota(ctx context.Context) error {
+	quota := s.params.Quota
+	if quota == nil {
+		return nil
+	}
+	subject := quota.Subject(s.contextVal)
+	usage, err := quota.Store.Usage(ctx, subject, quota.periodKey(time.Now()))
+	if err != nil {
+		return NewInitError(fmt.Errorf("quota store: %w", err))
+	}
+	if quota.MaxTokens > 0 && usage.Tokens >= quota.MaxTokens {
+		return NewQuotaExceededError(subject, fmt.Sprintf("%d of %d tokens used", usage.Tokens, quota.MaxTokens))
+	}
+	if quota.MaxCost > 0 && usage.Cost >= quota.MaxCost {
+		return NewQuotaExceededError(subject, fmt.Sprintf("%.6f of %.6f cost used", usage.Cost, quota.MaxCost))
+	}
+	return nil
+}
+
+// recordQuota charges the finished run's usage to the subject's current
+// period. Recording is best-effort: a store failure does not fail a run that
+// already completed.
+func (s *RunSession[C]) recordQuota(ctx context.Context, response *AgentResponse) {
+	quota := s.params.Quota
+	if quota == nil || response == nil {
+		return
+	}
+	usage := QuotaUsage{}
+	if response.Usage != nil {
+		usage.Tokens = response.Usage.InputTokens + response.Usage.OutputTokens
+	}
+	if response.Cost != nil {
+		usage.Cost = *response.Cost
+	}
+	if usage == (QuotaUsage{}) {
+		return
+	}
+	subject := quota.Subject(s.contextVal)
+	_ = quota.Store.Record(context.WithoutCancel(ctx), subject, quota.periodKey(time.Now()), usage)
+}
+
+// observeQuota wraps a run stream so the run's usage is charged to the quota
+// when the stream finishes.
+func (s *RunSession[C]) observeQuota(ctx context.Context, inner *AgentStream) *AgentStream {
+	if s.params.Quota == nil {
+		return inner
+	}
+
+	eventCh := make(chan *AgentStreamEvent)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(eventCh)
+		defer close(errCh)
+
+		var finalResponse *AgentResponse
+		for inner.Next() {
+			event := inner.Current()
+			if event.Response != nil {
+				finalResponse = event.Response
+			}
+			eventCh <- event
+		}
+
+		if err := inner.Err(); err != nil {
+			errCh <- err
+		}
+		s.recordQuota(ctx, finalResponse)
+	}()
+
+	return stream.New(eventCh, errCh)
+}
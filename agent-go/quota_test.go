@@ -0,0 +1,104 @@
+package llmagent_test
+
+import (
+	"errors"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+func newQuotaAgent(model *llmsdktest.MockLanguageModel, store llmagent.QuotaStore, maxTokens int, maxCost float64) *llmagent.Agent[string] {
+	return llmagent.NewAgent("test_agent", model,
+		llmagent.WithQuota(llmagent.QuotaParams[string]{
+			Store:     store,
+			Subject:   func(contextVal string) string { return contextVal },
+			Period:    llmagent.QuotaPeriodDaily,
+			MaxTokens: maxTokens,
+			MaxCost:   maxCost,
+		}),
+	)
+}
+
+func quotaRequest(subject string) llmagent.AgentRequest[string] {
+	return llmagent.AgentRequest[string]{
+		Context: subject,
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hi"))),
+		},
+	}
+}
+
+func TestQuotaRejectsSubjectOverTokenBudget(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	for range 2 {
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+			Usage:   &llmsdk.ModelUsage{InputTokens: 80, OutputTokens: 40},
+		}))
+	}
+	store := llmagent.NewMemoryQuotaStore()
+	agent := newQuotaAgent(model, store, 100, 0)
+
+	if _, err := agent.Run(t.Context(), quotaRequest("tenant-a")); err != nil {
+		t.Fatalf("expected the first run allowed, got %v", err)
+	}
+
+	_, err := agent.Run(t.Context(), quotaRequest("tenant-a"))
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.AgentErrorKindQuotaExceeded {
+		t.Fatalf("expected a quota exceeded error once the budget is spent, got %v", err)
+	}
+
+	if _, err := agent.Run(t.Context(), quotaRequest("tenant-b")); err != nil {
+		t.Errorf("expected another subject unaffected, got %v", err)
+	}
+}
+
+func TestQuotaRejectsSubjectOverCostBudget(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+		Cost:    ptr.To(0.25),
+	}))
+	store := llmagent.NewMemoryQuotaStore()
+	agent := newQuotaAgent(model, store, 0, 0.2)
+
+	if _, err := agent.Run(t.Context(), quotaRequest("tenant-a")); err != nil {
+		t.Fatalf("expected the first run allowed, got %v", err)
+	}
+
+	_, err := agent.Run(t.Context(), quotaRequest("tenant-a"))
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.AgentErrorKindQuotaExceeded {
+		t.Fatalf("expected a quota exceeded error once the cost budget is spent, got %v", err)
+	}
+}
+
+func TestQuotaRecordsStreamingUsage(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("hello")}},
+		{Usage: &llmsdk.ModelUsage{InputTokens: 90, OutputTokens: 20}},
+	}))
+	store := llmagent.NewMemoryQuotaStore()
+	agent := newQuotaAgent(model, store, 100, 0)
+
+	stream, err := agent.RunStream(t.Context(), quotaRequest("tenant-a"))
+	if err != nil {
+		t.Fatalf("create stream: %v", err)
+	}
+	for stream.Next() {
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+
+	_, err = agent.Run(t.Context(), quotaRequest("tenant-a"))
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.AgentErrorKindQuotaExceeded {
+		t.Fatalf("expected the streamed usage charged to the quota, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package rag
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PgVectorStore is a VectorStore backed by PostgreSQL with the pgvector
+// extension. It works against any database/sql driver for PostgreSQL; the
+// application owns the *sql.DB and schema migration.
+//
+// The table must have the following columns:
+//
+//	id        text primary key
+//	content   text not null
+//	embedding vector not null
+//	metadata  jsonb
+type PgVectorStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPgVectorStore creates a pgvector-backed store reading and writing the
+// given table.
+func NewPgVectorStore(db *sql.DB, table string) *PgVectorStore {
+	return &PgVectorStore{db: db, table: table}
+}
+
+func (s *PgVectorStore) Upsert(ctx context.Context, docs []Document) error {
+	for _, doc := range docs {
+		metadata, err := json.Marshal(doc.Metadata)
+		if err != nil {
+			return fmt.Errorf("marshal metadata for %q: %w", doc.ID, err)
+		}
+		query := fmt.Sprintf(`INSERT INTO %s (id, content, embedding, metadata) VALUES ($1, $2, $3::vector, $4)
+ON CONFLICT (id) DO UPDATE SET content = EXCLUDED.content, embedding = EXCLUDED.embedding, metadata = EXCLUDED.metadata`, s.table)
+		if _, err := s.db.ExecContext(ctx, query, doc.ID, doc.Content, encodeVector(doc.Embedding), metadata); err != nil {
+			return fmt.Errorf("upsert document %q: %w", doc.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *PgVectorStore) Query(ctx context.Context, query Query) ([]QueryResult, error) {
+	topK := query.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+
+	args := []any{encodeVector(query.Embedding)}
+	sqlQuery := fmt.Sprintf(`SELECT id, content, metadata, 1 - (embedding <=> $1::vector) AS score FROM %s`, s.table)
+	if len(query.Filter) > 0 {
+		filter, err := json.Marshal(query.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("marshal filter: %w", err)
+		}
+		args = append(args, filter)
+		sqlQuery += fmt.Sprintf(" WHERE metadata @> $%d", len(args))
+	}
+	args = append(args, topK)
+	sqlQuery += fmt.Sprintf(" ORDER BY embedding <=> $1::vector LIMIT $%d", len(args))
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query documents: %w", err)
+	}
+	defer rows.Close()
+
+	results := []QueryResult{}
+	for rows.Next() {
+		var doc Document
+		var metadata []byte
+		var score float64
+		if err := rows.Scan(&doc.ID, &doc.Content, &metadata, &score); err != nil {
+			return nil, fmt.Errorf("scan document: %w", err)
+		}
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &doc.Metadata); err != nil {
+				return nil, fmt.Errorf("unmarshal metadata for %q: %w", doc.ID, err)
+			}
+		}
+		results = append(results, QueryResult{Document: doc, Score: score})
+	}
+	return results, rows.Err()
+}
+
+func (s *PgVectorStore) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = id
+	}
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", s.table, strings.Join(placeholders, ", "))
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("delete documents: %w", err)
+	}
+	return nil
+}
+
+// encodeVector formats an embedding in the pgvector text representation,
+// e.g. "[0.1,0.2]".
+func encodeVector(embedding []float64) string {
+	parts := make([]string, len(embedding))
+	for i, v := range embedding {
+		parts[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
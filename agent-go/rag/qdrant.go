@@ -0,0 +1,177 @@
+package rag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// QdrantVectorStore is a VectorStore backed by a Qdrant collection through its
+// HTTP API. Document content and metadata are stored in the point payload.
+type QdrantVectorStore struct {
+	baseURL    string
+	collection string
+	apiKey     string
+	client     *http.Client
+}
+
+// QdrantOption customizes a QdrantVectorStore.
+type QdrantOption func(*QdrantVectorStore)
+
+// WithQdrantAPIKey sets the api-key header sent with every request.
+func WithQdrantAPIKey(apiKey string) QdrantOption {
+	return func(s *QdrantVectorStore) {
+		s.apiKey = apiKey
+	}
+}
+
+// WithQdrantHTTPClient overrides the HTTP client used for requests.
+func WithQdrantHTTPClient(client *http.Client) QdrantOption {
+	return func(s *QdrantVectorStore) {
+		s.client = client
+	}
+}
+
+// NewQdrantVectorStore creates a Qdrant-backed store for the given collection.
+// The collection must already exist with a vector size matching the embeddings.
+func NewQdrantVectorStore(baseURL, collection string, options ...QdrantOption) *QdrantVectorStore {
+	s := &QdrantVectorStore{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		collection: collection,
+		client:     http.DefaultClient,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+const (
+	qdrantContentKey = "content"
+	qdrantDocIDKey   = "doc_id"
+)
+
+func (s *QdrantVectorStore) Upsert(ctx context.Context, docs []Document) error {
+	points := make([]map[string]any, 0, len(docs))
+	for _, doc := range docs {
+		payload := map[string]any{
+			qdrantContentKey: doc.Content,
+			qdrantDocIDKey:   doc.ID,
+		}
+		for key, value := range doc.Metadata {
+			payload[key] = value
+		}
+		points = append(points, map[string]any{
+			"id":      doc.ID,
+			"vector":  doc.Embedding,
+			"payload": payload,
+		})
+	}
+	return s.do(ctx, http.MethodPut, fmt.Sprintf("/collections/%s/points?wait=true", s.collection), map[string]any{
+		"points": points,
+	}, nil)
+}
+
+func (s *QdrantVectorStore) Query(ctx context.Context, query Query) ([]QueryResult, error) {
+	topK := query.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+
+	body := map[string]any{
+		"vector":       query.Embedding,
+		"limit":        topK,
+		"with_payload": true,
+	}
+	if len(query.Filter) > 0 {
+		must := make([]map[string]any, 0, len(query.Filter))
+		for key, value := range query.Filter {
+			must = append(must, map[string]any{
+				"key":   key,
+				"match": map[string]any{"value": value},
+			})
+		}
+		body["filter"] = map[string]any{"must": must}
+	}
+
+	var response struct {
+		Result []struct {
+			ID      any            `json:"id"`
+			Score   float64        `json:"score"`
+			Payload map[string]any `json:"payload"`
+		} `json:"result"`
+	}
+	if err := s.do(ctx, http.MethodPost, fmt.Sprintf("/collections/%s/points/search", s.collection), body, &response); err != nil {
+		return nil, err
+	}
+
+	results := make([]QueryResult, 0, len(response.Result))
+	for _, point := range response.Result {
+		doc := Document{ID: fmt.Sprintf("%v", point.ID)}
+		metadata := map[string]any{}
+		for key, value := range point.Payload {
+			switch key {
+			case qdrantContentKey:
+				doc.Content, _ = value.(string)
+			case qdrantDocIDKey:
+				if id, ok := value.(string); ok {
+					doc.ID = id
+				}
+			default:
+				metadata[key] = value
+			}
+		}
+		if len(metadata) > 0 {
+			doc.Metadata = metadata
+		}
+		results = append(results, QueryResult{Document: doc, Score: point.Score})
+	}
+	return results, nil
+}
+
+func (s *QdrantVectorStore) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.do(ctx, http.MethodPost, fmt.Sprintf("/collections/%s/points/delete?wait=true", s.collection), map[string]any{
+		"points": ids,
+	}, nil)
+}
+
+// do issues a JSON request against the Qdrant HTTP API and decodes the
+// response into out when provided.
+func (s *QdrantVectorStore) do(ctx context.Context, method, path string, body any, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal qdrant request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create qdrant request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("api-key", s.apiKey)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("qdrant request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		data, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("qdrant request failed with status %d: %s", res.StatusCode, string(data))
+	}
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode qdrant response: %w", err)
+		}
+	}
+	return nil
+}
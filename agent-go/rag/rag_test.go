@@ -0,0 +1,102 @@
+package rag_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/rag"
+)
+
+func TestInMemoryVectorStoreQuery(t *testing.T) {
+	store := rag.NewInMemoryVectorStore()
+	ctx := t.Context()
+
+	err := store.Upsert(ctx, []rag.Document{
+		{ID: "a", Content: "about cats", Embedding: []float64{1, 0}, Metadata: map[string]any{"topic": "animals"}},
+		{ID: "b", Content: "about dogs", Embedding: []float64{0.9, 0.1}, Metadata: map[string]any{"topic": "animals"}},
+		{ID: "c", Content: "about planes", Embedding: []float64{0, 1}, Metadata: map[string]any{"topic": "travel"}},
+	})
+	if err != nil {
+		t.Fatalf("upsert documents: %v", err)
+	}
+
+	results, err := store.Query(ctx, rag.Query{Embedding: []float64{1, 0}, TopK: 2})
+	if err != nil {
+		t.Fatalf("query store: %v", err)
+	}
+	if len(results) != 2 || results[0].Document.ID != "a" || results[1].Document.ID != "b" {
+		t.Fatalf("expected [a b] ordered by similarity, got %#v", results)
+	}
+
+	results, err = store.Query(ctx, rag.Query{
+		Embedding: []float64{1, 0},
+		Filter:    map[string]any{"topic": "travel"},
+	})
+	if err != nil {
+		t.Fatalf("query store with filter: %v", err)
+	}
+	if len(results) != 1 || results[0].Document.ID != "c" {
+		t.Fatalf("expected metadata filter to match only c, got %#v", results)
+	}
+
+	if err := store.Delete(ctx, []string{"a"}); err != nil {
+		t.Fatalf("delete document: %v", err)
+	}
+	results, err = store.Query(ctx, rag.Query{Embedding: []float64{1, 0}})
+	if err != nil {
+		t.Fatalf("query store after delete: %v", err)
+	}
+	for _, result := range results {
+		if result.Document.ID == "a" {
+			t.Fatalf("expected a to be deleted, got %#v", results)
+		}
+	}
+}
+
+func TestRAGToolkitSearchKnowledge(t *testing.T) {
+	store := rag.NewInMemoryVectorStore()
+	ctx := t.Context()
+	err := store.Upsert(ctx, []rag.Document{
+		{ID: "doc-1", Content: "The Q3 report is due October 15.", Embedding: []float64{1, 0}, Metadata: map[string]any{"title": "Q3 Report"}},
+		{ID: "doc-2", Content: "Lunch menu for Friday.", Embedding: []float64{0, 1}},
+	})
+	if err != nil {
+		t.Fatalf("upsert documents: %v", err)
+	}
+
+	embedder := func(ctx context.Context, text string) ([]float64, error) {
+		return []float64{1, 0}, nil
+	}
+	toolkit := rag.NewRAGToolkit[struct{}](store, embedder, rag.WithTopK(1))
+
+	session, err := toolkit.CreateSession(ctx, struct{}{})
+	if err != nil {
+		t.Fatalf("create toolkit session: %v", err)
+	}
+	defer session.Close(ctx)
+
+	if session.SystemPrompt() == nil || *session.SystemPrompt() == "" {
+		t.Fatal("expected a system prompt")
+	}
+	tools := session.Tools()
+	if len(tools) != 1 || tools[0].Name() != "search_knowledge" {
+		t.Fatalf("expected search_knowledge tool, got %#v", tools)
+	}
+
+	params, _ := json.Marshal(map[string]string{"query": "when is the q3 report due"})
+	result, err := tools[0].AsFunctionTool().Execute(ctx, params, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("execute search_knowledge: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected tool error: %#v", result)
+	}
+	if len(result.Content) != 1 || result.Content[0].SourcePart == nil {
+		t.Fatalf("expected one source part, got %#v", result.Content)
+	}
+	source := result.Content[0].SourcePart
+	if source.Source != "doc-1" || source.Title != "Q3 Report" {
+		t.Fatalf("unexpected source part: %#v", source)
+	}
+}
@@ -0,0 +1,162 @@
+package rag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// Embedder converts text into an embedding vector used for similarity search.
+type Embedder func(ctx context.Context, text string) ([]float64, error)
+
+// RAGToolkitOption customizes a RAGToolkit.
+type RAGToolkitOption func(*ragToolkitOptions)
+
+type ragToolkitOptions struct {
+	topK         int
+	systemPrompt *string
+}
+
+// WithTopK sets the number of chunks returned from each knowledge search.
+func WithTopK(topK int) RAGToolkitOption {
+	return func(o *ragToolkitOptions) {
+		o.topK = topK
+	}
+}
+
+// WithSystemPrompt overrides the system prompt appended by the toolkit.
+func WithSystemPrompt(prompt string) RAGToolkitOption {
+	return func(o *ragToolkitOptions) {
+		o.systemPrompt = &prompt
+	}
+}
+
+const defaultRAGPrompt = `You have access to a knowledge base through the search_knowledge tool.
+When the user asks about information you are unsure of, search the knowledge base before answering and ground your answer in the retrieved sources.`
+
+// RAGToolkit exposes knowledge base search tools backed by a vector store.
+// Retrieved chunks are returned to the model as source parts so providers can
+// surface citations.
+type RAGToolkit[C any] struct {
+	store    VectorStore
+	embedder Embedder
+	options  ragToolkitOptions
+}
+
+// NewRAGToolkit creates a toolkit that searches the vector store using
+// embeddings produced by the embedder.
+func NewRAGToolkit[C any](store VectorStore, embedder Embedder, options ...RAGToolkitOption) *RAGToolkit[C] {
+	opts := ragToolkitOptions{topK: defaultTopK}
+	for _, option := range options {
+		option(&opts)
+	}
+	return &RAGToolkit[C]{store: store, embedder: embedder, options: opts}
+}
+
+// CreateSession returns a toolkit session exposing the search_knowledge tool.
+func (t *RAGToolkit[C]) CreateSession(ctx context.Context, contextVal C) (llmagent.ToolkitSession[C], error) {
+	prompt := defaultRAGPrompt
+	if t.options.systemPrompt != nil {
+		prompt = *t.options.systemPrompt
+	}
+	return &ragToolkitSession[C]{
+		prompt: prompt,
+		tools: []llmagent.AgentTool[C]{
+			llmagent.NewAgentFunctionTool[C](&searchKnowledgeTool[C]{toolkit: t}),
+		},
+	}, nil
+}
+
+type ragToolkitSession[C any] struct {
+	prompt string
+	tools  []llmagent.AgentTool[C]
+}
+
+func (s *ragToolkitSession[C]) SystemPrompt() *string {
+	return &s.prompt
+}
+
+func (s *ragToolkitSession[C]) Tools() []llmagent.AgentTool[C] {
+	return s.tools
+}
+
+func (s *ragToolkitSession[C]) Close(ctx context.Context) error {
+	return nil
+}
+
+// searchKnowledgeTool queries the vector store and returns retrieved chunks as
+// source parts.
+type searchKnowledgeTool[C any] struct {
+	toolkit *RAGToolkit[C]
+}
+
+func (t *searchKnowledgeTool[C]) Name() string { return "search_knowledge" }
+
+func (t *searchKnowledgeTool[C]) Description() string {
+	return "Search the knowledge base for chunks relevant to the query. Returns the matching chunks as sources."
+}
+
+func (t *searchKnowledgeTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{
+				"type":        "string",
+				"description": "The search query to match against the knowledge base",
+			},
+		},
+		"required":             []string{"query"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *searchKnowledgeTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var in struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(params, &in); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+
+	embedding, err := t.toolkit.embedder(ctx, in.Query)
+	if err != nil {
+		return llmagent.AgentToolResult{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(fmt.Sprintf("failed to embed query: %v", err))},
+			IsError: true,
+		}, nil
+	}
+
+	results, err := t.toolkit.store.Query(ctx, Query{
+		Embedding: embedding,
+		TopK:      t.toolkit.options.topK,
+	})
+	if err != nil {
+		return llmagent.AgentToolResult{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(fmt.Sprintf("failed to search knowledge base: %v", err))},
+			IsError: true,
+		}, nil
+	}
+
+	if len(results) == 0 {
+		return llmagent.AgentToolResult{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("No matching chunks found in the knowledge base.")},
+		}, nil
+	}
+
+	content := make([]llmsdk.Part, 0, len(results))
+	for _, result := range results {
+		title := result.Document.ID
+		if t, ok := result.Document.Metadata["title"].(string); ok && t != "" {
+			title = t
+		}
+		content = append(content, llmsdk.NewSourcePart(
+			result.Document.ID,
+			title,
+			[]llmsdk.Part{llmsdk.NewTextPart(result.Document.Content)},
+		))
+	}
+	return llmagent.AgentToolResult{Content: content}, nil
+}
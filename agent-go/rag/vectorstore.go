@@ -0,0 +1,139 @@
+// Package rag provides retrieval-augmented generation primitives: a
+// VectorStore interface with pluggable backends and a toolkit that exposes
+// knowledge search tools to the agent.
+package rag
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Document is a chunk of content stored in a vector store together with its
+// embedding and optional metadata.
+type Document struct {
+	ID        string         `json:"id"`
+	Content   string         `json:"content"`
+	Embedding []float64      `json:"embedding,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// QueryResult is a document returned from a vector store query together with
+// its similarity score. Higher scores indicate closer matches.
+type QueryResult struct {
+	Document Document `json:"document"`
+	Score    float64  `json:"score"`
+}
+
+// Query describes a vector store similarity search.
+type Query struct {
+	// Embedding is the query vector to match against stored documents.
+	Embedding []float64
+	// TopK limits the number of results. Zero means backend default.
+	TopK int
+	// Filter restricts results to documents whose metadata contains every
+	// listed key/value pair. A nil filter matches all documents.
+	Filter map[string]any
+}
+
+// VectorStore stores embedded documents and supports similarity search.
+// Implementations must be safe for concurrent use.
+type VectorStore interface {
+	// Upsert adds or replaces documents by ID.
+	Upsert(ctx context.Context, docs []Document) error
+	// Query returns the documents closest to the query embedding, most
+	// similar first.
+	Query(ctx context.Context, query Query) ([]QueryResult, error)
+	// Delete removes documents by ID. Missing IDs are ignored.
+	Delete(ctx context.Context, ids []string) error
+}
+
+const defaultTopK = 5
+
+// InMemoryVectorStore is a VectorStore backed by an in-process map using
+// cosine similarity. It is intended for tests and small corpora.
+type InMemoryVectorStore struct {
+	mu   sync.RWMutex
+	docs map[string]Document
+}
+
+// NewInMemoryVectorStore creates an empty in-memory vector store.
+func NewInMemoryVectorStore() *InMemoryVectorStore {
+	return &InMemoryVectorStore{docs: map[string]Document{}}
+}
+
+func (s *InMemoryVectorStore) Upsert(ctx context.Context, docs []Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, doc := range docs {
+		s.docs[doc.ID] = doc
+	}
+	return nil
+}
+
+func (s *InMemoryVectorStore) Query(ctx context.Context, query Query) ([]QueryResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	topK := query.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+
+	results := []QueryResult{}
+	for _, doc := range s.docs {
+		if !matchesFilter(doc.Metadata, query.Filter) {
+			continue
+		}
+		results = append(results, QueryResult{
+			Document: doc,
+			Score:    cosineSimilarity(query.Embedding, doc.Embedding),
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results, nil
+}
+
+func (s *InMemoryVectorStore) Delete(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.docs, id)
+	}
+	return nil
+}
+
+// matchesFilter reports whether metadata contains every key/value pair in filter.
+func matchesFilter(metadata, filter map[string]any) bool {
+	for key, want := range filter {
+		got, ok := metadata[key]
+		if !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors,
+// or 0 when either vector is empty or lengths differ.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,29 @@
+package llmagent
+
+import (
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// stripForeignReasoning removes provider-specific reasoning payloads from
+// assistant content produced by a different provider. Signatures and item IDs
+// — OpenAI encrypted reasoning, Anthropic thinking signatures — are only valid
+// when returned to the provider that issued them; replaying them elsewhere
+// fails the request. Reasoning parts that carry nothing but an opaque payload
+// are dropped entirely, while reasoning text is kept.
+func stripForeignReasoning(parts []llmsdk.Part) []llmsdk.Part {
+	sanitized := make([]llmsdk.Part, 0, len(parts))
+	for _, part := range parts {
+		if part.ReasoningPart == nil {
+			sanitized = append(sanitized, part)
+			continue
+		}
+		if part.ReasoningPart.Text == "" {
+			continue
+		}
+		reasoning := *part.ReasoningPart
+		reasoning.Signature = nil
+		reasoning.ID = nil
+		sanitized = append(sanitized, llmsdk.Part{ReasoningPart: &reasoning})
+	}
+	return sanitized
+}
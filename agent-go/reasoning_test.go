@@ -0,0 +1,106 @@
+package llmagent_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// storedModelItem simulates a model item that was persisted by a session
+// store and deserialized on a later run.
+func storedModelItem(t *testing.T, provider string) llmagent.AgentItem {
+	t.Helper()
+	data, err := json.Marshal(llmagent.AgentItem{Model: &llmagent.AgentItemModelResponse{
+		ModelResponse: &llmsdk.ModelResponse{Content: []llmsdk.Part{
+			llmsdk.NewReasoningPart("Considering the options.", llmsdk.WithReasoningSignature("sig_123"), llmsdk.WithReasoningID("rs_1")),
+			llmsdk.NewReasoningPart("", llmsdk.WithReasoningSignature("redacted_456")),
+			llmsdk.NewTextPart("The answer is 42."),
+		}},
+		Provider: provider,
+		ModelID:  "some-model",
+	}})
+	if err != nil {
+		t.Fatalf("marshal item: %v", err)
+	}
+	var item llmagent.AgentItem
+	if err := json.Unmarshal(data, &item); err != nil {
+		t.Fatalf("unmarshal item: %v", err)
+	}
+	return item
+}
+
+func runWithStoredItem(t *testing.T, model *llmsdktest.MockLanguageModel, item llmagent.AgentItem) llmsdk.LanguageModelInput {
+	t.Helper()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("ok")},
+	}))
+	agent := llmagent.NewAgent[struct{}]("test_agent", model)
+	_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			item,
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("And why?"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected one model call, got %d", len(inputs))
+	}
+	return inputs[0]
+}
+
+func TestReasoningPayloadsReplayedToSameProvider(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.SetProvider("openai")
+	input := runWithStoredItem(t, model, storedModelItem(t, "openai"))
+
+	assistant := input.Messages[0].AssistantMessage
+	if assistant == nil {
+		t.Fatalf("expected an assistant message, got %+v", input.Messages[0])
+	}
+	if len(assistant.Content) != 3 {
+		t.Fatalf("expected all parts replayed, got %+v", assistant.Content)
+	}
+	reasoning := assistant.Content[0].ReasoningPart
+	if reasoning == nil || reasoning.Signature == nil || *reasoning.Signature != "sig_123" {
+		t.Errorf("expected the reasoning signature re-attached, got %+v", reasoning)
+	}
+	if reasoning.ID == nil || *reasoning.ID != "rs_1" {
+		t.Errorf("expected the reasoning item id re-attached, got %+v", reasoning)
+	}
+}
+
+func TestForeignReasoningPayloadsStripped(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.SetProvider("openai")
+	input := runWithStoredItem(t, model, storedModelItem(t, "anthropic"))
+
+	assistant := input.Messages[0].AssistantMessage
+	if assistant == nil {
+		t.Fatalf("expected an assistant message, got %+v", input.Messages[0])
+	}
+	var reasoningParts, textParts int
+	for _, part := range assistant.Content {
+		if part.ReasoningPart != nil {
+			reasoningParts++
+			if part.ReasoningPart.Signature != nil || part.ReasoningPart.ID != nil {
+				t.Errorf("expected foreign reasoning payloads stripped, got %+v", part.ReasoningPart)
+			}
+			if part.ReasoningPart.Text == "" {
+				t.Errorf("expected opaque reasoning parts dropped, got %+v", part.ReasoningPart)
+			}
+		}
+		if part.TextPart != nil {
+			textParts++
+		}
+	}
+	if reasoningParts != 1 || textParts != 1 {
+		t.Errorf("expected the reasoning text and answer kept, got %+v", assistant.Content)
+	}
+}
@@ -0,0 +1,304 @@
+// Package redact filters personally identifiable information out of agent
+// items before they are persisted by a session store, exported to tracing, or
+// sent to third-party models. Built-in rules cover emails, phone numbers, and
+// credit card numbers; custom regular expression rules can be added alongside
+// them. With tokenization enabled, each distinct value is replaced by a stable
+// reversible token, and RestoreItems puts the originals back for flows that
+// must display the real values to an authorized caller.
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// Rule matches one category of sensitive content.
+type Rule struct {
+	// Name labels the category in replacement markers, e.g. "email".
+	Name string
+	// Pattern matches the sensitive content.
+	Pattern *regexp.Regexp
+	// Validate optionally rejects pattern matches, for example failing the
+	// Luhn check for candidate card numbers. Nil accepts every match.
+	Validate func(match string) bool
+}
+
+// EmailRule matches email addresses.
+func EmailRule() Rule {
+	return Rule{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	}
+}
+
+// CreditCardRule matches 13 to 16 digit card numbers, optionally separated by
+// spaces or dashes, that pass the Luhn check.
+func CreditCardRule() Rule {
+	return Rule{
+		Name:     "credit_card",
+		Pattern:  regexp.MustCompile(`\b(?:\d[ \-]?){12,15}\d\b`),
+		Validate: luhnValid,
+	}
+}
+
+// PhoneRule matches international and separator-formatted phone numbers. The
+// digit groups must be joined by dashes or dots, optionally after a +country
+// prefix or parenthesized area code, so plain numbers in ordinary text are
+// left alone.
+func PhoneRule() Rule {
+	return Rule{
+		Name:    "phone",
+		Pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .\-]?)?(?:\(\d{2,4}\)[ .\-]?)?\d{2,4}[.\-]\d{3,4}(?:[.\-]\d{3,4})?\b`),
+	}
+}
+
+// DefaultRules returns the built-in rules. Credit cards are matched before
+// phone numbers so separator-formatted card numbers are not misclassified.
+func DefaultRules() []Rule {
+	return []Rule{EmailRule(), CreditCardRule(), PhoneRule()}
+}
+
+// Redactor applies rules to the text reachable from agent items. It is safe
+// for concurrent use.
+type Redactor struct {
+	rules    []Rule
+	tokenize bool
+
+	mu sync.Mutex
+	// tokens maps a token back to the original value; byValue keeps tokens
+	// stable so the same value redacts to the same token across items.
+	tokens  map[string]string
+	byValue map[string]string
+	counts  map[string]int
+}
+
+// Option configures a Redactor.
+type Option func(*Redactor)
+
+// WithRules replaces the default rules.
+func WithRules(rules ...Rule) Option {
+	return func(r *Redactor) {
+		r.rules = rules
+	}
+}
+
+// WithRule appends a custom rule after the configured ones.
+func WithRule(rule Rule) Option {
+	return func(r *Redactor) {
+		r.rules = append(r.rules, rule)
+	}
+}
+
+// WithTokenization replaces matches with reversible tokens instead of plain
+// markers, so RestoreItems can recover the original values.
+func WithTokenization() Option {
+	return func(r *Redactor) {
+		r.tokenize = true
+	}
+}
+
+// NewRedactor creates a redactor with the default rules unless overridden.
+func NewRedactor(options ...Option) (*Redactor, error) {
+	r := &Redactor{
+		rules:   DefaultRules(),
+		tokens:  map[string]string{},
+		byValue: map[string]string{},
+		counts:  map[string]int{},
+	}
+	for _, option := range options {
+		option(r)
+	}
+	if len(r.rules) == 0 {
+		return nil, errors.New("redactor requires at least one rule")
+	}
+	for _, rule := range r.rules {
+		if rule.Name == "" || rule.Pattern == nil {
+			return nil, errors.New("redact rules require a name and a pattern")
+		}
+	}
+	return r, nil
+}
+
+// RedactItems applies the rules to every text reachable from the items:
+// message content, model response content, tool call arguments, and tool
+// output. The input items are not modified; the returned slice shares
+// unchanged items.
+func (r *Redactor) RedactItems(items []llmagent.AgentItem) []llmagent.AgentItem {
+	result := make([]llmagent.AgentItem, len(items))
+	for i, item := range items {
+		switch {
+		case item.Message != nil:
+			message := *item.Message
+			redactMessageContent(&message, r.redactParts)
+			item.Message = &message
+		case item.Model != nil && item.Model.ModelResponse != nil:
+			response := *item.Model.ModelResponse
+			response.Content = r.redactParts(response.Content)
+			// Copy the wrapper so provenance stamps like Provider, ModelID,
+			// and Duration survive the rewrite.
+			wrapper := *item.Model
+			wrapper.ModelResponse = &response
+			item.Model = &wrapper
+		case item.Tool != nil:
+			tool := *item.Tool
+			tool.Input = []byte(r.RedactString(string(tool.Input)))
+			tool.Output = r.redactParts(tool.Output)
+			item.Tool = &tool
+		}
+		result[i] = item
+	}
+	return result
+}
+
+// RestoreItems replaces tokens produced by a tokenizing redactor with the
+// original values. Without tokenization it returns the items unchanged.
+func (r *Redactor) RestoreItems(items []llmagent.AgentItem) []llmagent.AgentItem {
+	if !r.tokenize {
+		return items
+	}
+	result := make([]llmagent.AgentItem, len(items))
+	for i, item := range items {
+		switch {
+		case item.Message != nil:
+			message := *item.Message
+			redactMessageContent(&message, r.restoreParts)
+			item.Message = &message
+		case item.Model != nil && item.Model.ModelResponse != nil:
+			response := *item.Model.ModelResponse
+			response.Content = r.restoreParts(response.Content)
+			wrapper := *item.Model
+			wrapper.ModelResponse = &response
+			item.Model = &wrapper
+		case item.Tool != nil:
+			tool := *item.Tool
+			tool.Input = []byte(r.RestoreString(string(tool.Input)))
+			tool.Output = r.restoreParts(tool.Output)
+			item.Tool = &tool
+		}
+		result[i] = item
+	}
+	return result
+}
+
+// RedactString applies the rules to one string.
+func (r *Redactor) RedactString(s string) string {
+	for _, rule := range r.rules {
+		s = rule.Pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if rule.Validate != nil && !rule.Validate(match) {
+				return match
+			}
+			return r.replacement(rule.Name, match)
+		})
+	}
+	return s
+}
+
+// RestoreString replaces tokens in one string with the original values.
+func (r *Redactor) RestoreString(s string) string {
+	if !r.tokenize {
+		return s
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return tokenPattern.ReplaceAllStringFunc(s, func(token string) string {
+		if original, ok := r.tokens[token]; ok {
+			return original
+		}
+		return token
+	})
+}
+
+// tokenPattern matches the markers emitted by replacement.
+var tokenPattern = regexp.MustCompile(`\[REDACTED:[a-z0-9_]+(?::\d+)?\]`)
+
+func (r *Redactor) replacement(name, match string) string {
+	if !r.tokenize {
+		return fmt.Sprintf("[REDACTED:%s]", name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := name + "\x00" + match
+	if token, ok := r.byValue[key]; ok {
+		return token
+	}
+	r.counts[name]++
+	token := fmt.Sprintf("[REDACTED:%s:%d]", name, r.counts[name])
+	r.byValue[key] = token
+	r.tokens[token] = match
+	return token
+}
+
+func (r *Redactor) redactParts(parts []llmsdk.Part) []llmsdk.Part {
+	return r.mapTextParts(parts, r.RedactString)
+}
+
+func (r *Redactor) restoreParts(parts []llmsdk.Part) []llmsdk.Part {
+	return r.mapTextParts(parts, r.RestoreString)
+}
+
+func (r *Redactor) mapTextParts(parts []llmsdk.Part, transform func(string) string) []llmsdk.Part {
+	result := make([]llmsdk.Part, len(parts))
+	for i, part := range parts {
+		switch {
+		case part.TextPart != nil:
+			textPart := *part.TextPart
+			textPart.Text = transform(textPart.Text)
+			part.TextPart = &textPart
+		case part.ToolCallPart != nil && part.ToolCallPart.Call.Function != nil:
+			toolCallPart := *part.ToolCallPart
+			function := *toolCallPart.Call.Function
+			function.Args = []byte(transform(string(function.Args)))
+			toolCallPart.Call.Function = &function
+			part.ToolCallPart = &toolCallPart
+		}
+		result[i] = part
+	}
+	return result
+}
+
+func redactMessageContent(message *llmsdk.Message, transform func([]llmsdk.Part) []llmsdk.Part) {
+	switch {
+	case message.UserMessage != nil:
+		userMessage := *message.UserMessage
+		userMessage.Content = transform(userMessage.Content)
+		message.UserMessage = &userMessage
+	case message.AssistantMessage != nil:
+		assistantMessage := *message.AssistantMessage
+		assistantMessage.Content = transform(assistantMessage.Content)
+		message.AssistantMessage = &assistantMessage
+	case message.ToolMessage != nil:
+		toolMessage := *message.ToolMessage
+		toolMessage.Content = transform(toolMessage.Content)
+		message.ToolMessage = &toolMessage
+	}
+}
+
+// luhnValid reports whether the digits in the candidate pass the Luhn check.
+func luhnValid(candidate string) bool {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, candidate)
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return len(digits) > 0 && sum%10 == 0
+}
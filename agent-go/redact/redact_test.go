@@ -0,0 +1,179 @@
+package redact_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/redact"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// modelItem builds a model item stamped with provenance, so tests can assert
+// the stamps survive the rewrites.
+func modelItem(response llmsdk.ModelResponse) llmagent.AgentItem {
+	item := llmagent.NewAgentItemModelResponse(response)
+	item.Model.Provider = "mock"
+	item.Model.ModelID = "mock-model"
+	return item
+}
+
+func TestRedactString(t *testing.T) {
+	redactor, err := redact.NewRedactor()
+	if err != nil {
+		t.Fatalf("failed to create redactor: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "email",
+			in:   "Contact alice@example.com please",
+			want: "Contact [REDACTED:email] please",
+		},
+		{
+			name: "credit card",
+			in:   "Card 4539 1488 0343 6467 on file",
+			want: "Card [REDACTED:credit_card] on file",
+		},
+		{
+			name: "invalid card stays",
+			in:   "Order 1234 5678 9012 3456 shipped",
+			want: "Order 1234 5678 9012 3456 shipped",
+		},
+		{
+			name: "phone",
+			in:   "Call +1 555-123-4567 today",
+			want: "Call [REDACTED:phone] today",
+		},
+		{
+			name: "plain text untouched",
+			in:   "Nothing sensitive here",
+			want: "Nothing sensitive here",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := redactor.RedactString(tc.in); got != tc.want {
+				t.Errorf("RedactString(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedactItems(t *testing.T) {
+	redactor, err := redact.NewRedactor()
+	if err != nil {
+		t.Fatalf("failed to create redactor: %v", err)
+	}
+
+	items := []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(
+			llmsdk.NewTextPart("My email is alice@example.com"),
+		)),
+		modelItem(llmsdk.ModelResponse{Content: []llmsdk.Part{
+			llmsdk.NewToolCallPart("call_1", "send_mail", map[string]any{"to": "alice@example.com"}),
+		}}),
+		llmagent.NewAgentItemTool("call_1", "send_mail",
+			[]byte(`{"to":"alice@example.com"}`),
+			[]llmsdk.Part{llmsdk.NewTextPart("sent to alice@example.com")},
+			llmsdk.ToolResultStatusCompleted,
+		),
+	}
+
+	redacted := redactor.RedactItems(items)
+
+	if text := redacted[0].Message.UserMessage.Content[0].TextPart.Text; strings.Contains(text, "alice@") {
+		t.Errorf("expected the message redacted, got %q", text)
+	}
+	if args := string(redacted[1].Model.Content[0].ToolCallPart.Call.Function.Args); strings.Contains(args, "alice@") {
+		t.Errorf("expected the tool call args redacted, got %q", args)
+	}
+	if input := string(redacted[2].Tool.Input); strings.Contains(input, "alice@") {
+		t.Errorf("expected the tool input redacted, got %q", input)
+	}
+	if output := redacted[2].Tool.Output[0].TextPart.Text; strings.Contains(output, "alice@") {
+		t.Errorf("expected the tool output redacted, got %q", output)
+	}
+	if got := redacted[1].Model; got.Provider != "mock" || got.ModelID != "mock-model" {
+		t.Errorf("expected provenance stamps preserved, got provider=%q model_id=%q", got.Provider, got.ModelID)
+	}
+
+	// The input items must not be modified.
+	if text := items[0].Message.UserMessage.Content[0].TextPart.Text; text != "My email is alice@example.com" {
+		t.Errorf("input message was modified: %q", text)
+	}
+	if args := string(items[1].Model.Content[0].ToolCallPart.Call.Function.Args); !strings.Contains(args, "alice@") {
+		t.Errorf("input tool call args were modified: %q", args)
+	}
+}
+
+func TestTokenizationRoundTrip(t *testing.T) {
+	redactor, err := redact.NewRedactor(redact.WithTokenization())
+	if err != nil {
+		t.Fatalf("failed to create redactor: %v", err)
+	}
+
+	items := []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(
+			llmsdk.NewTextPart("Reach me at alice@example.com or bob@example.com"),
+		)),
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(
+			llmsdk.NewTextPart("I said alice@example.com, not bob"),
+		)),
+		modelItem(llmsdk.ModelResponse{Content: []llmsdk.Part{
+			llmsdk.NewTextPart("Noted, alice@example.com it is"),
+		}}),
+	}
+
+	redacted := redactor.RedactItems(items)
+	first := redacted[0].Message.UserMessage.Content[0].TextPart.Text
+	second := redacted[1].Message.UserMessage.Content[0].TextPart.Text
+	if first != "Reach me at [REDACTED:email:1] or [REDACTED:email:2]" {
+		t.Errorf("unexpected redacted text %q", first)
+	}
+	// The same value maps to the same token across items.
+	if !strings.Contains(second, "[REDACTED:email:1]") {
+		t.Errorf("expected a stable token for the repeated value, got %q", second)
+	}
+
+	restored := redactor.RestoreItems(redacted)
+	if text := restored[0].Message.UserMessage.Content[0].TextPart.Text; text != "Reach me at alice@example.com or bob@example.com" {
+		t.Errorf("expected the originals restored, got %q", text)
+	}
+	if text := restored[2].Model.Content[0].TextPart.Text; text != "Noted, alice@example.com it is" {
+		t.Errorf("expected the model content restored, got %q", text)
+	}
+	if got := restored[2].Model; got.Provider != "mock" || got.ModelID != "mock-model" {
+		t.Errorf("expected provenance stamps preserved, got provider=%q model_id=%q", got.Provider, got.ModelID)
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	redactor, err := redact.NewRedactor(redact.WithRule(redact.Rule{
+		Name:    "employee_id",
+		Pattern: regexp.MustCompile(`EMP-\d{6}`),
+	}))
+	if err != nil {
+		t.Fatalf("failed to create redactor: %v", err)
+	}
+
+	got := redactor.RedactString("Badge EMP-123456 for alice@example.com")
+	want := "Badge [REDACTED:employee_id] for [REDACTED:email]"
+	if got != want {
+		t.Errorf("RedactString = %q, want %q", got, want)
+	}
+}
+
+func TestNewRedactorValidatesRules(t *testing.T) {
+	if _, err := redact.NewRedactor(redact.WithRules()); err == nil {
+		t.Error("expected an error for no rules")
+	}
+	if _, err := redact.NewRedactor(redact.WithRule(redact.Rule{Name: "bad"})); err == nil {
+		t.Error("expected an error for a rule without a pattern")
+	}
+}
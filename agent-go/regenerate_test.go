@@ -0,0 +1,103 @@
+package llmagent_test
+
+import (
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+func TestRegenerate_ReplacesAssistantItem(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		textResponse("first answer"),
+		textResponse("regenerated answer"),
+	)
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+	}, nil)
+
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("question")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	history := session.History()
+	if len(history) != 2 || history[1].Model == nil {
+		t.Fatalf("unexpected history %+v", history)
+	}
+
+	response, err := session.Regenerate(t.Context(), 1, nil)
+	if err != nil {
+		t.Fatalf("failed to regenerate: %v", err)
+	}
+	if response.Content[0].TextPart.Text != "regenerated answer" {
+		t.Errorf("unexpected regenerated content %+v", response.Content)
+	}
+
+	history = session.History()
+	if len(history) != 2 {
+		t.Fatalf("expected the replaced item, got %d items", len(history))
+	}
+	if history[1].Model == nil || history[1].Model.Content[0].TextPart.Text != "regenerated answer" {
+		t.Errorf("expected the transcript tail replaced, got %+v", history[1])
+	}
+}
+
+func TestRegenerate_AppliesSamplingOverrides(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		textResponse("first answer"),
+		textResponse("warmer answer"),
+	)
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+	}, nil)
+
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("question")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := session.Regenerate(t.Context(), 1, &llmagent.SamplingOverrides{
+		Temperature: ptr.To(1.2),
+	}); err != nil {
+		t.Fatalf("failed to regenerate: %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 model calls, got %d", len(inputs))
+	}
+	if inputs[1].Temperature == nil || *inputs[1].Temperature != 1.2 {
+		t.Errorf("expected the sampling override applied, got %v", inputs[1].Temperature)
+	}
+}
+
+func TestRegenerate_RejectsNonModelItems(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(textResponse("answer"))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "assistant",
+		Model:    model,
+		MaxTurns: 2,
+	}, nil)
+
+	if _, err := session.Send(t.Context(), llmsdk.NewTextPart("question")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := session.Regenerate(t.Context(), 0, nil); err == nil {
+		t.Error("expected an error when targeting a user message")
+	}
+	if _, err := session.Regenerate(t.Context(), 5, nil); err == nil {
+		t.Error("expected an error for an out-of-range index")
+	}
+}
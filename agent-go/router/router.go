@@ -0,0 +1,182 @@
+// Package router provides a routing primitive for agents.
+//
+// A Router classifies each incoming request and dispatches it to one of
+// several registered sub-agents. Classification is pluggable: a
+// ModelClassifier asks a (typically cheap) language model to pick a route
+// from the route descriptions, while ClassifierFunc supports rule-based
+// routing without any model call. The chosen route is returned alongside the
+// sub-agent response so callers can observe and log routing decisions.
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// Route registers a sub-agent under a name the classifier can pick.
+type Route[C any] struct {
+	// Name identifies the route, e.g. "billing".
+	Name string
+	// Description tells the classifier which requests belong on this route.
+	Description string
+	// Agent handles requests dispatched to this route.
+	Agent *llmagent.Agent[C]
+}
+
+// Classifier picks the route for a request.
+type Classifier[C any] interface {
+	// Classify returns the name of the route the request should dispatch to.
+	Classify(ctx context.Context, request llmagent.AgentRequest[C], routes []Route[C]) (string, error)
+}
+
+// ClassifierFunc adapts a function to a Classifier, e.g. for rule-based
+// routing on the request content or context value.
+type ClassifierFunc[C any] func(ctx context.Context, request llmagent.AgentRequest[C], routes []Route[C]) (string, error)
+
+func (f ClassifierFunc[C]) Classify(ctx context.Context, request llmagent.AgentRequest[C], routes []Route[C]) (string, error) {
+	return f(ctx, request, routes)
+}
+
+// Response is a sub-agent response annotated with the chosen route.
+type Response struct {
+	*llmagent.AgentResponse
+	// Route is the name of the route the request was dispatched to.
+	Route string `json:"route"`
+}
+
+// Router dispatches requests to registered sub-agents.
+type Router[C any] struct {
+	classifier Classifier[C]
+	routes     []Route[C]
+}
+
+// New creates a router over the given routes.
+func New[C any](classifier Classifier[C], routes ...Route[C]) (*Router[C], error) {
+	if classifier == nil {
+		return nil, errors.New("router: classifier is required")
+	}
+	if len(routes) == 0 {
+		return nil, errors.New("router: at least one route is required")
+	}
+	seen := map[string]bool{}
+	for _, route := range routes {
+		if route.Name == "" || route.Agent == nil {
+			return nil, fmt.Errorf("router: route %q must have a name and an agent", route.Name)
+		}
+		if seen[route.Name] {
+			return nil, fmt.Errorf("router: duplicate route %q", route.Name)
+		}
+		seen[route.Name] = true
+	}
+	return &Router[C]{classifier: classifier, routes: routes}, nil
+}
+
+// Run classifies the request, dispatches it to the chosen sub-agent, and
+// returns the response annotated with the route name.
+func (r *Router[C]) Run(ctx context.Context, request llmagent.AgentRequest[C]) (*Response, error) {
+	name, err := r.classifier.Classify(ctx, request, r.routes)
+	if err != nil {
+		return nil, fmt.Errorf("router: classification failed: %w", err)
+	}
+	route, err := r.route(name)
+	if err != nil {
+		return nil, err
+	}
+	response, err := route.Agent.Run(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{AgentResponse: response, Route: route.Name}, nil
+}
+
+func (r *Router[C]) route(name string) (Route[C], error) {
+	for _, route := range r.routes {
+		if route.Name == name {
+			return route, nil
+		}
+	}
+	return Route[C]{}, fmt.Errorf("router: classifier chose unknown route %q", name)
+}
+
+// ModelClassifier picks a route by asking a language model, typically a
+// cheaper one than the sub-agents use.
+type ModelClassifier[C any] struct {
+	model llmsdk.LanguageModel
+}
+
+// NewModelClassifier creates a model-backed classifier.
+func NewModelClassifier[C any](model llmsdk.LanguageModel) *ModelClassifier[C] {
+	return &ModelClassifier[C]{model: model}
+}
+
+// Classify implements Classifier. It presents the route names and
+// descriptions to the model and constrains the reply to one route name.
+func (c *ModelClassifier[C]) Classify(ctx context.Context, request llmagent.AgentRequest[C], routes []Route[C]) (string, error) {
+	names := make([]string, len(routes))
+	var b strings.Builder
+	b.WriteString("Classify the user request into exactly one of the following routes and reply with the route name only.\n")
+	for i, route := range routes {
+		names[i] = route.Name
+		fmt.Fprintf(&b, "- %s: %s\n", route.Name, route.Description)
+	}
+
+	response, err := c.model.Generate(ctx, &llmsdk.LanguageModelInput{
+		SystemPrompt: ptr.To(b.String()),
+		Messages:     requestMessages(request),
+		ResponseFormat: llmsdk.NewResponseFormatJSON("route", nil, &llmsdk.JSONSchema{
+			"type": "object",
+			"properties": map[string]any{
+				"route": map[string]any{"type": "string", "enum": names},
+			},
+			"required":             []string{"route"},
+			"additionalProperties": false,
+		}),
+	})
+	if err != nil {
+		return "", err
+	}
+	return parseRoute(response, names)
+}
+
+// requestMessages extracts the message items of the request as model input
+// for the classifier.
+func requestMessages[C any](request llmagent.AgentRequest[C]) []llmsdk.Message {
+	var messages []llmsdk.Message
+	for _, item := range request.Input {
+		if item.Message != nil {
+			messages = append(messages, *item.Message)
+		}
+	}
+	return messages
+}
+
+// parseRoute reads the route name from the classifier response, accepting
+// either the structured {"route": ...} object or a bare route name.
+func parseRoute(response *llmsdk.ModelResponse, names []string) (string, error) {
+	var text strings.Builder
+	for _, part := range response.Content {
+		if part.TextPart != nil {
+			text.WriteString(part.TextPart.Text)
+		}
+	}
+	raw := strings.TrimSpace(text.String())
+	for _, name := range names {
+		if raw == name {
+			return name, nil
+		}
+	}
+	var parsed struct {
+		Route string `json:"route"`
+	}
+	if err := json.Unmarshal([]byte(raw), &parsed); err == nil && parsed.Route != "" {
+		return parsed.Route, nil
+	}
+	return "", fmt.Errorf("classifier returned unrecognized route %q", raw)
+}
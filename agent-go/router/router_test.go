@@ -0,0 +1,117 @@
+package router_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/router"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func newSubAgent(name, reply string) (*llmagent.Agent[struct{}], *llmsdktest.MockLanguageModel) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(reply)},
+	}))
+	return llmagent.NewAgent[struct{}](name, model), model
+}
+
+func userRequest(text string) llmagent.AgentRequest[struct{}] {
+	return llmagent.AgentRequest[struct{}]{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text))),
+		},
+	}
+}
+
+func TestRouterDispatchesByRule(t *testing.T) {
+	billingAgent, billingModel := newSubAgent("billing", "billing reply")
+	supportAgent, supportModel := newSubAgent("support", "support reply")
+
+	classifier := router.ClassifierFunc[struct{}](func(_ context.Context, request llmagent.AgentRequest[struct{}], _ []router.Route[struct{}]) (string, error) {
+		for _, item := range request.Input {
+			if item.Message != nil && item.Message.UserMessage != nil {
+				for _, part := range item.Message.UserMessage.Content {
+					if part.TextPart != nil && strings.Contains(part.TextPart.Text, "invoice") {
+						return "billing", nil
+					}
+				}
+			}
+		}
+		return "support", nil
+	})
+
+	r, err := router.New(classifier,
+		router.Route[struct{}]{Name: "billing", Description: "Invoices and payments", Agent: billingAgent},
+		router.Route[struct{}]{Name: "support", Description: "Everything else", Agent: supportAgent},
+	)
+	if err != nil {
+		t.Fatalf("failed to create router: %v", err)
+	}
+
+	response, err := r.Run(t.Context(), userRequest("where is my invoice?"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Route != "billing" {
+		t.Errorf("expected billing route, got %q", response.Route)
+	}
+	if response.Text() != "billing reply" {
+		t.Errorf("unexpected response text %q", response.Text())
+	}
+	if len(billingModel.TrackedGenerateInputs()) != 1 || len(supportModel.TrackedGenerateInputs()) != 0 {
+		t.Error("expected only the billing agent to run")
+	}
+}
+
+func TestRouterRejectsUnknownRoute(t *testing.T) {
+	agent, _ := newSubAgent("support", "reply")
+	classifier := router.ClassifierFunc[struct{}](func(context.Context, llmagent.AgentRequest[struct{}], []router.Route[struct{}]) (string, error) {
+		return "missing", nil
+	})
+	r, err := router.New(classifier, router.Route[struct{}]{Name: "support", Description: "d", Agent: agent})
+	if err != nil {
+		t.Fatalf("failed to create router: %v", err)
+	}
+
+	if _, err := r.Run(t.Context(), userRequest("hello")); err == nil || !strings.Contains(err.Error(), "unknown route") {
+		t.Errorf("expected unknown route error, got %v", err)
+	}
+}
+
+func TestModelClassifierPicksRoute(t *testing.T) {
+	classifierModel := llmsdktest.NewMockLanguageModel()
+	classifierModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(`{"route":"billing"}`)},
+	}))
+
+	billingAgent, _ := newSubAgent("billing", "billing reply")
+	supportAgent, _ := newSubAgent("support", "support reply")
+
+	r, err := router.New[struct{}](router.NewModelClassifier[struct{}](classifierModel),
+		router.Route[struct{}]{Name: "billing", Description: "Invoices and payments", Agent: billingAgent},
+		router.Route[struct{}]{Name: "support", Description: "Everything else", Agent: supportAgent},
+	)
+	if err != nil {
+		t.Fatalf("failed to create router: %v", err)
+	}
+
+	response, err := r.Run(t.Context(), userRequest("where is my invoice?"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Route != "billing" || response.Text() != "billing reply" {
+		t.Errorf("unexpected response route=%q text=%q", response.Route, response.Text())
+	}
+
+	inputs := classifierModel.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected 1 classifier call, got %d", len(inputs))
+	}
+	if inputs[0].SystemPrompt == nil || !strings.Contains(*inputs[0].SystemPrompt, "billing: Invoices and payments") {
+		t.Errorf("expected route descriptions in classifier prompt, got %v", inputs[0].SystemPrompt)
+	}
+}
@@ -2,14 +2,15 @@ package llmagent
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
-	"strings"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
-	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
 	"github.com/hoangvvo/llm-sdk/sdk-go/utils/stream"
 	"golang.org/x/sync/errgroup"
 )
@@ -27,25 +28,50 @@ func attachRunSnapshot(err error, snapshot *AgentRunSnapshot) error {
 	}).withSnapshot(snapshot)
 }
 
-func emitToolCancellationEvents(currCh chan<- ProcessEvent, pendingToolCalls []*llmsdk.ToolCallPart) {
-	for _, toolCall := range pendingToolCalls {
-		call := toolCall.Call.Function
-		if call == nil {
-			continue
+// toolExecutionResult carries the outcome of a single tool call execution.
+// Exactly one of item or err is set.
+type toolExecutionResult struct {
+	item *AgentItem
+	err  error
+}
+
+func newCancelledToolResult(toolCall *llmsdk.ToolCallPart) toolExecutionResult {
+	call := toolCall.Call.Function
+	item := NewAgentItemTool(
+		toolCall.ToolCallID,
+		call.Name,
+		call.Args,
+		[]llmsdk.Part{},
+		llmsdk.ToolResultStatusCancelled,
+	)
+	return toolExecutionResult{item: &item}
+}
+
+// newProviderToolItem builds the informational item for a provider-executed
+// tool call, pairing it with the matching result part in the same content.
+func newProviderToolItem(toolCall *llmsdk.ToolCallPart, content []llmsdk.Part) AgentItem {
+	providerTool := AgentItemProviderTool{
+		ToolCallID: toolCall.ToolCallID,
+		Call:       toolCall.Call,
+		Status:     llmsdk.ToolResultStatusCompleted,
+	}
+	if webSearch := toolCall.Call.WebSearch; webSearch != nil {
+		providerTool.ToolName = "web_search"
+		if webSearch.Status != nil && *webSearch.Status == llmsdk.WebSearchToolCallStatusFailed {
+			providerTool.Status = llmsdk.ToolResultStatusFailed
 		}
-		item := NewAgentItemTool(
-			toolCall.ToolCallID,
-			call.Name,
-			call.Args,
-			[]llmsdk.Part{},
-			llmsdk.ToolResultStatusCancelled,
-		)
-		currCh <- ProcessEvent{Item: &item}
-	}
-	currCh <- ProcessEvent{Response: &ProcessResponse{
-		Content: []llmsdk.Part{},
-		Status:  AgentResponseStatusCancelled,
-	}}
+	}
+	for _, part := range content {
+		if part.ToolResultPart != nil && part.ToolResultPart.ToolCallID == toolCall.ToolCallID {
+			result := part.ToolResultPart.Result
+			providerTool.Result = &result
+			if part.ToolResultPart.Status != "" {
+				providerTool.Status = part.ToolResultPart.Status
+			}
+			break
+		}
+	}
+	return NewAgentItemProviderTool(providerTool)
 }
 
 // RunSession manages the run session for an agent.
@@ -57,12 +83,30 @@ func emitToolCancellationEvents(currCh chan<- ProcessEvent, pendingToolCalls []*
 // context value that is used when resolving instructions and invoking tools,
 // while input items remain per run and are supplied to each invocation.
 type RunSession[C any] struct {
-	params             *AgentParams[C]     // params stores the agent configuration used during the run.
-	contextVal         C                   // contextVal is the bound context value used for instructions and tool executions.
-	staticSystemPrompt *string             // systemPrompt caches the resolved instructions as a system prompt.
-	staticTools        []AgentTool[C]      // staticTools holds the tools provided directly in the agent params.
-	toolkitSessions    []ToolkitSession[C] // toolkitSessions keeps the toolkit-provided sessions for this run session.
-	initialized        bool                // initialized ensures the session is ready before running.
+	params             *AgentParams[C]      // params stores the agent configuration used during the run.
+	id                 string               // id uniquely identifies the session and seeds tool idempotency keys.
+	model              llmsdk.LanguageModel // model is the language model resolved for this session.
+	contextVal         C                    // contextVal is the bound context value used for instructions and tool executions.
+	staticSystemPrompt *string              // staticSystemPrompt caches the resolved instructions, guarded by promptMu.
+	promptMu           sync.RWMutex         // promptMu guards staticSystemPrompt against concurrent refresh.
+	staticTools        []AgentTool[C]       // staticTools holds the tools provided directly in the agent params.
+	toolkitSessions    []ToolkitSession[C]  // toolkitSessions keeps the toolkit-provided sessions for this run session.
+	initialized        bool                 // initialized ensures the session is ready before running.
+
+	executedToolsMu sync.RWMutex         // executedToolsMu guards executedTools.
+	executedTools   map[string]AgentItem // executedTools records finished tool executions by idempotency key.
+
+	sendMu     sync.Mutex  // sendMu serializes Send turns and guards transcript.
+	transcript []AgentItem // transcript accumulates the conversation items owned by Send.
+
+	interruptMu sync.Mutex    // interruptMu guards activeRun.
+	activeRun   *runInterrupt // activeRun cancels the in-flight streamed run, if any.
+}
+
+// runInterrupt holds the cancel function of an in-flight streamed run so
+// Interrupt can target the run that is still active.
+type runInterrupt struct {
+	cancel context.CancelFunc
 }
 
 // NewRunSession creates a new run session, resolves instructions, and initializes dependencies.
@@ -73,6 +117,7 @@ func NewRunSession[C any](
 ) (*RunSession[C], error) {
 	session := &RunSession[C]{
 		params:      params,
+		id:          uuid.NewString(),
 		contextVal:  contextVal,
 		staticTools: append([]AgentTool[C]{}, params.Tools...),
 	}
@@ -84,7 +129,63 @@ func NewRunSession[C any](
 	return session, nil
 }
 
+// ID returns the unique identifier of this session. Tool idempotency keys
+// are derived from it together with the tool call ID.
+func (s *RunSession[C]) ID() string {
+	return s.id
+}
+
+// systemPrompt returns the cached system prompt resolved from the agent
+// instructions.
+func (s *RunSession[C]) systemPrompt() *string {
+	s.promptMu.RLock()
+	defer s.promptMu.RUnlock()
+	return s.staticSystemPrompt
+}
+
+// RefreshInstructions re-resolves the agent instructions against the bound
+// context value and replaces the session's cached system prompt. Instruction
+// funcs run once when the session is created — expensive lookups against
+// databases or remote services are not repeated on every turn — so a session
+// that outlives the data behind an instruction (e.g. a user profile edited
+// mid-conversation) calls this to refetch. On error the cached prompt is
+// left unchanged.
+func (s *RunSession[C]) RefreshInstructions(ctx context.Context) error {
+	if !s.initialized {
+		return NewInvariantError("run session not initialized")
+	}
+	if len(s.params.Instructions) == 0 {
+		return nil
+	}
+	prompt, err := getPrompt(ctx, s.params.Instructions, s.contextVal)
+	if err != nil {
+		return err
+	}
+	s.promptMu.Lock()
+	s.staticSystemPrompt = &prompt
+	s.promptMu.Unlock()
+	return nil
+}
+
 func (s *RunSession[C]) initialize(ctx context.Context) error {
+	s.model = s.params.Model
+	if s.params.ModelResolver != nil {
+		model, err := s.params.ModelResolver(ctx, s.contextVal)
+		if err != nil {
+			return NewInitError(err)
+		}
+		if model != nil {
+			s.model = model
+		}
+	}
+	if s.model == nil {
+		return NewInitError(errors.New("no language model configured for the agent"))
+	}
+
+	if err := lintFunctionTools(s.staticTools); err != nil {
+		return NewInitError(err)
+	}
+
 	if len(s.params.Instructions) > 0 {
 		prompt, err := getPrompt(ctx, s.params.Instructions, s.contextVal)
 		if err != nil {
@@ -215,7 +316,12 @@ func (s *RunSession[C]) process(
 				return
 			}
 
-		case lastItem.Tool != nil:
+		case lastItem.File != nil:
+			// ========== Case: File Attachment ==========
+			// A trailing upload is new input for the model, like a user message
+			currCh <- ProcessEvent{Next: &struct{}{}}
+			return
+		case lastItem.Tool != nil, lastItem.ProviderTool != nil:
 			// ========== Case: Tool Results (from AgentItemTool) ==========
 			// Each tool result is an individual item in this representation, so there could be other
 			// AgentItemTool before this one. We loop backwards to find the first non-tool item while also
@@ -228,6 +334,12 @@ func (s *RunSession[C]) process(
 					processedToolCallIDs[item.Tool.ToolCallID] = struct{}{}
 					// Continue searching for the originating model/assistant item
 					continue
+				} else if item.ProviderTool != nil {
+					// Provider-executed calls never re-execute; skip over them
+					continue
+				} else if item.File != nil {
+					// File attachments are informational; skip over them
+					continue
 				} else if item.Model != nil {
 					// Found the originating model response
 					content = item.Model.Content
@@ -282,6 +394,20 @@ func (s *RunSession[C]) process(
 			}
 		}
 
+		// Surface provider-executed built-in tool calls (those without a
+		// function payload) as informational items, once per call ID.
+		for _, part := range content {
+			toolCallPart := part.ToolCallPart
+			if toolCallPart == nil || toolCallPart.Call.Function != nil {
+				continue
+			}
+			if runState.hasProviderToolItem(toolCallPart.ToolCallID) {
+				continue
+			}
+			item := newProviderToolItem(toolCallPart, content)
+			currCh <- ProcessEvent{Item: &item}
+		}
+
 		if len(toolCallParts) == 0 {
 			status := AgentResponseStatusCompleted
 			if ctx.Err() != nil {
@@ -310,50 +436,105 @@ func (s *RunSession[C]) process(
 			}
 		}
 
-		for index, toolCallPart := range pendingToolCalls {
-			if ctx.Err() != nil {
-				emitToolCancellationEvents(currCh, pendingToolCalls[index:])
-				return
-			}
-			call := toolCallPart.Call.Function
-			if call == nil {
-				continue
+		if err := s.checkToolCallLimit(runState, pendingToolCalls); err != nil {
+			errCh <- err
+			return
+		}
+
+		// If the run is already cancelled, record cancelled results without
+		// resolving or executing any tool.
+		if ctx.Err() != nil {
+			for _, toolCallPart := range pendingToolCalls {
+				result := newCancelledToolResult(toolCallPart)
+				currCh <- ProcessEvent{Item: result.item}
 			}
+			currCh <- ProcessEvent{Response: &ProcessResponse{
+				Content: []llmsdk.Part{},
+				Status:  AgentResponseStatusCancelled,
+			}}
+			return
+		}
 
-			var agentTool AgentFunctionTool[C]
+		// Resolve every pending tool before executing any of them so a
+		// missing tool fails the run without side effects.
+		agentTools := make([]AgentFunctionTool[C], len(pendingToolCalls))
+		for index, toolCallPart := range pendingToolCalls {
+			call := toolCallPart.Call.Function
 			for _, tool := range tools {
 				if tool.Name() == call.Name {
-					agentTool = tool
+					agentTools[index] = tool
 					break
 				}
 			}
-
-			if agentTool == nil {
+			if agentTools[index] == nil {
 				errCh <- NewInvariantError(
 					fmt.Sprintf("tool %s not found for tool call", call.Name),
 				)
 				return
 			}
+		}
+
+		// Execute pending tools concurrently, bounded by the configured
+		// concurrency limit. Results are delivered per index so items are
+		// emitted deterministically in tool call order.
+		resultChs := make([]chan toolExecutionResult, len(pendingToolCalls))
+		for index := range resultChs {
+			resultChs[index] = make(chan toolExecutionResult, 1)
+		}
 
+		execute := func(index int, toolCallPart *llmsdk.ToolCallPart) {
+			call := toolCallPart.Call.Function
+			if ctx.Err() != nil {
+				resultChs[index] <- newCancelledToolResult(toolCallPart)
+				return
+			}
+
+			idempotencyKey := s.toolIdempotencyKey(toolCallPart.ToolCallID)
+			if item, ok := s.replayToolResult(idempotencyKey); ok {
+				resultChs[index] <- toolExecutionResult{item: item}
+				return
+			}
+
+			agentTool := agentTools[index]
+			args := call.Args
+			if s.params.ToolArgumentCoercion {
+				args = coerceToolArguments(agentTool.Parameters(), args)
+			}
+			if validationErr := validateToolArguments(agentTool.Parameters(), args); validationErr != nil {
+				item := invalidToolArgumentsResult(toolCallPart, validationErr)
+				s.auditToolExecution(toolCallPart.ToolCallID, call.Name, args, item.Tool.Output, item.Tool.Status, 0)
+				resultChs[index] <- toolExecutionResult{item: &item}
+				return
+			}
+			toolStart := time.Now()
 			toolRes, err := startActiveToolSpan(
 				ctx,
 				toolCallPart.ToolCallID,
 				call.Name,
 				agentTool.Description(),
 				func(ctx context.Context) (AgentToolResult, error) {
-					res, err := agentTool.Execute(ctx, call.Args, s.contextVal, runState)
+					res, err := agentTool.Execute(withToolIdempotencyKey(ctx, idempotencyKey), args, s.contextVal, runState)
 					if err != nil {
-						return AgentToolResult{}, NewToolExecutionError(err)
+						return AgentToolResult{}, NewToolCallExecutionError(call.Name, toolCallPart.ToolCallID, err)
 					}
 					return res, nil
 				},
 			)
 			if err != nil {
 				if ctx.Err() != nil {
-					emitToolCancellationEvents(currCh, pendingToolCalls[index:])
+					s.cleanupCancelledTool(ctx, agentTool, args)
+					s.auditToolExecution(toolCallPart.ToolCallID, call.Name, args, nil, llmsdk.ToolResultStatusCancelled, time.Since(toolStart))
+					resultChs[index] <- newCancelledToolResult(toolCallPart)
+					return
+				}
+				if s.toolErrorBehavior(call.Name) == ToolErrorReport {
+					item := failedToolExecutionResult(toolCallPart, err)
+					s.auditToolExecution(toolCallPart.ToolCallID, call.Name, args, item.Tool.Output, llmsdk.ToolResultStatusFailed, time.Since(toolStart))
+					resultChs[index] <- toolExecutionResult{item: &item}
 					return
 				}
-				errCh <- err
+				s.auditToolExecution(toolCallPart.ToolCallID, call.Name, args, nil, llmsdk.ToolResultStatusFailed, time.Since(toolStart))
+				resultChs[index] <- toolExecutionResult{err: err}
 				return
 			}
 
@@ -368,12 +549,77 @@ func (s *RunSession[C]) process(
 				toolRes.Content,
 				status,
 			)
-			currCh <- ProcessEvent{Item: &item}
+			s.recordToolResult(idempotencyKey, item)
+			s.auditToolExecution(toolCallPart.ToolCallID, call.Name, args, toolRes.Content, status, time.Since(toolStart))
+			resultChs[index] <- toolExecutionResult{item: &item}
+		}
 
-			if ctx.Err() != nil {
-				emitToolCancellationEvents(currCh, pendingToolCalls[index+1:])
+		var sem chan struct{}
+		if s.params.ToolConcurrency > 0 {
+			sem = make(chan struct{}, s.params.ToolConcurrency)
+		}
+		// done tells the dispatcher to stop when the result loop returns
+		// early (e.g. a tool error under the abort behavior), so it never
+		// sends a start event on a channel that is about to close. currCh
+		// must only close after the dispatcher has exited, hence the wait
+		// on dispatcherDone.
+		done := make(chan struct{})
+		dispatcherDone := make(chan struct{})
+		defer func() {
+			close(done)
+			<-dispatcherDone
+		}()
+		go func() {
+			defer close(dispatcherDone)
+			for index, toolCallPart := range pendingToolCalls {
+				if sem != nil {
+					select {
+					case sem <- struct{}{}:
+					case <-done:
+						return
+					}
+				}
+				// Announce the execution start before launching, so the
+				// start event always precedes the tool item.
+				call := toolCallPart.Call.Function
+				select {
+				case currCh <- ProcessEvent{ToolCallStart: &AgentStreamToolCallStartEvent{
+					ToolCallID: toolCallPart.ToolCallID,
+					ToolName:   call.Name,
+					Input:      call.Args,
+				}}:
+				case <-done:
+					return
+				}
+				go func() {
+					defer func() {
+						if sem != nil {
+							<-sem
+						}
+					}()
+					execute(index, toolCallPart)
+				}()
+			}
+		}()
+
+		cancelled := false
+		for index := range pendingToolCalls {
+			result := <-resultChs[index]
+			if result.err != nil {
+				errCh <- result.err
 				return
 			}
+			currCh <- ProcessEvent{Item: result.item}
+			if result.item.Tool != nil && result.item.Tool.Status == llmsdk.ToolResultStatusCancelled {
+				cancelled = true
+			}
+		}
+		if cancelled {
+			currCh <- ProcessEvent{Response: &ProcessResponse{
+				Content: []llmsdk.Part{},
+				Status:  AgentResponseStatusCancelled,
+			}}
+			return
 		}
 
 		currCh <- ProcessEvent{Next: &struct{}{}}
@@ -382,55 +628,213 @@ func (s *RunSession[C]) process(
 	return stream.New(currCh, errCh)
 }
 
+// cleanupCancelledTool invokes the optional Cleanup hook after a tool
+// execution was cut short by run cancellation. The hook runs on a detached
+// context so it can still reach external services; its error is dropped
+// because the run is already terminating with a cancelled result.
+func (s *RunSession[C]) cleanupCancelledTool(ctx context.Context, tool AgentFunctionTool[C], args json.RawMessage) {
+	cleaner, ok := tool.(AgentToolCleanup[C])
+	if !ok {
+		return
+	}
+	_ = cleaner.Cleanup(context.WithoutCancel(ctx), args, s.contextVal)
+}
+
 // Run runs a non-streaming execution of the agent.
 func (s *RunSession[C]) Run(ctx context.Context, request RunSessionRequest) (*AgentResponse, error) {
 	if !s.initialized {
 		return nil, NewInvariantError("run session not initialized")
 	}
 
-	return traceRun(ctx, s.params.Name, "run", func(ctx context.Context) (*AgentResponse, error) {
-		state := NewRunState(request.Input, s.params.MaxTurns)
-		tools := s.getFunctionTools()
-
-		for {
-			processStream := s.process(ctx, state, tools)
-			for processStream.Next() {
-				event := processStream.Current()
-				if event.Response != nil {
-					response := state.createResponse(event.Response.Content, event.Response.Status)
-					return response, nil
-				}
-				if event.Item != nil {
-					state.appendItem(*event.Item)
+	return traceRun(ctx, s.params.Name, "run", request, s.systemPrompt(), func(ctx context.Context) (*AgentResponse, error) {
+		if err := s.checkQuota(ctx); err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		response, err := s.run(ctx, request)
+		s.recordRunMetrics("run", time.Since(start), response, err)
+		s.recordQuota(ctx, response)
+		return response, err
+	})
+}
+
+// run executes the non-streaming agent loop.
+func (s *RunSession[C]) run(ctx context.Context, request RunSessionRequest) (*AgentResponse, error) {
+	state := NewRunState(request.Input, s.params.MaxTurns)
+	state.setModel(s.model.Provider(), s.model.ModelID())
+	tools := s.getFunctionToolsFrom(s.requestTools(request))
+
+	turnCtx, cancelTurn := ctx, context.CancelFunc(func() {})
+	defer func() { cancelTurn() }()
+
+	correctionAttempts := uint(0)
+	for {
+		processStream := s.process(turnCtx, state, tools)
+		for processStream.Next() {
+			event := processStream.Current()
+			if event.Response != nil {
+				if event.Response.Status == AgentResponseStatusCancelled && s.turnTimedOut(ctx, turnCtx) {
+					return nil, s.newTurnTimeoutError(state)
 				}
-				if event.Next != nil {
+				if verr := s.checkStructuredOutput(event.Response); verr != nil {
+					if correctionAttempts >= s.params.StructuredOutputRetries {
+						return nil, NewStructuredOutputError(verr).withSnapshot(state.createSnapshot())
+					}
+					correctionAttempts++
+					state.appendItem(structuredOutputCorrectionItem(verr))
 					if err := state.turn(); err != nil {
 						return nil, attachRunSnapshot(err, state.createSnapshot())
 					}
 					break
 				}
+				response := state.createResponse(event.Response.Content, event.Response.Status)
+				return response, nil
 			}
-			if err := processStream.Err(); err != nil {
-				return nil, attachRunSnapshot(err, state.createSnapshot())
+			if event.Item != nil {
+				state.appendItem(*event.Item)
 			}
+			if event.Next != nil {
+				if s.shouldStop(state) {
+					return state.createResponse(state.LastAssistantContent(), AgentResponseStatusCompleted), nil
+				}
+				if err := state.turn(); err != nil {
+					return nil, attachRunSnapshot(err, state.createSnapshot())
+				}
+				break
+			}
+		}
+		if err := processStream.Err(); err != nil {
+			if s.turnTimedOut(ctx, turnCtx) {
+				return nil, s.newTurnTimeoutError(state)
+			}
+			return nil, attachRunSnapshot(err, state.createSnapshot())
+		}
+
+		if _, err := s.maybeSummarize(ctx, state); err != nil {
+			return nil, attachRunSnapshot(err, state.createSnapshot())
+		}
 
-			input, nextTools := s.getTurnParams(state)
-			tools = nextTools
+		input, nextTools := s.getTurnParams(state, request)
+		tools = nextTools
+
+		if err := s.prepareStep(ctx, state, input); err != nil {
+			return nil, attachRunSnapshot(err, state.createSnapshot())
+		}
 
+		if ctx.Err() != nil {
+			return state.createCancelledResponse(), nil
+		}
+		// The turn timer covers the model call and the tool executions the
+		// response triggers, which run in the next process iteration.
+		cancelTurn()
+		turnCtx, cancelTurn = s.turnContext(ctx)
+		callStart := time.Now()
+		modelResponse, err := s.model.Generate(turnCtx, input)
+		if err != nil {
+			if s.turnTimedOut(ctx, turnCtx) {
+				return nil, s.newTurnTimeoutError(state)
+			}
 			if ctx.Err() != nil {
 				return state.createCancelledResponse(), nil
 			}
-			modelResponse, err := s.params.Model.Generate(ctx, input)
-			if err != nil {
-				if ctx.Err() != nil {
-					return state.createCancelledResponse(), nil
-				}
-				return nil, NewLanguageModelError(err).withSnapshot(state.createSnapshot())
-			}
+			return nil, wrapModelCallError(err).withSnapshot(state.createSnapshot())
+		}
 
-			state.appendModelResponse(*modelResponse)
+		state.appendModelResponse(*modelResponse, time.Since(callStart))
+		if err := s.checkTokensBudget(state); err != nil {
+			return nil, attachRunSnapshot(err, state.createSnapshot())
 		}
-	})
+	}
+}
+
+// prepareStep invokes the configured PrepareStep hook with the composed input
+// for the upcoming model call.
+func (s *RunSession[C]) prepareStep(ctx context.Context, state *RunState, input *llmsdk.LanguageModelInput) error {
+	if s.params.PrepareStep == nil {
+		return nil
+	}
+	if err := s.params.PrepareStep(ctx, state.CurrentTurn-1, input); err != nil {
+		return fmt.Errorf("prepare step failed: %w", err)
+	}
+	return nil
+}
+
+// turnContext derives the context bounding one turn — a model call and the
+// tool executions its response triggers. Without a configured TurnTimeout it
+// returns the parent unchanged with a no-op cancel.
+func (s *RunSession[C]) turnContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.params.TurnTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, s.params.TurnTimeout)
+}
+
+// turnTimedOut reports whether the turn context expired on its own while the
+// request context is still live, distinguishing a turn timeout from request
+// cancellation.
+func (s *RunSession[C]) turnTimedOut(ctx, turnCtx context.Context) bool {
+	return s.params.TurnTimeout > 0 && turnCtx.Err() != nil && ctx.Err() == nil
+}
+
+// newTurnTimeoutError builds the typed turn timeout error carrying the
+// partial turn as a snapshot.
+func (s *RunSession[C]) newTurnTimeoutError(state *RunState) error {
+	return NewTurnTimeoutError(state.Turn(), s.params.TurnTimeout).withSnapshot(state.createSnapshot())
+}
+
+// checkStructuredOutput validates a completed response against the JSON
+// response format schema when correction attempts are configured. It returns
+// nil when validation is disabled, the response did not complete, or the
+// output conforms.
+func (s *RunSession[C]) checkStructuredOutput(response *ProcessResponse) error {
+	if s.params.StructuredOutputRetries == 0 || response.Status != AgentResponseStatusCompleted {
+		return nil
+	}
+	return validateStructuredOutput(s.params.ResponseFormat, response.Content)
+}
+
+// shouldStop reports whether the configured stop condition requests ending
+// the run after the turn that just finished.
+func (s *RunSession[C]) shouldStop(state *RunState) bool {
+	return s.params.StopCondition != nil && s.params.StopCondition(state)
+}
+
+// checkTokensBudget returns a budget exceeded error when the configured token
+// budget has been exhausted by the cumulative run usage.
+func (s *RunSession[C]) checkTokensBudget(state *RunState) error {
+	if s.params.MaxTokensBudget == 0 {
+		return nil
+	}
+	usage := state.Usage()
+	used := usage.InputTokens + usage.OutputTokens
+	if used > int(s.params.MaxTokensBudget) {
+		return NewBudgetExceededError(s.params.MaxTokensBudget, used)
+	}
+	return nil
+}
+
+// checkToolCallLimit returns a limit exceeded error when executing the
+// pending tool calls would exceed the configured run-wide or per-tool caps.
+func (s *RunSession[C]) checkToolCallLimit(state *RunState, pending []*llmsdk.ToolCallPart) error {
+	if s.params.MaxToolCalls == 0 && len(s.params.MaxToolCallsPerTool) == 0 {
+		return nil
+	}
+
+	total, perTool := state.toolCallCounts()
+	for _, toolCallPart := range pending {
+		total++
+		perTool[toolCallPart.Call.Function.Name]++
+	}
+
+	if s.params.MaxToolCalls > 0 && total > int(s.params.MaxToolCalls) {
+		return NewMaxToolCallsExceededError(s.params.MaxToolCalls, total)
+	}
+	for toolName, limit := range s.params.MaxToolCallsPerTool {
+		if limit > 0 && perTool[toolName] > int(limit) {
+			return NewMaxToolCallsPerToolExceededError(toolName, limit, perTool[toolName])
+		}
+	}
+	return nil
 }
 
 // RunStream runs a streaming execution of the agent.
@@ -439,24 +843,71 @@ func (s *RunSession[C]) RunStream(ctx context.Context, request RunSessionRequest
 		return nil, NewInvariantError("run session not initialized")
 	}
 
-	return traceRunStream(ctx, s.params.Name, "run_stream", func(ctx context.Context) (*AgentStream, error) {
+	return traceRunStream(ctx, s.params.Name, "run_stream", request, s.systemPrompt(), func(ctx context.Context) (*AgentStream, error) {
+		if err := s.checkQuota(ctx); err != nil {
+			return nil, err
+		}
+		ctx, cancelRun := context.WithCancel(ctx)
+		active := &runInterrupt{cancel: cancelRun}
+		s.setActiveRun(active)
+
+		start := time.Now()
 		state := NewRunState(request.Input, s.params.MaxTurns)
+		state.setModel(s.model.Provider(), s.model.ModelID())
 
 		eventChan := make(chan *AgentStreamEvent)
 		errChan := make(chan error, 1)
 
+		// Tool goroutines finish before process completes, so sends here
+		// cannot race the channel close below; the context guard covers
+		// consumers that stop reading.
+		state.setDataHandler(func(event *AgentStreamDataEvent) {
+			select {
+			case eventChan <- &AgentStreamEvent{Data: event}:
+			case <-ctx.Done():
+			}
+		})
+
 		go func() {
 			defer close(eventChan)
 			defer close(errChan)
+			defer s.clearActiveRun(active)
+			defer cancelRun()
+
+			tools := s.getFunctionToolsFrom(s.requestTools(request))
 
-			tools := s.getFunctionTools()
+			turnCtx, cancelTurn := ctx, context.CancelFunc(func() {})
+			defer func() { cancelTurn() }()
 
+			correctionAttempts := uint(0)
 			for {
-				processStream := s.process(ctx, state, tools)
+				processStream := s.process(turnCtx, state, tools)
 
 				for processStream.Next() {
 					event := processStream.Current()
 					if event.Response != nil {
+						if event.Response.Status == AgentResponseStatusCancelled && s.turnTimedOut(ctx, turnCtx) {
+							errChan <- s.newTurnTimeoutError(state)
+							return
+						}
+						if verr := s.checkStructuredOutput(event.Response); verr != nil {
+							if correctionAttempts >= s.params.StructuredOutputRetries {
+								errChan <- NewStructuredOutputError(verr).withSnapshot(state.createSnapshot())
+								return
+							}
+							correctionAttempts++
+							item := structuredOutputCorrectionItem(verr)
+							index := state.appendItem(item)
+							eventChan <- NewAgentStreamItemEvent(index, item)
+							if err := state.turn(); err != nil {
+								errChan <- attachRunSnapshot(err, state.createSnapshot())
+								return
+							}
+							break
+						}
+						if followUp := s.followUpEvent(ctx, state, event.Response.Status); followUp != nil {
+							eventChan <- followUp
+						}
 						response := state.createResponse(event.Response.Content, event.Response.Status)
 						eventChan <- NewAgentStreamEventResponse(response)
 						return
@@ -465,7 +916,19 @@ func (s *RunSession[C]) RunStream(ctx context.Context, request RunSessionRequest
 						index := state.appendItem(*event.Item)
 						eventChan <- NewAgentStreamItemEvent(index, *event.Item)
 					}
+					if event.ToolCallStart != nil {
+						eventChan <- &AgentStreamEvent{ToolCallStart: event.ToolCallStart}
+					}
 					if event.Next != nil {
+						if s.shouldStop(state) {
+							if followUp := s.followUpEvent(ctx, state, AgentResponseStatusCompleted); followUp != nil {
+								eventChan <- followUp
+							}
+							eventChan <- NewAgentStreamEventResponse(
+								state.createResponse(state.LastAssistantContent(), AgentResponseStatusCompleted),
+							)
+							return
+						}
 						if err := state.turn(); err != nil {
 							errChan <- attachRunSnapshot(err, state.createSnapshot())
 							return
@@ -474,30 +937,58 @@ func (s *RunSession[C]) RunStream(ctx context.Context, request RunSessionRequest
 					}
 				}
 				if err := processStream.Err(); err != nil {
+					if s.turnTimedOut(ctx, turnCtx) {
+						errChan <- s.newTurnTimeoutError(state)
+						return
+					}
+					errChan <- attachRunSnapshot(err, state.createSnapshot())
+					return
+				}
+
+				compaction, err := s.maybeSummarize(ctx, state)
+				if err != nil {
 					errChan <- attachRunSnapshot(err, state.createSnapshot())
 					return
 				}
+				if compaction != nil {
+					eventChan <- &AgentStreamEvent{Compaction: compaction}
+				}
 
-				input, nextTools := s.getTurnParams(state)
+				input, nextTools := s.getTurnParams(state, request)
 				tools = nextTools
 
+				if err := s.prepareStep(ctx, state, input); err != nil {
+					errChan <- attachRunSnapshot(err, state.createSnapshot())
+					return
+				}
+
 				if ctx.Err() != nil {
 					eventChan <- NewAgentStreamEventResponse(state.createCancelledResponse())
 					return
 				}
-				modelStream, err := s.params.Model.Stream(ctx, input)
+				// The turn timer covers the model call and the tool
+				// executions the response triggers, which run in the next
+				// process iteration.
+				cancelTurn()
+				turnCtx, cancelTurn = s.turnContext(ctx)
+				callStart := time.Now()
+				modelStream, err := s.model.Stream(turnCtx, input)
 				if err != nil {
+					if s.turnTimedOut(ctx, turnCtx) {
+						errChan <- s.newTurnTimeoutError(state)
+						return
+					}
 					if ctx.Err() != nil {
 						eventChan <- NewAgentStreamEventResponse(state.createCancelledResponse())
 						return
 					}
-					errChan <- NewLanguageModelError(err).withSnapshot(state.createSnapshot())
+					errChan <- wrapModelCallError(err).withSnapshot(state.createSnapshot())
 					return
 				}
 
 				accumulator := llmsdk.NewStreamAccumulator()
 				commitModelSnapshot := func(snapshot llmsdk.ModelResponse) bool {
-					if item, index, ok := state.appendModelSnapshot(snapshot); ok {
+					if item, index, ok := state.appendModelSnapshot(snapshot, time.Since(callStart)); ok {
 						eventChan <- NewAgentStreamItemEvent(index, item)
 						return true
 					}
@@ -533,7 +1024,11 @@ func (s *RunSession[C]) RunStream(ctx context.Context, request RunSessionRequest
 				if err := modelStream.Err(); err != nil {
 					snapshot := accumulator.Snapshot()
 					commitModelSnapshot(snapshot)
-					errChan <- NewLanguageModelError(err).withSnapshot(
+					if s.turnTimedOut(ctx, turnCtx) {
+						errChan <- s.newTurnTimeoutError(state)
+						return
+					}
+					errChan <- wrapModelCallError(err).withSnapshot(
 						state.createSnapshot(),
 					)
 					return
@@ -549,21 +1044,59 @@ func (s *RunSession[C]) RunStream(ctx context.Context, request RunSessionRequest
 					return
 				}
 
-				item, index := state.appendModelResponse(modelResponse)
+				item, index := state.appendModelResponse(modelResponse, time.Since(callStart))
 
 				eventChan <- NewAgentStreamItemEvent(index, item)
+
+				if err := s.checkTokensBudget(state); err != nil {
+					errChan <- attachRunSnapshot(err, state.createSnapshot())
+					return
+				}
 			}
 		}()
 
-		return stream.New(eventChan, errChan), nil
+		return s.observeQuota(ctx, s.observeRunMetrics("run_stream", start, stream.New(eventChan, errChan))), nil
 	})
 }
 
+// Interrupt cancels the in-flight streamed run, if any, for barge-in during
+// voice or chat conversations. The model turn stops cleanly: the content
+// emitted so far is committed as a truncated model item on the run output, the
+// stream finishes with a cancelled response, and the next user input can
+// proceed immediately. Interrupting a session with no active streamed run is a
+// no-op.
+func (s *RunSession[C]) Interrupt() {
+	s.interruptMu.Lock()
+	defer s.interruptMu.Unlock()
+	if s.activeRun != nil {
+		s.activeRun.cancel()
+	}
+}
+
+// setActiveRun marks the streamed run as the target of Interrupt.
+func (s *RunSession[C]) setActiveRun(active *runInterrupt) {
+	s.interruptMu.Lock()
+	defer s.interruptMu.Unlock()
+	s.activeRun = active
+}
+
+// clearActiveRun detaches the finished run from Interrupt, unless another run
+// has already taken its place.
+func (s *RunSession[C]) clearActiveRun(active *runInterrupt) {
+	s.interruptMu.Lock()
+	defer s.interruptMu.Unlock()
+	if s.activeRun == active {
+		s.activeRun = nil
+	}
+}
+
 func (s *RunSession[C]) Close(ctx context.Context) error {
 	if !s.initialized {
 		return nil
 	}
+	s.promptMu.Lock()
 	s.staticSystemPrompt = nil
+	s.promptMu.Unlock()
 	s.staticTools = nil
 
 	var g errgroup.Group
@@ -585,9 +1118,13 @@ func (s *RunSession[C]) Close(ctx context.Context) error {
 	return nil
 }
 
-func (s *RunSession[C]) getTurnParams(state *RunState) (*llmsdk.LanguageModelInput, []AgentFunctionTool[C]) {
+func (s *RunSession[C]) getTurnParams(state *RunState, request RunSessionRequest) (*llmsdk.LanguageModelInput, []AgentFunctionTool[C]) {
+	messages := s.params.HistoryPolicy.apply(state.getTurnMessages())
+	if len(s.params.Examples) > 0 {
+		messages = append(exampleMessages(s.params.Examples), messages...)
+	}
 	input := &llmsdk.LanguageModelInput{
-		Messages:         state.getTurnMessages(),
+		Messages:         messages,
 		ResponseFormat:   s.params.ResponseFormat,
 		Temperature:      s.params.Temperature,
 		TopP:             s.params.TopP,
@@ -599,26 +1136,41 @@ func (s *RunSession[C]) getTurnParams(state *RunState) (*llmsdk.LanguageModelInp
 		Reasoning:        s.params.Reasoning,
 	}
 
-	systemPrompts := []string{}
-	if s.staticSystemPrompt != nil && *s.staticSystemPrompt != "" {
-		systemPrompts = append(systemPrompts, *s.staticSystemPrompt)
+	if s.params.ToolChoice != nil {
+		// CurrentTurn has already been bumped for the upcoming model call, so
+		// the first call observes turn 0.
+		input.ToolChoice = s.params.ToolChoice(state.CurrentTurn - 1)
+	}
+
+	if sampling := request.Sampling; sampling != nil {
+		if sampling.Temperature != nil {
+			input.Temperature = sampling.Temperature
+		}
+		if sampling.TopP != nil {
+			input.TopP = sampling.TopP
+		}
+		if sampling.MaxTokens != nil {
+			input.MaxTokens = sampling.MaxTokens
+		}
+		if sampling.Reasoning != nil {
+			input.Reasoning = sampling.Reasoning
+		}
 	}
 
+	toolkitPrompts := make([]string, 0, len(s.toolkitSessions))
 	for _, toolkitSession := range s.toolkitSessions {
 		if toolkitSession == nil {
 			continue
 		}
 		if prompt := toolkitSession.SystemPrompt(); prompt != nil && *prompt != "" {
-			systemPrompts = append(systemPrompts, *prompt)
+			toolkitPrompts = append(toolkitPrompts, *prompt)
 		}
 	}
+	input.SystemPrompt = s.params.PromptComposition.compose(s.systemPrompt(), toolkitPrompts)
 
-	tools := s.getTools()
+	tools := s.requestTools(request)
 	functionTools := s.getFunctionToolsFrom(tools)
 
-	if len(systemPrompts) > 0 {
-		input.SystemPrompt = ptr.To(strings.Join(systemPrompts, "\n"))
-	}
 	if len(tools) > 0 {
 		sdkTools := make([]llmsdk.Tool, 0, len(tools))
 		for _, tool := range tools {
@@ -644,24 +1196,79 @@ func (s *RunSession[C]) getTools() []AgentTool[C] {
 	return tools
 }
 
-func (s *RunSession[C]) getFunctionTools() []AgentFunctionTool[C] {
-	return s.getFunctionToolsFrom(s.getTools())
+// requestTools applies the per-run tool selection from the request to the
+// session tools. A non-nil EnabledTools keeps only the listed names, then
+// DisabledTools removes any listed name. Names that do not match a session
+// tool are ignored.
+func (s *RunSession[C]) requestTools(request RunSessionRequest) []AgentTool[C] {
+	tools := s.getTools()
+	if request.EnabledTools == nil && len(request.DisabledTools) == 0 {
+		return tools
+	}
+
+	var enabled map[string]struct{}
+	if request.EnabledTools != nil {
+		enabled = make(map[string]struct{}, len(request.EnabledTools))
+		for _, name := range request.EnabledTools {
+			enabled[name] = struct{}{}
+		}
+	}
+	disabled := make(map[string]struct{}, len(request.DisabledTools))
+	for _, name := range request.DisabledTools {
+		disabled[name] = struct{}{}
+	}
+
+	filtered := make([]AgentTool[C], 0, len(tools))
+	for _, tool := range tools {
+		name := tool.Name()
+		if enabled != nil {
+			if _, ok := enabled[name]; !ok {
+				continue
+			}
+		}
+		if _, ok := disabled[name]; ok {
+			continue
+		}
+		filtered = append(filtered, tool)
+	}
+	return filtered
 }
 
 func (s *RunSession[C]) getFunctionToolsFrom(tools []AgentTool[C]) []AgentFunctionTool[C] {
 	functionTools := make([]AgentFunctionTool[C], 0, len(tools))
 	for _, tool := range tools {
 		if functionTool := tool.AsFunctionTool(); functionTool != nil {
+			if options, ok := s.params.ToolOptions[functionTool.Name()]; ok {
+				functionTool = &guardedTool[C]{tool: functionTool, options: options}
+			}
 			functionTools = append(functionTools, functionTool)
 		}
 	}
 	return functionTools
 }
 
+// toolErrorBehavior resolves the error behavior for the named tool: the
+// per-tool option wins, then the agent-wide default, then abort.
+func (s *RunSession[C]) toolErrorBehavior(toolName string) ToolErrorBehavior {
+	if options, ok := s.params.ToolOptions[toolName]; ok && options.OnError != "" {
+		return options.OnError
+	}
+	if s.params.ToolErrorBehavior != "" {
+		return s.params.ToolErrorBehavior
+	}
+	return ToolErrorAbort
+}
+
 // RunSessionRequest contains the input used for a run.
 type RunSessionRequest struct {
 	// Input holds the items to seed the run, such as LLM messages.
 	Input []AgentItem
+	// Sampling overrides agent-level sampling defaults for this run only.
+	Sampling *SamplingOverrides
+	// EnabledTools, when non-nil, restricts the run to the listed tool names.
+	EnabledTools []string
+	// DisabledTools removes the listed tool names for this run.
+	DisabledTools []string
 }
 
 // ProcessEvent represents an event returned by the process function.
@@ -672,6 +1279,8 @@ type ProcessEvent struct {
 	Response *ProcessResponse
 	// Emit when the loop should continue to the next iteration
 	Next *struct{}
+	// Emit when a tool call execution begins
+	ToolCallStart *AgentStreamToolCallStartEvent
 }
 
 type ProcessResponse struct {
@@ -680,13 +1289,27 @@ type ProcessResponse struct {
 }
 
 type RunState struct {
-	maxTurns uint
-	input    []AgentItem
+	maxTurns  uint
+	input     []AgentItem
+	startTime time.Time
 
 	// CurrentTurn is the current turn number in the run.
 	CurrentTurn uint
 	// output contains all items generated during the run, such as new `Tool` and `Model` items
 	output []AgentItem
+	// usage accumulates the model token usage across turns.
+	usage llmsdk.ModelUsage
+	// hasUsage records whether any model response reported usage.
+	hasUsage bool
+	// cost accumulates the model cost across turns when reported.
+	cost *float64
+	// dataHandler delivers tool-emitted data events to the stream consumer.
+	// Nil outside RunStream, where data events are discarded.
+	dataHandler func(event *AgentStreamDataEvent)
+	// provider and modelID identify the session model. They are stamped on
+	// model items and gate the replay of provider-specific reasoning payloads.
+	provider string
+	modelID  string
 
 	mu sync.RWMutex
 }
@@ -695,11 +1318,22 @@ func NewRunState(input []AgentItem, maxTurns uint) *RunState {
 	return &RunState{
 		maxTurns:    maxTurns,
 		input:       input,
+		startTime:   time.Now(),
 		CurrentTurn: 0,
 		output:      []AgentItem{},
 	}
 }
 
+// setModel records the session model identity. It is stamped on appended
+// model items and gates the replay of provider-specific reasoning payloads in
+// getTurnMessages.
+func (s *RunState) setModel(provider, modelID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.provider = provider
+	s.modelID = modelID
+}
+
 // turn marks a new turn in the conversation and throw an error if max turns
 // exceeded.
 func (s *RunState) turn() error {
@@ -722,21 +1356,171 @@ func (s *RunState) appendItem(item AgentItem) int {
 }
 
 // appendModelResponse appends a model response as a model AgentItem and returns it and its index.
-func (s *RunState) appendModelResponse(resp llmsdk.ModelResponse) (AgentItem, int) {
+func (s *RunState) appendModelResponse(resp llmsdk.ModelResponse, duration time.Duration) (AgentItem, int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	item := NewAgentItemModelResponse(resp)
+	item := AgentItem{Model: &AgentItemModelResponse{
+		ModelResponse: &resp,
+		Provider:      s.provider,
+		ModelID:       s.modelID,
+		Duration:      duration.Truncate(time.Millisecond),
+	}}
 	s.output = append(s.output, item)
+	if resp.Usage != nil {
+		s.usage.Add(resp.Usage)
+		s.hasUsage = true
+	}
+	if resp.Cost != nil {
+		if s.cost == nil {
+			s.cost = new(float64)
+		}
+		*s.cost += *resp.Cost
+	}
 	return item, len(s.output) - 1
 }
 
+// recordUsage accumulates usage and cost from an auxiliary model call, such
+// as summarization, without appending an output item.
+func (s *RunState) recordUsage(resp *llmsdk.ModelResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if resp.Usage != nil {
+		s.usage.Add(resp.Usage)
+		s.hasUsage = true
+	}
+	if resp.Cost != nil {
+		if s.cost == nil {
+			s.cost = new(float64)
+		}
+		*s.cost += *resp.Cost
+	}
+}
+
+// compact replaces the first count run items with a single summary item.
+func (s *RunState) compact(count int, item AgentItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if count <= len(s.input) {
+		s.input = append([]AgentItem{item}, s.input[count:]...)
+		return
+	}
+	count -= len(s.input)
+	s.input = []AgentItem{item}
+	s.output = slices.Clone(s.output[count:])
+}
+
+// Usage returns the cumulative model token usage across turns.
+func (s *RunState) Usage() llmsdk.ModelUsage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.usage
+}
+
+// Cost returns the cumulative model cost across turns, or nil when no model
+// response reported cost.
+func (s *RunState) Cost() *float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cost == nil {
+		return nil
+	}
+	cost := *s.cost
+	return &cost
+}
+
+// Turn returns the current turn number, starting at 1 for the first model
+// call.
+func (s *RunState) Turn() uint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.CurrentTurn
+}
+
+// Elapsed returns how long the run has been executing.
+func (s *RunState) Elapsed() time.Duration {
+	return time.Since(s.startTime)
+}
+
+// EmitData sends a custom typed event to the run's stream consumers, e.g.
+// tool progress or intermediate results driving a UI widget. The payload is
+// serialized to JSON. Events pass through RunStream in emission order; in a
+// non-streaming Run there is no consumer and they are discarded.
+func (s *RunState) EmitData(name string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return NewInvariantError(fmt.Sprintf("marshal data event %q: %s", name, err))
+	}
+	s.mu.RLock()
+	handler := s.dataHandler
+	s.mu.RUnlock()
+	if handler != nil {
+		handler(&AgentStreamDataEvent{Name: name, Data: data})
+	}
+	return nil
+}
+
+// setDataHandler installs the delivery function for tool-emitted data
+// events. The handler may be called from concurrent tool goroutines.
+func (s *RunState) setDataHandler(handler func(event *AgentStreamDataEvent)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dataHandler = handler
+}
+
+// ToolCalls returns the tool executions recorded so far in the run output,
+// oldest first, so tools can adapt to what already happened — e.g. take a
+// cheaper path once the budget is nearly exhausted or avoid repeating a
+// call.
+func (s *RunState) ToolCalls() []AgentItemTool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var calls []AgentItemTool
+	for _, item := range s.output {
+		if item.Tool != nil {
+			calls = append(calls, *item.Tool)
+		}
+	}
+	return calls
+}
+
+// toolCallCounts returns the number of tool executions recorded in the run
+// output, in total and per tool name.
+func (s *RunState) toolCallCounts() (int, map[string]int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	total := 0
+	perTool := make(map[string]int)
+	for _, item := range s.output {
+		if item.Tool != nil {
+			total++
+			perTool[item.Tool.ToolName]++
+		}
+	}
+	return total, perTool
+}
+
+// hasProviderToolItem reports whether a provider tool item with the given
+// call ID is already part of the run state.
+func (s *RunState) hasProviderToolItem(toolCallID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, items := range [][]AgentItem{s.input, s.output} {
+		for _, item := range items {
+			if item.ProviderTool != nil && item.ProviderTool.ToolCallID == toolCallID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // appendModelSnapshot appends the independently materializable portion of an
 // interrupted model stream. An empty snapshot does not represent an output item.
-func (s *RunState) appendModelSnapshot(resp llmsdk.ModelResponse) (AgentItem, int, bool) {
+func (s *RunState) appendModelSnapshot(resp llmsdk.ModelResponse, duration time.Duration) (AgentItem, int, bool) {
 	if len(resp.Content) == 0 {
 		return AgentItem{}, 0, false
 	}
-	item, index := s.appendModelResponse(resp)
+	item, index := s.appendModelResponse(resp, duration)
 	return item, index, true
 }
 
@@ -757,7 +1541,11 @@ func (s *RunState) getTurnMessages() []llmsdk.Message {
 			messages = append(messages, *msg)
 		}
 		if modelResponse := it.Model; modelResponse != nil {
-			messages = append(messages, llmsdk.NewAssistantMessage(modelResponse.Content...))
+			content := modelResponse.Content
+			if modelResponse.Provider != "" && s.provider != "" && modelResponse.Provider != s.provider {
+				content = stripForeignReasoning(content)
+			}
+			messages = append(messages, llmsdk.NewAssistantMessage(content...))
 		}
 		if tool := it.Tool; tool != nil {
 			toolResultPart := llmsdk.NewToolResultPart(
@@ -784,11 +1572,21 @@ func (s *RunState) createResponse(finalContent []llmsdk.Part, status AgentRespon
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return &AgentResponse{
-		Content: finalContent,
-		Output:  s.output,
-		Status:  status,
+	response := &AgentResponse{
+		Content:   finalContent,
+		Output:    s.output,
+		Status:    status,
+		Citations: collectCitations(s.Items(), finalContent),
+	}
+	if s.hasUsage {
+		usage := s.usage
+		response.Usage = &usage
 	}
+	if s.cost != nil {
+		cost := *s.cost
+		response.Cost = &cost
+	}
+	return response
 }
 
 func (s *RunState) createSnapshot() *AgentRunSnapshot {
@@ -796,10 +1594,35 @@ func (s *RunState) createSnapshot() *AgentRunSnapshot {
 	defer s.mu.RUnlock()
 
 	return &AgentRunSnapshot{
-		Output: slices.Clone(s.output),
+		Output:  slices.Clone(s.output),
+		Content: s.lastAssistantContent(),
 	}
 }
 
+// LastAssistantContent returns the content of the most recent model response
+// or assistant message in the run output, e.g. for stop conditions that look
+// for a marker in the output.
+func (s *RunState) LastAssistantContent() []llmsdk.Part {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastAssistantContent()
+}
+
+// lastAssistantContent returns the content of the most recent model response
+// or assistant message in the run output. Callers must hold the state lock.
+func (s *RunState) lastAssistantContent() []llmsdk.Part {
+	for i := len(s.output) - 1; i >= 0; i-- {
+		item := s.output[i]
+		if item.Model != nil {
+			return item.Model.Content
+		}
+		if item.Message != nil && item.Message.AssistantMessage != nil {
+			return item.Message.AssistantMessage.Content
+		}
+	}
+	return nil
+}
+
 func (s *RunState) createCancelledResponse() *AgentResponse {
 	return s.createResponse([]llmsdk.Part{}, AgentResponseStatusCancelled)
 }
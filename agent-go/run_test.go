@@ -6,6 +6,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
@@ -161,6 +162,93 @@ func mustNewRunSession[C any](t *testing.T, params *llmagent.AgentParams[C], con
 	return session
 }
 
+// modelItemFrom builds the model item the session records for a response,
+// stamped with the producing model's provenance.
+func modelItemFrom(model llmsdk.LanguageModel, response llmsdk.ModelResponse) llmagent.AgentItem {
+	item := llmagent.NewAgentItemModelResponse(response)
+	item.Model.Provider = model.Provider()
+	item.Model.ModelID = model.ModelID()
+	return item
+}
+
+func TestRun_ResolvesModelPerSession(t *testing.T) {
+	defaultModel := llmsdktest.NewMockLanguageModel()
+	premiumModel := llmsdktest.NewMockLanguageModel()
+	premiumModel.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("premium response")},
+	}))
+
+	type tierContext struct{ Tier string }
+	agent := llmagent.NewAgent(
+		"test_agent",
+		defaultModel,
+		llmagent.WithModelResolver(func(_ context.Context, contextVal tierContext) (llmsdk.LanguageModel, error) {
+			if contextVal.Tier == "premium" {
+				return premiumModel, nil
+			}
+			return nil, nil
+		}),
+	)
+
+	response, err := agent.Run(t.Context(), llmagent.AgentRequest[tierContext]{
+		Context: tierContext{Tier: "premium"},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hello"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response.Text() != "premium response" {
+		t.Fatalf("expected resolver-selected model response, got %q", response.Text())
+	}
+	if len(defaultModel.TrackedGenerateInputs()) != 0 {
+		t.Fatal("expected default model to be bypassed")
+	}
+}
+
+func TestRun_AppliesPerRequestSamplingOverrides(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+
+	agent := llmagent.NewAgent[struct{}](
+		"test_agent",
+		model,
+		llmagent.WithTemperature[struct{}](0.7),
+		llmagent.WithTopP[struct{}](0.9),
+	)
+
+	_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hello"))),
+		},
+		Sampling: &llmagent.SamplingOverrides{
+			Temperature: ptr.To(0.1),
+			MaxTokens:   ptr.To(uint32(128)),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected one model call, got %d", len(inputs))
+	}
+	if inputs[0].Temperature == nil || *inputs[0].Temperature != 0.1 {
+		t.Fatalf("expected overridden temperature 0.1, got %#v", inputs[0].Temperature)
+	}
+	if inputs[0].TopP == nil || *inputs[0].TopP != 0.9 {
+		t.Fatalf("expected agent-level top_p 0.9 to remain, got %#v", inputs[0].TopP)
+	}
+	if inputs[0].MaxTokens == nil || *inputs[0].MaxTokens != 128 {
+		t.Fatalf("expected overridden max_tokens 128, got %#v", inputs[0].MaxTokens)
+	}
+}
+
 func TestRun_RejectsEmptyInputWithoutCallingModel(t *testing.T) {
 	model := llmsdktest.NewMockLanguageModel()
 	session := mustNewRunSession(
@@ -430,7 +518,7 @@ func TestRun_ReturnsResponse_NoToolCall(t *testing.T) {
 			llmsdk.NewTextPart("Hi!"),
 		},
 		Output: []llmagent.AgentItem{
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewTextPart("Hi!"),
 				},
@@ -517,9 +605,11 @@ func TestRun_ExecutesSingleToolCallAndReturnsResponse(t *testing.T) {
 		Content: []llmsdk.Part{
 			llmsdk.NewTextPart("Final response"),
 		},
+		Usage: &llmsdk.ModelUsage{InputTokens: 1000, OutputTokens: 50},
+		Cost:  ptr.To(0.0015),
 		Output: []llmagent.AgentItem{
 			// Assistant tool call model item
-			llmagent.NewAgentItemModelResponse(
+			modelItemFrom(model,
 				llmsdk.ModelResponse{
 					Content: []llmsdk.Part{
 						llmsdk.NewToolCallPart("call_1", "test_tool", json.RawMessage(`{"param": "value"}`)),
@@ -537,7 +627,7 @@ func TestRun_ExecutesSingleToolCallAndReturnsResponse(t *testing.T) {
 				llmsdk.ToolResultStatusCompleted,
 			),
 			// Final model item
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewTextPart("Final response"),
 				},
@@ -638,9 +728,11 @@ func TestRun_ExecutesMultipleToolCallsInParallel(t *testing.T) {
 	expectedResponse := &llmagent.AgentResponse{
 		Status:  llmagent.AgentResponseStatusCompleted,
 		Content: []llmsdk.Part{llmsdk.NewTextPart("Processed both tools")},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 2050, OutputTokens: 110},
+		Cost:    ptr.To(0.0003),
 		Output: []llmagent.AgentItem{
 			// model with tool calls
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart("call_1", "tool_1", map[string]any{"param": "value1"}),
 					llmsdk.NewToolCallPart("call_2", "tool_2", map[string]any{"param": "value2"}),
@@ -661,7 +753,7 @@ func TestRun_ExecutesMultipleToolCallsInParallel(t *testing.T) {
 				[]llmsdk.Part{llmsdk.NewTextPart("Tool 2 result")},
 				llmsdk.ToolResultStatusCompleted,
 			),
-			llmagent.NewAgentItemModelResponse(
+			modelItemFrom(model,
 				llmsdk.ModelResponse{
 					Content: []llmsdk.Part{llmsdk.NewTextPart("Processed both tools")},
 					Usage:   &llmsdk.ModelUsage{InputTokens: 50, OutputTokens: 10},
@@ -676,6 +768,68 @@ func TestRun_ExecutesMultipleToolCallsInParallel(t *testing.T) {
 	}
 }
 
+func TestRun_RunsToolCallsConcurrentlyWithDeterministicOrder(t *testing.T) {
+	// tool_1 blocks until tool_2 has executed, which only completes when both
+	// tools run concurrently. Output items must still follow tool call order.
+	secondDone := make(chan struct{})
+	tool1 := NewMockTool[struct{}]("tool_1", llmagent.AgentToolResult{}, func(ctx context.Context, _ json.RawMessage, _ struct{}, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		select {
+		case <-secondDone:
+		case <-ctx.Done():
+			return llmagent.AgentToolResult{}, ctx.Err()
+		}
+		return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("first")}}, nil
+	})
+	tool2 := NewMockTool[struct{}]("tool_2", llmagent.AgentToolResult{}, func(context.Context, json.RawMessage, struct{}, *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		close(secondDone)
+		return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("second")}}, nil
+	})
+
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{
+				llmsdk.NewToolCallPart("call_1", "tool_1", map[string]any{}),
+				llmsdk.NewToolCallPart("call_2", "tool_2", map[string]any{}),
+			},
+		}),
+	)
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		}),
+	)
+
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name:           "test_agent",
+			Model:          model,
+			Tools:          llmagent.FunctionTools[struct{}](tool1, tool2),
+			ResponseFormat: llmsdk.NewResponseFormatText(),
+			MaxTurns:       10,
+		},
+		struct{}{},
+	)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Use both tools"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(response.Output) != 4 || response.Output[1].Tool == nil || response.Output[2].Tool == nil {
+		t.Fatalf("expected model, two tool items, and model output; got %#v", response.Output)
+	}
+	if response.Output[1].Tool.ToolCallID != "call_1" || response.Output[2].Tool.ToolCallID != "call_2" {
+		t.Fatalf("expected tool items in call order, got %q then %q",
+			response.Output[1].Tool.ToolCallID, response.Output[2].Tool.ToolCallID)
+	}
+}
+
 func TestRun_HandlesMultipleTurnsWithToolCalls(t *testing.T) {
 	toolResult := llmagent.AgentToolResult{
 		Content: []llmsdk.Part{
@@ -784,7 +938,7 @@ func TestRun_HandlesMultipleTurnsWithToolCalls(t *testing.T) {
 			llmsdk.NewTextPart("All calculations done"),
 		},
 		Output: []llmagent.AgentItem{
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart(
 						"call_1",
@@ -800,7 +954,7 @@ func TestRun_HandlesMultipleTurnsWithToolCalls(t *testing.T) {
 				[]llmsdk.Part{llmsdk.NewTextPart("Calculation result")},
 				llmsdk.ToolResultStatusCompleted,
 			),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart(
 						"call_2",
@@ -816,7 +970,7 @@ func TestRun_HandlesMultipleTurnsWithToolCalls(t *testing.T) {
 				[]llmsdk.Part{llmsdk.NewTextPart("Calculation result")},
 				llmsdk.ToolResultStatusCompleted,
 			),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewTextPart("All calculations done"),
 				},
@@ -931,7 +1085,7 @@ func TestRun_ResumesToolProcessingFromToolMessageWithPartialResults(t *testing.T
 		Content: []llmsdk.Part{llmsdk.NewTextPart("Final reply")},
 		Output: []llmagent.AgentItem{
 			llmagent.NewAgentItemTool("call_2", "resume_tool", call2Args, []llmsdk.Part{llmsdk.NewTextPart("call_2 result")}, llmsdk.ToolResultStatusCompleted),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("Final reply")}}),
+			modelItemFrom(model, llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("Final reply")}}),
 		},
 	}
 	if diff := cmp.Diff(expected, response); diff != "" {
@@ -999,7 +1153,7 @@ func TestRun_ResumesToolProcessingWhenTrailingToolEntries(t *testing.T) {
 		Content: []llmsdk.Part{llmsdk.NewTextPart("Final reply from items")},
 		Output: []llmagent.AgentItem{
 			llmagent.NewAgentItemTool("call_2", "resume_tool", call2Args, []llmsdk.Part{llmsdk.NewTextPart("call_2 via item")}, llmsdk.ToolResultStatusCompleted),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("Final reply from items")}}),
+			modelItemFrom(model, llmsdk.ModelResponse{Content: []llmsdk.Part{llmsdk.NewTextPart("Final reply from items")}}),
 		},
 	}
 	if diff := cmp.Diff(expected, response); diff != "" {
@@ -1125,6 +1279,13 @@ func TestRun_ThrowsAgentMaxTurnsExceededError(t *testing.T) {
 	if agentErr.Snapshot == nil {
 		t.Fatal("expected a run snapshot")
 	}
+	if len(agentErr.Snapshot.Output) != 4 {
+		t.Fatalf("expected the accumulated items in the snapshot, got %#v", agentErr.Snapshot.Output)
+	}
+	if len(agentErr.Snapshot.Content) != 1 || agentErr.Snapshot.Content[0].ToolCallPart == nil ||
+		agentErr.Snapshot.Content[0].ToolCallPart.ToolCallID != "call_2" {
+		t.Fatalf("expected the last model content in the snapshot, got %#v", agentErr.Snapshot.Content)
+	}
 }
 
 func TestRun_ThrowsAgentInvariantError_WhenToolNotFound(t *testing.T) {
@@ -1215,6 +1376,128 @@ func TestRun_ThrowsAgentToolExecutionError_WhenToolExecutionFails(t *testing.T)
 	}
 }
 
+func TestRun_AbortsWhenOneToolAmongManyFails(t *testing.T) {
+	failingTool := NewMockTool("failing_tool", llmagent.AgentToolResult{}, func(ctx context.Context, params json.RawMessage, contextVal map[string]interface{}, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		return llmagent.AgentToolResult{}, errors.New("tool execution failed")
+	})
+	slowTool := NewMockTool("slow_tool", llmagent.AgentToolResult{}, func(ctx context.Context, params json.RawMessage, contextVal map[string]interface{}, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		time.Sleep(50 * time.Millisecond)
+		return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("done")}}, nil
+	})
+
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{
+				llmsdk.NewToolCallPart("call_1", "failing_tool", json.RawMessage(`{}`)),
+				llmsdk.NewToolCallPart("call_2", "slow_tool", json.RawMessage(`{}`)),
+				llmsdk.NewToolCallPart("call_3", "slow_tool", json.RawMessage(`{}`)),
+			},
+		}),
+	)
+
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[map[string]interface{}]{
+			Name:         "test_agent",
+			Model:        model,
+			Instructions: []llmagent.InstructionParam[map[string]interface{}]{},
+			Tools:        llmagent.FunctionTools[map[string]interface{}](failingTool, slowTool),
+			MaxTurns:     10,
+			// Serialize dispatch so the failure surfaces while later tool
+			// calls are still waiting to be announced.
+			ToolConcurrency: 1,
+		},
+		map[string]interface{}{},
+	)
+
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Use the tools"))),
+		},
+	})
+
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) {
+		t.Fatalf("expected AgentError, got %v", err)
+	}
+	if agentErr.Kind != llmagent.ToolExecutionErrorKind {
+		t.Errorf("expected tool execution error, got %s", agentErr.Kind)
+	}
+
+	// Give the dispatcher and in-flight tools time to wind down; a start
+	// event sent on the closed process stream would panic here.
+	time.Sleep(100 * time.Millisecond)
+}
+
+func TestRunStream_AbortsWhenOneToolAmongManyFails(t *testing.T) {
+	failingTool := NewMockTool("failing_tool", llmagent.AgentToolResult{}, func(ctx context.Context, params json.RawMessage, contextVal map[string]interface{}, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		return llmagent.AgentToolResult{}, errors.New("tool execution failed")
+	})
+	slowTool := NewMockTool("slow_tool", llmagent.AgentToolResult{}, func(ctx context.Context, params json.RawMessage, contextVal map[string]interface{}, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		time.Sleep(50 * time.Millisecond)
+		return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("done")}}, nil
+	})
+
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(
+		llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+			{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewToolCallPartDelta(
+				llmsdk.WithToolCallPartDeltaToolName("failing_tool"),
+				llmsdk.WithToolCallPartDeltaToolCallID("call_1"),
+				llmsdk.WithToolCallPartDeltaArgs(`{}`),
+			)}},
+			{Delta: &llmsdk.ContentDelta{Index: 1, Part: llmsdk.NewToolCallPartDelta(
+				llmsdk.WithToolCallPartDeltaToolName("slow_tool"),
+				llmsdk.WithToolCallPartDeltaToolCallID("call_2"),
+				llmsdk.WithToolCallPartDeltaArgs(`{}`),
+			)}},
+			{Delta: &llmsdk.ContentDelta{Index: 2, Part: llmsdk.NewToolCallPartDelta(
+				llmsdk.WithToolCallPartDeltaToolName("slow_tool"),
+				llmsdk.WithToolCallPartDeltaToolCallID("call_3"),
+				llmsdk.WithToolCallPartDeltaArgs(`{}`),
+			)}},
+		}),
+	)
+
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[map[string]interface{}]{
+			Name:            "test_agent",
+			Model:           model,
+			Instructions:    []llmagent.InstructionParam[map[string]interface{}]{},
+			Tools:           llmagent.FunctionTools[map[string]interface{}](failingTool, slowTool),
+			MaxTurns:        10,
+			ToolConcurrency: 1,
+		},
+		map[string]interface{}{},
+	)
+
+	stream, err := session.RunStream(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Use the tools"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for stream.Next() {
+	}
+
+	var agentErr *llmagent.AgentError
+	if !errors.As(stream.Err(), &agentErr) {
+		t.Fatalf("expected AgentError, got %v", stream.Err())
+	}
+	if agentErr.Kind != llmagent.ToolExecutionErrorKind {
+		t.Errorf("expected tool execution error, got %s", agentErr.Kind)
+	}
+
+	// Give the dispatcher and in-flight tools time to wind down; a start
+	// event sent on the closed process stream would panic here.
+	time.Sleep(100 * time.Millisecond)
+}
+
 func TestRun_HandlesToolReturningErrorResult(t *testing.T) {
 	toolResult := llmagent.AgentToolResult{
 		Content: []llmsdk.Part{
@@ -1281,7 +1564,7 @@ func TestRun_HandlesToolReturningErrorResult(t *testing.T) {
 			llmsdk.NewTextPart("Handled the error"),
 		},
 		Output: []llmagent.AgentItem{
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart("call_1", "test_tool", json.RawMessage(`{"invalid": true}`)),
 				},
@@ -1293,7 +1576,7 @@ func TestRun_HandlesToolReturningErrorResult(t *testing.T) {
 				[]llmsdk.Part{llmsdk.NewTextPart("Error: Invalid parameters")},
 				llmsdk.ToolResultStatusFailed,
 			),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewTextPart("Handled the error"),
 				},
@@ -1306,6 +1589,82 @@ func TestRun_HandlesToolReturningErrorResult(t *testing.T) {
 	}
 }
 
+// slowMockLanguageModel delays generate calls so wall-clock attribution on
+// model items is observable.
+type slowMockLanguageModel struct {
+	*llmsdktest.MockLanguageModel
+	delay time.Duration
+}
+
+func (m *slowMockLanguageModel) Generate(ctx context.Context, input *llmsdk.LanguageModelInput) (*llmsdk.ModelResponse, error) {
+	time.Sleep(m.delay)
+	return m.MockLanguageModel.Generate(ctx, input)
+}
+
+func TestRun_AttributesUsageAndDurationPerModelItem(t *testing.T) {
+	model := &slowMockLanguageModel{
+		MockLanguageModel: llmsdktest.NewMockLanguageModel(),
+		delay:             5 * time.Millisecond,
+	}
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "test_tool", map[string]any{})},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 1000, OutputTokens: 50},
+		Cost:    ptr.To(0.0015),
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Done")},
+		Usage:   &llmsdk.ModelUsage{InputTokens: 60, OutputTokens: 10},
+	}))
+
+	tool := NewMockTool[struct{}](
+		"test_tool",
+		llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("ok")}},
+		nil,
+	)
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name:     "test_agent",
+			Model:    model,
+			Tools:    llmagent.FunctionTools[struct{}](tool),
+			MaxTurns: 10,
+		},
+		struct{}{},
+	)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Use the tool"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var modelItems []*llmagent.AgentItemModelResponse
+	for _, item := range response.Output {
+		if item.Model != nil {
+			modelItems = append(modelItems, item.Model)
+		}
+	}
+	if len(modelItems) != 2 {
+		t.Fatalf("expected two model items, got %#v", response.Output)
+	}
+
+	if modelItems[0].Usage == nil || modelItems[0].Usage.InputTokens != 1000 ||
+		modelItems[0].Cost == nil || *modelItems[0].Cost != 0.0015 {
+		t.Errorf("expected the first call's usage and cost on its item, got %#v", modelItems[0])
+	}
+	if modelItems[1].Usage == nil || modelItems[1].Usage.InputTokens != 60 || modelItems[1].Cost != nil {
+		t.Errorf("expected the second call's usage on its item, got %#v", modelItems[1])
+	}
+	for i, item := range modelItems {
+		if item.Duration < 5*time.Millisecond {
+			t.Errorf("expected model item %d to record the call duration, got %v", i, item.Duration)
+		}
+	}
+}
+
 func TestRun_PassesSamplingParametersToModel(t *testing.T) {
 	model := llmsdktest.NewMockLanguageModel()
 	model.EnqueueGenerateResult(
@@ -1615,7 +1974,7 @@ func TestRun_MergesToolkitPromptsAndTools(t *testing.T) {
 		Status:  llmagent.AgentResponseStatusCompleted,
 		Content: []llmsdk.Part{llmsdk.NewTextPart("Order ready")},
 		Output: []llmagent.AgentItem{
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart("call-1", "lookup-order", json.RawMessage(`{"orderId":"123"}`)),
 				},
@@ -1627,7 +1986,7 @@ func TestRun_MergesToolkitPromptsAndTools(t *testing.T) {
 				[]llmsdk.Part{llmsdk.NewTextPart("Order 123 ready for Ada")},
 				llmsdk.ToolResultStatusCompleted,
 			),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{llmsdk.NewTextPart("Order ready")},
 			}),
 		},
@@ -1748,7 +2107,7 @@ func TestRunStream_StreamsResponse_NoToolCall(t *testing.T) {
 		},
 		{
 			Item: &llmagent.AgentStreamItemEvent{
-				Item: llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+				Item: modelItemFrom(model, llmsdk.ModelResponse{
 					Content: []llmsdk.Part{
 						llmsdk.NewTextPart("Hello!"),
 					},
@@ -1762,7 +2121,7 @@ func TestRunStream_StreamsResponse_NoToolCall(t *testing.T) {
 					llmsdk.NewTextPart("Hello!"),
 				},
 				Output: []llmagent.AgentItem{
-					llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+					modelItemFrom(model, llmsdk.ModelResponse{
 						Content: []llmsdk.Part{
 							llmsdk.NewTextPart("Hello!"),
 						},
@@ -1882,6 +2241,8 @@ func TestRunStream_StreamsToolCallExecutionAndResponse(t *testing.T) {
 			} else {
 				summaries = append(summaries, "response:other")
 			}
+		case event.ToolCallStart != nil:
+			summaries = append(summaries, "tool-call-start:"+event.ToolCallStart.ToolName)
 		default:
 			summaries = append(summaries, "unknown")
 		}
@@ -1890,6 +2251,7 @@ func TestRunStream_StreamsToolCallExecutionAndResponse(t *testing.T) {
 	expectedSummaries := []string{
 		"partial:tool-call:test_tool",
 		"item:model:tool-call:test_tool",
+		"tool-call-start:test_tool",
 		"item:tool:test_tool:false",
 		"partial:text:Final response",
 		"item:model:text:Final response",
@@ -1911,7 +2273,7 @@ func TestRunStream_StreamsToolCallExecutionAndResponse(t *testing.T) {
 			llmsdk.NewTextPart("Final response"),
 		},
 		Output: []llmagent.AgentItem{
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewToolCallPart("call_1", "test_tool", json.RawMessage(`{"operation": "add", "a": 1, "b": 2}`)),
 				},
@@ -1923,7 +2285,7 @@ func TestRunStream_StreamsToolCallExecutionAndResponse(t *testing.T) {
 				[]llmsdk.Part{llmsdk.NewTextPart("Tool result")},
 				llmsdk.ToolResultStatusCompleted,
 			),
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{
 					llmsdk.NewTextPart("Final response"),
 				},
@@ -2098,7 +2460,7 @@ func TestRunStream_CommitsMaterializablePartialContentBeforeError(t *testing.T)
 	if agentErr.Snapshot == nil {
 		t.Fatal("expected a run snapshot")
 	}
-	expectedItem := llmagent.NewAgentItemModelResponse(mixedSnapshotModelResponse())
+	expectedItem := modelItemFrom(model, mixedSnapshotModelResponse())
 	if diff := cmp.Diff([]llmagent.AgentItem{expectedItem}, agentErr.Snapshot.Output); diff != "" {
 		t.Fatalf("snapshot output mismatch (-want +got):\n%s", diff)
 	}
@@ -2138,7 +2500,7 @@ func TestRunStream_RecordsCancelledResultsForMaterializedToolCalls(t *testing.T)
 	if !stream.Next() || stream.Current().Item == nil {
 		t.Fatalf("expected committed model item, got error %v", stream.Err())
 	}
-	expectedItem := llmagent.NewAgentItemModelResponse(mixedSnapshotModelResponse())
+	expectedItem := modelItemFrom(model, mixedSnapshotModelResponse())
 	if diff := cmp.Diff(expectedItem, stream.Current().Item.Item); diff != "" {
 		t.Fatalf("model item mismatch (-want +got):\n%s", diff)
 	}
@@ -2178,6 +2540,133 @@ func TestRunStream_RecordsCancelledResultsForMaterializedToolCalls(t *testing.T)
 	}
 }
 
+// cleanupMockTool augments MockAgentTool with the optional Cleanup hook and
+// records the arguments it was invoked with.
+type cleanupMockTool[C any] struct {
+	*MockAgentTool[C]
+	cleanupCalls chan json.RawMessage
+}
+
+func (t *cleanupMockTool[C]) Cleanup(ctx context.Context, params json.RawMessage, contextVal C) error {
+	t.cleanupCalls <- params
+	return nil
+}
+
+func TestRun_InvokesToolCleanupWhenCancelledMidExecution(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "reserve", map[string]any{"order": 1})},
+	}))
+
+	started := make(chan struct{})
+	tool := &cleanupMockTool[struct{}]{
+		MockAgentTool: NewMockTool[struct{}]("reserve", llmagent.AgentToolResult{}, func(ctx context.Context, params json.RawMessage, contextVal struct{}, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+			close(started)
+			<-ctx.Done()
+			return llmagent.AgentToolResult{}, ctx.Err()
+		}),
+		cleanupCalls: make(chan json.RawMessage, 1),
+	}
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:     "test_agent",
+		Model:    model,
+		MaxTurns: 10,
+		Tools:    []llmagent.AgentTool[struct{}]{llmagent.NewAgentFunctionTool[struct{}](tool)},
+	}, struct{}{})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	response, err := session.Run(ctx, llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Reserve it"))),
+	}})
+	if err != nil {
+		t.Fatalf("expected cancelled response, got %v", err)
+	}
+	if response.Status != llmagent.AgentResponseStatusCancelled {
+		t.Fatalf("expected cancelled status, got %q", response.Status)
+	}
+	if len(response.Output) == 0 || response.Output[len(response.Output)-1].Tool == nil ||
+		response.Output[len(response.Output)-1].Tool.Status != llmsdk.ToolResultStatusCancelled {
+		t.Fatalf("expected a cancelled tool item, got %#v", response.Output)
+	}
+
+	select {
+	case params := <-tool.cleanupCalls:
+		if string(params) != `{"order":1}` {
+			t.Fatalf("unexpected cleanup params: %s", params)
+		}
+	default:
+		t.Fatal("expected the cleanup hook to be invoked")
+	}
+}
+
+func TestRun_ReplaysToolResultsWithIdempotencyEnabled(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	toolCallResponse := llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "place_order", map[string]any{"item": "book"})},
+	}
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(toolCallResponse))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Ordered")},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Still ordered")},
+	}))
+
+	executions := 0
+	var seenKey string
+	tool := NewMockTool[struct{}]("place_order", llmagent.AgentToolResult{}, func(ctx context.Context, params json.RawMessage, contextVal struct{}, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		executions++
+		seenKey, _ = llmagent.ToolIdempotencyKeyFromContext(ctx)
+		return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("order placed")}}, nil
+	})
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[struct{}]{
+		Name:            "test_agent",
+		Model:           model,
+		MaxTurns:        10,
+		ToolIdempotency: true,
+		Tools:           []llmagent.AgentTool[struct{}]{llmagent.NewAgentFunctionTool[struct{}](tool)},
+	}, struct{}{})
+
+	userMessage := llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Order a book")))
+	if _, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{userMessage}}); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if executions != 1 {
+		t.Fatalf("expected one tool execution, got %d", executions)
+	}
+	if seenKey != session.ID()+":call_1" {
+		t.Fatalf("unexpected idempotency key: %q", seenKey)
+	}
+
+	// A retried run replays the model output containing the same call ID; the
+	// recorded result is reused instead of executing the tool again.
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		userMessage,
+		llmagent.NewAgentItemModelResponse(toolCallResponse),
+	}})
+	if err != nil {
+		t.Fatalf("retried run: %v", err)
+	}
+	if executions != 1 {
+		t.Fatalf("expected the replayed call to skip execution, got %d executions", executions)
+	}
+	if len(response.Output) == 0 || response.Output[0].Tool == nil {
+		t.Fatalf("expected the replayed tool item first, got %#v", response.Output)
+	}
+	replayed := response.Output[0].Tool
+	if replayed.Status != llmsdk.ToolResultStatusCompleted ||
+		len(replayed.Output) != 1 || replayed.Output[0].TextPart.Text != "order placed" {
+		t.Fatalf("unexpected replayed tool item: %#v", replayed)
+	}
+}
+
 func TestRunStream_ThrowsErrorWhenMaxTurnsExceeded(t *testing.T) {
 	toolResult := llmagent.AgentToolResult{
 		Content: []llmsdk.Part{
@@ -2337,7 +2826,7 @@ func TestRunStream_MergesToolkitPromptsAndTools(t *testing.T) {
 		},
 		llmagent.NewAgentStreamItemEvent(
 			0,
-			llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+			modelItemFrom(model, llmsdk.ModelResponse{
 				Content: []llmsdk.Part{llmsdk.NewTextPart("Done")},
 			}),
 		),
@@ -2346,7 +2835,7 @@ func TestRunStream_MergesToolkitPromptsAndTools(t *testing.T) {
 				Status:  llmagent.AgentResponseStatusCompleted,
 				Content: []llmsdk.Part{llmsdk.NewTextPart("Done")},
 				Output: []llmagent.AgentItem{
-					llmagent.NewAgentItemModelResponse(llmsdk.ModelResponse{
+					modelItemFrom(model, llmsdk.ModelResponse{
 						Content: []llmsdk.Part{llmsdk.NewTextPart("Done")},
 					}),
 				},
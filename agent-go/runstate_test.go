@@ -0,0 +1,88 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRunStateExposesRunTelemetry(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "telemetry", map[string]any{})},
+			Usage:   &llmsdk.ModelUsage{InputTokens: 10, OutputTokens: 5},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_2", "telemetry", map[string]any{})},
+			Usage:   &llmsdk.ModelUsage{InputTokens: 20, OutputTokens: 5},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		}),
+	)
+
+	type observation struct {
+		turn          uint
+		elapsed       time.Duration
+		inputTokens   int
+		priorCalls    int
+		priorToolName string
+	}
+	observed := make([]observation, 0, 2)
+	tool := NewMockTool[struct{}]("telemetry", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("ok")},
+	}, func(_ context.Context, _ json.RawMessage, _ struct{}, state *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		calls := state.ToolCalls()
+		o := observation{
+			turn:        state.Turn(),
+			elapsed:     state.Elapsed(),
+			inputTokens: state.Usage().InputTokens,
+			priorCalls:  len(calls),
+		}
+		if len(calls) > 0 {
+			o.priorToolName = calls[0].ToolName
+		}
+		observed = append(observed, o)
+		return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("ok")}}, nil
+	})
+	session := mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name: "test_agent", Model: model, Tools: llmagent.FunctionTools[struct{}](tool), MaxTurns: 10,
+		},
+		struct{}{},
+	)
+
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Report"))),
+	}})
+	if err != nil {
+		t.Fatalf("run session: %v", err)
+	}
+
+	if len(observed) != 2 {
+		t.Fatalf("expected 2 tool executions, got %d", len(observed))
+	}
+	first, second := observed[0], observed[1]
+	if first.turn != 1 || second.turn != 2 {
+		t.Errorf("unexpected turn numbers %d, %d", first.turn, second.turn)
+	}
+	if first.elapsed <= 0 || second.elapsed < first.elapsed {
+		t.Errorf("expected elapsed time to grow, got %v then %v", first.elapsed, second.elapsed)
+	}
+	if first.inputTokens != 10 || second.inputTokens != 30 {
+		t.Errorf("expected accumulated usage visible to tools, got %d then %d", first.inputTokens, second.inputTokens)
+	}
+	if first.priorCalls != 0 {
+		t.Errorf("expected no prior tool calls on the first execution, got %d", first.priorCalls)
+	}
+	if second.priorCalls != 1 || second.priorToolName != "telemetry" {
+		t.Errorf("expected the first call visible to the second, got %d calls (%q)", second.priorCalls, second.priorToolName)
+	}
+}
@@ -0,0 +1,184 @@
+package llmagent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// SessionManagerParams configures the session manager.
+type SessionManagerParams struct {
+	// TTL is how long a session may stay idle before the reaper closes and
+	// evicts it. Required.
+	TTL time.Duration
+	// Interval is the time between reaper sweeps. Zero defaults to half the
+	// TTL.
+	Interval time.Duration
+	// OnEvict is called after the reaper evicts an idle session, with the
+	// error from closing it, if any.
+	OnEvict func(key string, err error)
+}
+
+// SessionManager tracks run sessions by key and closes the ones that stay
+// idle past the TTL, so toolkit resources such as MCP connections do not leak
+// in long-lived servers. Sessions are considered used whenever they are
+// fetched through GetOrCreate; the TTL should comfortably exceed the longest
+// expected run.
+type SessionManager[C any] struct {
+	agent  *Agent[C]
+	params SessionManagerParams
+
+	mu       sync.Mutex
+	sessions map[string]*managedSession[C]
+	closed   bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type managedSession[C any] struct {
+	session  *RunSession[C]
+	lastUsed time.Time
+}
+
+// NewSessionManager creates a session manager for the agent and starts its
+// background reaper. Call Close to stop the reaper and close the remaining
+// sessions.
+func NewSessionManager[C any](agent *Agent[C], params SessionManagerParams) (*SessionManager[C], error) {
+	if agent == nil {
+		return nil, errors.New("session manager requires an agent")
+	}
+	if params.TTL <= 0 {
+		return nil, errors.New("session manager requires a positive TTL")
+	}
+	if params.Interval <= 0 {
+		params.Interval = params.TTL / 2
+	}
+
+	m := &SessionManager[C]{
+		agent:    agent,
+		params:   params,
+		sessions: map[string]*managedSession[C]{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go m.reap()
+	return m, nil
+}
+
+// GetOrCreate returns the session stored under the key, creating it with the
+// context value on a miss. Fetching a session resets its idle timer.
+func (m *SessionManager[C]) GetOrCreate(ctx context.Context, key string, contextVal C) (*RunSession[C], error) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil, errors.New("session manager is closed")
+	}
+	if managed, ok := m.sessions[key]; ok {
+		managed.lastUsed = time.Now()
+		session := managed.session
+		m.mu.Unlock()
+		return session, nil
+	}
+	m.mu.Unlock()
+
+	session, err := m.agent.CreateSession(ctx, contextVal)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		_ = session.Close(context.WithoutCancel(ctx))
+		return nil, errors.New("session manager is closed")
+	}
+	if managed, ok := m.sessions[key]; ok {
+		// Another caller created the session concurrently; keep theirs.
+		managed.lastUsed = time.Now()
+		existing := managed.session
+		m.mu.Unlock()
+		_ = session.Close(context.WithoutCancel(ctx))
+		return existing, nil
+	}
+	m.sessions[key] = &managedSession[C]{session: session, lastUsed: time.Now()}
+	m.mu.Unlock()
+	return session, nil
+}
+
+// Remove closes and evicts the session stored under the key, if any.
+func (m *SessionManager[C]) Remove(ctx context.Context, key string) error {
+	m.mu.Lock()
+	managed, ok := m.sessions[key]
+	delete(m.sessions, key)
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return managed.session.Close(ctx)
+}
+
+// Len returns the number of tracked sessions.
+func (m *SessionManager[C]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+// Close stops the reaper and closes all remaining sessions. The manager
+// cannot be used afterwards.
+func (m *SessionManager[C]) Close(ctx context.Context) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	remaining := m.sessions
+	m.sessions = map[string]*managedSession[C]{}
+	m.mu.Unlock()
+
+	close(m.stop)
+	<-m.done
+
+	var errs []error
+	for _, managed := range remaining {
+		if err := managed.session.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reap periodically closes and evicts the sessions idle past the TTL.
+func (m *SessionManager[C]) reap() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.params.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		deadline := time.Now().Add(-m.params.TTL)
+		m.mu.Lock()
+		expired := map[string]*managedSession[C]{}
+		for key, managed := range m.sessions {
+			if managed.lastUsed.Before(deadline) {
+				expired[key] = managed
+				delete(m.sessions, key)
+			}
+		}
+		m.mu.Unlock()
+
+		for key, managed := range expired {
+			err := managed.session.Close(context.Background())
+			if m.params.OnEvict != nil {
+				m.params.OnEvict(key, err)
+			}
+		}
+	}
+}
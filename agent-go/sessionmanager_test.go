@@ -0,0 +1,135 @@
+package llmagent_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// closableToolkit counts how many of its sessions have been closed.
+type closableToolkit struct {
+	closed atomic.Int64
+}
+
+type closableToolkitSession struct {
+	toolkit *closableToolkit
+}
+
+func (tk *closableToolkit) CreateSession(context.Context, any) (llmagent.ToolkitSession[any], error) {
+	return &closableToolkitSession{toolkit: tk}, nil
+}
+
+func (s *closableToolkitSession) SystemPrompt() *string            { return nil }
+func (s *closableToolkitSession) Tools() []llmagent.AgentTool[any] { return nil }
+func (s *closableToolkitSession) Close(context.Context) error {
+	s.toolkit.closed.Add(1)
+	return nil
+}
+
+func TestSessionManagerEvictsIdleSessions(t *testing.T) {
+	toolkit := &closableToolkit{}
+	agent := llmagent.NewAgent("assistant", llmsdktest.NewMockLanguageModel(),
+		llmagent.WithToolkits[any](toolkit),
+	)
+
+	var mu sync.Mutex
+	var evicted []string
+	manager, err := llmagent.NewSessionManager(agent, llmagent.SessionManagerParams{
+		TTL:      30 * time.Millisecond,
+		Interval: 10 * time.Millisecond,
+		OnEvict: func(key string, err error) {
+			if err != nil {
+				t.Errorf("unexpected evict error for %q: %v", key, err)
+			}
+			mu.Lock()
+			evicted = append(evicted, key)
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create session manager: %v", err)
+	}
+	defer manager.Close(context.Background())
+
+	if _, err := manager.GetOrCreate(t.Context(), "idle", nil); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for toolkit.closed.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if toolkit.closed.Load() != 1 {
+		t.Fatalf("expected the toolkit session to be closed, got %d closes", toolkit.closed.Load())
+	}
+	if manager.Len() != 0 {
+		t.Error("expected the idle session to be evicted")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(evicted) != 1 || evicted[0] != "idle" {
+		t.Errorf("unexpected evictions %v", evicted)
+	}
+}
+
+func TestSessionManagerKeepsActiveSessions(t *testing.T) {
+	agent := llmagent.NewAgent[any]("assistant", llmsdktest.NewMockLanguageModel())
+	manager, err := llmagent.NewSessionManager(agent, llmagent.SessionManagerParams{
+		TTL:      500 * time.Millisecond,
+		Interval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create session manager: %v", err)
+	}
+	defer manager.Close(context.Background())
+
+	first, err := manager.GetOrCreate(t.Context(), "active", nil)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// Touch the session more often than the TTL; it must survive.
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		current, err := manager.GetOrCreate(t.Context(), "active", nil)
+		if err != nil {
+			t.Fatalf("failed to fetch session: %v", err)
+		}
+		if current != first {
+			t.Fatal("expected the same session while it stays active")
+		}
+	}
+}
+
+func TestSessionManagerCloseClosesRemainingSessions(t *testing.T) {
+	toolkit := &closableToolkit{}
+	agent := llmagent.NewAgent("assistant", llmsdktest.NewMockLanguageModel(),
+		llmagent.WithToolkits[any](toolkit),
+	)
+	manager, err := llmagent.NewSessionManager(agent, llmagent.SessionManagerParams{TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("failed to create session manager: %v", err)
+	}
+
+	if _, err := manager.GetOrCreate(t.Context(), "a", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.GetOrCreate(t.Context(), "b", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.Close(t.Context()); err != nil {
+		t.Fatalf("failed to close manager: %v", err)
+	}
+	if toolkit.closed.Load() != 2 {
+		t.Errorf("expected both sessions closed, got %d", toolkit.closed.Load())
+	}
+	if _, err := manager.GetOrCreate(t.Context(), "c", nil); err == nil {
+		t.Error("expected an error after the manager is closed")
+	}
+}
@@ -0,0 +1,246 @@
+// Package shelltool provides a controlled shell command tool for agents.
+//
+// The run_command tool executes a binary with arguments directly (no shell
+// interpretation). Which binaries may run is governed by an allowlist and a
+// denylist, the working directory is confined to a configured root, every
+// execution is bounded by a timeout, and captured output is truncated to a
+// configurable size before it is returned to the model.
+package shelltool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+const (
+	// defaultTimeout bounds executions when no timeout is configured.
+	defaultTimeout = 30 * time.Second
+	// defaultMaxOutputBytes bounds captured stdout and stderr when no limit
+	// is configured.
+	defaultMaxOutputBytes = 16 * 1024
+)
+
+// Options configures the run_command tool.
+type Options struct {
+	// Dir is the working directory commands run in. Required. A relative
+	// directory supplied by the model is resolved against it and rejected if
+	// it escapes it.
+	Dir string
+	// Allow lists the command names that may run (e.g. "git" or "ls").
+	// Commands must be supplied as bare names and are resolved on PATH;
+	// paths are rejected. An empty list allows every command not denied.
+	Allow []string
+	// Deny lists command names that may never run, checked before the
+	// allowlist.
+	Deny []string
+	// Timeout bounds each execution. Zero defaults to 30 seconds.
+	Timeout time.Duration
+	// MaxOutputBytes truncates captured stdout and stderr. Zero defaults to
+	// 16 KiB.
+	MaxOutputBytes int
+}
+
+func (o Options) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return defaultTimeout
+	}
+	return o.Timeout
+}
+
+func (o Options) maxOutputBytes() int {
+	if o.MaxOutputBytes <= 0 {
+		return defaultMaxOutputBytes
+	}
+	return o.MaxOutputBytes
+}
+
+// allowed reports whether the command name passes the deny and allow lists.
+// Only bare names are accepted: a path would let the model run any binary
+// whose base name happens to match the allowlist.
+func (o Options) allowed(name string) error {
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) {
+		return fmt.Errorf("command %q must be a bare name, not a path", name)
+	}
+	for _, denied := range o.Deny {
+		if name == denied {
+			return fmt.Errorf("command %q is denied", name)
+		}
+	}
+	if len(o.Allow) == 0 {
+		return nil
+	}
+	for _, allowed := range o.Allow {
+		if name == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("command %q is not in the allowlist", name)
+}
+
+// workDir resolves the model-supplied directory against the configured root,
+// rejecting escapes.
+func (o Options) workDir(dir string) (string, error) {
+	if dir == "" {
+		return o.Dir, nil
+	}
+	abs := filepath.Join(o.Dir, filepath.FromSlash(dir))
+	rel, err := filepath.Rel(o.Dir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("directory %q is outside the working directory", dir)
+	}
+	return abs, nil
+}
+
+// RunCommandTool executes allowlisted commands as the "run_command" function
+// tool.
+type RunCommandTool[C any] struct {
+	options Options
+}
+
+// NewRunCommandTool creates the run_command tool. Options.Dir is required.
+func NewRunCommandTool[C any](options Options) (*RunCommandTool[C], error) {
+	if options.Dir == "" {
+		return nil, errors.New("shelltool: working directory is required")
+	}
+	dir, err := filepath.Abs(options.Dir)
+	if err != nil {
+		return nil, err
+	}
+	options.Dir = dir
+	return &RunCommandTool[C]{options: options}, nil
+}
+
+func (t *RunCommandTool[C]) Name() string { return "run_command" }
+
+func (t *RunCommandTool[C]) Description() string {
+	return "Run a command with arguments in the working directory and return its stdout, stderr, and exit code. The command is executed directly, not through a shell."
+}
+
+func (t *RunCommandTool[C]) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"command": map[string]any{
+				"type":        "string",
+				"description": "The command to run, e.g. \"git\".",
+			},
+			"args": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"description": "The arguments passed to the command.",
+			},
+			"dir": map[string]any{
+				"type":        "string",
+				"description": "An optional directory to run in, relative to the working directory.",
+			},
+		},
+		"required":             []string{"command"},
+		"additionalProperties": false,
+	}
+}
+
+type runCommandArgs struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	Dir     string   `json:"dir"`
+}
+
+func (t *RunCommandTool[C]) Execute(ctx context.Context, params json.RawMessage, _ C, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	var args runCommandArgs
+	if err := json.Unmarshal(params, &args); err != nil {
+		return llmagent.AgentToolResult{}, err
+	}
+
+	if err := t.options.allowed(args.Command); err != nil {
+		return errorResult(err), nil
+	}
+	// Resolve the bare name on PATH before the working directory applies, so
+	// a binary planted in the (possibly writable) working directory is never
+	// picked up.
+	path, err := exec.LookPath(args.Command)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	dir, err := t.options.workDir(args.Dir)
+	if err != nil {
+		return errorResult(err), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.options.timeout())
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args.Args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	exitCode := 0
+	if runErr != nil {
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() != nil:
+			return errorResult(fmt.Errorf("command timed out after %s", t.options.timeout())), nil
+		case errors.As(runErr, &exitErr):
+			exitCode = exitErr.ExitCode()
+		default:
+			return errorResult(runErr), nil
+		}
+	}
+
+	var parts []llmsdk.Part
+	if out := t.truncate(stdout.String()); out != "" {
+		parts = append(parts, llmsdk.NewTextPart(out))
+	}
+	if errOut := t.truncate(stderr.String()); errOut != "" {
+		parts = append(parts, llmsdk.NewTextPart("stderr:\n"+errOut))
+	}
+	if exitCode != 0 {
+		parts = append(parts, llmsdk.NewTextPart(fmt.Sprintf("exit code: %d", exitCode)))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, llmsdk.NewTextPart("(no output)"))
+	}
+
+	return llmagent.AgentToolResult{
+		Content: parts,
+		IsError: exitCode != 0,
+	}, nil
+}
+
+// truncate bounds the captured output, marking the cut so the model knows the
+// output is incomplete.
+func (t *RunCommandTool[C]) truncate(s string) string {
+	limit := t.options.maxOutputBytes()
+	if len(s) <= limit {
+		return s
+	}
+	return s[:limit] + fmt.Sprintf("\n[truncated %d bytes]", len(s)-limit)
+}
+
+func errorResult(err error) llmagent.AgentToolResult {
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart(err.Error())},
+		IsError: true,
+	}
+}
+
+// Tools returns the agent tools for controlled command execution.
+func Tools[C any](options Options) ([]llmagent.AgentTool[C], error) {
+	tool, err := NewRunCommandTool[C](options)
+	if err != nil {
+		return nil, err
+	}
+	return llmagent.FunctionTools[C](tool), nil
+}
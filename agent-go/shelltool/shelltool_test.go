@@ -0,0 +1,146 @@
+package shelltool_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/shelltool"
+)
+
+func newTool(t *testing.T, options shelltool.Options) *shelltool.RunCommandTool[struct{}] {
+	t.Helper()
+	if options.Dir == "" {
+		options.Dir = t.TempDir()
+	}
+	tool, err := shelltool.NewRunCommandTool[struct{}](options)
+	if err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+	return tool
+}
+
+func execute(t *testing.T, tool *shelltool.RunCommandTool[struct{}], args any) llmagent.AgentToolResult {
+	t.Helper()
+	params, err := json.Marshal(args)
+	if err != nil {
+		t.Fatalf("failed to marshal args: %v", err)
+	}
+	result, err := tool.Execute(t.Context(), params, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected execute error: %v", err)
+	}
+	return result
+}
+
+func resultText(t *testing.T, result llmagent.AgentToolResult) string {
+	t.Helper()
+	if len(result.Content) == 0 || result.Content[0].TextPart == nil {
+		t.Fatalf("expected a text part, got %+v", result.Content)
+	}
+	return result.Content[0].TextPart.Text
+}
+
+func TestRunCommandCapturesOutput(t *testing.T) {
+	tool := newTool(t, shelltool.Options{Allow: []string{"echo"}})
+
+	result := execute(t, tool, map[string]any{"command": "echo", "args": []string{"hello"}})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %+v", result)
+	}
+	if got := resultText(t, result); got != "hello\n" {
+		t.Errorf("unexpected output %q", got)
+	}
+}
+
+func TestRunCommandEnforcesAllowAndDenyLists(t *testing.T) {
+	allow := newTool(t, shelltool.Options{Allow: []string{"echo"}})
+	result := execute(t, allow, map[string]any{"command": "cat"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "allowlist") {
+		t.Errorf("expected allowlist rejection, got %+v", result)
+	}
+
+	deny := newTool(t, shelltool.Options{Deny: []string{"rm"}})
+	result = execute(t, deny, map[string]any{"command": "rm", "args": []string{"-rf", "/"}})
+	if !result.IsError || !strings.Contains(resultText(t, result), "denied") {
+		t.Errorf("expected denylist rejection, got %+v", result)
+	}
+
+	// Paths are rejected outright, so neither list can be bypassed with a
+	// binary of the same name elsewhere.
+	result = execute(t, deny, map[string]any{"command": "/bin/rm"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "bare name") {
+		t.Errorf("expected path rejection, got %+v", result)
+	}
+	result = execute(t, allow, map[string]any{"command": "/bin/echo"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "bare name") {
+		t.Errorf("expected path rejection, got %+v", result)
+	}
+	result = execute(t, allow, map[string]any{"command": "./echo"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "bare name") {
+		t.Errorf("expected path rejection, got %+v", result)
+	}
+}
+
+func TestRunCommandDoesNotResolveFromWorkingDirectory(t *testing.T) {
+	// An allowlisted name must resolve on PATH, never to a binary planted in
+	// the writable working directory.
+	dir := t.TempDir()
+	script := filepath.Join(dir, "allowed_probe")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho planted\n"), 0o755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	tool := newTool(t, shelltool.Options{Dir: dir, Allow: []string{"allowed_probe"}})
+	result := execute(t, tool, map[string]any{"command": "allowed_probe"})
+	if !result.IsError || strings.Contains(resultText(t, result), "planted") {
+		t.Errorf("expected PATH resolution failure, got %+v", result)
+	}
+}
+
+func TestRunCommandConfinesWorkingDirectory(t *testing.T) {
+	tool := newTool(t, shelltool.Options{Allow: []string{"pwd"}})
+
+	result := execute(t, tool, map[string]any{"command": "pwd", "dir": "../.."})
+	if !result.IsError || !strings.Contains(resultText(t, result), "outside") {
+		t.Errorf("expected directory escape rejection, got %+v", result)
+	}
+}
+
+func TestRunCommandTimesOut(t *testing.T) {
+	tool := newTool(t, shelltool.Options{
+		Allow:   []string{"sleep"},
+		Timeout: 50 * time.Millisecond,
+	})
+
+	result := execute(t, tool, map[string]any{"command": "sleep", "args": []string{"5"}})
+	if !result.IsError || !strings.Contains(resultText(t, result), "timed out") {
+		t.Errorf("expected timeout result, got %+v", result)
+	}
+}
+
+func TestRunCommandTruncatesOutput(t *testing.T) {
+	tool := newTool(t, shelltool.Options{
+		Allow:          []string{"printf"},
+		MaxOutputBytes: 8,
+	})
+
+	result := execute(t, tool, map[string]any{"command": "printf", "args": []string{"%032d", "0"}})
+	got := resultText(t, result)
+	if !strings.HasPrefix(got, "00000000\n[truncated 24 bytes]") {
+		t.Errorf("expected truncated output, got %q", got)
+	}
+}
+
+func TestRunCommandReportsExitCode(t *testing.T) {
+	tool := newTool(t, shelltool.Options{Allow: []string{"false"}})
+
+	result := execute(t, tool, map[string]any{"command": "false"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "exit code: 1") {
+		t.Errorf("expected exit code result, got %+v", result)
+	}
+}
@@ -0,0 +1,183 @@
+package llmagent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/stream"
+)
+
+// SpendGuardParams configures a deployment-wide spend guard.
+type SpendGuardParams struct {
+	// MaxSpend is the spend allowed per window, in the same unit as the cost
+	// the models report. Required.
+	MaxSpend float64
+	// Window is the fixed window the spend accumulates over, e.g. time.Hour
+	// for an hourly budget or 24*time.Hour for a daily one. Required.
+	Window time.Duration
+	// EstimateCost estimates the cost of a response that reports usage but no
+	// cost. When nil, only model-reported costs count toward the budget.
+	EstimateCost func(usage *llmsdk.ModelUsage) float64
+}
+
+// SpendGuard accumulates the cost of every model call charged to it and trips
+// once the spend in the current window crosses the configured threshold. One
+// guard is shared across every agent in the process: wrap each agent's model
+// with WrapModel so all runs draw from the same budget. While tripped, guarded
+// calls either fail fast with a spend limit error or degrade to a configured
+// cheaper model until the window rolls over.
+type SpendGuard struct {
+	params SpendGuardParams
+
+	mu          sync.Mutex
+	windowStart time.Time
+	spend       float64
+}
+
+// NewSpendGuard creates a spend guard with an empty window.
+func NewSpendGuard(params SpendGuardParams) (*SpendGuard, error) {
+	if params.MaxSpend <= 0 {
+		return nil, NewInvariantError("spend guard max spend must be positive")
+	}
+	if params.Window <= 0 {
+		return nil, NewInvariantError("spend guard window must be positive")
+	}
+	return &SpendGuard{params: params}, nil
+}
+
+// Spend returns the spend recorded in the current window.
+func (g *SpendGuard) Spend() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollWindow(time.Now())
+	return g.spend
+}
+
+// Tripped reports whether the current window's spend has crossed the
+// threshold.
+func (g *SpendGuard) Tripped() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollWindow(time.Now())
+	return g.spend >= g.params.MaxSpend
+}
+
+// rollWindow resets the tally when the current window has elapsed. The caller
+// must hold g.mu.
+func (g *SpendGuard) rollWindow(now time.Time) {
+	if now.Sub(g.windowStart) >= g.params.Window {
+		g.windowStart = now
+		g.spend = 0
+	}
+}
+
+// record charges one model response to the current window.
+func (g *SpendGuard) record(cost *float64, usage *llmsdk.ModelUsage) {
+	amount := 0.0
+	switch {
+	case cost != nil:
+		amount = *cost
+	case usage != nil && g.params.EstimateCost != nil:
+		amount = g.params.EstimateCost(usage)
+	}
+	if amount == 0 {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollWindow(time.Now())
+	g.spend += amount
+}
+
+// WrapModel returns a model that charges every response to the guard and
+// enforces the budget. Fallback, when non-nil, serves calls while the guard is
+// tripped — typically a cheaper model — and its spend still counts toward the
+// window. With a nil fallback, calls fail fast with a spend limit error until
+// the window rolls over.
+func (g *SpendGuard) WrapModel(model llmsdk.LanguageModel, fallback llmsdk.LanguageModel) llmsdk.LanguageModel {
+	return &spendGuardedModel{guard: g, model: model, fallback: fallback}
+}
+
+type spendGuardedModel struct {
+	guard    *SpendGuard
+	model    llmsdk.LanguageModel
+	fallback llmsdk.LanguageModel
+}
+
+func (m *spendGuardedModel) Provider() string {
+	return m.model.Provider()
+}
+
+func (m *spendGuardedModel) ModelID() string {
+	return m.model.ModelID()
+}
+
+func (m *spendGuardedModel) Metadata() *llmsdk.LanguageModelMetadata {
+	return m.model.Metadata()
+}
+
+// pick returns the model to serve the call, degrading to the fallback while
+// the guard is tripped.
+func (m *spendGuardedModel) pick() (llmsdk.LanguageModel, error) {
+	if !m.guard.Tripped() {
+		return m.model, nil
+	}
+	if m.fallback != nil {
+		return m.fallback, nil
+	}
+	return nil, NewSpendLimitError(m.guard.Spend(), m.guard.params.MaxSpend)
+}
+
+func (m *spendGuardedModel) Generate(ctx context.Context, input *llmsdk.LanguageModelInput) (*llmsdk.ModelResponse, error) {
+	model, err := m.pick()
+	if err != nil {
+		return nil, err
+	}
+	response, err := model.Generate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	m.guard.record(response.Cost, response.Usage)
+	return response, nil
+}
+
+func (m *spendGuardedModel) Stream(ctx context.Context, input *llmsdk.LanguageModelInput) (*llmsdk.LanguageModelStream, error) {
+	model, err := m.pick()
+	if err != nil {
+		return nil, err
+	}
+	inner, err := model.Stream(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	partialCh := make(chan *llmsdk.PartialModelResponse)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(partialCh)
+		defer close(errCh)
+
+		var cost *float64
+		var usage *llmsdk.ModelUsage
+		for inner.Next() {
+			partial := inner.Current()
+			if partial.Cost != nil {
+				cost = partial.Cost
+			}
+			if partial.Usage != nil {
+				usage = partial.Usage
+			}
+			partialCh <- partial
+		}
+
+		if err := inner.Err(); err != nil {
+			errCh <- err
+		}
+		m.guard.record(cost, usage)
+	}()
+
+	return stream.New(partialCh, errCh), nil
+}
@@ -0,0 +1,122 @@
+package llmagent_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+func spendGuardRequest() llmagent.AgentRequest[string] {
+	return llmagent.AgentRequest[string]{
+		Context: "ctx",
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hi"))),
+		},
+	}
+}
+
+func TestSpendGuardTripsCircuitBreaker(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hello")},
+		Cost:    ptr.To(0.5),
+	}))
+	guard, err := llmagent.NewSpendGuard(llmagent.SpendGuardParams{
+		MaxSpend: 0.4,
+		Window:   time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("create guard: %v", err)
+	}
+	agent := llmagent.NewAgent[string]("test_agent", guard.WrapModel(model, nil))
+
+	if _, err := agent.Run(t.Context(), spendGuardRequest()); err != nil {
+		t.Fatalf("expected the first run allowed, got %v", err)
+	}
+	if !guard.Tripped() {
+		t.Fatal("expected the guard tripped once the window's spend crossed the threshold")
+	}
+
+	_, err = agent.Run(t.Context(), spendGuardRequest())
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.AgentErrorKindSpendLimit {
+		t.Fatalf("expected a spend limit error while the guard is tripped, got %v", err)
+	}
+}
+
+func TestSpendGuardDegradesToFallbackModel(t *testing.T) {
+	primary := llmsdktest.NewMockLanguageModel()
+	primary.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("from primary")},
+		Cost:    ptr.To(1.0),
+	}))
+	fallback := llmsdktest.NewMockLanguageModel()
+	fallback.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("from fallback")},
+		Cost:    ptr.To(0.01),
+	}))
+	guard, err := llmagent.NewSpendGuard(llmagent.SpendGuardParams{
+		MaxSpend: 0.5,
+		Window:   time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("create guard: %v", err)
+	}
+	agent := llmagent.NewAgent[string]("test_agent", guard.WrapModel(primary, fallback))
+
+	if _, err := agent.Run(t.Context(), spendGuardRequest()); err != nil {
+		t.Fatalf("expected the first run allowed, got %v", err)
+	}
+
+	response, err := agent.Run(t.Context(), spendGuardRequest())
+	if err != nil {
+		t.Fatalf("expected the run degraded to the fallback model, got %v", err)
+	}
+	if len(response.Content) != 1 || response.Content[0].TextPart.Text != "from fallback" {
+		t.Errorf("expected the fallback model's response, got %v", response.Content)
+	}
+	if len(primary.TrackedGenerateInputs()) != 1 {
+		t.Errorf("expected the primary model not called while tripped, got %d calls", len(primary.TrackedGenerateInputs()))
+	}
+}
+
+func TestSpendGuardChargesStreamedEstimatedSpend(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("hello")}},
+		{Usage: &llmsdk.ModelUsage{InputTokens: 400, OutputTokens: 100}},
+	}))
+	guard, err := llmagent.NewSpendGuard(llmagent.SpendGuardParams{
+		MaxSpend: 0.4,
+		Window:   time.Hour,
+		EstimateCost: func(usage *llmsdk.ModelUsage) float64 {
+			return float64(usage.InputTokens+usage.OutputTokens) * 0.001
+		},
+	})
+	if err != nil {
+		t.Fatalf("create guard: %v", err)
+	}
+	agent := llmagent.NewAgent[string]("test_agent", guard.WrapModel(model, nil))
+
+	stream, err := agent.RunStream(t.Context(), spendGuardRequest())
+	if err != nil {
+		t.Fatalf("create stream: %v", err)
+	}
+	for stream.Next() {
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+
+	if got := guard.Spend(); got != 0.5 {
+		t.Errorf("expected the estimated spend charged to the window, got %v", got)
+	}
+	if !guard.Tripped() {
+		t.Error("expected the guard tripped once the estimated spend crossed the threshold")
+	}
+}
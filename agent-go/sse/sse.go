@@ -0,0 +1,148 @@
+// Package sse streams JSON payloads as Server-Sent Events. It centralises
+// the framing used by the agent server adapters and examples: data frames,
+// named events, incrementing event IDs, error frames, and the [DONE]
+// sentinel.
+package sse
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// SetHeaders writes the standard SSE response headers. Call it before the
+// first frame is sent.
+func SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+}
+
+// Writer emits SSE frames on an http.ResponseWriter. It is safe for
+// concurrent use.
+type Writer struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+
+	mu        sync.Mutex
+	autoFlush bool
+	eventIDs  bool
+	nextID    uint64
+}
+
+// WriterOption customises the SSE writer.
+type WriterOption func(*Writer)
+
+// WithEventIDs emits an incrementing id field with every frame so clients
+// can resume with Last-Event-ID.
+func WithEventIDs() WriterOption {
+	return func(w *Writer) {
+		w.eventIDs = true
+	}
+}
+
+// WithAutoFlush controls whether every frame is flushed immediately. It is
+// enabled by default; disable it to batch frames and call Flush manually.
+func WithAutoFlush(enabled bool) WriterOption {
+	return func(w *Writer) {
+		w.autoFlush = enabled
+	}
+}
+
+// NewWriter wraps the ResponseWriter for SSE framing. It fails when the
+// ResponseWriter does not support flushing.
+func NewWriter(w http.ResponseWriter, options ...WriterOption) (*Writer, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("streaming unsupported by response writer")
+	}
+	writer := &Writer{w: w, flusher: flusher, autoFlush: true}
+	for _, option := range options {
+		option(writer)
+	}
+	return writer, nil
+}
+
+// Send marshals the payload and emits it as a data frame.
+func (w *Writer) Send(payload any) error {
+	return w.SendEvent("", payload)
+}
+
+// SendEvent marshals the payload and emits it as a data frame with an
+// optional event name.
+func (w *Writer) SendEvent(event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return w.write("", event, data)
+}
+
+// SendWithID marshals the payload and emits it as a data frame with an
+// explicit id field, for resumable streams where the caller assigns event IDs
+// that clients echo back via Last-Event-ID.
+func (w *Writer) SendWithID(id string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return w.write(id, "", data)
+}
+
+// SendError emits an error frame in the shape used by the example servers:
+// {"event":"error","error":"..."}.
+func (w *Writer) SendError(errorText string) error {
+	data, err := json.Marshal(map[string]string{"event": "error", "error": errorText})
+	if err != nil {
+		return err
+	}
+	return w.write("", "", data)
+}
+
+// Done emits the [DONE] sentinel that terminates OpenAI-style streams.
+func (w *Writer) Done() error {
+	return w.write("", "", []byte("[DONE]"))
+}
+
+// Flush forces any buffered frames to the client. Only needed when auto
+// flush is disabled.
+func (w *Writer) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flusher.Flush()
+}
+
+func (w *Writer) write(id, event string, data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var frame []byte
+	if id != "" {
+		frame = append(frame, "id: "...)
+		frame = append(frame, id...)
+		frame = append(frame, '\n')
+	} else if w.eventIDs {
+		w.nextID++
+		frame = append(frame, "id: "...)
+		frame = strconv.AppendUint(frame, w.nextID, 10)
+		frame = append(frame, '\n')
+	}
+	if event != "" {
+		frame = append(frame, "event: "...)
+		frame = append(frame, event...)
+		frame = append(frame, '\n')
+	}
+	frame = append(frame, "data: "...)
+	frame = append(frame, data...)
+	frame = append(frame, "\n\n"...)
+
+	if _, err := w.w.Write(frame); err != nil {
+		return err
+	}
+	if w.autoFlush {
+		w.flusher.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package sse_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/sse"
+)
+
+func TestWriterFrames(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writer, err := sse.NewWriter(recorder)
+	if err != nil {
+		t.Fatalf("new writer: %v", err)
+	}
+
+	if err := writer.Send(map[string]string{"event": "partial"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if err := writer.SendEvent("response.completed", map[string]int{"turns": 1}); err != nil {
+		t.Fatalf("send event: %v", err)
+	}
+	if err := writer.SendError("model exploded"); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+	if err := writer.Done(); err != nil {
+		t.Fatalf("done: %v", err)
+	}
+
+	want := "data: {\"event\":\"partial\"}\n\n" +
+		"event: response.completed\ndata: {\"turns\":1}\n\n" +
+		"data: {\"error\":\"model exploded\",\"event\":\"error\"}\n\n" +
+		"data: [DONE]\n\n"
+	if got := recorder.Body.String(); got != want {
+		t.Fatalf("expected frames %q, got %q", want, got)
+	}
+	if !recorder.Flushed {
+		t.Fatal("expected the response to be flushed")
+	}
+}
+
+func TestWriterEventIDs(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writer, err := sse.NewWriter(recorder, sse.WithEventIDs())
+	if err != nil {
+		t.Fatalf("new writer: %v", err)
+	}
+
+	_ = writer.Send("a")
+	_ = writer.Send("b")
+
+	want := "id: 1\ndata: \"a\"\n\nid: 2\ndata: \"b\"\n\n"
+	if got := recorder.Body.String(); got != want {
+		t.Fatalf("expected numbered frames %q, got %q", want, got)
+	}
+}
+
+func TestWriterManualFlush(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writer, err := sse.NewWriter(recorder, sse.WithAutoFlush(false))
+	if err != nil {
+		t.Fatalf("new writer: %v", err)
+	}
+
+	_ = writer.Send("a")
+	if recorder.Flushed {
+		t.Fatal("expected no flush before Flush is called")
+	}
+	writer.Flush()
+	if !recorder.Flushed {
+		t.Fatal("expected Flush to flush the response")
+	}
+}
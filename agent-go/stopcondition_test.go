@@ -0,0 +1,128 @@
+package llmagent_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRun_StopsWhenConditionMet(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	// The model requests a tool call; without the stop condition the run
+	// would call the model again with the tool result.
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{
+			llmsdk.NewToolCallPart("call_1", "submit_answer", json.RawMessage(`{"answer":42}`)),
+		},
+	}))
+
+	tool := NewMockTool[any]("submit_answer", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("recorded")},
+	}, nil)
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "stopper",
+		Model:    model,
+		MaxTurns: 10,
+		Tools:    llmagent.FunctionTools[any](tool),
+		StopCondition: func(state *llmagent.RunState) bool {
+			for _, item := range state.Items() {
+				if item.Tool != nil && item.Tool.ToolName == "submit_answer" {
+					return true
+				}
+			}
+			return false
+		},
+	}, nil)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("answer the question"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if response.Status != llmagent.AgentResponseStatusCompleted {
+		t.Errorf("unexpected status %q", response.Status)
+	}
+	if len(model.TrackedGenerateInputs()) != 1 {
+		t.Errorf("expected the run to stop before a second model call, got %d calls", len(model.TrackedGenerateInputs()))
+	}
+	// The tool still executed and its item is part of the output.
+	var sawToolItem bool
+	for _, item := range response.Output {
+		if item.Tool != nil && item.Tool.ToolName == "submit_answer" {
+			sawToolItem = true
+		}
+	}
+	if !sawToolItem {
+		t.Error("expected the tool item in the run output")
+	}
+	// The final content is the last assistant content, i.e. the tool call turn.
+	if len(response.Content) != 1 || response.Content[0].ToolCallPart == nil {
+		t.Errorf("unexpected final content %+v", response.Content)
+	}
+}
+
+func TestRunStream_StopsWhenConditionMet(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewToolCallPartDelta(
+			llmsdk.WithToolCallPartDeltaToolCallID("call_1"),
+			llmsdk.WithToolCallPartDeltaToolName("submit_answer"),
+			llmsdk.WithToolCallPartDeltaArgs(`{"answer":42}`),
+		)}},
+	}))
+
+	tool := NewMockTool[any]("submit_answer", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("recorded")},
+	}, nil)
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "stopper",
+		Model:    model,
+		MaxTurns: 10,
+		Tools:    llmagent.FunctionTools[any](tool),
+		StopCondition: func(state *llmagent.RunState) bool {
+			for _, item := range state.Items() {
+				if item.Tool != nil && item.Tool.ToolName == "submit_answer" {
+					return true
+				}
+			}
+			return false
+		},
+	}, nil)
+
+	agentStream, err := session.RunStream(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("answer the question"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var response *llmagent.AgentResponse
+	for agentStream.Next() {
+		if event := agentStream.Current(); event.Response != nil {
+			response = event.Response
+		}
+	}
+	if err := agentStream.Err(); err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+	if response == nil {
+		t.Fatal("expected a response event")
+	}
+	if response.Status != llmagent.AgentResponseStatusCompleted {
+		t.Errorf("unexpected status %q", response.Status)
+	}
+	if len(model.TrackedStreamInputs()) != 1 {
+		t.Errorf("expected the run to stop before a second model call, got %d calls", len(model.TrackedStreamInputs()))
+	}
+}
@@ -0,0 +1,66 @@
+package llmagent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// validateStructuredOutput checks the final output text against the agent's
+// JSON response format. It returns nil when the format has no JSON schema or
+// when the output conforms, and a descriptive error when it does not.
+//
+// As with tool parameters, schemas that cannot be compiled are skipped rather
+// than failing the run: a malformed schema is an agent authoring problem and
+// should not reject every response.
+func validateStructuredOutput(format *llmsdk.ResponseFormatOption, content []llmsdk.Part) error {
+	if format == nil || format.JSON == nil {
+		return nil
+	}
+
+	var texts []string
+	for _, part := range content {
+		if part.TextPart != nil {
+			texts = append(texts, part.TextPart.Text)
+		}
+	}
+	text := strings.TrimSpace(strings.Join(texts, ""))
+	if text == "" {
+		return fmt.Errorf("output is empty, expected a JSON value")
+	}
+
+	var instance any
+	if err := json.Unmarshal([]byte(text), &instance); err != nil {
+		return fmt.Errorf("output is not valid JSON: %w", err)
+	}
+
+	if format.JSON.Schema == nil {
+		return nil
+	}
+	data, err := json.Marshal(format.JSON.Schema)
+	if err != nil {
+		return nil
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil
+	}
+	return resolved.Validate(instance)
+}
+
+// structuredOutputCorrectionItem builds the corrective user message appended
+// before rerunning the model when the output failed schema validation.
+func structuredOutputCorrectionItem(validationErr error) AgentItem {
+	text := fmt.Sprintf(
+		"The previous response did not match the required JSON schema: %s. Respond again with only a JSON value that conforms to the schema.",
+		validationErr.Error(),
+	)
+	return NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(text)))
+}
@@ -0,0 +1,152 @@
+package llmagent_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func answerResponseFormat() *llmsdk.ResponseFormatOption {
+	schema := llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"answer": map[string]any{"type": "string"},
+		},
+		"required":             []string{"answer"},
+		"additionalProperties": false,
+	}
+	return llmsdk.NewResponseFormatJSON("answer", nil, &schema)
+}
+
+func newStructuredOutputSession(t *testing.T, model *llmsdktest.MockLanguageModel, retries uint) *llmagent.RunSession[struct{}] {
+	t.Helper()
+	return mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name: "test_agent", Model: model, MaxTurns: 10,
+			ResponseFormat:          answerResponseFormat(),
+			StructuredOutputRetries: retries,
+		},
+		struct{}{},
+	)
+}
+
+func structuredOutputRequest() llmagent.RunSessionRequest {
+	return llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Answer"))),
+	}}
+}
+
+func TestStructuredOutputRetryCorrectsInvalidOutput(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("not json")},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(`{"answer":"42"}`)},
+		}),
+	)
+	session := newStructuredOutputSession(t, model, 2)
+
+	response, err := session.Run(t.Context(), structuredOutputRequest())
+	if err != nil {
+		t.Fatalf("expected the correction rerun to recover, got %v", err)
+	}
+	if response.Text() != `{"answer":"42"}` {
+		t.Errorf("unexpected response text %q", response.Text())
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 model calls, got %d", len(inputs))
+	}
+	lastMessage := inputs[1].Messages[len(inputs[1].Messages)-1]
+	if lastMessage.UserMessage == nil || len(lastMessage.UserMessage.Content) != 1 ||
+		!strings.Contains(lastMessage.UserMessage.Content[0].TextPart.Text, "JSON schema") {
+		t.Errorf("expected the corrective message on the rerun, got %+v", lastMessage)
+	}
+}
+
+func TestStructuredOutputRetrySurfacesFailureAfterAttempts(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(`{"wrong":"field"}`)},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart(`{"wrong":"again"}`)},
+		}),
+	)
+	session := newStructuredOutputSession(t, model, 1)
+
+	_, err := session.Run(t.Context(), structuredOutputRequest())
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.StructuredOutputErrorKind {
+		t.Fatalf("expected a structured output error, got %v", err)
+	}
+	if agentErr.Snapshot == nil {
+		t.Error("expected the partial run preserved in the snapshot")
+	}
+	if len(model.TrackedGenerateInputs()) != 2 {
+		t.Errorf("expected 2 model calls, got %d", len(model.TrackedGenerateInputs()))
+	}
+}
+
+func TestStructuredOutputValidationDisabledByDefault(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("not json")},
+	}))
+	session := newStructuredOutputSession(t, model, 0)
+
+	response, err := session.Run(t.Context(), structuredOutputRequest())
+	if err != nil {
+		t.Fatalf("expected the invalid output passed through, got %v", err)
+	}
+	if response.Text() != "not json" {
+		t.Errorf("unexpected response text %q", response.Text())
+	}
+}
+
+func TestRunStreamStructuredOutputRetryCorrectsInvalidOutput(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(
+		llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+			{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("not json")}},
+		}),
+		llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+			{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta(`{"answer":"42"}`)}},
+		}),
+	)
+	session := newStructuredOutputSession(t, model, 1)
+
+	stream, err := session.RunStream(t.Context(), structuredOutputRequest())
+	if err != nil {
+		t.Fatalf("create stream: %v", err)
+	}
+	var response *llmagent.AgentResponse
+	correctionItems := 0
+	for stream.Next() {
+		event := stream.Current()
+		if event.Item != nil && event.Item.Item.Message != nil && event.Item.Item.Message.UserMessage != nil {
+			correctionItems++
+		}
+		if event.Response != nil {
+			response = event.Response
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("expected the correction rerun to recover, got %v", err)
+	}
+	if response == nil || response.Text() != `{"answer":"42"}` {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	if correctionItems != 1 {
+		t.Errorf("expected 1 corrective message item, got %d", correctionItems)
+	}
+}
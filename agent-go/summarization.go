@@ -0,0 +1,140 @@
+package llmagent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+// summarizationPrompt instructs the summarizer model on how to compact the
+// conversation without losing information the agent still needs.
+const summarizationPrompt = "You are summarizing an agent conversation that is being compacted to fit the " +
+	"context window. Write a concise summary that preserves the user's goals, important facts, " +
+	"decisions made, and any unresolved tasks."
+
+// defaultSummarizationKeepLastItems is the number of most recent run items
+// kept verbatim when no explicit value is configured.
+const defaultSummarizationKeepLastItems = 2
+
+// SummarizationParams configure automatic mid-run summarization. When the
+// estimated token count of the composed input exceeds the threshold, older run
+// items are summarized with the summarizer model and replaced with a single
+// summary item before the next model call.
+type SummarizationParams struct {
+	// Model used to produce the summary. Nil uses the session model.
+	Model llmsdk.LanguageModel
+	// TokenThreshold is the estimated token count of the conversation that
+	// triggers summarization. Zero disables summarization.
+	TokenThreshold int
+	// KeepLastItems is the number of most recent run items kept verbatim.
+	// Zero uses a default of 2.
+	KeepLastItems int
+	// CountTokens measures text for the TokenThreshold. Nil uses a rough
+	// four-characters-per-token heuristic; pass a tokenizer's Count method
+	// (see the tokenizer package) for model-accurate thresholds.
+	CountTokens func(text string) int
+}
+
+// maybeSummarize compacts older run items into a summary item when the
+// conversation exceeds the configured token threshold. It returns the
+// compaction event to announce when summarization happened.
+func (s *RunSession[C]) maybeSummarize(ctx context.Context, state *RunState) (*AgentStreamCompactionEvent, error) {
+	params := s.params.Summarization
+	if params == nil || params.TokenThreshold <= 0 {
+		return nil, nil
+	}
+
+	tokens := 0
+	for _, message := range state.getTurnMessages() {
+		tokens += estimateMessageTokens(message, params.CountTokens)
+	}
+	if tokens < params.TokenThreshold {
+		return nil, nil
+	}
+
+	keep := params.KeepLastItems
+	if keep <= 0 {
+		keep = defaultSummarizationKeepLastItems
+	}
+
+	items := state.Items()
+	cut := len(items) - keep
+	// Never cut between a tool call and its results: include boundary tool
+	// results in the summary instead of orphaning them in the kept window.
+	for cut < len(items) && (items[cut].Tool != nil || (items[cut].Message != nil && items[cut].Message.ToolMessage != nil)) {
+		cut++
+	}
+	if cut <= 0 || cut >= len(items) {
+		return nil, nil
+	}
+
+	model := params.Model
+	if model == nil {
+		model = s.model
+	}
+
+	response, err := model.Generate(ctx, &llmsdk.LanguageModelInput{
+		SystemPrompt: ptr.To(summarizationPrompt),
+		Messages: []llmsdk.Message{
+			llmsdk.NewUserMessage(llmsdk.NewTextPart(renderItemsForSummary(items[:cut]))),
+		},
+	})
+	if err != nil {
+		return nil, NewLanguageModelError(err)
+	}
+	state.recordUsage(response)
+
+	summary := partsToText(response.Content)
+	summaryItem := NewAgentItemMessage(llmsdk.NewUserMessage(
+		llmsdk.NewTextPart("Summary of the conversation so far:\n" + summary),
+	))
+	state.compact(cut, summaryItem)
+
+	return &AgentStreamCompactionEvent{
+		Summary:         summary,
+		ItemsSummarized: cut,
+	}, nil
+}
+
+// renderItemsForSummary renders run items as a plain text transcript for the
+// summarizer model.
+func renderItemsForSummary(items []AgentItem) string {
+	var b strings.Builder
+	for _, item := range items {
+		switch {
+		case item.Message != nil:
+			switch {
+			case item.Message.UserMessage != nil:
+				fmt.Fprintf(&b, "user: %s\n", partsToText(item.Message.UserMessage.Content))
+			case item.Message.AssistantMessage != nil:
+				fmt.Fprintf(&b, "assistant: %s\n", partsToText(item.Message.AssistantMessage.Content))
+			case item.Message.ToolMessage != nil:
+				for _, part := range item.Message.ToolMessage.Content {
+					if part.ToolResultPart != nil && part.ToolResultPart.Result.Function != nil {
+						result := part.ToolResultPart.Result.Function
+						fmt.Fprintf(&b, "tool %s: %s\n", result.Name, partsToText(result.Content))
+					}
+				}
+			}
+		case item.Model != nil:
+			fmt.Fprintf(&b, "assistant: %s\n", partsToText(item.Model.Content))
+		case item.Tool != nil:
+			fmt.Fprintf(&b, "tool %s: %s\n", item.Tool.ToolName, partsToText(item.Tool.Output))
+		}
+	}
+	return b.String()
+}
+
+// partsToText extracts all text content from parts, separated by a space.
+func partsToText(parts []llmsdk.Part) string {
+	var texts []string
+	for _, part := range parts {
+		if part.TextPart != nil && part.TextPart.Text != "" {
+			texts = append(texts, part.TextPart.Text)
+		}
+	}
+	return strings.Join(texts, " ")
+}
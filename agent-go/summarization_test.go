@@ -0,0 +1,126 @@
+package llmagent_test
+
+import (
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRun_SummarizesOlderItemsWhenThresholdExceeded(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("final")},
+	}))
+
+	summarizer := llmsdktest.NewMockLanguageModel()
+	summarizer.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("the user asked about weather twice")},
+	}))
+
+	agent := llmagent.NewAgent[struct{}](
+		"test_agent",
+		model,
+		llmagent.WithSummarization[struct{}](llmagent.SummarizationParams{
+			Model:          summarizer,
+			TokenThreshold: 50,
+			KeepLastItems:  2,
+		}),
+	)
+
+	filler := strings.Repeat("weather question ", 30)
+	_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(filler))),
+			llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart(filler))),
+			llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart("short answer"))),
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("latest question"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	summarizerInputs := summarizer.TrackedGenerateInputs()
+	if len(summarizerInputs) != 1 {
+		t.Fatalf("expected one summarizer call, got %d", len(summarizerInputs))
+	}
+	if summarizerInputs[0].SystemPrompt == nil || !strings.Contains(*summarizerInputs[0].SystemPrompt, "summarizing") {
+		t.Fatalf("expected the summarizer system prompt, got %#v", summarizerInputs[0].SystemPrompt)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected one model call, got %d", len(inputs))
+	}
+
+	messages := inputs[0].Messages
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages after compaction, got %d", len(messages))
+	}
+	if messages[0].UserMessage == nil ||
+		!strings.Contains(messages[0].UserMessage.Content[0].TextPart.Text, "the user asked about weather twice") {
+		t.Fatalf("expected the first message to carry the summary, got %+v", messages[0])
+	}
+	last := messages[len(messages)-1]
+	if last.UserMessage == nil || last.UserMessage.Content[0].TextPart.Text != "latest question" {
+		t.Fatalf("expected the latest user message to be kept, got %+v", last)
+	}
+}
+
+func TestRunStream_EmitsCompactionEvent(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewTextPartDelta("final")}},
+	}))
+
+	summarizer := llmsdktest.NewMockLanguageModel()
+	summarizer.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("compacted summary")},
+	}))
+
+	agent := llmagent.NewAgent[struct{}](
+		"test_agent",
+		model,
+		llmagent.WithSummarization[struct{}](llmagent.SummarizationParams{
+			Model:          summarizer,
+			TokenThreshold: 50,
+		}),
+	)
+
+	filler := strings.Repeat("weather question ", 30)
+	agentStream, err := agent.RunStream(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart(filler))),
+			llmagent.NewAgentItemMessage(llmsdk.NewAssistantMessage(llmsdk.NewTextPart(filler))),
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("latest question"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var compaction *llmagent.AgentStreamCompactionEvent
+	for agentStream.Next() {
+		if event := agentStream.Current(); event.Compaction != nil {
+			compaction = event.Compaction
+		}
+	}
+	if err := agentStream.Err(); err != nil {
+		t.Fatalf("expected no stream error, got %v", err)
+	}
+
+	if compaction == nil {
+		t.Fatal("expected a compaction event")
+	}
+	if compaction.Summary != "compacted summary" {
+		t.Fatalf("unexpected summary: %q", compaction.Summary)
+	}
+	if compaction.ItemsSummarized != 1 {
+		t.Fatalf("expected 1 item summarized, got %d", compaction.ItemsSummarized)
+	}
+}
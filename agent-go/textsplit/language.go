@@ -0,0 +1,61 @@
+package textsplit
+
+import "fmt"
+
+// MarkdownSeparators split markdown at structural boundaries: headings
+// first, then horizontal rules and fenced code blocks, then paragraphs.
+var MarkdownSeparators = []string{
+	"\n# ", "\n## ", "\n### ", "\n#### ", "\n##### ", "\n###### ",
+	"\n---\n", "\n***\n",
+	"\n```",
+	"\n\n", "\n", " ", "",
+}
+
+// NewMarkdownSplitter creates a recursive splitter that prefers markdown
+// structure, so chunks start at headings where possible and code fences are
+// not cut through unless a block alone exceeds the budget.
+func NewMarkdownSplitter(params Params) (*RecursiveSplitter, error) {
+	return NewRecursiveSplitterWithSeparators(params, MarkdownSeparators)
+}
+
+// languageSeparators maps a language name to separators that split source
+// code at declaration and block boundaries before falling back to lines.
+var languageSeparators = map[string][]string{
+	"go": {
+		"\nfunc ", "\ntype ", "\nvar ", "\nconst ",
+		"\n\tif ", "\n\tfor ", "\n\tswitch ",
+		"\n\n", "\n", " ", "",
+	},
+	"python": {
+		"\nclass ", "\ndef ", "\n\tdef ", "\n    def ",
+		"\n\n", "\n", " ", "",
+	},
+	"javascript": {
+		"\nfunction ", "\nclass ", "\nconst ", "\nlet ", "\nvar ",
+		"\nexport ",
+		"\n\n", "\n", " ", "",
+	},
+	"typescript": {
+		"\nfunction ", "\nclass ", "\ninterface ", "\ntype ",
+		"\nconst ", "\nlet ", "\nexport ",
+		"\n\n", "\n", " ", "",
+	},
+	"rust": {
+		"\nfn ", "\nimpl ", "\nstruct ", "\nenum ", "\ntrait ",
+		"\nmod ", "\npub ",
+		"\n\n", "\n", " ", "",
+	},
+	"markdown": MarkdownSeparators,
+}
+
+// NewCodeSplitter creates a recursive splitter with separators tuned for the
+// given language, so chunks break at declarations rather than mid-function.
+// It errors on languages it has no separators for; use
+// NewRecursiveSplitterWithSeparators to supply your own.
+func NewCodeSplitter(language string, params Params) (*RecursiveSplitter, error) {
+	separators, ok := languageSeparators[language]
+	if !ok {
+		return nil, fmt.Errorf("textsplit: no separators for language %q", language)
+	}
+	return NewRecursiveSplitterWithSeparators(params, separators)
+}
@@ -0,0 +1,91 @@
+package textsplit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SentenceSplitter cuts text at sentence boundaries and packs whole
+// sentences into chunks up to the budget, repeating trailing sentences as
+// overlap. A sentence over the budget on its own is cut by the recursive
+// character splitter. Boundary detection is heuristic — a terminator
+// followed by whitespace and an upper-case letter — so unusual abbreviation
+// styles may split early.
+type SentenceSplitter struct {
+	params   Params
+	fallback *RecursiveSplitter
+}
+
+// NewSentenceSplitter creates a sentence splitter.
+func NewSentenceSplitter(params Params) (*SentenceSplitter, error) {
+	resolved, err := params.resolve()
+	if err != nil {
+		return nil, err
+	}
+	fallback, err := NewRecursiveSplitter(resolved)
+	if err != nil {
+		return nil, err
+	}
+	return &SentenceSplitter{params: resolved, fallback: fallback}, nil
+}
+
+func (s *SentenceSplitter) Split(text string) []string {
+	var pieces []string
+	for _, sentence := range splitSentences(text) {
+		if s.params.LenFunc(sentence) <= s.params.ChunkSize {
+			pieces = append(pieces, sentence)
+			continue
+		}
+		pieces = append(pieces, s.fallback.Split(sentence)...)
+	}
+	// Re-attach a trailing space so merged sentences read naturally.
+	for i := range pieces[:max(len(pieces)-1, 0)] {
+		pieces[i] += " "
+	}
+	return cleanChunks(mergeSplits(pieces, s.params))
+}
+
+// sentence terminators; closing quotes and brackets after one still belong
+// to the sentence.
+const terminators = ".!?"
+const closers = "\"')]”’"
+
+// splitSentences cuts text into trimmed sentences. Paragraph breaks always
+// end a sentence.
+func splitSentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+	start := 0
+	flush := func(end int) {
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\n' && i+1 < len(runes) && runes[i+1] == '\n' {
+			flush(i)
+			continue
+		}
+		if !strings.ContainsRune(terminators, runes[i]) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(closers, runes[end]) {
+			end++
+		}
+		// Only break when followed by whitespace and a capitalized start,
+		// so decimals and most abbreviations stay intact.
+		next := end
+		for next < len(runes) && unicode.IsSpace(runes[next]) {
+			next++
+		}
+		if next == end || (next < len(runes) && !unicode.IsUpper(runes[next])) {
+			continue
+		}
+		flush(end)
+		i = end - 1
+	}
+	flush(len(runes))
+	return sentences
+}
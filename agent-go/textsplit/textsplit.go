@@ -0,0 +1,195 @@
+// Package textsplit provides text chunking utilities for knowledge
+// ingestion: a recursive character splitter, markdown- and code-aware
+// variants, and a sentence splitter. Chunks feed the rag package's vector
+// stores and the memory subsystem, where documents must be cut to an
+// embedding-friendly size while keeping semantically related text together.
+package textsplit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Splitter cuts text into chunks. Chunks are trimmed of surrounding
+// whitespace and empty chunks are dropped.
+type Splitter interface {
+	Split(text string) []string
+}
+
+// Params configures a splitter. The zero value uses the package defaults.
+type Params struct {
+	// ChunkSize is the maximum length of a chunk as measured by LenFunc.
+	// Zero or less defaults to DefaultChunkSize.
+	ChunkSize int
+	// ChunkOverlap is how much of the end of a chunk is repeated at the
+	// start of the next one, to keep context across boundaries. It must be
+	// smaller than ChunkSize. Zero or less defaults to DefaultChunkOverlap.
+	ChunkOverlap int
+	// LenFunc measures text length. It defaults to the rune count; plug in
+	// a tokenizer to budget chunks by token count instead.
+	LenFunc func(text string) int
+}
+
+// DefaultChunkSize is the chunk budget when none is configured.
+const DefaultChunkSize = 1000
+
+// DefaultChunkOverlap is the overlap between chunks when none is configured.
+const DefaultChunkOverlap = 200
+
+func (p Params) resolve() (Params, error) {
+	if p.ChunkSize <= 0 {
+		p.ChunkSize = DefaultChunkSize
+	}
+	if p.ChunkOverlap <= 0 {
+		p.ChunkOverlap = DefaultChunkOverlap
+	}
+	if p.ChunkOverlap >= p.ChunkSize {
+		return p, fmt.Errorf("textsplit: chunk overlap %d must be smaller than chunk size %d", p.ChunkOverlap, p.ChunkSize)
+	}
+	if p.LenFunc == nil {
+		p.LenFunc = func(text string) int { return len([]rune(text)) }
+	}
+	return p, nil
+}
+
+// RecursiveSplitter splits on the first separator found in the text and
+// recurses with finer separators into any piece still over the chunk budget,
+// so paragraphs are preferred over lines, lines over words, and only
+// unbreakable runs are cut mid-word. Adjacent small pieces are merged back
+// up to the budget with the configured overlap.
+type RecursiveSplitter struct {
+	params     Params
+	separators []string
+}
+
+// DefaultSeparators split plain text by paragraph, then line, then word.
+// The final empty separator cuts by length as a last resort.
+var DefaultSeparators = []string{"\n\n", "\n", " ", ""}
+
+// NewRecursiveSplitter creates a recursive character splitter using
+// DefaultSeparators.
+func NewRecursiveSplitter(params Params) (*RecursiveSplitter, error) {
+	return NewRecursiveSplitterWithSeparators(params, DefaultSeparators)
+}
+
+// NewRecursiveSplitterWithSeparators creates a recursive splitter trying the
+// given separators in order, coarsest first. The list must end with the
+// empty separator so oversized unbreakable runs can still be cut.
+func NewRecursiveSplitterWithSeparators(params Params, separators []string) (*RecursiveSplitter, error) {
+	resolved, err := params.resolve()
+	if err != nil {
+		return nil, err
+	}
+	if len(separators) == 0 || separators[len(separators)-1] != "" {
+		return nil, fmt.Errorf("textsplit: separators must end with the empty separator")
+	}
+	return &RecursiveSplitter{params: resolved, separators: separators}, nil
+}
+
+func (s *RecursiveSplitter) Split(text string) []string {
+	return cleanChunks(s.split(text, s.separators))
+}
+
+func (s *RecursiveSplitter) split(text string, separators []string) []string {
+	// Pick the coarsest separator that occurs in the text; the trailing
+	// empty separator always matches.
+	separator := separators[len(separators)-1]
+	finer := []string{}
+	for i, candidate := range separators {
+		if candidate == "" || strings.Contains(text, candidate) {
+			separator = candidate
+			finer = separators[i+1:]
+			break
+		}
+	}
+
+	if separator == "" {
+		return s.hardSplit(text)
+	}
+
+	var chunks []string
+	var pending []string
+	for _, piece := range splitKeepSeparator(text, separator) {
+		if s.params.LenFunc(piece) <= s.params.ChunkSize {
+			pending = append(pending, piece)
+			continue
+		}
+		// The piece alone is over budget: flush what we have and recurse
+		// into it with finer separators.
+		chunks = append(chunks, mergeSplits(pending, s.params)...)
+		pending = nil
+		chunks = append(chunks, s.split(piece, finer)...)
+	}
+	return append(chunks, mergeSplits(pending, s.params)...)
+}
+
+// hardSplit cuts text into rune windows of at most ChunkSize, stepping by
+// ChunkSize minus the overlap.
+func (s *RecursiveSplitter) hardSplit(text string) []string {
+	runes := []rune(text)
+	step := s.params.ChunkSize - s.params.ChunkOverlap
+	var chunks []string
+	for start := 0; start < len(runes); start += step {
+		end := min(start+s.params.ChunkSize, len(runes))
+		chunks = append(chunks, string(runes[start:end]))
+		if end == len(runes) {
+			break
+		}
+	}
+	return chunks
+}
+
+// splitKeepSeparator splits text on separator, prepending the separator to
+// the piece that follows it so rejoining pieces reproduces the original
+// text.
+func splitKeepSeparator(text, separator string) []string {
+	parts := strings.Split(text, separator)
+	pieces := make([]string, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 {
+			part = separator + part
+		}
+		if part != "" {
+			pieces = append(pieces, part)
+		}
+	}
+	return pieces
+}
+
+// mergeSplits packs consecutive pieces into chunks up to the budget. When a
+// chunk fills up, the trailing pieces within the overlap budget carry over
+// into the next chunk.
+func mergeSplits(pieces []string, params Params) []string {
+	var chunks []string
+	var window []string
+	length := 0
+	for _, piece := range pieces {
+		pieceLen := params.LenFunc(piece)
+		if length+pieceLen > params.ChunkSize && len(window) > 0 {
+			chunks = append(chunks, strings.Join(window, ""))
+			// Keep trailing pieces as overlap for the next chunk.
+			for len(window) > 0 && (length+pieceLen > params.ChunkSize || length > params.ChunkOverlap) {
+				length -= params.LenFunc(window[0])
+				window = window[1:]
+			}
+		}
+		window = append(window, piece)
+		length += pieceLen
+	}
+	if len(window) > 0 {
+		chunks = append(chunks, strings.Join(window, ""))
+	}
+	return chunks
+}
+
+// cleanChunks trims surrounding whitespace and drops chunks that end up
+// empty.
+func cleanChunks(chunks []string) []string {
+	cleaned := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		if trimmed := strings.TrimSpace(chunk); trimmed != "" {
+			cleaned = append(cleaned, trimmed)
+		}
+	}
+	return cleaned
+}
@@ -0,0 +1,166 @@
+package textsplit_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/textsplit"
+)
+
+func TestRecursiveSplitterPrefersParagraphs(t *testing.T) {
+	splitter, err := textsplit.NewRecursiveSplitter(textsplit.Params{ChunkSize: 40, ChunkOverlap: 5})
+	if err != nil {
+		t.Fatalf("failed to create splitter: %v", err)
+	}
+
+	text := "First paragraph stays whole.\n\nSecond paragraph also stays whole.\n\nThird one too."
+	chunks := splitter.Split(text)
+	if len(chunks) != 3 {
+		t.Fatalf("expected one chunk per paragraph, got %v", chunks)
+	}
+	for _, chunk := range chunks {
+		if strings.Contains(chunk, "\n\n") {
+			t.Errorf("expected chunks split at paragraph boundaries, got %q", chunk)
+		}
+	}
+}
+
+func TestRecursiveSplitterRespectsChunkSize(t *testing.T) {
+	splitter, err := textsplit.NewRecursiveSplitter(textsplit.Params{ChunkSize: 20, ChunkOverlap: 4})
+	if err != nil {
+		t.Fatalf("failed to create splitter: %v", err)
+	}
+
+	text := strings.Repeat("word ", 50) + "unbreakablerunoftextthatexceedsthebudget"
+	for _, chunk := range splitter.Split(text) {
+		if len([]rune(chunk)) > 20 {
+			t.Errorf("chunk over budget: %q (%d runes)", chunk, len([]rune(chunk)))
+		}
+	}
+}
+
+func TestRecursiveSplitterOverlap(t *testing.T) {
+	splitter, err := textsplit.NewRecursiveSplitter(textsplit.Params{ChunkSize: 30, ChunkOverlap: 12})
+	if err != nil {
+		t.Fatalf("failed to create splitter: %v", err)
+	}
+
+	chunks := splitter.Split("alpha beta gamma delta epsilon zeta eta theta")
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %v", chunks)
+	}
+	for i := 1; i < len(chunks); i++ {
+		lastWords := strings.Fields(chunks[i-1])
+		if !strings.HasPrefix(chunks[i], lastWords[len(lastWords)-1]) {
+			t.Errorf("expected chunk %d to overlap the previous one, got %q after %q", i, chunks[i], chunks[i-1])
+		}
+	}
+}
+
+func TestMarkdownSplitterBreaksAtHeadings(t *testing.T) {
+	splitter, err := textsplit.NewMarkdownSplitter(textsplit.Params{ChunkSize: 60, ChunkOverlap: 5})
+	if err != nil {
+		t.Fatalf("failed to create splitter: %v", err)
+	}
+
+	text := "# Title\n\nIntro paragraph under the title.\n\n## Install\n\nRun the installer.\n\n## Usage\n\nCall the function."
+	chunks := splitter.Split(text)
+	var headingStarts int
+	for _, chunk := range chunks {
+		if strings.HasPrefix(chunk, "#") {
+			headingStarts++
+		}
+	}
+	if headingStarts < 2 {
+		t.Errorf("expected chunks to start at headings, got %v", chunks)
+	}
+}
+
+func TestCodeSplitterBreaksAtDeclarations(t *testing.T) {
+	splitter, err := textsplit.NewCodeSplitter("go", textsplit.Params{ChunkSize: 50, ChunkOverlap: 5})
+	if err != nil {
+		t.Fatalf("failed to create splitter: %v", err)
+	}
+
+	source := "package main\n\nfunc add(a, b int) int {\n\treturn a + b\n}\n\nfunc sub(a, b int) int {\n\treturn a - b\n}\n\ntype pair struct {\n\ta, b int\n}"
+	chunks := splitter.Split(source)
+	var declStarts int
+	for _, chunk := range chunks {
+		if strings.HasPrefix(chunk, "func ") || strings.HasPrefix(chunk, "type ") {
+			declStarts++
+		}
+	}
+	if declStarts < 2 {
+		t.Errorf("expected chunks to start at declarations, got %v", chunks)
+	}
+
+	if _, err := textsplit.NewCodeSplitter("cobol", textsplit.Params{}); err == nil {
+		t.Error("expected an error for an unknown language")
+	}
+}
+
+func TestSentenceSplitter(t *testing.T) {
+	splitter, err := textsplit.NewSentenceSplitter(textsplit.Params{ChunkSize: 60, ChunkOverlap: 20})
+	if err != nil {
+		t.Fatalf("failed to create splitter: %v", err)
+	}
+
+	text := "The quick brown fox jumps. It lands on version 2.5 of the fence. The dog barks loudly! Does anyone care? Nobody does."
+	chunks := splitter.Split(text)
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %v", chunks)
+	}
+	for _, chunk := range chunks {
+		if strings.Contains(chunk, "2.5 of") && !strings.Contains(chunk, "version 2.5") {
+			t.Errorf("expected decimals not to break sentences, got %q", chunk)
+		}
+		if len([]rune(chunk)) > 60 {
+			t.Errorf("chunk over budget: %q", chunk)
+		}
+	}
+	if !strings.Contains(chunks[len(chunks)-1], "Nobody does.") {
+		t.Errorf("expected the final sentence retained, got %v", chunks)
+	}
+}
+
+func TestSentenceSplitterOverlapRepeatsSentences(t *testing.T) {
+	splitter, err := textsplit.NewSentenceSplitter(textsplit.Params{ChunkSize: 45, ChunkOverlap: 25})
+	if err != nil {
+		t.Fatalf("failed to create splitter: %v", err)
+	}
+
+	chunks := splitter.Split("One short line here. Another short line. A third short line. A fourth short line.")
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %v", chunks)
+	}
+	joined := strings.Join(chunks, " ")
+	if strings.Count(joined, "Another short line.") < 2 && strings.Count(joined, "A third short line.") < 2 {
+		t.Errorf("expected a sentence repeated as overlap, got %v", chunks)
+	}
+}
+
+func TestParamsValidation(t *testing.T) {
+	if _, err := textsplit.NewRecursiveSplitter(textsplit.Params{ChunkSize: 10, ChunkOverlap: 10}); err == nil {
+		t.Error("expected an error when overlap is not smaller than chunk size")
+	}
+	if _, err := textsplit.NewRecursiveSplitterWithSeparators(textsplit.Params{}, []string{"\n\n", "\n"}); err == nil {
+		t.Error("expected an error when separators do not end with the empty separator")
+	}
+}
+
+func TestLenFuncOverride(t *testing.T) {
+	// Count words instead of runes; chunks must stay within the word budget.
+	splitter, err := textsplit.NewRecursiveSplitter(textsplit.Params{
+		ChunkSize:    4,
+		ChunkOverlap: 1,
+		LenFunc:      func(text string) int { return len(strings.Fields(text)) },
+	})
+	if err != nil {
+		t.Fatalf("failed to create splitter: %v", err)
+	}
+	for _, chunk := range splitter.Split(strings.Repeat("tick tock ", 10)) {
+		if words := len(strings.Fields(chunk)); words > 4 {
+			t.Errorf("chunk over word budget: %q (%d words)", chunk, words)
+		}
+	}
+}
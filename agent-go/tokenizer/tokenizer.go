@@ -0,0 +1,174 @@
+// Package tokenizer lets chunkers, truncation policies, and budgets operate
+// on token counts rather than characters. It ships a fast estimator that
+// needs no encoding tables and a registry where a real tiktoken-compatible
+// encoder can be plugged in per encoding, selected per model with
+// EncodingForModel. The core packages accept a plain counting function, so a
+// Tokenizer's Count method can be passed anywhere a length function is
+// expected (e.g. textsplit.Params.LenFunc or HistoryPolicy.CountTokens).
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Tokenizer counts the tokens of a text under one encoding.
+type Tokenizer interface {
+	Count(text string) int
+}
+
+// CountFunc adapts a plain counting function to the Tokenizer interface.
+type CountFunc func(text string) int
+
+func (f CountFunc) Count(text string) int { return f(text) }
+
+// Encoding names follow tiktoken.
+const (
+	// EncodingCL100K is used by gpt-4, gpt-3.5-turbo, and the
+	// text-embedding-3 models.
+	EncodingCL100K = "cl100k_base"
+	// EncodingO200K is used by gpt-4o and later OpenAI models.
+	EncodingO200K = "o200k_base"
+)
+
+// encodingPrefixes maps model name prefixes to their encoding, longest
+// prefix first within each entry's family.
+var encodingPrefixes = []struct {
+	prefix   string
+	encoding string
+}{
+	{"gpt-4o", EncodingO200K},
+	{"gpt-4.1", EncodingO200K},
+	{"gpt-5", EncodingO200K},
+	{"o1", EncodingO200K},
+	{"o3", EncodingO200K},
+	{"o4", EncodingO200K},
+	{"chatgpt-4o", EncodingO200K},
+	{"gpt-4", EncodingCL100K},
+	{"gpt-3.5", EncodingCL100K},
+	{"text-embedding-3", EncodingCL100K},
+	{"text-embedding-ada-002", EncodingCL100K},
+}
+
+// EncodingForModel returns the tiktoken encoding name for a model ID.
+// Unknown models map to EncodingO200K, the encoding of current models.
+func EncodingForModel(model string) string {
+	for _, entry := range encodingPrefixes {
+		if strings.HasPrefix(model, entry.prefix) {
+			return entry.encoding
+		}
+	}
+	return EncodingO200K
+}
+
+var (
+	registryMu   sync.Mutex
+	constructors = map[string]func() (Tokenizer, error){}
+	instances    = map[string]Tokenizer{}
+)
+
+// Register installs a constructor for an encoding, typically from an init
+// function in the application wiring a tiktoken implementation:
+//
+//	tokenizer.Register(tokenizer.EncodingO200K, func() (tokenizer.Tokenizer, error) {
+//		tk, err := tiktoken.GetEncoding("o200k_base")
+//		if err != nil {
+//			return nil, err
+//		}
+//		return tokenizer.CountFunc(func(text string) int {
+//			return len(tk.Encode(text, nil, nil))
+//		}), nil
+//	})
+//
+// The constructor runs once on first use; the instance is cached.
+func Register(encoding string, constructor func() (Tokenizer, error)) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	constructors[encoding] = constructor
+	delete(instances, encoding)
+}
+
+// New returns the registered tokenizer for an encoding, constructing and
+// caching it on first use. It errors when no constructor is registered.
+func New(encoding string) (Tokenizer, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if instance, ok := instances[encoding]; ok {
+		return instance, nil
+	}
+	constructor, ok := constructors[encoding]
+	if !ok {
+		return nil, fmt.Errorf("tokenizer: no tokenizer registered for encoding %q", encoding)
+	}
+	instance, err := constructor()
+	if err != nil {
+		return nil, fmt.Errorf("tokenizer: construct %q: %w", encoding, err)
+	}
+	instances[encoding] = instance
+	return instance, nil
+}
+
+// ForModel returns the best available tokenizer for a model: the registered
+// encoder for its encoding when one is installed, the estimator otherwise.
+func ForModel(model string) Tokenizer {
+	if instance, err := New(EncodingForModel(model)); err == nil {
+		return instance
+	}
+	return Estimator{}
+}
+
+// Estimator approximates BPE token counts without encoding tables. Runs of
+// letters or digits cost roughly one token per four runes, other symbols one
+// token each, and CJK text one token per rune — close enough for budgets
+// and chunk sizing, within ~15% of tiktoken on typical English prose.
+type Estimator struct{}
+
+func (Estimator) Count(text string) int {
+	tokens := 0
+	run := 0
+	flush := func() {
+		tokens += (run + 3) / 4
+		run = 0
+	}
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			tokens++
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			run++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
+
+// Truncate cuts text to at most maxTokens tokens under the tokenizer,
+// searching for the longest rune prefix that fits. It returns the text
+// unchanged when it already fits.
+func Truncate(t Tokenizer, text string, maxTokens int) string {
+	if maxTokens <= 0 {
+		return ""
+	}
+	if t.Count(text) <= maxTokens {
+		return text
+	}
+	runes := []rune(text)
+	low, high := 0, len(runes)
+	for low < high {
+		mid := (low + high + 1) / 2
+		if t.Count(string(runes[:mid])) <= maxTokens {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return string(runes[:low])
+}
@@ -0,0 +1,109 @@
+package tokenizer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hoangvvo/llm-sdk/agent-go/tokenizer"
+)
+
+func TestEstimatorCount(t *testing.T) {
+	cases := []struct {
+		name     string
+		text     string
+		min, max int
+	}{
+		{"empty", "", 0, 0},
+		{"short word", "Hi", 1, 1},
+		{"sentence", "The quick brown fox jumps over the lazy dog.", 9, 14},
+		{"punctuation", "a,b,c", 5, 5},
+		{"cjk", "こんにちは", 5, 5},
+	}
+	estimator := tokenizer.Estimator{}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := estimator.Count(tc.text)
+			if got < tc.min || got > tc.max {
+				t.Errorf("Count(%q) = %d, want between %d and %d", tc.text, got, tc.min, tc.max)
+			}
+		})
+	}
+}
+
+func TestEncodingForModel(t *testing.T) {
+	cases := map[string]string{
+		"gpt-4o-mini":            tokenizer.EncodingO200K,
+		"gpt-5":                  tokenizer.EncodingO200K,
+		"o3-mini":                tokenizer.EncodingO200K,
+		"gpt-4-turbo":            tokenizer.EncodingCL100K,
+		"gpt-3.5-turbo":          tokenizer.EncodingCL100K,
+		"text-embedding-3-small": tokenizer.EncodingCL100K,
+		"future-model":           tokenizer.EncodingO200K,
+	}
+	for model, want := range cases {
+		if got := tokenizer.EncodingForModel(model); got != want {
+			t.Errorf("EncodingForModel(%q) = %q, want %q", model, got, want)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	constructed := 0
+	tokenizer.Register("test_encoding", func() (tokenizer.Tokenizer, error) {
+		constructed++
+		return tokenizer.CountFunc(func(text string) int { return len(text) }), nil
+	})
+
+	for range 2 {
+		tk, err := tokenizer.New("test_encoding")
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if got := tk.Count("abcd"); got != 4 {
+			t.Errorf("Count = %d, want 4", got)
+		}
+	}
+	if constructed != 1 {
+		t.Errorf("expected the constructor cached after first use, ran %d times", constructed)
+	}
+
+	if _, err := tokenizer.New("missing_encoding"); err == nil {
+		t.Error("expected an error for an unregistered encoding")
+	}
+
+	tokenizer.Register("broken_encoding", func() (tokenizer.Tokenizer, error) {
+		return nil, errors.New("boom")
+	})
+	if _, err := tokenizer.New("broken_encoding"); err == nil {
+		t.Error("expected the constructor error surfaced")
+	}
+}
+
+func TestForModelFallsBackToEstimator(t *testing.T) {
+	tk := tokenizer.ForModel("some-unregistered-model")
+	if tk == nil {
+		t.Fatal("expected a tokenizer")
+	}
+	if got := tk.Count("hello world"); got <= 0 {
+		t.Errorf("expected a positive estimate, got %d", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	words := tokenizer.CountFunc(func(text string) int { return len(strings.Fields(text)) })
+
+	if got := tokenizer.Truncate(words, "one two three", 5); got != "one two three" {
+		t.Errorf("expected text within budget unchanged, got %q", got)
+	}
+	got := tokenizer.Truncate(words, "one two three four five", 3)
+	if count := words.Count(got); count > 3 {
+		t.Errorf("truncated text has %d tokens, want at most 3: %q", count, got)
+	}
+	if !strings.HasPrefix(got, "one two three") {
+		t.Errorf("expected the longest fitting prefix, got %q", got)
+	}
+	if got := tokenizer.Truncate(words, "anything", 0); got != "" {
+		t.Errorf("expected empty text for a zero budget, got %q", got)
+	}
+}
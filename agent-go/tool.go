@@ -25,6 +25,16 @@ type AgentFunctionTool[C any] interface {
 	Execute(ctx context.Context, params json.RawMessage, contextVal C, runState *RunState) (AgentToolResult, error)
 }
 
+// AgentToolCleanup is an optional interface for function tools with external
+// side effects. When the run context is cancelled while the tool is
+// executing, the runtime calls Cleanup with the original call arguments so
+// the tool can undo partial work. Cleanup runs on a context detached from the
+// cancelled run context; its error is ignored because the run is already
+// terminating.
+type AgentToolCleanup[C any] interface {
+	Cleanup(ctx context.Context, params json.RawMessage, contextVal C) error
+}
+
 // AgentTool is the union of agent-executed function tools and provider-hosted
 // web search tools.
 type AgentTool[C any] struct {
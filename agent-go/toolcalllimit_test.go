@@ -0,0 +1,124 @@
+package llmagent_test
+
+import (
+	"errors"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRun_StopsWhenMaxToolCallsExceeded(t *testing.T) {
+	tool := NewMockTool[struct{}]("lookup", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("result")},
+	}, nil)
+
+	model := llmsdktest.NewMockLanguageModel()
+	// The model keeps asking for the same tool, simulating a runaway loop.
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "lookup", map[string]any{})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_2", "lookup", map[string]any{})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_3", "lookup", map[string]any{})},
+	}))
+
+	agent := llmagent.NewAgent(
+		"test_agent",
+		model,
+		llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](tool)),
+		llmagent.WithMaxTurns[struct{}](10),
+		llmagent.WithMaxToolCalls[struct{}](2),
+	)
+
+	_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hello"))),
+		},
+	})
+
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.AgentErrorKindToolCallsLimit {
+		t.Fatalf("expected tool call limit error, got %v", err)
+	}
+	// Two executions plus their model responses made it into the snapshot.
+	if agentErr.Snapshot == nil || len(agentErr.Snapshot.Output) != 5 {
+		t.Fatalf("expected partial results in snapshot, got %#v", agentErr.Snapshot)
+	}
+}
+
+func TestRun_StopsWhenPerToolCallLimitExceeded(t *testing.T) {
+	lookup := NewMockTool[struct{}]("lookup", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("result")},
+	}, nil)
+
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "lookup", map[string]any{})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_2", "lookup", map[string]any{})},
+	}))
+
+	agent := llmagent.NewAgent(
+		"test_agent",
+		model,
+		llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](lookup)),
+		llmagent.WithMaxTurns[struct{}](10),
+		llmagent.WithMaxToolCallsPerTool[struct{}](map[string]uint{"lookup": 1}),
+	)
+
+	_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hello"))),
+		},
+	})
+
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.AgentErrorKindToolCallsLimit {
+		t.Fatalf("expected tool call limit error, got %v", err)
+	}
+	if agentErr.ToolName != "lookup" {
+		t.Errorf("expected tool name on error, got %q", agentErr.ToolName)
+	}
+}
+
+func TestRun_CompletesWithinToolCallLimit(t *testing.T) {
+	tool := NewMockTool[struct{}]("lookup", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("result")},
+	}, nil)
+
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "lookup", map[string]any{})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+
+	agent := llmagent.NewAgent(
+		"test_agent",
+		model,
+		llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](tool)),
+		llmagent.WithMaxTurns[struct{}](10),
+		llmagent.WithMaxToolCalls[struct{}](2),
+	)
+
+	response, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+		Context: struct{}{},
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Hello"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Text() != "done" {
+		t.Errorf("unexpected final text %q", response.Text())
+	}
+}
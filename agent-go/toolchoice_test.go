@@ -0,0 +1,109 @@
+package llmagent_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestRun_ForcesToolChoiceOnFirstTurn(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{
+			llmsdk.NewToolCallPart("call_1", "update_plan", json.RawMessage(`{"steps":["a"]}`)),
+		},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+
+	tool := NewMockTool[any]("update_plan", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("plan recorded")},
+	}, nil)
+
+	params := &llmagent.AgentParams[any]{
+		Name:     "planner",
+		Model:    model,
+		MaxTurns: 10,
+		Tools:    llmagent.FunctionTools[any](tool),
+	}
+	llmagent.WithFirstTurnToolChoice[any](*llmsdk.NewToolChoiceTool("update_plan"))(params)
+
+	session := mustNewRunSession(t, params, nil)
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("plan the task"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Text() != "done" {
+		t.Errorf("unexpected response %q", response.Text())
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 model calls, got %d", len(inputs))
+	}
+	first := inputs[0].ToolChoice
+	if first == nil || first.Tool == nil || first.Tool.ToolName != "update_plan" {
+		t.Errorf("expected forced update_plan tool choice on the first turn, got %+v", first)
+	}
+	if inputs[1].ToolChoice != nil {
+		t.Errorf("expected provider default tool choice on the second turn, got %+v", inputs[1].ToolChoice)
+	}
+}
+
+func TestRun_AppliesToolChoicePerTurn(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{
+			llmsdk.NewToolCallPart("call_1", "lookup", json.RawMessage(`{}`)),
+		},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("answer")},
+	}))
+
+	tool := NewMockTool[any]("lookup", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("found")},
+	}, nil)
+
+	var turns []uint
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "researcher",
+		Model:    model,
+		MaxTurns: 10,
+		Tools:    llmagent.FunctionTools[any](tool),
+		ToolChoice: func(turn uint) *llmsdk.ToolChoiceOption {
+			turns = append(turns, turn)
+			if turn == 0 {
+				return llmsdk.NewToolChoiceRequired()
+			}
+			return llmsdk.NewToolChoiceNone()
+		},
+	}, nil)
+
+	if _, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("look it up"))),
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(turns) != 2 || turns[0] != 0 || turns[1] != 1 {
+		t.Errorf("expected zero-based turn numbers [0 1], got %v", turns)
+	}
+	inputs := model.TrackedGenerateInputs()
+	if inputs[0].ToolChoice == nil || inputs[0].ToolChoice.Required == nil {
+		t.Errorf("expected required tool choice on the first turn, got %+v", inputs[0].ToolChoice)
+	}
+	if inputs[1].ToolChoice == nil || inputs[1].ToolChoice.None == nil {
+		t.Errorf("expected none tool choice on the second turn, got %+v", inputs[1].ToolChoice)
+	}
+}
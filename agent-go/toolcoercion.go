@@ -0,0 +1,75 @@
+package llmagent
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// coerceToolArguments applies lenient type coercion to model-produced tool
+// arguments so slightly-wrong shapes from smaller models still validate:
+// numeric strings become numbers, "true"/"false" strings become booleans, and
+// a single value becomes a one-element array when the schema expects an array.
+// Values that cannot be coerced are left untouched for validation to report.
+func coerceToolArguments(parameters llmsdk.JSONSchema, args json.RawMessage) json.RawMessage {
+	if len(parameters) == 0 || len(args) == 0 {
+		return args
+	}
+	var instance any
+	if err := json.Unmarshal(args, &instance); err != nil {
+		return args
+	}
+	coerced := coerceValue(map[string]any(parameters), instance)
+	data, err := json.Marshal(coerced)
+	if err != nil {
+		return args
+	}
+	return data
+}
+
+func coerceValue(schema map[string]any, value any) any {
+	schemaType, _ := schema["type"].(string)
+	switch schemaType {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for key, raw := range obj {
+			if propertySchema, ok := properties[key].(map[string]any); ok {
+				obj[key] = coerceValue(propertySchema, raw)
+			}
+		}
+		return obj
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			arr = []any{value}
+		}
+		if itemSchema, ok := schema["items"].(map[string]any); ok {
+			for i, element := range arr {
+				arr[i] = coerceValue(itemSchema, element)
+			}
+		}
+		return arr
+	case "integer", "number":
+		if s, ok := value.(string); ok {
+			if n, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return n
+			}
+		}
+		return value
+	case "boolean":
+		if s, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(strings.TrimSpace(s)); err == nil {
+				return b
+			}
+		}
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,137 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// coercionTool records the arguments it actually received after coercion.
+type coercionTool struct {
+	received json.RawMessage
+}
+
+func (t *coercionTool) Name() string        { return "book_table" }
+func (t *coercionTool) Description() string { return "Book a table" }
+func (t *coercionTool) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"guests":   map[string]any{"type": "integer"},
+			"outdoors": map[string]any{"type": "boolean"},
+			"times": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+		},
+		"required": []any{"guests", "outdoors", "times"},
+	}
+}
+
+func (t *coercionTool) Execute(ctx context.Context, params json.RawMessage, contextVal any, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	t.received = params
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("booked")},
+	}, nil
+}
+
+func TestRun_CoercesToolArgumentsWhenEnabled(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	tool := &coercionTool{}
+
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "book_table", map[string]any{
+			"guests":   "5",
+			"outdoors": "true",
+			"times":    "19:00",
+		})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("Booked.")},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:                 "test_agent",
+		Model:                model,
+		Tools:                llmagent.FunctionTools[any](tool),
+		MaxTurns:             10,
+		ToolArgumentCoercion: true,
+	}, nil)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("book a table"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Text() != "Booked." {
+		t.Errorf("unexpected final text %q", response.Text())
+	}
+
+	var received map[string]any
+	if err := json.Unmarshal(tool.received, &received); err != nil {
+		t.Fatalf("tool received invalid JSON: %v", err)
+	}
+	want := map[string]any{
+		"guests":   float64(5),
+		"outdoors": true,
+		"times":    []any{"19:00"},
+	}
+	if diff := cmp.Diff(want, received); diff != "" {
+		t.Errorf("coerced arguments mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRun_LeavesArgumentsAloneWithoutCoercion(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	tool := &coercionTool{}
+
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "book_table", map[string]any{
+			"guests":   "5",
+			"outdoors": "true",
+			"times":    "19:00",
+		})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "test_agent",
+		Model:    model,
+		Tools:    llmagent.FunctionTools[any](tool),
+		MaxTurns: 10,
+	}, nil)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("book a table"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without coercion the wrongly-typed arguments fail validation and the
+	// tool never runs.
+	if tool.received != nil {
+		t.Errorf("expected tool not to run, received %s", tool.received)
+	}
+	var failed *llmagent.AgentItemTool
+	for _, item := range response.Output {
+		if item.Tool != nil {
+			failed = item.Tool
+		}
+	}
+	if failed == nil || failed.Status != llmsdk.ToolResultStatusFailed {
+		t.Errorf("expected failed tool result, got %+v", failed)
+	}
+}
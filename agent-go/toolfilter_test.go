@@ -0,0 +1,92 @@
+package llmagent_test
+
+import (
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func toolNamesFromInput(input llmsdk.LanguageModelInput) []string {
+	names := make([]string, 0, len(input.Tools))
+	for _, tool := range input.Tools {
+		if tool.FunctionTool != nil {
+			names = append(names, tool.FunctionTool.Name)
+		}
+	}
+	return names
+}
+
+func TestRun_FiltersToolsPerRequest(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	search := NewMockTool[any]("search", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("found")},
+	}, nil)
+	delete := NewMockTool[any]("delete_file", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("deleted")},
+	}, nil)
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "test_agent",
+		Model:    model,
+		Tools:    llmagent.FunctionTools[any](search, delete),
+		MaxTurns: 10,
+	}, nil)
+
+	input := []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("hello"))),
+	}
+
+	// EnabledTools restricts the run to the listed names.
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hi")},
+	}))
+	if _, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input:        input,
+		EnabledTools: []string{"search"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// DisabledTools removes the listed names.
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hi")},
+	}))
+	if _, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input:         input,
+		DisabledTools: []string{"delete_file"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An unfiltered run still sees every tool.
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("hi")},
+	}))
+	if _, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: input}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inputs := model.TrackedGenerateInputs()
+	if len(inputs) != 3 {
+		t.Fatalf("expected 3 model calls, got %d", len(inputs))
+	}
+	for i, want := range [][]string{
+		{"search"},
+		{"search"},
+		{"search", "delete_file"},
+	} {
+		got := toolNamesFromInput(inputs[i])
+		if len(got) != len(want) {
+			t.Errorf("call %d: expected tools %v, got %v", i, want, got)
+			continue
+		}
+		for j := range want {
+			if got[j] != want[j] {
+				t.Errorf("call %d: expected tools %v, got %v", i, want, got)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package llmagent
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+	"golang.org/x/sync/errgroup"
+)
+
+// MergeToolkits combines multiple toolkits into one. The merged session
+// concatenates the system prompts of its members and exposes all of their
+// tools. Tools whose names clash across members are namespaced with the
+// position of their toolkit (e.g. `toolkit2_lookup`) so they never collide.
+func MergeToolkits[C any](toolkits ...Toolkit[C]) Toolkit[C] {
+	return &mergedToolkit[C]{toolkits: toolkits}
+}
+
+// ConditionalToolkit enables a toolkit only when the predicate holds for the
+// session context value. When disabled, the toolkit contributes no session,
+// tools, or system prompt.
+func ConditionalToolkit[C any](toolkit Toolkit[C], enabled func(ctx context.Context, contextVal C) bool) Toolkit[C] {
+	return &conditionalToolkit[C]{toolkit: toolkit, enabled: enabled}
+}
+
+// NamespaceToolkit prefixes all function tool names of a toolkit with
+// `<namespace>_` so agents assembled from several toolkits don't collide.
+func NamespaceToolkit[C any](namespace string, toolkit Toolkit[C]) Toolkit[C] {
+	return &namespacedToolkit[C]{namespace: namespace, toolkit: toolkit}
+}
+
+type mergedToolkit[C any] struct {
+	toolkits []Toolkit[C]
+}
+
+func (t *mergedToolkit[C]) CreateSession(ctx context.Context, contextVal C) (ToolkitSession[C], error) {
+	sessions := make([]ToolkitSession[C], len(t.toolkits))
+	cleanupCtx := context.WithoutCancel(ctx)
+	g, ctx := errgroup.WithContext(ctx)
+	for i, toolkit := range t.toolkits {
+		g.Go(func() error {
+			session, err := toolkit.CreateSession(ctx, contextVal)
+			if err != nil {
+				return err
+			}
+			sessions[i] = session
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		var cleanupGroup errgroup.Group
+		for _, session := range sessions {
+			if session != nil {
+				cleanupGroup.Go(func() error { return session.Close(cleanupCtx) })
+			}
+		}
+		_ = cleanupGroup.Wait()
+		return nil, err
+	}
+	return &mergedToolkitSession[C]{sessions: sessions}, nil
+}
+
+type mergedToolkitSession[C any] struct {
+	sessions []ToolkitSession[C]
+}
+
+func (s *mergedToolkitSession[C]) SystemPrompt() *string {
+	var prompts []string
+	for _, session := range s.sessions {
+		if prompt := session.SystemPrompt(); prompt != nil && *prompt != "" {
+			prompts = append(prompts, *prompt)
+		}
+	}
+	if len(prompts) == 0 {
+		return nil
+	}
+	return ptr.To(strings.Join(prompts, "\n"))
+}
+
+func (s *mergedToolkitSession[C]) Tools() []AgentTool[C] {
+	seen := make(map[string]struct{})
+	var tools []AgentTool[C]
+	for i, session := range s.sessions {
+		for _, tool := range session.Tools() {
+			if functionTool := tool.AsFunctionTool(); functionTool != nil {
+				if _, clash := seen[functionTool.Name()]; clash {
+					namespace := "toolkit" + strconv.Itoa(i+1)
+					tool = NewAgentFunctionTool[C](&namespacedTool[C]{namespace: namespace, tool: functionTool})
+				}
+				seen[tool.Name()] = struct{}{}
+			}
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+func (s *mergedToolkitSession[C]) Close(ctx context.Context) error {
+	var g errgroup.Group
+	for _, session := range s.sessions {
+		g.Go(func() error { return session.Close(ctx) })
+	}
+	return g.Wait()
+}
+
+type conditionalToolkit[C any] struct {
+	toolkit Toolkit[C]
+	enabled func(ctx context.Context, contextVal C) bool
+}
+
+func (t *conditionalToolkit[C]) CreateSession(ctx context.Context, contextVal C) (ToolkitSession[C], error) {
+	if t.enabled != nil && !t.enabled(ctx, contextVal) {
+		return &disabledToolkitSession[C]{}, nil
+	}
+	return t.toolkit.CreateSession(ctx, contextVal)
+}
+
+// disabledToolkitSession is the inert session used when a conditional toolkit
+// is disabled for the context value.
+type disabledToolkitSession[C any] struct{}
+
+func (s *disabledToolkitSession[C]) SystemPrompt() *string           { return nil }
+func (s *disabledToolkitSession[C]) Tools() []AgentTool[C]           { return nil }
+func (s *disabledToolkitSession[C]) Close(ctx context.Context) error { return nil }
+
+type namespacedToolkit[C any] struct {
+	namespace string
+	toolkit   Toolkit[C]
+}
+
+func (t *namespacedToolkit[C]) CreateSession(ctx context.Context, contextVal C) (ToolkitSession[C], error) {
+	session, err := t.toolkit.CreateSession(ctx, contextVal)
+	if err != nil {
+		return nil, err
+	}
+	return &namespacedToolkitSession[C]{namespace: t.namespace, session: session}, nil
+}
+
+type namespacedToolkitSession[C any] struct {
+	namespace string
+	session   ToolkitSession[C]
+}
+
+func (s *namespacedToolkitSession[C]) SystemPrompt() *string {
+	return s.session.SystemPrompt()
+}
+
+func (s *namespacedToolkitSession[C]) Tools() []AgentTool[C] {
+	tools := s.session.Tools()
+	namespaced := make([]AgentTool[C], 0, len(tools))
+	for _, tool := range tools {
+		if functionTool := tool.AsFunctionTool(); functionTool != nil {
+			tool = NewAgentFunctionTool[C](&namespacedTool[C]{namespace: s.namespace, tool: functionTool})
+		}
+		namespaced = append(namespaced, tool)
+	}
+	return namespaced
+}
+
+func (s *namespacedToolkitSession[C]) Close(ctx context.Context) error {
+	return s.session.Close(ctx)
+}
+
+// namespacedTool renames a function tool to `<namespace>_<name>` while
+// delegating everything else to the underlying tool.
+type namespacedTool[C any] struct {
+	namespace string
+	tool      AgentFunctionTool[C]
+}
+
+func (t *namespacedTool[C]) Name() string {
+	return t.namespace + "_" + t.tool.Name()
+}
+
+func (t *namespacedTool[C]) Description() string {
+	return t.tool.Description()
+}
+
+func (t *namespacedTool[C]) Parameters() llmsdk.JSONSchema {
+	return t.tool.Parameters()
+}
+
+func (t *namespacedTool[C]) Execute(ctx context.Context, params json.RawMessage, contextVal C, runState *RunState) (AgentToolResult, error) {
+	return t.tool.Execute(ctx, params, contextVal, runState)
+}
@@ -0,0 +1,137 @@
+package llmagent_test
+
+import (
+	"context"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/ptr"
+)
+
+func TestMergeToolkits(t *testing.T) {
+	t.Run("combines prompts and tools and namespaces clashes", func(t *testing.T) {
+		sessionA := &mockToolkitSession[struct{}]{
+			systemPrompt: ptr.To("prompt a"),
+			tools: []llmagent.AgentTool[struct{}]{
+				llmagent.NewAgentFunctionTool[struct{}](NewMockTool[struct{}]("lookup", llmagent.AgentToolResult{}, nil)),
+				llmagent.NewAgentFunctionTool[struct{}](NewMockTool[struct{}]("alpha_only", llmagent.AgentToolResult{}, nil)),
+			},
+		}
+		sessionB := &mockToolkitSession[struct{}]{
+			systemPrompt: ptr.To("prompt b"),
+			tools: []llmagent.AgentTool[struct{}]{
+				llmagent.NewAgentFunctionTool[struct{}](NewMockTool[struct{}]("lookup", llmagent.AgentToolResult{}, nil)),
+			},
+		}
+
+		merged := llmagent.MergeToolkits[struct{}](
+			&mockToolkit[struct{}]{createFn: func(context.Context, struct{}) (llmagent.ToolkitSession[struct{}], error) {
+				return sessionA, nil
+			}},
+			&mockToolkit[struct{}]{createFn: func(context.Context, struct{}) (llmagent.ToolkitSession[struct{}], error) {
+				return sessionB, nil
+			}},
+		)
+
+		session, err := merged.CreateSession(t.Context(), struct{}{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if prompt := session.SystemPrompt(); prompt == nil || *prompt != "prompt a\nprompt b" {
+			t.Fatalf("unexpected merged prompt: %#v", prompt)
+		}
+
+		var names []string
+		for _, tool := range session.Tools() {
+			names = append(names, tool.Name())
+		}
+		want := []string{"lookup", "alpha_only", "toolkit2_lookup"}
+		if len(names) != len(want) {
+			t.Fatalf("expected %v, got %v", want, names)
+		}
+		for i := range want {
+			if names[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, names)
+			}
+		}
+
+		if err := session.Close(t.Context()); err != nil {
+			t.Fatalf("expected no close error, got %v", err)
+		}
+		if sessionA.closeCalls != 1 || sessionB.closeCalls != 1 {
+			t.Fatalf("expected both sessions closed, got %d and %d", sessionA.closeCalls, sessionB.closeCalls)
+		}
+	})
+}
+
+func TestConditionalToolkit(t *testing.T) {
+	toolkit := &mockToolkit[string]{createFn: func(context.Context, string) (llmagent.ToolkitSession[string], error) {
+		return &mockToolkitSession[string]{
+			systemPrompt: ptr.To("enabled prompt"),
+			tools: []llmagent.AgentTool[string]{
+				llmagent.NewAgentFunctionTool[string](NewMockTool[string]("lookup", llmagent.AgentToolResult{}, nil)),
+			},
+		}, nil
+	}}
+
+	conditional := llmagent.ConditionalToolkit[string](toolkit, func(_ context.Context, contextVal string) bool {
+		return contextVal == "admin"
+	})
+
+	enabled, err := conditional.CreateSession(t.Context(), "admin")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(enabled.Tools()) != 1 || enabled.SystemPrompt() == nil {
+		t.Fatal("expected the toolkit to be enabled for admin")
+	}
+
+	disabled, err := conditional.CreateSession(t.Context(), "guest")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(disabled.Tools()) != 0 || disabled.SystemPrompt() != nil {
+		t.Fatal("expected the toolkit to be disabled for guest")
+	}
+	if err := disabled.Close(t.Context()); err != nil {
+		t.Fatalf("expected no close error, got %v", err)
+	}
+}
+
+func TestNamespaceToolkit(t *testing.T) {
+	toolkit := &mockToolkit[struct{}]{createFn: func(context.Context, struct{}) (llmagent.ToolkitSession[struct{}], error) {
+		return &mockToolkitSession[struct{}]{
+			tools: []llmagent.AgentTool[struct{}]{
+				llmagent.NewAgentFunctionTool[struct{}](NewMockTool[struct{}]("lookup", llmagent.AgentToolResult{
+					Content: []llmsdk.Part{llmsdk.NewTextPart("found")},
+				}, nil)),
+			},
+		}, nil
+	}}
+
+	namespaced := llmagent.NamespaceToolkit[struct{}]("kb", toolkit)
+
+	session, err := namespaced.CreateSession(t.Context(), struct{}{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tools := session.Tools()
+	if len(tools) != 1 {
+		t.Fatalf("expected one tool, got %d", len(tools))
+	}
+	if tools[0].Name() != "kb_lookup" {
+		t.Fatalf("expected namespaced name kb_lookup, got %q", tools[0].Name())
+	}
+
+	functionTool := tools[0].AsFunctionTool()
+	result, err := functionTool.Execute(t.Context(), nil, struct{}{}, nil)
+	if err != nil {
+		t.Fatalf("expected no execute error, got %v", err)
+	}
+	if len(result.Content) != 1 || result.Content[0].TextPart.Text != "found" {
+		t.Fatalf("expected delegated execution result, got %+v", result)
+	}
+}
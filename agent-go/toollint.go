@@ -0,0 +1,145 @@
+package llmagent
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// lintFunctionTools checks the registered function tools when a session is
+// created, so schema authoring mistakes fail fast with an actionable message
+// instead of surfacing as an opaque provider 400 mid-run. All problems are
+// reported at once.
+func lintFunctionTools[C any](tools []AgentTool[C]) error {
+	var errs []error
+	seen := map[string]bool{}
+	for _, tool := range tools {
+		if tool.FunctionTool == nil {
+			continue
+		}
+		name := tool.FunctionTool.Name()
+		if name == "" {
+			errs = append(errs, errors.New("tool has an empty name"))
+			continue
+		}
+		if seen[name] {
+			errs = append(errs, fmt.Errorf("tool %s is registered more than once", name))
+			continue
+		}
+		seen[name] = true
+		if err := lintToolSchema(name, tool.FunctionTool.Parameters()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lintToolSchema checks structural requirements every provider shares: the
+// parameters schema must describe an object, and required names must exist in
+// properties.
+func lintToolSchema(name string, parameters llmsdk.JSONSchema) error {
+	if len(parameters) == 0 {
+		return nil
+	}
+	if schemaType, ok := parameters["type"].(string); ok && schemaType != "object" {
+		return fmt.Errorf("tool %s: parameters schema has type %q, providers require \"object\"", name, schemaType)
+	}
+	properties, _ := parameters["properties"].(map[string]any)
+	required, _ := parameters["required"].([]any)
+	for _, entry := range required {
+		requiredName, ok := entry.(string)
+		if !ok {
+			return fmt.Errorf("tool %s: required entries must be property name strings, got %T", name, entry)
+		}
+		if _, ok := properties[requiredName]; !ok {
+			return fmt.Errorf("tool %s: required property %q is not declared in properties", name, requiredName)
+		}
+	}
+	return nil
+}
+
+// strictUnsupportedKeywords are JSON Schema keywords that provider strict
+// modes reject.
+var strictUnsupportedKeywords = []string{
+	"if", "then", "else", "not",
+	"dependentRequired", "dependentSchemas",
+	"patternProperties", "unevaluatedProperties", "propertyNames",
+	"minProperties", "maxProperties",
+}
+
+// LintStrictToolSchema reports problems that make the parameters schema
+// unusable with provider strict modes: unsupported keywords, object schemas
+// without additionalProperties set to false, and optional properties (strict
+// modes require every property listed in required). It returns one actionable
+// message per problem, or nil when the schema is strict-compatible. Use it
+// from tool authoring tests before enabling strict structured outputs.
+func LintStrictToolSchema(name string, parameters llmsdk.JSONSchema) []string {
+	return lintStrictValue(fmt.Sprintf("tool %s: parameters", name), map[string]any(parameters))
+}
+
+func lintStrictValue(path string, value any) []string {
+	schema, ok := value.(map[string]any)
+	if !ok || len(schema) == 0 {
+		return nil
+	}
+
+	var problems []string
+	for _, keyword := range strictUnsupportedKeywords {
+		if _, ok := schema[keyword]; ok {
+			problems = append(problems, fmt.Sprintf("%s: keyword %q is not supported in strict mode", path, keyword))
+		}
+	}
+
+	if schemaType, _ := schema["type"].(string); schemaType == "object" {
+		if additional, ok := schema["additionalProperties"]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: strict mode requires \"additionalProperties\": false on object schemas", path))
+		} else if allowed, isBool := additional.(bool); !isBool || allowed {
+			problems = append(problems, fmt.Sprintf("%s: strict mode requires \"additionalProperties\" to be false", path))
+		}
+
+		properties, _ := schema["properties"].(map[string]any)
+		requiredSet := map[string]bool{}
+		if required, ok := schema["required"].([]any); ok {
+			for _, entry := range required {
+				if requiredName, ok := entry.(string); ok {
+					requiredSet[requiredName] = true
+				}
+			}
+		}
+		names := make([]string, 0, len(properties))
+		for propertyName := range properties {
+			names = append(names, propertyName)
+		}
+		sort.Strings(names)
+		for _, propertyName := range names {
+			if !requiredSet[propertyName] {
+				problems = append(problems, fmt.Sprintf("%s: property %q must be listed in required (strict mode rejects optional properties; use a nullable type instead)", path, propertyName))
+			}
+			problems = append(problems, lintStrictValue(fmt.Sprintf("%s.properties.%s", path, propertyName), properties[propertyName])...)
+		}
+	}
+
+	if items, ok := schema["items"]; ok {
+		problems = append(problems, lintStrictValue(path+".items", items)...)
+	}
+	for _, combinator := range []string{"anyOf", "allOf", "oneOf"} {
+		if branches, ok := schema[combinator].([]any); ok {
+			for i, branch := range branches {
+				problems = append(problems, lintStrictValue(fmt.Sprintf("%s.%s[%d]", path, combinator, i), branch)...)
+			}
+		}
+	}
+	if defs, ok := schema["$defs"].(map[string]any); ok {
+		names := make([]string, 0, len(defs))
+		for defName := range defs {
+			names = append(names, defName)
+		}
+		sort.Strings(names)
+		for _, defName := range names {
+			problems = append(problems, lintStrictValue(fmt.Sprintf("%s.$defs.%s", path, defName), defs[defName])...)
+		}
+	}
+	return problems
+}
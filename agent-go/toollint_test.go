@@ -0,0 +1,142 @@
+package llmagent_test
+
+import (
+	"strings"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// lintSchemaTool exposes an arbitrary parameters schema for lint tests.
+type lintSchemaTool struct {
+	*MockAgentTool[any]
+	parameters llmsdk.JSONSchema
+}
+
+func (t *lintSchemaTool) Parameters() llmsdk.JSONSchema { return t.parameters }
+
+func newSchemaTool(name string, parameters llmsdk.JSONSchema) *lintSchemaTool {
+	return &lintSchemaTool{
+		MockAgentTool: NewMockTool[any](name, llmagent.AgentToolResult{}, nil),
+		parameters:    parameters,
+	}
+}
+
+func TestSessionRejectsDuplicateToolNames(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	_, err := llmagent.NewRunSession(t.Context(), &llmagent.AgentParams[any]{
+		Name:  "assistant",
+		Model: model,
+		Tools: []llmagent.AgentTool[any]{
+			llmagent.NewAgentFunctionTool[any](NewMockTool[any]("get_weather", llmagent.AgentToolResult{}, nil)),
+			llmagent.NewAgentFunctionTool[any](NewMockTool[any]("get_weather", llmagent.AgentToolResult{}, nil)),
+		},
+	}, nil)
+	if err == nil || !strings.Contains(err.Error(), "registered more than once") {
+		t.Fatalf("expected a duplicate tool name error, got %v", err)
+	}
+}
+
+func TestSessionRejectsInvalidToolSchemas(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+
+	cases := []struct {
+		name       string
+		parameters llmsdk.JSONSchema
+		want       string
+	}{
+		{
+			name:       "non-object type",
+			parameters: llmsdk.JSONSchema{"type": "array"},
+			want:       `providers require "object"`,
+		},
+		{
+			name: "required missing from properties",
+			parameters: llmsdk.JSONSchema{
+				"type":       "object",
+				"properties": map[string]any{"city": map[string]any{"type": "string"}},
+				"required":   []any{"city", "country"},
+			},
+			want: `required property "country" is not declared`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := llmagent.NewRunSession(t.Context(), &llmagent.AgentParams[any]{
+				Name:  "assistant",
+				Model: model,
+				Tools: []llmagent.AgentTool[any]{
+					llmagent.NewAgentFunctionTool[any](newSchemaTool("get_weather", tc.parameters)),
+				},
+			}, nil)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestSessionAcceptsValidToolSchemas(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	session, err := llmagent.NewRunSession(t.Context(), &llmagent.AgentParams[any]{
+		Name:  "assistant",
+		Model: model,
+		Tools: []llmagent.AgentTool[any]{
+			llmagent.NewAgentFunctionTool[any](newSchemaTool("get_weather", llmsdk.JSONSchema{
+				"type":       "object",
+				"properties": map[string]any{"city": map[string]any{"type": "string"}},
+				"required":   []any{"city"},
+			})),
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := session.Close(t.Context()); err != nil {
+		t.Fatalf("failed to close session: %v", err)
+	}
+}
+
+func TestLintStrictToolSchema(t *testing.T) {
+	problems := llmagent.LintStrictToolSchema("get_weather", llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"city": map[string]any{"type": "string"},
+			"unit": map[string]any{"type": "string"},
+		},
+		"required": []any{"city"},
+		"not":      map[string]any{},
+	})
+
+	wants := []string{
+		`keyword "not" is not supported in strict mode`,
+		`"additionalProperties": false`,
+		`property "unit" must be listed in required`,
+	}
+	for _, want := range wants {
+		found := false
+		for _, problem := range problems {
+			if strings.Contains(problem, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected a problem containing %q, got %v", want, problems)
+		}
+	}
+
+	clean := llmagent.LintStrictToolSchema("get_weather", llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"city": map[string]any{"type": "string"},
+		},
+		"required":             []any{"city"},
+		"additionalProperties": false,
+	})
+	if len(clean) != 0 {
+		t.Errorf("expected no problems for a strict-compatible schema, got %v", clean)
+	}
+}
@@ -0,0 +1,144 @@
+package llmagent
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// ToolErrorBehavior selects what happens when a tool execution still fails
+// after its configured retries and no fallback result applies.
+type ToolErrorBehavior string
+
+const (
+	// ToolErrorAbort fails the run with a tool execution error. This is the
+	// default.
+	ToolErrorAbort ToolErrorBehavior = "abort"
+	// ToolErrorReport converts the error into a failed tool result visible to
+	// the model so it can recover, e.g. by retrying with different arguments
+	// or calling another tool.
+	ToolErrorReport ToolErrorBehavior = "report"
+)
+
+// ToolExecutionOptions configures runtime guards applied to a registered tool.
+type ToolExecutionOptions struct {
+	// Timeout bounds a single execution attempt. Zero means no timeout.
+	Timeout time.Duration
+	// Retries is the number of additional attempts after a failed execution.
+	Retries int
+	// FallbackResult is returned as the tool result when every attempt fails.
+	// When nil, OnError decides what happens with the last error.
+	FallbackResult *AgentToolResult
+	// OnError selects what happens when every attempt fails and no fallback
+	// result is configured, overriding the agent-wide ToolErrorBehavior.
+	// Empty falls back to the agent-wide behavior.
+	OnError ToolErrorBehavior
+}
+
+// WithToolOptions applies execution timeouts, bounded retries, and an optional
+// fallback result to the registered tool with the given name, so one hung or
+// flaky tool does not stall the whole run.
+func WithToolOptions[C any](name string, options ToolExecutionOptions) AgentParamsOption[C] {
+	return func(p *AgentParams[C]) {
+		if p.ToolOptions == nil {
+			p.ToolOptions = map[string]ToolExecutionOptions{}
+		}
+		p.ToolOptions[name] = options
+	}
+}
+
+// failedToolExecutionResult converts a tool execution error into a failed
+// tool result so the model sees what went wrong, used under ToolErrorReport.
+func failedToolExecutionResult(toolCall *llmsdk.ToolCallPart, execErr error) AgentItem {
+	call := toolCall.Call.Function
+	body, err := json.Marshal(map[string]string{
+		"error":   "tool_execution_error",
+		"message": execErr.Error(),
+	})
+	if err != nil {
+		body = []byte(`{"error":"tool_execution_error"}`)
+	}
+	return NewAgentItemTool(
+		toolCall.ToolCallID,
+		call.Name,
+		call.Args,
+		[]llmsdk.Part{llmsdk.NewTextPart(string(body))},
+		llmsdk.ToolResultStatusFailed,
+	)
+}
+
+// guardedTool decorates an AgentFunctionTool with timeout, retry, and
+// fallback behavior.
+type guardedTool[C any] struct {
+	tool    AgentFunctionTool[C]
+	options ToolExecutionOptions
+}
+
+func (t *guardedTool[C]) Name() string {
+	return t.tool.Name()
+}
+
+func (t *guardedTool[C]) Description() string {
+	return t.tool.Description()
+}
+
+func (t *guardedTool[C]) Parameters() llmsdk.JSONSchema {
+	return t.tool.Parameters()
+}
+
+func (t *guardedTool[C]) Execute(ctx context.Context, params json.RawMessage, contextVal C, runState *RunState) (AgentToolResult, error) {
+	var lastErr error
+	for attempt := 0; attempt <= t.options.Retries; attempt++ {
+		result, err := t.executeOnce(ctx, params, contextVal, runState)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		// Do not retry when the run itself was cancelled.
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if t.options.FallbackResult != nil && ctx.Err() == nil {
+		return *t.options.FallbackResult, nil
+	}
+	return AgentToolResult{}, lastErr
+}
+
+// Cleanup forwards the cancellation hook to the wrapped tool when it opts in.
+func (t *guardedTool[C]) Cleanup(ctx context.Context, params json.RawMessage, contextVal C) error {
+	if cleaner, ok := t.tool.(AgentToolCleanup[C]); ok {
+		return cleaner.Cleanup(ctx, params, contextVal)
+	}
+	return nil
+}
+
+// executeOnce runs a single attempt. The attempt is raced against the timeout
+// so a tool that ignores context cancellation still cannot stall the run.
+func (t *guardedTool[C]) executeOnce(ctx context.Context, params json.RawMessage, contextVal C, runState *RunState) (AgentToolResult, error) {
+	if t.options.Timeout <= 0 {
+		return t.tool.Execute(ctx, params, contextVal, runState)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.options.Timeout)
+	defer cancel()
+
+	type executeResult struct {
+		result AgentToolResult
+		err    error
+	}
+	resultCh := make(chan executeResult, 1)
+	go func() {
+		result, err := t.tool.Execute(ctx, params, contextVal, runState)
+		resultCh <- executeResult{result: result, err: err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.result, result.err
+	case <-ctx.Done():
+		return AgentToolResult{}, ctx.Err()
+	}
+}
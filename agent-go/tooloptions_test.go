@@ -0,0 +1,202 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func TestToolOptions(t *testing.T) {
+	t.Run("retries failed executions up to the configured bound", func(t *testing.T) {
+		attempts := 0
+		tool := NewMockTool[struct{}]("flaky", llmagent.AgentToolResult{}, func(context.Context, json.RawMessage, struct{}, *llmagent.RunState) (llmagent.AgentToolResult, error) {
+			attempts++
+			if attempts < 3 {
+				return llmagent.AgentToolResult{}, errors.New("transient failure")
+			}
+			return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("ok")}}, nil
+		})
+
+		model := llmsdktest.NewMockLanguageModel()
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "flaky", map[string]any{})},
+		}))
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		}))
+
+		agent := llmagent.NewAgent(
+			"test_agent",
+			model,
+			llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](tool)),
+			llmagent.WithToolOptions[struct{}]("flaky", llmagent.ToolExecutionOptions{Retries: 2}),
+		)
+
+		response, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+			Context: struct{}{},
+			Input: []llmagent.AgentItem{
+				llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go"))),
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected retries to recover, got %v", err)
+		}
+		if attempts != 3 {
+			t.Fatalf("expected 3 attempts, got %d", attempts)
+		}
+		if response.Status != llmagent.AgentResponseStatusCompleted {
+			t.Fatalf("expected completed status, got %q", response.Status)
+		}
+	})
+
+	t.Run("returns fallback result when every attempt fails", func(t *testing.T) {
+		tool := NewMockTool[struct{}]("broken", llmagent.AgentToolResult{}, func(context.Context, json.RawMessage, struct{}, *llmagent.RunState) (llmagent.AgentToolResult, error) {
+			return llmagent.AgentToolResult{}, errors.New("always fails")
+		})
+
+		model := llmsdktest.NewMockLanguageModel()
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "broken", map[string]any{})},
+		}))
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		}))
+
+		agent := llmagent.NewAgent(
+			"test_agent",
+			model,
+			llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](tool)),
+			llmagent.WithToolOptions[struct{}]("broken", llmagent.ToolExecutionOptions{
+				Retries: 1,
+				FallbackResult: &llmagent.AgentToolResult{
+					Content: []llmsdk.Part{llmsdk.NewTextPart("tool unavailable")},
+					IsError: true,
+				},
+			}),
+		)
+
+		response, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+			Context: struct{}{},
+			Input: []llmagent.AgentItem{
+				llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go"))),
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected fallback result instead of error, got %v", err)
+		}
+		if len(response.Output) < 2 || response.Output[1].Tool == nil {
+			t.Fatalf("expected tool item in output, got %#v", response.Output)
+		}
+		if response.Output[1].Tool.Status != llmsdk.ToolResultStatusFailed {
+			t.Fatalf("expected failed tool status from fallback, got %q", response.Output[1].Tool.Status)
+		}
+	})
+
+	t.Run("reports errors to the model when the agent opts in", func(t *testing.T) {
+		tool := NewMockTool[struct{}]("broken", llmagent.AgentToolResult{}, func(context.Context, json.RawMessage, struct{}, *llmagent.RunState) (llmagent.AgentToolResult, error) {
+			return llmagent.AgentToolResult{}, errors.New("upstream unavailable")
+		})
+
+		model := llmsdktest.NewMockLanguageModel()
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "broken", map[string]any{})},
+		}))
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		}))
+
+		agent := llmagent.NewAgent(
+			"test_agent",
+			model,
+			llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](tool)),
+			llmagent.WithToolErrorBehavior[struct{}](llmagent.ToolErrorReport),
+		)
+
+		response, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+			Context: struct{}{},
+			Input: []llmagent.AgentItem{
+				llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go"))),
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected the error reported to the model instead of aborting, got %v", err)
+		}
+		if len(response.Output) < 2 || response.Output[1].Tool == nil {
+			t.Fatalf("expected tool item in output, got %#v", response.Output)
+		}
+		toolItem := response.Output[1].Tool
+		if toolItem.Status != llmsdk.ToolResultStatusFailed {
+			t.Fatalf("expected failed tool status, got %q", toolItem.Status)
+		}
+		if len(toolItem.Output) != 1 || toolItem.Output[0].TextPart == nil ||
+			!strings.Contains(toolItem.Output[0].TextPart.Text, "upstream unavailable") {
+			t.Fatalf("expected the error message in the tool output, got %#v", toolItem.Output)
+		}
+	})
+
+	t.Run("per-tool OnError overrides the agent-wide behavior", func(t *testing.T) {
+		tool := NewMockTool[struct{}]("critical", llmagent.AgentToolResult{}, func(context.Context, json.RawMessage, struct{}, *llmagent.RunState) (llmagent.AgentToolResult, error) {
+			return llmagent.AgentToolResult{}, errors.New("must not be swallowed")
+		})
+
+		model := llmsdktest.NewMockLanguageModel()
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "critical", map[string]any{})},
+		}))
+
+		agent := llmagent.NewAgent(
+			"test_agent",
+			model,
+			llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](tool)),
+			llmagent.WithToolErrorBehavior[struct{}](llmagent.ToolErrorReport),
+			llmagent.WithToolOptions[struct{}]("critical", llmagent.ToolExecutionOptions{OnError: llmagent.ToolErrorAbort}),
+		)
+
+		_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+			Context: struct{}{},
+			Input: []llmagent.AgentItem{
+				llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go"))),
+			},
+		})
+		var agentErr *llmagent.AgentError
+		if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.ToolExecutionErrorKind {
+			t.Fatalf("expected a tool execution error, got %v", err)
+		}
+	})
+
+	t.Run("times out a hung tool", func(t *testing.T) {
+		tool := NewMockTool[struct{}]("hung", llmagent.AgentToolResult{}, func(ctx context.Context, _ json.RawMessage, _ struct{}, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+			<-ctx.Done()
+			return llmagent.AgentToolResult{}, ctx.Err()
+		})
+
+		model := llmsdktest.NewMockLanguageModel()
+		model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "hung", map[string]any{})},
+		}))
+
+		agent := llmagent.NewAgent(
+			"test_agent",
+			model,
+			llmagent.WithTools[struct{}](llmagent.NewAgentFunctionTool[struct{}](tool)),
+			llmagent.WithToolOptions[struct{}]("hung", llmagent.ToolExecutionOptions{Timeout: 10 * time.Millisecond}),
+		)
+
+		_, err := agent.Run(t.Context(), llmagent.AgentRequest[struct{}]{
+			Context: struct{}{},
+			Input: []llmagent.AgentItem{
+				llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go"))),
+			},
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+	})
+}
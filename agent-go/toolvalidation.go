@@ -0,0 +1,67 @@
+package llmagent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+// validateToolArguments checks model-produced arguments against the tool's
+// declared parameters schema before the tool executes. It returns nil when the
+// arguments conform, and a descriptive error when they do not, so the failure
+// can be reported back to the model as a failed tool result instead of
+// crashing the tool.
+//
+// Schemas that cannot be compiled are skipped rather than failing the call: a
+// malformed schema is a tool authoring problem and should not reject every
+// model request.
+func validateToolArguments(parameters llmsdk.JSONSchema, args json.RawMessage) error {
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(parameters)
+	if err != nil {
+		return nil
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil
+	}
+
+	var instance any = map[string]any{}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &instance); err != nil {
+			return fmt.Errorf("arguments are not valid JSON: %w", err)
+		}
+	}
+
+	return resolved.Validate(instance)
+}
+
+// invalidToolArgumentsResult builds the failed tool result returned to the
+// model when its arguments do not match the tool's parameters schema. The
+// structured payload lets the model correct the call on the next turn.
+func invalidToolArgumentsResult(toolCall *llmsdk.ToolCallPart, validationErr error) AgentItem {
+	call := toolCall.Call.Function
+	body, err := json.Marshal(map[string]string{
+		"error":   "invalid_tool_arguments",
+		"message": validationErr.Error(),
+	})
+	if err != nil {
+		body = []byte(`{"error":"invalid_tool_arguments"}`)
+	}
+	return NewAgentItemTool(
+		toolCall.ToolCallID,
+		call.Name,
+		call.Args,
+		[]llmsdk.Part{llmsdk.NewTextPart(string(body))},
+		llmsdk.ToolResultStatusFailed,
+	)
+}
@@ -0,0 +1,132 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// schemaTool declares a strict parameters schema so argument validation has
+// something to reject.
+type schemaTool struct {
+	executions atomic.Int64
+}
+
+func (t *schemaTool) Name() string        { return "weather" }
+func (t *schemaTool) Description() string { return "Get the weather for a city" }
+func (t *schemaTool) Parameters() llmsdk.JSONSchema {
+	return llmsdk.JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"city": map[string]any{"type": "string"},
+		},
+		"required":             []any{"city"},
+		"additionalProperties": false,
+	}
+}
+
+func (t *schemaTool) Execute(ctx context.Context, params json.RawMessage, contextVal any, runState *llmagent.RunState) (llmagent.AgentToolResult, error) {
+	t.executions.Add(1)
+	return llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("sunny")},
+	}, nil
+}
+
+func TestRun_RejectsInvalidToolArgumentsBeforeExecute(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	tool := &schemaTool{}
+
+	// First call sends the wrong type, second call corrects it.
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "weather", map[string]any{"city": 42})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_2", "weather", map[string]any{"city": "Paris"})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("It is sunny in Paris.")},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "test_agent",
+		Model:    model,
+		Tools:    llmagent.FunctionTools[any](tool),
+		MaxTurns: 10,
+	}, nil)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("weather in Paris?"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := tool.executions.Load(); got != 1 {
+		t.Errorf("expected 1 execution (invalid call rejected), got %d", got)
+	}
+
+	var invalidResult *llmagent.AgentItemTool
+	for _, item := range response.Output {
+		if item.Tool != nil && item.Tool.ToolCallID == "call_1" {
+			invalidResult = item.Tool
+		}
+	}
+	if invalidResult == nil {
+		t.Fatal("expected a tool item for the rejected call")
+	}
+	if invalidResult.Status != llmsdk.ToolResultStatusFailed {
+		t.Errorf("expected failed status, got %q", invalidResult.Status)
+	}
+	if len(invalidResult.Output) != 1 || invalidResult.Output[0].TextPart == nil {
+		t.Fatalf("expected a single text part, got %+v", invalidResult.Output)
+	}
+	text := invalidResult.Output[0].TextPart.Text
+	if !strings.Contains(text, "invalid_tool_arguments") {
+		t.Errorf("expected structured validation error, got %q", text)
+	}
+
+	if response.Text() != "It is sunny in Paris." {
+		t.Errorf("unexpected final text %q", response.Text())
+	}
+}
+
+func TestRun_AllowsToolsWithoutParametersSchema(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	tool := NewMockTool[any]("noop", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("ok")},
+	}, nil)
+
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "noop", map[string]any{"anything": true})},
+	}))
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+	}))
+
+	session := mustNewRunSession(t, &llmagent.AgentParams[any]{
+		Name:     "test_agent",
+		Model:    model,
+		Tools:    llmagent.FunctionTools[any](tool),
+		MaxTurns: 10,
+	}, nil)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{
+		Input: []llmagent.AgentItem{
+			llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("go"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Text() != "done" {
+		t.Errorf("unexpected final text %q", response.Text())
+	}
+}
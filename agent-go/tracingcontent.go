@@ -0,0 +1,85 @@
+package llmagent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+)
+
+// TraceContentPolicy controls how prompts, messages, and outputs are recorded
+// on run spans.
+type TraceContentPolicy string
+
+const (
+	// TraceContentOmit drops the content entirely. This is the default.
+	TraceContentOmit TraceContentPolicy = "omit"
+	// TraceContentTruncate records the content up to MaxLength bytes.
+	TraceContentTruncate TraceContentPolicy = "truncate"
+	// TraceContentHash records only a SHA-256 digest of the content, so spans
+	// can be correlated by content without storing it.
+	TraceContentHash TraceContentPolicy = "hash"
+)
+
+// TraceContentOptions configures prompt/completion capture on run spans.
+type TraceContentOptions struct {
+	// Policy selects the redaction policy applied to captured content.
+	Policy TraceContentPolicy
+	// MaxLength bounds the recorded content in bytes when truncating.
+	// Zero defaults to 2048.
+	MaxLength int
+}
+
+const defaultTraceContentMaxLength = 2048
+
+var (
+	traceContentMu      sync.RWMutex
+	traceContentOptions = TraceContentOptions{Policy: TraceContentOmit}
+)
+
+// SetTraceContentOptions configures process-wide whether and how the resolved
+// system prompt, input messages, and final outputs are attached to agent run
+// spans. Content is omitted by default so traces stay free of user data
+// unless capture is explicitly enabled.
+func SetTraceContentOptions(options TraceContentOptions) {
+	traceContentMu.Lock()
+	defer traceContentMu.Unlock()
+	traceContentOptions = options
+}
+
+func currentTraceContentOptions() TraceContentOptions {
+	traceContentMu.RLock()
+	defer traceContentMu.RUnlock()
+	return traceContentOptions
+}
+
+// renderTraceContent applies the configured redaction policy to the payload.
+// It returns the recordable value and whether it should be recorded at all.
+func renderTraceContent(payload any) (string, bool) {
+	options := currentTraceContentOptions()
+	if options.Policy == TraceContentOmit || options.Policy == "" {
+		return "", false
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", false
+	}
+
+	switch options.Policy {
+	case TraceContentHash:
+		digest := sha256.Sum256(data)
+		return "sha256:" + hex.EncodeToString(digest[:]), true
+	case TraceContentTruncate:
+		maxLength := options.MaxLength
+		if maxLength <= 0 {
+			maxLength = defaultTraceContentMaxLength
+		}
+		if len(data) > maxLength {
+			return string(data[:maxLength]) + "...(truncated)", true
+		}
+		return string(data), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,139 @@
+package llmagent_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+// newSlowToolSession creates a session whose tool blocks until the execution
+// context is cancelled or the given duration passes.
+func newSlowToolSession(t *testing.T, model *llmsdktest.MockLanguageModel, toolDuration, turnTimeout time.Duration) *llmagent.RunSession[struct{}] {
+	t.Helper()
+	tool := NewMockTool[struct{}]("slow_tool", llmagent.AgentToolResult{
+		Content: []llmsdk.Part{llmsdk.NewTextPart("slow result")},
+	}, func(ctx context.Context, _ json.RawMessage, _ struct{}, _ *llmagent.RunState) (llmagent.AgentToolResult, error) {
+		select {
+		case <-time.After(toolDuration):
+			return llmagent.AgentToolResult{Content: []llmsdk.Part{llmsdk.NewTextPart("slow result")}}, nil
+		case <-ctx.Done():
+			return llmagent.AgentToolResult{}, ctx.Err()
+		}
+	})
+	return mustNewRunSession(
+		t,
+		&llmagent.AgentParams[struct{}]{
+			Name: "test_agent", Model: model, Tools: llmagent.FunctionTools[struct{}](tool),
+			MaxTurns: 10, TurnTimeout: turnTimeout,
+		},
+		struct{}{},
+	)
+}
+
+func TestRunTurnTimeoutFailsSlowTurn(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "slow_tool", map[string]any{})},
+	}))
+	session := newSlowToolSession(t, model, time.Minute, 50*time.Millisecond)
+
+	_, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go slow"))),
+	}})
+	var agentErr *llmagent.AgentError
+	if !errors.As(err, &agentErr) || agentErr.Kind != llmagent.AgentErrorKindTurnTimeout {
+		t.Fatalf("expected a turn timeout error, got %v", err)
+	}
+	if agentErr.Snapshot == nil {
+		t.Fatal("expected the partial turn preserved in the snapshot")
+	}
+	foundModel, foundTool := false, false
+	for _, item := range agentErr.Snapshot.Output {
+		if item.Model != nil {
+			foundModel = true
+		}
+		if item.Tool != nil && item.Tool.Status == llmsdk.ToolResultStatusCancelled {
+			foundTool = true
+		}
+	}
+	if !foundModel || !foundTool {
+		t.Errorf("expected the model response and cancelled tool call in the snapshot, got %+v", agentErr.Snapshot.Output)
+	}
+}
+
+func TestRunTurnTimeoutAllowsFastTurns(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "slow_tool", map[string]any{})},
+		}),
+		llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+			Content: []llmsdk.Part{llmsdk.NewTextPart("done")},
+		}),
+	)
+	session := newSlowToolSession(t, model, time.Millisecond, time.Second)
+
+	response, err := session.Run(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go fast"))),
+	}})
+	if err != nil {
+		t.Fatalf("expected fast turns to run within the timeout, got %v", err)
+	}
+	if response.Text() != "done" {
+		t.Errorf("unexpected response text %q", response.Text())
+	}
+}
+
+func TestRunTurnTimeoutDistinctFromRequestCancellation(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueGenerateResult(llmsdktest.NewMockGenerateResultResponse(llmsdk.ModelResponse{
+		Content: []llmsdk.Part{llmsdk.NewToolCallPart("call_1", "slow_tool", map[string]any{})},
+	}))
+	session := newSlowToolSession(t, model, time.Minute, time.Minute)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	response, err := session.Run(ctx, llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go slow"))),
+	}})
+	if err != nil {
+		t.Fatalf("expected request cancellation to keep returning a cancelled response, got %v", err)
+	}
+	if response.Status != llmagent.AgentResponseStatusCancelled {
+		t.Errorf("unexpected response status %v", response.Status)
+	}
+}
+
+func TestRunStreamTurnTimeoutFailsSlowTurn(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewToolCallPartDelta(
+			llmsdk.WithToolCallPartDeltaToolCallID("call_1"),
+			llmsdk.WithToolCallPartDeltaToolName("slow_tool"),
+			llmsdk.WithToolCallPartDeltaArgs("{}"),
+		)}},
+	}))
+	session := newSlowToolSession(t, model, time.Minute, 50*time.Millisecond)
+
+	stream, err := session.RunStream(t.Context(), llmagent.RunSessionRequest{Input: []llmagent.AgentItem{
+		llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(llmsdk.NewTextPart("Go slow"))),
+	}})
+	if err != nil {
+		t.Fatalf("create stream: %v", err)
+	}
+	for stream.Next() {
+	}
+	var agentErr *llmagent.AgentError
+	if !errors.As(stream.Err(), &agentErr) || agentErr.Kind != llmagent.AgentErrorKindTurnTimeout {
+		t.Fatalf("expected a turn timeout error, got %v", stream.Err())
+	}
+	if agentErr.Snapshot == nil {
+		t.Error("expected the partial turn preserved in the snapshot")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
 	"github.com/hoangvvo/llm-sdk/sdk-go/utils/stream"
@@ -15,12 +16,35 @@ type AgentRequest[C any] struct {
 	Input []AgentItem `json:"input"`
 	// Context is the value used to resolve instructions and passed to tool executions.
 	Context C `json:"context"`
+	// Sampling overrides agent-level sampling defaults for this run only.
+	Sampling *SamplingOverrides `json:"sampling,omitempty"`
+	// EnabledTools, when non-nil, restricts this run to the listed tool names.
+	EnabledTools []string `json:"enabled_tools,omitempty"`
+	// DisabledTools removes the listed tool names for this run.
+	DisabledTools []string `json:"disabled_tools,omitempty"`
+}
+
+// SamplingOverrides are per-run overrides for model sampling parameters that
+// take precedence over agent-level defaults. Nil fields keep the defaults.
+type SamplingOverrides struct {
+	// Amount of randomness injected into the response.
+	Temperature *float64 `json:"temperature,omitempty"`
+	// Nucleus sampling probability mass.
+	TopP *float64 `json:"top_p,omitempty"`
+	// Max number of tokens the model can generate in a turn.
+	MaxTokens *uint32 `json:"max_tokens,omitempty"`
+	// Options for reasoning generation.
+	Reasoning *llmsdk.ReasoningOptions `json:"reasoning,omitempty"`
 }
 
 // AgentRunSnapshot contains best-effort work completed before an agent run failed.
 type AgentRunSnapshot struct {
 	// Output contains the items generated before the run failed.
 	Output []AgentItem `json:"output"`
+	// Content is the last assistant content produced before the run failed,
+	// if any. When the turn limit is hit, it holds the content of the final
+	// model response so callers do not lose the accumulated progress.
+	Content []llmsdk.Part `json:"content,omitempty"`
 }
 
 type AgentResponse struct {
@@ -32,6 +56,19 @@ type AgentResponse struct {
 
 	// Status is the terminal status of the run.
 	Status AgentResponseStatus `json:"status"`
+
+	// Usage is the aggregated model token usage across all turns of the run,
+	// if any model response reported usage.
+	Usage *llmsdk.ModelUsage `json:"usage,omitempty"`
+
+	// Cost is the aggregated cost across all turns of the run, if any model
+	// response reported a cost.
+	Cost *float64 `json:"cost,omitempty"`
+
+	// Citations are the citation annotations aggregated from the final
+	// content, if any, with titles resolved from the source parts that flowed
+	// through the run.
+	Citations []AgentCitation `json:"citations,omitempty"`
 }
 
 type AgentResponseStatus string
@@ -61,6 +98,10 @@ type AgentItem struct {
 	Model *AgentItemModelResponse `json:"-"`
 	// A tool call result generated during the run
 	Tool *AgentItemTool `json:"-"`
+	// A provider-executed built-in tool call surfaced from a model response
+	ProviderTool *AgentItemProviderTool `json:"-"`
+	// A file attached to the transcript, such as an upload
+	File *AgentItemFile `json:"-"`
 }
 
 func (r AgentItem) Type() AgentItemType {
@@ -73,6 +114,12 @@ func (r AgentItem) Type() AgentItemType {
 	if r.Tool != nil {
 		return AgentItemTypeTool
 	}
+	if r.ProviderTool != nil {
+		return AgentItemTypeProviderTool
+	}
+	if r.File != nil {
+		return AgentItemTypeFile
+	}
 	return ""
 }
 
@@ -85,17 +132,58 @@ type AgentItemTool struct {
 	Status     llmsdk.ToolResultStatus `json:"status"`
 }
 
+// AgentItemProviderTool represents a provider-executed built-in tool call
+// (such as web search) surfaced from the model response content. Unlike
+// AgentItemTool, it is informational: the call and its result already live in
+// the model content, so the item is not replayed to the model on later turns.
+type AgentItemProviderTool struct {
+	ToolCallID string                  `json:"tool_call_id"`
+	ToolName   string                  `json:"tool_name"`
+	Call       llmsdk.ToolCall         `json:"call"`
+	Result     *llmsdk.ToolResult      `json:"result,omitempty"`
+	Status     llmsdk.ToolResultStatus `json:"status"`
+}
+
+// AgentItemFile represents a file attached to the transcript — typically an
+// upload — independent of any specific message. The binary payload lives in a
+// blob store (see the blob package); the item carries only the reference, so
+// transcripts round-trip through session persistence without inlining data.
+// Like AgentItemProviderTool it is informational: the item is not replayed to
+// the model on later turns; tools locate the payload through the reference.
+type AgentItemFile struct {
+	// Name is the display file name, e.g. "report.pdf".
+	Name string `json:"name"`
+	// MimeType of the file content, e.g. "application/pdf".
+	MimeType string `json:"mime_type"`
+	// BlobRef is the reference of the payload in the blob store.
+	BlobRef string `json:"blob_ref"`
+	// Size is the payload size in bytes, when known.
+	Size int64 `json:"size,omitempty"`
+}
+
 // AgentItemModelResponse represents a model response item in the run output.
 type AgentItemModelResponse struct {
 	*llmsdk.ModelResponse
+	// Provider and ModelID identify the model that produced the response.
+	// They survive serialization, so provider-specific reasoning payloads
+	// (OpenAI encrypted reasoning, Anthropic thinking signatures) are
+	// re-attached on later turns only when the run targets the same provider.
+	Provider string `json:"provider,omitempty"`
+	ModelID  string `json:"model_id,omitempty"`
+	// Duration is how long the originating model call took, truncated to
+	// millisecond granularity. Together with the usage and cost on the
+	// embedded response it attributes spend to individual turns of a run.
+	Duration time.Duration `json:"duration,omitempty"`
 }
 
 type AgentItemType string
 
 const (
-	AgentItemTypeMessage AgentItemType = "message"
-	AgentItemTypeModel   AgentItemType = "model"
-	AgentItemTypeTool    AgentItemType = "tool"
+	AgentItemTypeMessage      AgentItemType = "message"
+	AgentItemTypeModel        AgentItemType = "model"
+	AgentItemTypeTool         AgentItemType = "tool"
+	AgentItemTypeProviderTool AgentItemType = "provider_tool"
+	AgentItemTypeFile         AgentItemType = "file"
 )
 
 func (r AgentItem) MarshalJSON() ([]byte, error) {
@@ -139,6 +227,24 @@ func (r AgentItem) MarshalJSON() ([]byte, error) {
 			AgentItemTool: r.Tool,
 		})
 	}
+	if r.ProviderTool != nil {
+		return json.Marshal(struct {
+			Type AgentItemType `json:"type"`
+			*AgentItemProviderTool
+		}{
+			Type:                  AgentItemTypeProviderTool,
+			AgentItemProviderTool: r.ProviderTool,
+		})
+	}
+	if r.File != nil {
+		return json.Marshal(struct {
+			Type AgentItemType `json:"type"`
+			*AgentItemFile
+		}{
+			Type:          AgentItemTypeFile,
+			AgentItemFile: r.File,
+		})
+	}
 	return nil, errors.New("invalid AgentItem")
 }
 
@@ -168,6 +274,18 @@ func (r *AgentItem) UnmarshalJSON(data []byte) error {
 			return err
 		}
 		*r = AgentItem{Tool: &tool}
+	case AgentItemTypeProviderTool:
+		var providerTool AgentItemProviderTool
+		if err := json.Unmarshal(data, &providerTool); err != nil {
+			return err
+		}
+		*r = AgentItem{ProviderTool: &providerTool}
+	case AgentItemTypeFile:
+		var file AgentItemFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return err
+		}
+		*r = AgentItem{File: &file}
 	default:
 		return fmt.Errorf("unknown AgentItem type: %s", aux.Type)
 	}
@@ -177,9 +295,13 @@ func (r *AgentItem) UnmarshalJSON(data []byte) error {
 // AgentStreamEvent are stream events emitted by the agent during runStream call.
 // Only one of the fields will be non-nil.
 type AgentStreamEvent struct {
-	Partial  *llmsdk.PartialModelResponse `json:"-"`
-	Item     *AgentStreamItemEvent        `json:"-"`
-	Response *AgentResponse               `json:"-"`
+	Partial       *llmsdk.PartialModelResponse   `json:"-"`
+	Item          *AgentStreamItemEvent          `json:"-"`
+	Response      *AgentResponse                 `json:"-"`
+	ToolCallStart *AgentStreamToolCallStartEvent `json:"-"`
+	Compaction    *AgentStreamCompactionEvent    `json:"-"`
+	FollowUp      *AgentStreamFollowUpEvent      `json:"-"`
+	Data          *AgentStreamDataEvent          `json:"-"`
 }
 
 func (e AgentStreamEvent) MarshalJSON() ([]byte, error) {
@@ -210,6 +332,42 @@ func (e AgentStreamEvent) MarshalJSON() ([]byte, error) {
 			AgentResponse: e.Response,
 		})
 	}
+	if e.ToolCallStart != nil {
+		return json.Marshal(struct {
+			Event AgentStreamEventType `json:"event"`
+			*AgentStreamToolCallStartEvent
+		}{
+			Event:                         AgentStreamEventTypeToolCallStart,
+			AgentStreamToolCallStartEvent: e.ToolCallStart,
+		})
+	}
+	if e.Compaction != nil {
+		return json.Marshal(struct {
+			Event AgentStreamEventType `json:"event"`
+			*AgentStreamCompactionEvent
+		}{
+			Event:                      AgentStreamEventTypeCompaction,
+			AgentStreamCompactionEvent: e.Compaction,
+		})
+	}
+	if e.FollowUp != nil {
+		return json.Marshal(struct {
+			Event AgentStreamEventType `json:"event"`
+			*AgentStreamFollowUpEvent
+		}{
+			Event:                    AgentStreamEventTypeFollowUp,
+			AgentStreamFollowUpEvent: e.FollowUp,
+		})
+	}
+	if e.Data != nil {
+		return json.Marshal(struct {
+			Event AgentStreamEventType `json:"event"`
+			*AgentStreamDataEvent
+		}{
+			Event:                AgentStreamEventTypeData,
+			AgentStreamDataEvent: e.Data,
+		})
+	}
 	return nil, errors.New("invalid AgentStreamEvent")
 }
 
@@ -239,6 +397,30 @@ func (e *AgentStreamEvent) UnmarshalJSON(data []byte) error {
 			return err
 		}
 		*e = AgentStreamEvent{Response: &resp}
+	case AgentStreamEventTypeToolCallStart:
+		var toolCallStart AgentStreamToolCallStartEvent
+		if err := json.Unmarshal(data, &toolCallStart); err != nil {
+			return err
+		}
+		*e = AgentStreamEvent{ToolCallStart: &toolCallStart}
+	case AgentStreamEventTypeCompaction:
+		var compaction AgentStreamCompactionEvent
+		if err := json.Unmarshal(data, &compaction); err != nil {
+			return err
+		}
+		*e = AgentStreamEvent{Compaction: &compaction}
+	case AgentStreamEventTypeFollowUp:
+		var followUp AgentStreamFollowUpEvent
+		if err := json.Unmarshal(data, &followUp); err != nil {
+			return err
+		}
+		*e = AgentStreamEvent{FollowUp: &followUp}
+	case AgentStreamEventTypeData:
+		var dataEvent AgentStreamDataEvent
+		if err := json.Unmarshal(data, &dataEvent); err != nil {
+			return err
+		}
+		*e = AgentStreamEvent{Data: &dataEvent}
 	default:
 		return fmt.Errorf("unknown AgentStreamEvent event: %s", aux.Event)
 	}
@@ -250,12 +432,51 @@ type AgentStreamItemEvent struct {
 	Item  AgentItem `json:"item"`
 }
 
+// AgentStreamToolCallStartEvent is emitted when the agent begins executing a
+// tool call, before the corresponding tool item is produced.
+type AgentStreamToolCallStartEvent struct {
+	ToolCallID string          `json:"tool_call_id"`
+	ToolName   string          `json:"tool_name"`
+	Input      json.RawMessage `json:"input"`
+}
+
+// AgentStreamDataEvent is a custom typed event emitted by a tool through
+// RunState.EmitData, passed through to stream consumers without
+// interpretation — e.g. progress or intermediate results driving custom UI
+// widgets.
+type AgentStreamDataEvent struct {
+	// Name identifies the event type for the consumer, e.g. "search_progress".
+	Name string `json:"name"`
+	// Data is the JSON payload of the event.
+	Data json.RawMessage `json:"data"`
+}
+
+// AgentStreamCompactionEvent is emitted when older run items have been
+// summarized and replaced with a summary item to fit the context window.
+type AgentStreamCompactionEvent struct {
+	// Summary is the text produced by the summarizer model.
+	Summary string `json:"summary"`
+	// ItemsSummarized is the number of run items replaced by the summary.
+	ItemsSummarized int `json:"items_summarized"`
+}
+
+// AgentStreamFollowUpEvent is emitted after a streamed run completes, carrying
+// suggested follow-up prompts the user might send next.
+type AgentStreamFollowUpEvent struct {
+	// Suggestions are the suggested follow-up prompts, in the user's voice.
+	Suggestions []string `json:"suggestions"`
+}
+
 type AgentStreamEventType string
 
 const (
-	AgentStreamEventTypePartial  AgentStreamEventType = "partial"
-	AgentStreamEventTypeItem     AgentStreamEventType = "item"
-	AgentStreamEventTypeResponse AgentStreamEventType = "response"
+	AgentStreamEventTypePartial       AgentStreamEventType = "partial"
+	AgentStreamEventTypeItem          AgentStreamEventType = "item"
+	AgentStreamEventTypeResponse      AgentStreamEventType = "response"
+	AgentStreamEventTypeToolCallStart AgentStreamEventType = "tool_call_start"
+	AgentStreamEventTypeCompaction    AgentStreamEventType = "compaction"
+	AgentStreamEventTypeFollowUp      AgentStreamEventType = "follow_up"
+	AgentStreamEventTypeData          AgentStreamEventType = "data"
 )
 
 type AgentStream = stream.Stream[*AgentStreamEvent]
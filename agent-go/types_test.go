@@ -53,6 +53,16 @@ func TestAgentItem_JSONContractAndRoundTrip(t *testing.T) {
 			),
 			expected: `{"type":"tool","tool_call_id":"call_1","tool_name":"lookup","input":{"id":42},"output":[{"type":"text","text":"found"}],"status":"completed"}`,
 		},
+		{
+			name: "file",
+			item: llmagent.NewAgentItemFile(llmagent.AgentItemFile{
+				Name:     "report.pdf",
+				MimeType: "application/pdf",
+				BlobRef:  "blob:abc123",
+				Size:     2048,
+			}),
+			expected: `{"type":"file","name":"report.pdf","mime_type":"application/pdf","blob_ref":"blob:abc123","size":2048}`,
+		},
 	}
 
 	for _, tt := range tests {
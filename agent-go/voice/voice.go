@@ -0,0 +1,136 @@
+// Package voice builds a realtime voice session on top of a regular
+// multi-modal agent, in the style of the realtime console example: microphone
+// audio is appended chunk by chunk, a turn is committed when the user stops
+// speaking (voice activity detection stays on the capture side), and the
+// streamed run returns synthesized audio deltas for playback. Tools configured
+// on the agent execute mid-conversation like in any other run, and every turn
+// is recorded on the session transcript as regular AgentItems. Configure the
+// agent with audio modalities (WithModalities, WithAudio) so the model
+// produces speech.
+package voice
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"sync"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/utils/stream"
+)
+
+// SessionParams describe the microphone audio appended to the input buffer.
+type SessionParams struct {
+	// Format of the appended audio, e.g. llmsdk.AudioFormatLinear16.
+	// Required.
+	Format llmsdk.AudioFormat
+	// SampleRate of the appended audio in Hz, e.g. 16000 or 24000.
+	SampleRate int
+	// Channels of the appended audio, e.g. 1 for mono microphone input.
+	Channels int
+}
+
+// Session is a realtime voice conversation bound to a run session. It is safe
+// for concurrent use; audio may be appended while a committed turn is still
+// streaming.
+type Session[C any] struct {
+	runSession *llmagent.RunSession[C]
+	params     SessionParams
+
+	mu     sync.Mutex
+	buffer []byte
+}
+
+// NewSession creates a voice session on top of the run session.
+func NewSession[C any](runSession *llmagent.RunSession[C], params SessionParams) (*Session[C], error) {
+	if runSession == nil {
+		return nil, errors.New("voice session requires a run session")
+	}
+	if params.Format == "" {
+		return nil, errors.New("voice session requires the microphone audio format")
+	}
+	return &Session[C]{runSession: runSession, params: params}, nil
+}
+
+// AppendAudio appends a chunk of raw microphone audio to the input buffer.
+func (s *Session[C]) AppendAudio(chunk []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buffer = append(s.buffer, chunk...)
+}
+
+// ClearAudio drops the buffered audio without committing a turn, for example
+// when the captured segment turns out to be noise.
+func (s *Session[C]) ClearAudio() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buffer = nil
+}
+
+// Commit sends the buffered audio as the next user turn and streams the run.
+// The returned stream carries the usual agent events — audio part deltas for
+// playback, tool call starts, items, and the final response. When the stream
+// finishes successfully, the turn (the audio user message and the run output)
+// is recorded on the session transcript; on error the transcript is left
+// unchanged so the audio can be resent.
+func (s *Session[C]) Commit(ctx context.Context) (*llmagent.AgentStream, error) {
+	s.mu.Lock()
+	if len(s.buffer) == 0 {
+		s.mu.Unlock()
+		return nil, errors.New("no audio buffered for the turn")
+	}
+	data := base64.StdEncoding.EncodeToString(s.buffer)
+	s.buffer = nil
+	s.mu.Unlock()
+
+	opts := []llmsdk.AudioPartOption{}
+	if s.params.SampleRate > 0 {
+		opts = append(opts, llmsdk.WithAudioSampleRate(s.params.SampleRate))
+	}
+	if s.params.Channels > 0 {
+		opts = append(opts, llmsdk.WithAudioChannels(s.params.Channels))
+	}
+	userItem := llmagent.NewAgentItemMessage(llmsdk.NewUserMessage(
+		llmsdk.NewAudioPart(data, s.params.Format, opts...),
+	))
+
+	input := append(s.runSession.History(), userItem)
+	inner, err := s.runSession.RunStream(ctx, llmagent.RunSessionRequest{Input: input})
+	if err != nil {
+		return nil, err
+	}
+
+	eventCh := make(chan *llmagent.AgentStreamEvent)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(eventCh)
+		defer close(errCh)
+
+		var response *llmagent.AgentResponse
+		for inner.Next() {
+			event := inner.Current()
+			if event.Response != nil {
+				response = event.Response
+			}
+			eventCh <- event
+		}
+
+		if err := inner.Err(); err != nil {
+			errCh <- err
+			return
+		}
+		if response != nil {
+			s.runSession.AppendHistory(slices.Concat([]llmagent.AgentItem{userItem}, response.Output)...)
+		}
+	}()
+
+	return stream.New(eventCh, errCh), nil
+}
+
+// History returns a copy of the transcript recorded by committed turns.
+func (s *Session[C]) History() []llmagent.AgentItem {
+	return s.runSession.History()
+}
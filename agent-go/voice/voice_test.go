@@ -0,0 +1,105 @@
+package voice_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	llmagent "github.com/hoangvvo/llm-sdk/agent-go"
+	"github.com/hoangvvo/llm-sdk/agent-go/voice"
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+	"github.com/hoangvvo/llm-sdk/sdk-go/llmsdktest"
+)
+
+func newVoiceSession(t *testing.T, model *llmsdktest.MockLanguageModel) *voice.Session[struct{}] {
+	t.Helper()
+	agent := llmagent.NewAgent[struct{}]("voice_agent", model,
+		llmagent.WithModalities[struct{}](llmsdk.ModalityText, llmsdk.ModalityAudio),
+	)
+	runSession, err := agent.CreateSession(t.Context(), struct{}{})
+	if err != nil {
+		t.Fatalf("create run session: %v", err)
+	}
+	t.Cleanup(func() { _ = runSession.Close(t.Context()) })
+
+	session, err := voice.NewSession(runSession, voice.SessionParams{
+		Format:     llmsdk.AudioFormatLinear16,
+		SampleRate: 16000,
+		Channels:   1,
+	})
+	if err != nil {
+		t.Fatalf("create voice session: %v", err)
+	}
+	return session
+}
+
+func TestVoiceSessionCommitStreamsAudioAndRecordsTranscript(t *testing.T) {
+	model := llmsdktest.NewMockLanguageModel()
+	model.EnqueueStreamResult(llmsdktest.NewMockStreamResultPartials([]llmsdk.PartialModelResponse{
+		{Delta: &llmsdk.ContentDelta{Index: 0, Part: llmsdk.NewAudioPartDelta(
+			llmsdk.WithAudioPartDeltaData("c3ludGgh"),
+			llmsdk.WithAudioPartDeltaFormat(llmsdk.AudioFormatLinear16),
+			llmsdk.WithAudioPartDeltaTranscript("Hello there"),
+		)}},
+	}))
+	session := newVoiceSession(t, model)
+
+	session.AppendAudio([]byte("chunk-one "))
+	session.AppendAudio([]byte("chunk-two"))
+
+	stream, err := session.Commit(t.Context())
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	sawAudioDelta := false
+	for stream.Next() {
+		event := stream.Current()
+		if event.Partial != nil && event.Partial.Delta != nil && event.Partial.Delta.Part.AudioPartDelta != nil {
+			sawAudioDelta = true
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	if !sawAudioDelta {
+		t.Error("expected synthesized audio deltas on the stream")
+	}
+
+	inputs := model.TrackedStreamInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("expected one model call, got %d", len(inputs))
+	}
+	audio := inputs[0].Messages[0].UserMessage.Content[0].AudioPart
+	if audio == nil {
+		t.Fatal("expected the buffered audio sent as an audio part")
+	}
+	if audio.Data != base64.StdEncoding.EncodeToString([]byte("chunk-one chunk-two")) {
+		t.Errorf("unexpected audio data: %q", audio.Data)
+	}
+	if audio.Format != llmsdk.AudioFormatLinear16 || audio.SampleRate == nil || *audio.SampleRate != 16000 {
+		t.Errorf("unexpected audio metadata: %+v", audio)
+	}
+
+	history := session.History()
+	if len(history) != 2 {
+		t.Fatalf("expected the user and model items on the transcript, got %d items", len(history))
+	}
+	if history[0].Message == nil || history[0].Message.UserMessage == nil {
+		t.Errorf("expected the audio user message first, got %+v", history[0])
+	}
+	if history[1].Model == nil {
+		t.Errorf("expected the model response recorded, got %+v", history[1])
+	}
+}
+
+func TestVoiceSessionCommitRequiresBufferedAudio(t *testing.T) {
+	session := newVoiceSession(t, llmsdktest.NewMockLanguageModel())
+	if _, err := session.Commit(t.Context()); err == nil {
+		t.Fatal("expected an error when no audio is buffered")
+	}
+
+	session.AppendAudio([]byte("noise"))
+	session.ClearAudio()
+	if _, err := session.Commit(t.Context()); err == nil {
+		t.Fatal("expected an error after the buffer is cleared")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
@@ -23,22 +24,41 @@ const (
 	DefaultAPIVersion = "2023-06-01"
 )
 
+const (
+	betaTokenEfficientTools      = "token-efficient-tools-2025-02-19"
+	betaFineGrainedToolStreaming = "fine-grained-tool-streaming-2025-05-14"
+)
+
 type AnthropicModelOptions struct {
 	BaseURL    string
 	APIKey     string
 	APIVersion string
 	Headers    map[string]string
 	HTTPClient *http.Client
+	// TokenEfficientTools opts in to the token-efficient tool use beta,
+	// which reduces the output tokens spent encoding tool calls.
+	TokenEfficientTools bool
+	// FineGrainedToolStreaming opts in to the fine-grained tool streaming
+	// beta. Tool input JSON is streamed as it is generated instead of being
+	// buffered and validated by the API, so argument deltas arrive earlier
+	// and a stream that stops early can leave the trailing input truncated.
+	FineGrainedToolStreaming bool
+	// Betas lists additional beta identifiers to send in the anthropic-beta
+	// header, e.g. "context-1m-2025-08-07". Betas with typed options above
+	// do not need to be repeated here.
+	Betas []string
 }
 
 type AnthropicModel struct {
-	modelID    string
-	apiKey     string
-	baseURL    string
-	apiVersion string
-	client     *http.Client
-	metadata   *llmsdk.LanguageModelMetadata
-	headers    map[string]string
+	modelID                  string
+	apiKey                   string
+	baseURL                  string
+	apiVersion               string
+	client                   *http.Client
+	metadata                 *llmsdk.LanguageModelMetadata
+	headers                  map[string]string
+	betas                    []string
+	fineGrainedToolStreaming bool
 }
 
 func NewAnthropicModel(modelID string, options AnthropicModelOptions) *AnthropicModel {
@@ -63,13 +83,28 @@ func NewAnthropicModel(modelID string, options AnthropicModelOptions) *Anthropic
 		headers[k] = v
 	}
 
+	var betas []string
+	if options.TokenEfficientTools {
+		betas = append(betas, betaTokenEfficientTools)
+	}
+	if options.FineGrainedToolStreaming {
+		betas = append(betas, betaFineGrainedToolStreaming)
+	}
+	for _, beta := range options.Betas {
+		if beta != "" && !slices.Contains(betas, beta) {
+			betas = append(betas, beta)
+		}
+	}
+
 	return &AnthropicModel{
-		modelID:    modelID,
-		apiKey:     options.APIKey,
-		baseURL:    baseURL,
-		apiVersion: apiVersion,
-		client:     client,
-		headers:    headers,
+		modelID:                  modelID,
+		apiKey:                   options.APIKey,
+		baseURL:                  baseURL,
+		apiVersion:               apiVersion,
+		client:                   client,
+		headers:                  headers,
+		betas:                    betas,
+		fineGrainedToolStreaming: options.FineGrainedToolStreaming,
 	}
 }
 
@@ -161,6 +196,10 @@ func (m *AnthropicModel) Stream(ctx context.Context, input *llmsdk.LanguageModel
 			providerToolBlockIndexes := map[int]bool{}
 			serverToolBlocks := map[int]*serverToolBlock{}
 			serverToolCallIndexes := map[string]int{}
+			var toolJSONTrackers map[int]*toolJSONTracker
+			if m.fineGrainedToolStreaming {
+				toolJSONTrackers = map[int]*toolJSONTracker{}
+			}
 			for sseStream.Next() {
 				event, err := sseStream.Current()
 				if err != nil {
@@ -210,6 +249,9 @@ func (m *AnthropicModel) Stream(ctx context.Context, input *llmsdk.LanguageModel
 							serverToolCallIndexes[block.ServerToolUse.Id] = event.ContentBlockStart.Index
 						}
 					}
+					if block.ToolUse != nil && toolJSONTrackers != nil {
+						toolJSONTrackers[event.ContentBlockStart.Index] = &toolJSONTracker{}
+					}
 					if block.WebSearchToolResult != nil {
 						if callIndex, ok := serverToolCallIndexes[block.WebSearchToolResult.ToolUseId]; ok {
 							status := anthropicWebSearchResultStatus(block.WebSearchToolResult)
@@ -237,6 +279,9 @@ func (m *AnthropicModel) Stream(ctx context.Context, input *llmsdk.LanguageModel
 					if providerToolBlockIndexes[event.ContentBlockDelta.Index] {
 						continue
 					}
+					if tracker := toolJSONTrackers[event.ContentBlockDelta.Index]; tracker != nil && event.ContentBlockDelta.Delta.InputJsonDelta != nil {
+						tracker.feed(event.ContentBlockDelta.Delta.InputJsonDelta.PartialJson)
+					}
 					deltas, err := mapAnthropicRawContentBlockDeltaEvent(*event.ContentBlockDelta)
 					if err != nil {
 						errCh <- fmt.Errorf("failed to map content block delta: %w", err)
@@ -250,6 +295,18 @@ func (m *AnthropicModel) Stream(ctx context.Context, input *llmsdk.LanguageModel
 				}
 
 				if event.ContentBlockStop != nil {
+					if tracker := toolJSONTrackers[event.ContentBlockStop.Index]; tracker != nil {
+						delete(toolJSONTrackers, event.ContentBlockStop.Index)
+						// The fine-grained beta does not buffer input JSON, so a
+						// stream that stops early leaves it truncated. Emit the
+						// suffix that closes it so the accumulated arguments
+						// stay parseable.
+						if suffix := tracker.closingSuffix(); suffix != "" {
+							delta := llmsdk.NewToolCallPartDelta(llmsdk.WithToolCallPartDeltaArgs(suffix))
+							responseCh <- &llmsdk.PartialModelResponse{Delta: &llmsdk.ContentDelta{Index: event.ContentBlockStop.Index, Part: delta}}
+						}
+						continue
+					}
 					block := serverToolBlocks[event.ContentBlockStop.Index]
 					if block == nil {
 						continue
@@ -276,6 +333,118 @@ func (m *AnthropicModel) Stream(ctx context.Context, input *llmsdk.LanguageModel
 	})
 }
 
+// toolJSONTracker follows the tool input JSON streamed under the fine-grained
+// tool streaming beta, which forwards argument deltas without buffering or
+// validating them. It records which strings, objects and arrays are still
+// open so that a truncated input — for example when the stream stops at
+// max_tokens — can be closed back into parseable JSON. The repair is
+// best-effort: a literal cut off mid-token cannot be recovered.
+type toolJSONTracker struct {
+	inString bool
+	escaped  bool
+	// closers holds the closing bracket for each open object or array,
+	// innermost last.
+	closers []byte
+	// pendingKey is set while an object key has been read (or is still
+	// open) without its ':' yet.
+	pendingKey bool
+	// needValue is set when the input is positioned where a value must
+	// still appear, after a ':' or an array ','.
+	needValue bool
+	// pendingComma is set after an object ',' until the next key starts.
+	// The comma has already been streamed, so a truncation here must append
+	// a dummy member to stay parseable.
+	pendingComma bool
+}
+
+func (t *toolJSONTracker) feed(chunk string) {
+	for i := 0; i < len(chunk); i++ {
+		c := chunk[i]
+		if t.inString {
+			switch {
+			case t.escaped:
+				t.escaped = false
+			case c == '\\':
+				t.escaped = true
+			case c == '"':
+				t.inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			t.inString = true
+			t.pendingComma = false
+			if t.topCloser() == '}' && !t.needValue {
+				t.pendingKey = true
+			} else {
+				t.needValue = false
+			}
+		case '{':
+			t.closers = append(t.closers, '}')
+			t.pendingKey = false
+			t.needValue = false
+			t.pendingComma = false
+		case '[':
+			t.closers = append(t.closers, ']')
+			t.needValue = false
+			t.pendingComma = false
+		case '}', ']':
+			if len(t.closers) > 0 && t.closers[len(t.closers)-1] == c {
+				t.closers = t.closers[:len(t.closers)-1]
+			}
+			t.pendingKey = false
+			t.needValue = false
+			t.pendingComma = false
+		case ':':
+			t.pendingKey = false
+			t.needValue = true
+		case ',':
+			t.needValue = t.topCloser() == ']'
+			t.pendingComma = t.topCloser() == '}'
+		case ' ', '\t', '\n', '\r':
+		default:
+			// A number or literal token begins; the position no longer
+			// awaits a value.
+			t.needValue = false
+			t.pendingComma = false
+		}
+	}
+}
+
+func (t *toolJSONTracker) topCloser() byte {
+	if len(t.closers) == 0 {
+		return 0
+	}
+	return t.closers[len(t.closers)-1]
+}
+
+// closingSuffix returns the characters that terminate an open string,
+// complete a dangling key or missing value, and close the remaining
+// containers. It returns "" when the accumulated JSON is already complete.
+func (t *toolJSONTracker) closingSuffix() string {
+	var b strings.Builder
+	if t.inString {
+		if t.escaped {
+			b.WriteByte('\\')
+		}
+		b.WriteByte('"')
+	}
+	if t.pendingComma {
+		// A closer right after the streamed comma would be invalid, so
+		// complete the member with a dummy key instead.
+		b.WriteString(`"":null`)
+	} else if t.pendingKey {
+		b.WriteString(":null")
+	} else if t.needValue {
+		b.WriteString("null")
+	}
+	for i := len(t.closers) - 1; i >= 0; i-- {
+		b.WriteByte(t.closers[i])
+	}
+	return b.String()
+}
+
 func anthropicRefusalMessage(details *anthropicapi.RefusalStopDetails) string {
 	if details != nil {
 		if details.Explanation != nil {
@@ -293,6 +462,9 @@ func (m *AnthropicModel) requestHeaders() map[string]string {
 		"x-api-key":         m.apiKey,
 		"anthropic-version": m.apiVersion,
 	}
+	if len(m.betas) > 0 {
+		headers["anthropic-beta"] = strings.Join(m.betas, ",")
+	}
 
 	for k, v := range m.headers {
 		headers[k] = v
@@ -0,0 +1,54 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToolJSONTrackerClosingSuffix(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "complete object", input: `{"a":1}`, want: ""},
+		{name: "complete nested", input: `{"a":[1,{"b":"c"}]}`, want: ""},
+		{name: "open object", input: `{`, want: `}`},
+		{name: "open array", input: `[`, want: `]`},
+		{name: "mid string", input: `{"a":"hel`, want: `"}`},
+		{name: "mid escape", input: `{"a":"hel\`, want: `\"}`},
+		{name: "open key", input: `{"a":1,"b`, want: `":null}`},
+		{name: "dangling key", input: `{"a":1,"b"`, want: `:null}`},
+		{name: "dangling colon", input: `{"a":`, want: `null}`},
+		{name: "object comma", input: `{"a":1,`, want: `"":null}`},
+		{name: "object comma with space", input: `{"a":1, `, want: `"":null}`},
+		{name: "array comma", input: `[1,`, want: `null]`},
+		{name: "nested containers", input: `{"a":[1,{"b":`, want: `null}]}`},
+		{name: "brackets inside string", input: `{"a":"x}{[",`, want: `"":null}`},
+		{name: "string value closed", input: `{"a":"done"`, want: `}`},
+		{name: "number cut mid array", input: `[1, 2`, want: `]`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := &toolJSONTracker{}
+			tracker.feed(tc.input)
+			if got := tracker.closingSuffix(); got != tc.want {
+				t.Errorf("closingSuffix(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+
+			// The same input fed byte by byte must track identically.
+			tracker = &toolJSONTracker{}
+			for i := range tc.input {
+				tracker.feed(tc.input[i : i+1])
+			}
+			if got := tracker.closingSuffix(); got != tc.want {
+				t.Errorf("closingSuffix(%q) fed per byte = %q, want %q", tc.input, got, tc.want)
+			}
+
+			if repaired := tc.input + tc.want; repaired != "" && !json.Valid([]byte(repaired)) {
+				t.Errorf("repaired input %q is not valid JSON", repaired)
+			}
+		})
+	}
+}
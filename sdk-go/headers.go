@@ -0,0 +1,30 @@
+package llmsdk
+
+import (
+	"context"
+)
+
+// requestHeadersContextKey carries per-request HTTP header overrides in the
+// context passed to a Generate or Stream call.
+type requestHeadersContextKey struct{}
+
+// WithRequestHeaders returns a context that attaches extra outbound HTTP
+// headers to provider calls made with it — for example an end-user
+// identifier or an idempotency key. The headers apply only to the calls the
+// returned context is passed to and are set after the model's configured
+// headers, so no per-request model instance is needed. Calling it again
+// replaces headers attached earlier on the context.
+func WithRequestHeaders(ctx context.Context, headers map[string]string) context.Context {
+	copied := make(map[string]string, len(headers))
+	for k, v := range headers {
+		copied[k] = v
+	}
+	return context.WithValue(ctx, requestHeadersContextKey{}, copied)
+}
+
+// RequestHeadersFromContext returns the per-request headers attached with
+// WithRequestHeaders, or nil when none are set.
+func RequestHeadersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(requestHeadersContextKey{}).(map[string]string)
+	return headers
+}
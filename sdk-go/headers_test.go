@@ -0,0 +1,36 @@
+package llmsdk_test
+
+import (
+	"context"
+	"testing"
+
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
+)
+
+func TestRequestHeadersRoundTripThroughContext(t *testing.T) {
+	ctx := llmsdk.WithRequestHeaders(context.Background(), map[string]string{
+		"X-End-User-Id":   "user_42",
+		"Idempotency-Key": "key_1",
+	})
+
+	headers := llmsdk.RequestHeadersFromContext(ctx)
+	if headers["X-End-User-Id"] != "user_42" || headers["Idempotency-Key"] != "key_1" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+}
+
+func TestRequestHeadersCopiedOnAttach(t *testing.T) {
+	original := map[string]string{"X-End-User-Id": "user_42"}
+	ctx := llmsdk.WithRequestHeaders(context.Background(), original)
+	original["X-End-User-Id"] = "user_43"
+
+	if got := llmsdk.RequestHeadersFromContext(ctx)["X-End-User-Id"]; got != "user_42" {
+		t.Fatalf("expected headers snapshot at attach time, got %q", got)
+	}
+}
+
+func TestRequestHeadersAbsentByDefault(t *testing.T) {
+	if headers := llmsdk.RequestHeadersFromContext(context.Background()); headers != nil {
+		t.Fatalf("expected no headers, got %v", headers)
+	}
+}
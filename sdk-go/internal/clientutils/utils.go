@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strings"
 
+	llmsdk "github.com/hoangvvo/llm-sdk/sdk-go"
 	"github.com/hoangvvo/llm-sdk/sdk-go/internal/sse"
 )
 
@@ -48,6 +49,11 @@ func DoJSON[T any](ctx context.Context, client *http.Client, config JSONRequestC
 		req.Header.Set(key, value)
 	}
 
+	// Set per-request header overrides carried on the context
+	for key, value := range llmsdk.RequestHeadersFromContext(ctx) {
+		req.Header.Set(key, value)
+	}
+
 	// Execute request
 	resp, err := client.Do(req)
 	if err != nil {
@@ -100,6 +106,11 @@ func DoSSE[T any](ctx context.Context, client *http.Client, config SSERequestCon
 		req.Header.Set(key, value)
 	}
 
+	// Set per-request header overrides carried on the context
+	for key, value := range llmsdk.RequestHeadersFromContext(ctx) {
+		req.Header.Set(key, value)
+	}
+
 	// Execute request
 	resp, err := client.Do(req)
 	if err != nil {
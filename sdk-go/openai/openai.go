@@ -22,12 +22,14 @@ import (
 
 // OpenAIModel implements the LanguageModel interface for OpenAI using Responses API
 type OpenAIModel struct {
-	modelID  string
-	apiKey   string
-	baseURL  string
-	client   *http.Client
-	metadata *llmsdk.LanguageModelMetadata
-	headers  map[string]string
+	modelID      string
+	apiKey       string
+	baseURL      string
+	client       *http.Client
+	metadata     *llmsdk.LanguageModelMetadata
+	headers      map[string]string
+	organization string
+	project      string
 }
 
 // OpenAIModelOptions represents configuration options for OpenAI model
@@ -36,6 +38,12 @@ type OpenAIModelOptions struct {
 	APIKey     string
 	Headers    map[string]string
 	HTTPClient *http.Client
+	// Organization, when set, is sent as the OpenAI-Organization header so
+	// usage counts against that organization.
+	Organization string
+	// Project, when set, is sent as the OpenAI-Project header. Required for
+	// keys scoped to a specific project.
+	Project string
 }
 
 type OpenAIReasoningEffort uint32
@@ -64,11 +72,13 @@ func NewOpenAIModel(modelID string, options OpenAIModelOptions) *OpenAIModel {
 	}
 
 	return &OpenAIModel{
-		modelID: modelID,
-		apiKey:  options.APIKey,
-		baseURL: baseURL,
-		client:  client,
-		headers: headers,
+		modelID:      modelID,
+		apiKey:       options.APIKey,
+		baseURL:      baseURL,
+		client:       client,
+		headers:      headers,
+		organization: options.Organization,
+		project:      options.Project,
 	}
 }
 
@@ -96,6 +106,12 @@ func (m *OpenAIModel) requestHeaders() map[string]string {
 	headers := map[string]string{
 		"Authorization": fmt.Sprintf("Bearer %s", m.apiKey),
 	}
+	if m.organization != "" {
+		headers["OpenAI-Organization"] = m.organization
+	}
+	if m.project != "" {
+		headers["OpenAI-Project"] = m.project
+	}
 
 	for k, v := range m.headers {
 		headers[k] = v
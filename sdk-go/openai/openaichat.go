@@ -18,12 +18,14 @@ import (
 
 // OpenAIChatModel implements the LanguageModel interface for the Chat Completions API.
 type OpenAIChatModel struct {
-	modelID  string
-	apiKey   string
-	baseURL  string
-	client   *http.Client
-	metadata *llmsdk.LanguageModelMetadata
-	headers  map[string]string
+	modelID      string
+	apiKey       string
+	baseURL      string
+	client       *http.Client
+	metadata     *llmsdk.LanguageModelMetadata
+	headers      map[string]string
+	organization string
+	project      string
 }
 
 // OpenAIChatModelOptions represents configuration options for OpenAI chat models.
@@ -32,6 +34,12 @@ type OpenAIChatModelOptions struct {
 	APIKey     string
 	Headers    map[string]string
 	HTTPClient *http.Client
+	// Organization, when set, is sent as the OpenAI-Organization header so
+	// usage counts against that organization.
+	Organization string
+	// Project, when set, is sent as the OpenAI-Project header. Required for
+	// keys scoped to a specific project.
+	Project string
 }
 
 // NewOpenAIChatModel constructs a new OpenAIChatModel instance.
@@ -52,11 +60,13 @@ func NewOpenAIChatModel(modelID string, options OpenAIChatModelOptions) *OpenAIC
 	}
 
 	return &OpenAIChatModel{
-		modelID: modelID,
-		apiKey:  options.APIKey,
-		baseURL: baseURL,
-		client:  client,
-		headers: headers,
+		modelID:      modelID,
+		apiKey:       options.APIKey,
+		baseURL:      baseURL,
+		client:       client,
+		headers:      headers,
+		organization: options.Organization,
+		project:      options.Project,
 	}
 }
 
@@ -85,6 +95,12 @@ func (m *OpenAIChatModel) requestHeaders() map[string]string {
 	headers := map[string]string{
 		"Authorization": fmt.Sprintf("Bearer %s", m.apiKey),
 	}
+	if m.organization != "" {
+		headers["OpenAI-Organization"] = m.organization
+	}
+	if m.project != "" {
+		headers["OpenAI-Project"] = m.project
+	}
 
 	for k, v := range m.headers {
 		headers[k] = v
@@ -245,6 +261,11 @@ func convertToOpenAIChatCreateParams(input *llmsdk.LanguageModelInput, modelID s
 	params.TopP = input.TopP
 	params.PresencePenalty = input.PresencePenalty
 	params.FrequencyPenalty = input.FrequencyPenalty
+	// Open-model sampling extensions, forwarded for OpenAI-compatible
+	// servers such as Ollama, Together, Fireworks and llama.cpp.
+	params.MinP = input.MinP
+	params.RepetitionPenalty = input.RepetitionPenalty
+	params.TypicalP = input.TypicalP
 	if input.Seed != nil {
 		seed := int(*input.Seed)
 		params.Seed = &seed
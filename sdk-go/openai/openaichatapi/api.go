@@ -460,8 +460,24 @@ type CreateChatCompletionRequest struct {
 	// If specified, our system will make a best effort to sample deterministically, such that repeated requests with the same `seed` and parameters should return the same result.
 	// Determinism is not guaranteed, and you should refer to the `system_fingerprint` response parameter to monitor changes in the backend.
 	//
-	Seed *int               `json:"seed,omitempty"`
-	Stop *StopConfiguration `json:"stop,omitempty"`
+	Seed *int `json:"seed,omitempty"`
+	// Tokens whose probability is below `min_p` times the probability of the
+	// most likely token are filtered out. Extension accepted by
+	// OpenAI-compatible open-model servers (Ollama, Together, Fireworks,
+	// llama.cpp); not part of the OpenAI API.
+	//
+	MinP *float64 `json:"min_p,omitempty"`
+	// Penalizes tokens that already appeared in the prompt and output; 1.0
+	// disables the penalty. Extension accepted by OpenAI-compatible
+	// open-model servers; not part of the OpenAI API.
+	//
+	RepetitionPenalty *float64 `json:"repetition_penalty,omitempty"`
+	// Locally typical sampling mass, comparable to `top_p`. Extension
+	// accepted by OpenAI-compatible open-model servers; not part of the
+	// OpenAI API.
+	//
+	TypicalP *float64           `json:"typical_p,omitempty"`
+	Stop     *StopConfiguration `json:"stop,omitempty"`
 	// Whether or not to store the output of this chat completion request for
 	// use in our [model distillation](/docs/guides/distillation) or
 	// [evals](/docs/guides/evals) products.
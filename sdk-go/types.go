@@ -1130,6 +1130,12 @@ type LanguageModelInput struct {
 	FrequencyPenalty *float64 `json:"frequency_penalty,omitempty"`
 	// The seed (integer), if set and supported by the model, to enable deterministic results.
 	Seed *int64 `json:"seed,omitempty"`
+	// Tokens whose probability is below min_p times the probability of the most likely token are filtered out. Supported by open-model servers such as Ollama, Together, Fireworks and llama.cpp; ignored elsewhere.
+	MinP *float64 `json:"min_p,omitempty"`
+	// Penalizes tokens that already appeared in the prompt and output; 1.0 disables the penalty. Supported by open-model servers; ignored elsewhere.
+	RepetitionPenalty *float64 `json:"repetition_penalty,omitempty"`
+	// Locally typical sampling mass, comparable to top_p. Supported by open-model servers; ignored elsewhere.
+	TypicalP *float64 `json:"typical_p,omitempty"`
 	// The modalities that the model should support.
 	Modalities []Modality `json:"modalities,omitempty"`
 	// A set of key/value pairs that store additional information about the request. This is forwarded to the model provider if supported.
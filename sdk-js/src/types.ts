@@ -681,6 +681,18 @@ export interface LanguageModelInput {
    * The seed (integer), if set and supported by the model, to enable deterministic results.
    */
   seed?: number;
+  /**
+   * Tokens whose probability is below min_p times the probability of the most likely token are filtered out. Supported by open-model servers such as Ollama, Together, Fireworks and llama.cpp; ignored elsewhere.
+   */
+  min_p?: number;
+  /**
+   * Penalizes tokens that already appeared in the prompt and output; 1.0 disables the penalty. Supported by open-model servers; ignored elsewhere.
+   */
+  repetition_penalty?: number;
+  /**
+   * Locally typical sampling mass, comparable to top_p. Supported by open-model servers; ignored elsewhere.
+   */
+  typical_p?: number;
   /**
    * The modalities that the model should support.
    */
@@ -419,6 +419,9 @@ fn convert_to_anthropic_create_params(
         presence_penalty: _,
         frequency_penalty: _,
         seed: _,
+        min_p: _,
+        repetition_penalty: _,
+        typical_p: _,
         modalities: _,
         metadata: _,
         audio: _,
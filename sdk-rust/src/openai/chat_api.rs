@@ -340,6 +340,22 @@ pub struct CreateChatCompletionRequest {
     /// parameter to monitor changes in the backend.
     #[serde(skip_serializing_if = "Option::is_none")]
     pub seed: Option<i64>,
+    /// Tokens whose probability is below `min_p` times the probability of the
+    /// most likely token are filtered out. Extension accepted by
+    /// OpenAI-compatible open-model servers (Ollama, Together, Fireworks,
+    /// llama.cpp); not part of the OpenAI API.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub min_p: Option<f64>,
+    /// Penalizes tokens that already appeared in the prompt and output; 1.0
+    /// disables the penalty. Extension accepted by OpenAI-compatible
+    /// open-model servers; not part of the OpenAI API.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub repetition_penalty: Option<f64>,
+    /// Locally typical sampling mass, comparable to `top_p`. Extension
+    /// accepted by OpenAI-compatible open-model servers; not part of the
+    /// OpenAI API.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub typical_p: Option<f64>,
     #[serde(skip_serializing_if = "Option::is_none")]
     pub stop: Option<StopConfiguration>,
     /// Whether or not to store the output of this chat completion request for
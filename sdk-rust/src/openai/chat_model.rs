@@ -344,6 +344,7 @@ fn convert_to_openai_create_params(
         max_completion_tokens: input.max_tokens.map(i64::from),
         max_tokens: None,
         messages,
+        min_p: input.min_p,
         modalities,
         model: Some(model_id.to_string()),
         n: None,
@@ -351,6 +352,7 @@ fn convert_to_openai_create_params(
         prediction: None,
         presence_penalty: input.presence_penalty,
         reasoning_effort,
+        repetition_penalty: input.repetition_penalty,
         response_format: input.response_format.map(convert_to_openai_response_format),
         seed: input.seed,
         stop: None,
@@ -374,6 +376,7 @@ fn convert_to_openai_create_params(
                     .collect::<LanguageModelResult<Vec<_>>>()
             })
             .transpose()?,
+        typical_p: input.typical_p,
         verbosity: None,
         web_search_options: None,
     })
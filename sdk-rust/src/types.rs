@@ -752,6 +752,20 @@ pub struct LanguageModelInput {
     /// deterministic results.
     #[serde(skip_serializing_if = "Option::is_none")]
     pub seed: Option<i64>,
+    /// Tokens whose probability is below min_p times the probability of the
+    /// most likely token are filtered out. Supported by open-model servers
+    /// such as Ollama, Together, Fireworks and llama.cpp; ignored elsewhere.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub min_p: Option<f64>,
+    /// Penalizes tokens that already appeared in the prompt and output; 1.0
+    /// disables the penalty. Supported by open-model servers; ignored
+    /// elsewhere.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub repetition_penalty: Option<f64>,
+    /// Locally typical sampling mass, comparable to top_p. Supported by
+    /// open-model servers; ignored elsewhere.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub typical_p: Option<f64>,
     /// The modalities that the model should support.
     #[serde(skip_serializing_if = "Option::is_none")]
     pub modalities: Option<Vec<Modality>>,